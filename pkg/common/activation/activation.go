@@ -0,0 +1,189 @@
+// Package activation contains the polling, retry and error handling logic shared by the activation resources
+package activation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+)
+
+var (
+	// ErrContextTerminated is returned when the context of an activation is done before the activation completes
+	ErrContextTerminated = errors.New("activation context terminated")
+
+	// ErrTooManyRetries is returned when polling an activation fails with retryable errors too many times in a row
+	ErrTooManyRetries = errors.New("reached max number of retries")
+)
+
+const (
+	// DefaultJitter is the default share of a polling interval by which a wait is randomly shortened or extended
+	DefaultJitter = 0.1
+
+	// DefaultMaxRetries is the default number of consecutive retryable errors tolerated while polling an activation
+	DefaultMaxRetries = 5
+
+	// DefaultCreateRetryMaxInterval is the default maximum wait between retries of an activation request
+	DefaultCreateRetryMaxInterval = 5 * time.Minute
+
+	// DefaultTimeout is the default timeout of the activation resources
+	DefaultTimeout = 90 * time.Minute
+)
+
+// Poller computes the waits between consecutive requests of an activation. Waits start at Interval and grow by
+// Multiplier up to MaxInterval. Each wait is randomly shortened or extended by the Jitter share of the interval.
+type Poller struct {
+	Interval    time.Duration
+	MaxInterval time.Duration
+	Multiplier  float64
+	Jitter      float64
+
+	current time.Duration
+}
+
+// NewPoller returns a Poller waiting the given interval between status checks
+func NewPoller(interval time.Duration) *Poller {
+	return &Poller{Interval: interval, Jitter: DefaultJitter}
+}
+
+// NewBackoff returns a Poller whose waits start at the given interval and double up to maxInterval
+func NewBackoff(interval, maxInterval time.Duration) *Poller {
+	return &Poller{Interval: interval, MaxInterval: maxInterval, Multiplier: 2, Jitter: DefaultJitter}
+}
+
+// Next returns the duration of the next wait
+func (p *Poller) Next() time.Duration {
+	if p.current == 0 {
+		p.current = p.Interval
+	}
+	wait := p.current
+	if p.Multiplier > 1 {
+		p.current = time.Duration(float64(p.current) * p.Multiplier)
+		if p.MaxInterval > 0 && p.current > p.MaxInterval {
+			p.current = p.MaxInterval
+		}
+	}
+	if p.Jitter > 0 {
+		wait += time.Duration(float64(wait) * p.Jitter * (2*rand.Float64() - 1))
+	}
+	return wait
+}
+
+// Wait blocks until the next wait has passed. If the context is done first, an error wrapping
+// ErrContextTerminated and the context error is returned.
+func (p *Poller) Wait(ctx context.Context) error {
+	timer := time.NewTimer(p.Next())
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ContextError(ctx.Err())
+	}
+}
+
+// Poll waits and calls check until it reports the activation as done, returns a non-retryable error, returns
+// retryable errors more than maxRetries times in a row, or the context is done
+func Poll(ctx context.Context, p *Poller, maxRetries int, isRetryable func(error) bool, check func(context.Context) (bool, error)) error {
+	return poll(ctx, p, false, maxRetries, isRetryable, check)
+}
+
+// PollNow works like Poll, but calls check before the first wait
+func PollNow(ctx context.Context, p *Poller, maxRetries int, isRetryable func(error) bool, check func(context.Context) (bool, error)) error {
+	return poll(ctx, p, true, maxRetries, isRetryable, check)
+}
+
+func poll(ctx context.Context, p *Poller, immediate bool, maxRetries int, isRetryable func(error) bool, check func(context.Context) (bool, error)) error {
+	retries := 0
+	for {
+		if !immediate {
+			if err := p.Wait(ctx); err != nil {
+				return err
+			}
+		}
+		immediate = false
+
+		done, err := check(ctx)
+		if err != nil {
+			if isRetryable == nil || !isRetryable(err) {
+				return err
+			}
+			retries++
+			if retries > maxRetries {
+				return fmt.Errorf("%w: %d: %w", ErrTooManyRetries, retries, err)
+			}
+			continue
+		}
+		retries = 0
+		if done {
+			return nil
+		}
+	}
+}
+
+// Retry calls op until it succeeds, returns a non-retryable error or the context is done, waiting between the calls
+// as given by the poller
+func Retry(ctx context.Context, p *Poller, isRetryable func(error) bool, op func(context.Context) error) error {
+	for {
+		err := op(ctx)
+		if err == nil {
+			return nil
+		}
+		if isRetryable == nil || !isRetryable(err) {
+			return err
+		}
+		if err := p.Wait(ctx); err != nil {
+			return err
+		}
+	}
+}
+
+// IsRetryableStatusCode reports whether an activation request which failed with the given HTTP status code can be
+// retried. Server errors, conflicts, throttling and unprocessable requests are considered transient.
+func IsRetryableStatusCode(statusCode int) bool {
+	return statusCode >= http.StatusInternalServerError ||
+		statusCode == http.StatusConflict ||
+		statusCode == http.StatusUnprocessableEntity ||
+		statusCode == http.StatusTooManyRequests
+}
+
+// IsRetryable reports whether err is a connection reset or an API error of type E whose status code, as given by
+// statusCode, is retryable according to isRetryableStatusCode. Products which retry the default set of status codes
+// pass IsRetryableStatusCode.
+func IsRetryable[E interface {
+	*T
+	error
+}, T any](err error, statusCode func(E) int, isRetryableStatusCode func(int) bool) bool {
+	if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return true
+	}
+	var target E
+	if !errors.As(err, &target) {
+		return false
+	}
+	return isRetryableStatusCode(statusCode(target))
+}
+
+// ContextError wraps the error of a done context of an activation
+func ContextError(err error) error {
+	return fmt.Errorf("%w: %w", ErrContextTerminated, err)
+}
+
+// Diagnostics converts an error of an activation into diagnostics. Errors caused by the operation timeout
+// point to the 'timeouts' block of the resource.
+func Diagnostics(summary string, err error) diag.Diagnostics {
+	d := diag.Diagnostic{
+		Severity: diag.Error,
+		Summary:  fmt.Sprintf("%s: %s", summary, err),
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		d.Detail = "The activation did not complete within the operation timeout. It may still complete on the Akamai side. Increase the timeout in the 'timeouts' block of the resource to wait longer."
+	}
+	return diag.Diagnostics{d}
+}
@@ -0,0 +1,215 @@
+package activation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type apiError struct {
+	StatusCode int
+}
+
+func (e *apiError) Error() string {
+	return fmt.Sprintf("API error: %d", e.StatusCode)
+}
+
+func isAPIErrorRetryable(err error) bool {
+	return IsRetryable(err, func(e *apiError) int { return e.StatusCode }, IsRetryableStatusCode)
+}
+
+func TestPollerNext(t *testing.T) {
+	t.Run("constant interval without jitter", func(t *testing.T) {
+		p := &Poller{Interval: time.Second}
+		for i := 0; i < 3; i++ {
+			assert.Equal(t, time.Second, p.Next())
+		}
+	})
+
+	t.Run("backoff is capped", func(t *testing.T) {
+		p := NewBackoff(time.Second, 5*time.Second)
+		p.Jitter = 0
+		var waits []time.Duration
+		for i := 0; i < 5; i++ {
+			waits = append(waits, p.Next())
+		}
+		assert.Equal(t, []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 5 * time.Second, 5 * time.Second}, waits)
+	})
+
+	t.Run("jitter stays within bounds", func(t *testing.T) {
+		p := NewPoller(time.Second)
+		for i := 0; i < 100; i++ {
+			wait := p.Next()
+			assert.GreaterOrEqual(t, wait, 900*time.Millisecond)
+			assert.LessOrEqual(t, wait, 1100*time.Millisecond)
+		}
+	})
+}
+
+func TestPoll(t *testing.T) {
+	errTransient := &apiError{StatusCode: http.StatusServiceUnavailable}
+	errPermanent := &apiError{StatusCode: http.StatusBadRequest}
+
+	tests := map[string]struct {
+		results   []error
+		immediate bool
+		expected  int
+		withError error
+	}{
+		"done after a few checks": {
+			results:  []error{nil, nil, nil},
+			expected: 3,
+		},
+		"done on first check": {
+			results:   []error{nil},
+			immediate: true,
+			expected:  1,
+		},
+		"retryable errors are tolerated": {
+			results:  []error{errTransient, errTransient, nil},
+			expected: 3,
+		},
+		"non retryable error": {
+			results:   []error{errTransient, errPermanent},
+			expected:  2,
+			withError: errPermanent,
+		},
+		"too many retries": {
+			results:   []error{errTransient, errTransient, errTransient},
+			expected:  3,
+			withError: ErrTooManyRetries,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			calls := 0
+			check := func(context.Context) (bool, error) {
+				err := test.results[calls]
+				calls++
+				return calls == len(test.results) && err == nil, err
+			}
+			pollFunc := Poll
+			if test.immediate {
+				pollFunc = PollNow
+			}
+
+			err := pollFunc(context.Background(), NewPoller(time.Millisecond), 2, isAPIErrorRetryable, check)
+			assert.Equal(t, test.expected, calls)
+			if test.withError != nil {
+				assert.ErrorIs(t, err, test.withError)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+
+	t.Run("context deadline", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+
+		err := Poll(ctx, NewPoller(time.Millisecond), DefaultMaxRetries, nil, func(context.Context) (bool, error) {
+			return false, nil
+		})
+		assert.ErrorIs(t, err, ErrContextTerminated)
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+}
+
+func TestRetry(t *testing.T) {
+	t.Run("retries until success", func(t *testing.T) {
+		calls := 0
+		err := Retry(context.Background(), NewBackoff(time.Millisecond, 2*time.Millisecond), isAPIErrorRetryable, func(context.Context) error {
+			calls++
+			if calls < 3 {
+				return &apiError{StatusCode: http.StatusTooManyRequests}
+			}
+			return nil
+		})
+		require.NoError(t, err)
+		assert.Equal(t, 3, calls)
+	})
+
+	t.Run("non retryable error", func(t *testing.T) {
+		calls := 0
+		err := Retry(context.Background(), NewPoller(time.Millisecond), isAPIErrorRetryable, func(context.Context) error {
+			calls++
+			return &apiError{StatusCode: http.StatusForbidden}
+		})
+		var target *apiError
+		require.ErrorAs(t, err, &target)
+		assert.Equal(t, http.StatusForbidden, target.StatusCode)
+		assert.Equal(t, 1, calls)
+	})
+
+	t.Run("canceled context", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		err := Retry(ctx, NewPoller(time.Hour), isAPIErrorRetryable, func(context.Context) error {
+			cancel()
+			return io.EOF
+		})
+		assert.ErrorIs(t, err, ErrContextTerminated)
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+}
+
+func TestIsRetryable(t *testing.T) {
+	tests := map[string]struct {
+		err      error
+		expected bool
+	}{
+		"internal server error": {&apiError{StatusCode: http.StatusInternalServerError}, true},
+		"conflict":              {&apiError{StatusCode: http.StatusConflict}, true},
+		"unprocessable entity":  {&apiError{StatusCode: http.StatusUnprocessableEntity}, true},
+		"too many requests":     {&apiError{StatusCode: http.StatusTooManyRequests}, true},
+		"wrapped API error":     {fmt.Errorf("creating activation: %w", &apiError{StatusCode: http.StatusBadGateway}), true},
+		"bad request":           {&apiError{StatusCode: http.StatusBadRequest}, false},
+		"not found":             {&apiError{StatusCode: http.StatusNotFound}, false},
+		"connection reset":      {fmt.Errorf("sending request: %w", io.ErrUnexpectedEOF), true},
+		"other error":           {errors.New("oops"), false},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.expected, isAPIErrorRetryable(test.err))
+		})
+	}
+}
+
+func TestIsRetryableWithProductStatusCodes(t *testing.T) {
+	withoutConflict := func(statusCode int) bool {
+		return statusCode != http.StatusConflict && IsRetryableStatusCode(statusCode)
+	}
+	isRetryable := func(err error) bool {
+		return IsRetryable(err, func(e *apiError) int { return e.StatusCode }, withoutConflict)
+	}
+
+	assert.False(t, isRetryable(&apiError{StatusCode: http.StatusConflict}))
+	assert.True(t, isRetryable(&apiError{StatusCode: http.StatusTooManyRequests}))
+	assert.True(t, isRetryable(io.EOF))
+}
+
+func TestDiagnostics(t *testing.T) {
+	t.Run("timeout points to timeouts block", func(t *testing.T) {
+		diags := Diagnostics("waiting for activation", ContextError(context.DeadlineExceeded))
+		require.Len(t, diags, 1)
+		assert.Equal(t, diag.Error, diags[0].Severity)
+		assert.Equal(t, "waiting for activation: activation context terminated: context deadline exceeded", diags[0].Summary)
+		assert.Contains(t, diags[0].Detail, "'timeouts' block")
+	})
+
+	t.Run("other errors have no detail", func(t *testing.T) {
+		diags := Diagnostics("creating activation", errors.New("oops"))
+		require.Len(t, diags, 1)
+		assert.Equal(t, "creating activation: oops", diags[0].Summary)
+		assert.Empty(t, diags[0].Detail)
+	})
+}
@@ -0,0 +1,295 @@
+package appsec
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v9/pkg/appsec"
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/common/tf"
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/meta"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"golang.org/x/exp/slices"
+)
+
+// preActivationChecks holds the pre-activation checks enabled in the 'pre_activation_checks' block
+type preActivationChecks struct {
+	blockUnexpectedEvaluation bool
+	allowedEvaluationPolicies []string
+	blockPendingRuleUpgrade   bool
+	blockHostnameChanges      bool
+	blockModifiedVersion      bool
+}
+
+func preActivationChecksSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Optional:    true,
+		MaxItems:    1,
+		Description: "Checks run before the configuration version is activated. The activation is not started if any enabled check fails",
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"block_unexpected_evaluation": {
+					Type:        schema.TypeBool,
+					Optional:    true,
+					Default:     true,
+					Description: "Whether to block the activation if a security policy not listed in 'allowed_evaluation_policies' is in evaluation mode",
+				},
+				"allowed_evaluation_policies": {
+					Type:        schema.TypeSet,
+					Optional:    true,
+					Elem:        &schema.Schema{Type: schema.TypeString},
+					Description: "Security policies which are expected to be in evaluation mode",
+				},
+				"block_pending_rule_upgrade": {
+					Type:        schema.TypeBool,
+					Optional:    true,
+					Default:     true,
+					Description: "Whether to block the activation if a KRS rule upgrade is pending for any security policy",
+				},
+				"block_hostname_changes": {
+					Type:        schema.TypeBool,
+					Optional:    true,
+					Default:     true,
+					Description: "Whether to block the activation if the selected hostnames differ from the version currently active on the network",
+				},
+				"block_modified_version": {
+					Type:     schema.TypeBool,
+					Optional: true,
+					Default:  false,
+					Description: "Whether to block the activation if the version was modified after the plan was created. " +
+						"Only enable it if the version is not modified by other resources in the same run",
+				},
+			},
+		},
+	}
+}
+
+// getPreActivationChecks returns the checks enabled in the 'pre_activation_checks' block, or nil if the block is not set
+func getPreActivationChecks(d interface{ Get(string) interface{} }) *preActivationChecks {
+	blocks, ok := d.Get("pre_activation_checks").([]interface{})
+	if !ok || len(blocks) == 0 || blocks[0] == nil {
+		return nil
+	}
+	block := blocks[0].(map[string]interface{})
+	checks := preActivationChecks{
+		blockUnexpectedEvaluation: block["block_unexpected_evaluation"].(bool),
+		blockPendingRuleUpgrade:   block["block_pending_rule_upgrade"].(bool),
+		blockHostnameChanges:      block["block_hostname_changes"].(bool),
+		blockModifiedVersion:      block["block_modified_version"].(bool),
+	}
+	if allowed, ok := block["allowed_evaluation_policies"].(*schema.Set); ok {
+		checks.allowedEvaluationPolicies = tf.SetToStringSlice(allowed)
+	}
+	return &checks
+}
+
+// customDiffPlannedVersionFingerprint records the fingerprint of the version to be activated when the
+// 'block_modified_version' check is enabled, so that changes made between plan and apply can be detected
+func customDiffPlannedVersionFingerprint(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	meta := meta.Must(m)
+	logger := meta.Log("APPSEC", "customDiffPlannedVersionFingerprint")
+
+	checks := getPreActivationChecks(d)
+	if checks == nil || !checks.blockModifiedVersion {
+		if d.Get("planned_version_fingerprint").(string) != "" {
+			return d.SetNew("planned_version_fingerprint", "")
+		}
+		return nil
+	}
+	if d.Id() != "" && !d.HasChanges("config_id", "version", "network", "pre_activation_checks") {
+		return nil
+	}
+	if !d.NewValueKnown("config_id") || !d.NewValueKnown("version") {
+		return d.SetNewComputed("planned_version_fingerprint")
+	}
+
+	client := inst.Client(meta)
+	export, err := client.GetExportConfiguration(ctx, appsec.GetExportConfigurationRequest{
+		ConfigID: d.Get("config_id").(int),
+		Version:  d.Get("version").(int),
+	})
+	if err != nil {
+		logger.Errorf("calling 'getExportConfiguration': %s", err.Error())
+		return err
+	}
+	fingerprint, err := versionFingerprint(export)
+	if err != nil {
+		return err
+	}
+	return d.SetNew("planned_version_fingerprint", fingerprint)
+}
+
+// runPreActivationChecks runs the enabled pre-activation checks against the version to be activated and
+// returns an error diagnostic for every failed check
+func runPreActivationChecks(ctx context.Context, client appsec.APPSEC, d *schema.ResourceData, configID, version int, network string) diag.Diagnostics {
+	checks := getPreActivationChecks(d)
+	if checks == nil {
+		return nil
+	}
+
+	export, err := client.GetExportConfiguration(ctx, appsec.GetExportConfigurationRequest{ConfigID: configID, Version: version})
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("pre-activation checks: %w", err))
+	}
+
+	var diags diag.Diagnostics
+	checkFailed := func(check, detail string) {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("pre-activation check '%s' failed for version %d of configuration %d", check, version, configID),
+			Detail:   detail,
+		})
+	}
+
+	if checks.blockUnexpectedEvaluation {
+		if policies := unexpectedEvaluationPolicies(export, checks.allowedEvaluationPolicies); len(policies) > 0 {
+			checkFailed("block_unexpected_evaluation", fmt.Sprintf(
+				"security policies %s are in evaluation mode. Conclude the evaluation or add them to 'allowed_evaluation_policies'",
+				strings.Join(policies, ", ")))
+		}
+	}
+
+	if checks.blockPendingRuleUpgrade {
+		for _, policy := range export.SecurityPolicies {
+			upgrade, err := client.GetRuleUpgrade(ctx, appsec.GetRuleUpgradeRequest{ConfigID: configID, Version: version, PolicyID: policy.ID})
+			if err != nil {
+				return append(diags, diag.FromErr(fmt.Errorf("pre-activation checks: %w", err))...)
+			}
+			if updates := pendingRuleUpgradeUpdates(upgrade); len(updates) > 0 {
+				checkFailed("block_pending_rule_upgrade", fmt.Sprintf(
+					"a rule upgrade from %s to %s is pending for security policy %s (%s). See the 'akamai_appsec_rule_upgrade_details' data source for details",
+					upgrade.Current, upgrade.Latest, policy.ID, strings.Join(updates, ", ")))
+			}
+		}
+	}
+
+	if checks.blockHostnameChanges {
+		config, err := client.GetConfiguration(ctx, appsec.GetConfigurationRequest{ConfigID: configID})
+		if err != nil {
+			return append(diags, diag.FromErr(fmt.Errorf("pre-activation checks: %w", err))...)
+		}
+		activeVersion := config.StagingVersion
+		if network == string(appsec.NetworkProduction) {
+			activeVersion = config.ProductionVersion
+		}
+		if activeVersion != 0 && activeVersion != version {
+			activeExport, err := client.GetExportConfiguration(ctx, appsec.GetExportConfigurationRequest{ConfigID: configID, Version: activeVersion})
+			if err != nil {
+				return append(diags, diag.FromErr(fmt.Errorf("pre-activation checks: %w", err))...)
+			}
+			if added, removed := selectedHostnamesChanges(activeExport.SelectedHosts, export.SelectedHosts); len(added)+len(removed) > 0 {
+				checkFailed("block_hostname_changes", fmt.Sprintf(
+					"selected hostnames differ from version %d active on %s: added [%s], removed [%s]",
+					activeVersion, network, strings.Join(added, ", "), strings.Join(removed, ", ")))
+			}
+		}
+	}
+
+	if checks.blockModifiedVersion {
+		planned, err := tf.GetStringValue("planned_version_fingerprint", d)
+		if err != nil {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Warning,
+				Summary:  "pre-activation check 'block_modified_version' skipped",
+				Detail:   "the version to be activated was not known at plan time",
+			})
+		} else {
+			fingerprint, err := versionFingerprint(export)
+			if err != nil {
+				return append(diags, diag.FromErr(err)...)
+			}
+			if fingerprint != planned {
+				checkFailed("block_modified_version",
+					"the version was modified after the plan was created. Review the changes and run the plan again")
+			}
+		}
+	}
+
+	return diags
+}
+
+// unexpectedEvaluationPolicies returns the sorted IDs of the security policies in evaluation mode which are not allowed to be
+func unexpectedEvaluationPolicies(export *appsec.GetExportConfigurationResponse, allowed []string) []string {
+	evaluating := make(map[string]struct{})
+	for _, policy := range export.SecurityPolicies {
+		if policy.WebApplicationFirewall.Evaluation != nil {
+			evaluating[policy.ID] = struct{}{}
+		}
+	}
+	for _, policy := range export.Evaluating.SecurityPolicies {
+		evaluating[policy.SecurityPolicyID] = struct{}{}
+	}
+
+	policies := make([]string, 0, len(evaluating))
+	for policyID := range evaluating {
+		if !slices.Contains(allowed, policyID) {
+			policies = append(policies, policyID)
+		}
+	}
+	sort.Strings(policies)
+	return policies
+}
+
+// pendingRuleUpgradeUpdates summarizes the updates available when upgrading to the latest KRS rule set
+func pendingRuleUpgradeUpdates(upgrade *appsec.GetRuleUpgradeResponse) []string {
+	updates := make([]string, 0)
+	if upgrade == nil || upgrade.KRSToLatestUpdates == nil {
+		return updates
+	}
+	latest := upgrade.KRSToLatestUpdates
+	addRules := func(kind string, rules *appsec.RuleData) {
+		if rules != nil && len(*rules) > 0 {
+			updates = append(updates, fmt.Sprintf("%d %s rules", len(*rules), kind))
+		}
+	}
+	addGroups := func(kind string, groups *appsec.GroupData) {
+		if groups != nil && len(*groups) > 0 {
+			updates = append(updates, fmt.Sprintf("%d %s attack groups", len(*groups), kind))
+		}
+	}
+	addRules("new", latest.NewRules)
+	addRules("updated", latest.UpdatedRules)
+	addRules("deleted", latest.DeletedRules)
+	addGroups("new", latest.NewAttackGroups)
+	addGroups("updated", latest.UpdatedAttackGroups)
+	addGroups("deleted", latest.DeletedAttackGroups)
+	return updates
+}
+
+// selectedHostnamesChanges returns the sorted hostnames added to and removed from the active selection
+func selectedHostnamesChanges(active, selected []string) (added, removed []string) {
+	added, removed = make([]string, 0), make([]string, 0)
+	for _, hostname := range selected {
+		if !slices.Contains(active, hostname) {
+			added = append(added, hostname)
+		}
+	}
+	for _, hostname := range active {
+		if !slices.Contains(selected, hostname) {
+			removed = append(removed, hostname)
+		}
+	}
+	sort.Strings(added)
+	sort.Strings(removed)
+	return added, removed
+}
+
+// versionFingerprint returns a hash of the content of an exported configuration version. The activation
+// status of the version is not part of its content.
+func versionFingerprint(export *appsec.GetExportConfigurationResponse) (string, error) {
+	content := *export
+	content.Staging.Status = ""
+	content.Production.Status = ""
+	b, err := json.Marshal(content)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:]), nil
+}
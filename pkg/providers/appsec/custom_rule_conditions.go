@@ -0,0 +1,479 @@
+package appsec
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/netip"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v9/pkg/appsec"
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/common/tf"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"golang.org/x/exp/slices"
+)
+
+type (
+	// customRuleDefinition is the JSON representation of a custom rule defined with the `rule` block
+	customRuleDefinition struct {
+		Name                string                     `json:"name"`
+		Description         string                     `json:"description,omitempty"`
+		Tag                 []string                   `json:"tag,omitempty"`
+		Operation           string                     `json:"operation,omitempty"`
+		SamplingRate        int                        `json:"samplingRate,omitempty"`
+		EffectiveTimePeriod *customRuleEffectivePeriod `json:"effectiveTimePeriod,omitempty"`
+		Conditions          []customRuleCondition      `json:"conditions"`
+	}
+
+	customRuleEffectivePeriod struct {
+		StartDate string `json:"startDate"`
+		EndDate   string `json:"endDate"`
+	}
+
+	customRuleCondition struct {
+		Type                  string      `json:"type"`
+		PositiveMatch         bool        `json:"positiveMatch"`
+		Name                  []string    `json:"name,omitempty"`
+		NameCase              *bool       `json:"nameCase,omitempty"`
+		NameWildcard          *bool       `json:"nameWildcard,omitempty"`
+		Value                 interface{} `json:"value,omitempty"`
+		ValueCase             *bool       `json:"valueCase,omitempty"`
+		ValueExactMatch       *bool       `json:"valueExactMatch,omitempty"`
+		ValueIgnoreSegment    *bool       `json:"valueIgnoreSegment,omitempty"`
+		ValueNormalize        *bool       `json:"valueNormalize,omitempty"`
+		ValueRecursive        *bool       `json:"valueRecursive,omitempty"`
+		ValueWildcard         *bool       `json:"valueWildcard,omitempty"`
+		UseXForwardForHeaders *bool       `json:"useXForwardForHeaders,omitempty"`
+	}
+)
+
+var (
+	// customRuleConditionTypes lists the condition types accepted by the `rule` block
+	customRuleConditionTypes = []string{
+		"argsMatch",
+		"argsNamesMatch",
+		"argsPostMatch",
+		"argsPostNamesMatch",
+		"asNumberMatch",
+		"clientCertPresentMatch",
+		"clientCertValidMatch",
+		"clientTlsFingerprintMatch",
+		"cookieMatch",
+		"cookieNameMatch",
+		"extensionMatch",
+		"filenameMatch",
+		"geoMatch",
+		"headerOrderMatch",
+		"hostMatch",
+		"ipMatch",
+		"pathMatch",
+		"requestHeaderMatch",
+		"requestHeaderValueMatch",
+		"requestMethodMatch",
+		"requestProtocolVersionMatch",
+		"uriQueryMatch",
+	}
+
+	// customRuleNoValueConditionTypes lists condition types which match without any value
+	customRuleNoValueConditionTypes = []string{"clientCertPresentMatch", "clientCertValidMatch"}
+
+	// customRuleScalarValueConditionTypes lists condition types whose value is a single string
+	customRuleScalarValueConditionTypes = []string{"headerOrderMatch", "requestProtocolVersionMatch"}
+
+	// customRuleNamedConditionTypes lists condition types which require the name of the matched element
+	customRuleNamedConditionTypes = []string{"cookieMatch", "requestHeaderMatch", "uriQueryMatch"}
+
+	customRuleRequestMethods = []string{"CONNECT", "DELETE", "GET", "HEAD", "OPTIONS", "PATCH", "POST", "PUT", "TRACE"}
+
+	countryCodeRegexp = regexp.MustCompile("^[A-Z]{2}$")
+)
+
+// customRuleSchema returns the schema of the `rule` block of the akamai_appsec_custom_rule resource
+func customRuleSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:         schema.TypeList,
+		Optional:     true,
+		MaxItems:     1,
+		ExactlyOneOf: []string{"custom_rule", "rule"},
+		Description:  "Structured definition of the custom rule, alternative to the JSON-formatted 'custom_rule'",
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"name": {
+					Type:             schema.TypeString,
+					Required:         true,
+					ValidateDiagFunc: validation.ToDiagFunc(validation.StringIsNotWhiteSpace),
+					Description:      "Name of the custom rule",
+				},
+				"description": {
+					Type:        schema.TypeString,
+					Optional:    true,
+					Description: "Description of the custom rule",
+				},
+				"tag": {
+					Type:        schema.TypeList,
+					Optional:    true,
+					Elem:        &schema.Schema{Type: schema.TypeString},
+					Description: "List of labels assigned to the custom rule",
+				},
+				"operation": {
+					Type:             schema.TypeString,
+					Optional:         true,
+					Default:          "AND",
+					ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice([]string{"AND", "OR"}, false)),
+					Description:      "Whether all ('AND') or any ('OR') of the conditions have to match",
+				},
+				"sampling_rate": {
+					Type:             schema.TypeInt,
+					Optional:         true,
+					ValidateDiagFunc: validation.ToDiagFunc(validation.IntBetween(0, 100)),
+					Description:      "Percentage of requests evaluated by the custom rule",
+				},
+				"effective_time_period": {
+					Type:     schema.TypeList,
+					Optional: true,
+					MaxItems: 1,
+					Elem: &schema.Resource{
+						Schema: map[string]*schema.Schema{
+							"start_date": {
+								Type:             schema.TypeString,
+								Required:         true,
+								ValidateDiagFunc: validation.ToDiagFunc(validation.IsRFC3339Time),
+								Description:      "Date and time in RFC 3339 format when the custom rule becomes active",
+							},
+							"end_date": {
+								Type:             schema.TypeString,
+								Required:         true,
+								ValidateDiagFunc: validation.ToDiagFunc(validation.IsRFC3339Time),
+								Description:      "Date and time in RFC 3339 format when the custom rule stops being active",
+							},
+						},
+					},
+					Description: "Period during which the custom rule is active",
+				},
+				"condition": {
+					Type:        schema.TypeList,
+					Required:    true,
+					MinItems:    1,
+					Elem:        customRuleConditionResource(),
+					Description: "Conditions which a request has to match to trigger the custom rule",
+				},
+			},
+		},
+	}
+}
+
+func customRuleConditionResource() *schema.Resource {
+	flag := func(description string) *schema.Schema {
+		return &schema.Schema{
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Description: description,
+		}
+	}
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"type": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice(customRuleConditionTypes, false)),
+				Description:      "Type of the condition",
+			},
+			"positive_match": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Whether the condition triggers on a match (true) or on a lack of match (false)",
+			},
+			"value": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Values to match",
+			},
+			"value_case":                flag("Whether the values are matched case sensitively"),
+			"value_wildcard":            flag("Whether the values may contain '?' and '*' wildcards"),
+			"value_exact_match":         flag("Whether the values have to match exactly"),
+			"value_ignore_segment":      flag("Whether path segment parameters are ignored when matching the values"),
+			"value_normalize":           flag("Whether the request is normalized before matching the values"),
+			"value_recursive":           flag("Whether the values are matched recursively in subdirectories"),
+			"use_x_forward_for_headers": flag("Whether the client IP is taken from the X-Forwarded-For header"),
+			"name_case":                 flag("Whether the names are matched case sensitively"),
+			"name_wildcard":             flag("Whether the names may contain '?' and '*' wildcards"),
+			"name": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Names of the matched header, cookie or query argument",
+			},
+		},
+	}
+}
+
+// customDiffCustomRule validates the conditions of the `rule` block and marks the JSON definition as changing
+// together with it
+func customDiffCustomRule(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	rules, ok := d.Get("rule").([]interface{})
+	if !ok || len(rules) == 0 || rules[0] == nil {
+		return nil
+	}
+	rule := rules[0].(map[string]interface{})
+
+	conditions, _ := rule["condition"].([]interface{})
+	for i, conditionRaw := range conditions {
+		condition, ok := conditionRaw.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		known := func(key string) bool {
+			return d.NewValueKnown(fmt.Sprintf("rule.0.condition.%d.%s", i, key))
+		}
+		if err := validateCustomRuleCondition(condition, known); err != nil {
+			return fmt.Errorf("rule.0.condition.%d: %w", i, err)
+		}
+	}
+
+	if periods, _ := rule["effective_time_period"].([]interface{}); len(periods) > 0 && periods[0] != nil {
+		period := periods[0].(map[string]interface{})
+		start, errStart := time.Parse(time.RFC3339, period["start_date"].(string))
+		end, errEnd := time.Parse(time.RFC3339, period["end_date"].(string))
+		if errStart == nil && errEnd == nil && !start.Before(end) {
+			return fmt.Errorf("'start_date' of 'effective_time_period' has to be before 'end_date'")
+		}
+	}
+
+	if d.HasChange("rule") && d.Id() != "" {
+		return d.SetNewComputed("custom_rule")
+	}
+	return nil
+}
+
+// validateCustomRuleCondition checks that the values of a condition are valid for its type.
+// Attributes for which known returns false are not known yet at plan time and are not validated.
+func validateCustomRuleCondition(condition map[string]interface{}, known func(key string) bool) error {
+	conditionType, _ := condition["type"].(string)
+	if !known("type") || !known("value") {
+		return nil
+	}
+	values := tf.InterfaceSliceToStringSlice(condition["value"].([]interface{}))
+
+	switch {
+	case slices.Contains(customRuleNoValueConditionTypes, conditionType):
+		if len(values) > 0 {
+			return fmt.Errorf("condition of type '%s' does not accept 'value'", conditionType)
+		}
+		return nil
+	case len(values) == 0:
+		return fmt.Errorf("condition of type '%s' requires at least one 'value'", conditionType)
+	case slices.Contains(customRuleScalarValueConditionTypes, conditionType) && len(values) > 1:
+		return fmt.Errorf("condition of type '%s' accepts exactly one 'value', got: %d", conditionType, len(values))
+	}
+
+	if known("name") && slices.Contains(customRuleNamedConditionTypes, conditionType) && len(tf.InterfaceSliceToStringSlice(condition["name"].([]interface{}))) == 0 {
+		return fmt.Errorf("condition of type '%s' requires 'name'", conditionType)
+	}
+
+	for _, value := range values {
+		if err := validateCustomRuleConditionValue(conditionType, value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func validateCustomRuleConditionValue(conditionType, value string) error {
+	switch conditionType {
+	case "requestMethodMatch":
+		if !slices.Contains(customRuleRequestMethods, value) {
+			return fmt.Errorf("condition of type 'requestMethodMatch' accepts only %v, got: '%s'", customRuleRequestMethods, value)
+		}
+	case "ipMatch":
+		if _, err := netip.ParsePrefix(value); err == nil {
+			return nil
+		}
+		if _, err := netip.ParseAddr(value); err != nil {
+			return fmt.Errorf("condition of type 'ipMatch' accepts only IP addresses and CIDR blocks, got: '%s'", value)
+		}
+	case "geoMatch":
+		if !countryCodeRegexp.MatchString(value) {
+			return fmt.Errorf("condition of type 'geoMatch' accepts only two-letter country codes, got: '%s'", value)
+		}
+	case "asNumberMatch":
+		if n, err := strconv.ParseUint(value, 10, 32); err != nil || n == 0 {
+			return fmt.Errorf("condition of type 'asNumberMatch' accepts only AS numbers, got: '%s'", value)
+		}
+	case "pathMatch":
+		if !strings.HasPrefix(value, "/") && !strings.HasPrefix(value, "*") {
+			return fmt.Errorf("condition of type 'pathMatch' accepts only paths starting with '/', got: '%s'", value)
+		}
+	}
+	return nil
+}
+
+// customRulePayloadFromSchema builds the JSON definition of the custom rule from the `rule` block
+func customRulePayloadFromSchema(d *schema.ResourceData) (json.RawMessage, error) {
+	rules, ok := d.Get("rule").([]interface{})
+	if !ok || len(rules) == 0 || rules[0] == nil {
+		return nil, fmt.Errorf("'rule' block is not defined")
+	}
+	rule := rules[0].(map[string]interface{})
+
+	payload := customRuleDefinition{
+		Name:         rule["name"].(string),
+		Description:  rule["description"].(string),
+		Tag:          tf.InterfaceSliceToStringSlice(rule["tag"].([]interface{})),
+		Operation:    rule["operation"].(string),
+		SamplingRate: rule["sampling_rate"].(int),
+		Conditions:   []customRuleCondition{},
+	}
+	if periods, _ := rule["effective_time_period"].([]interface{}); len(periods) > 0 && periods[0] != nil {
+		period := periods[0].(map[string]interface{})
+		payload.EffectiveTimePeriod = &customRuleEffectivePeriod{
+			StartDate: period["start_date"].(string),
+			EndDate:   period["end_date"].(string),
+		}
+	}
+
+	conditions, _ := rule["condition"].([]interface{})
+	for _, conditionRaw := range conditions {
+		c := conditionRaw.(map[string]interface{})
+		condition := customRuleCondition{
+			Type:                  c["type"].(string),
+			PositiveMatch:         c["positive_match"].(bool),
+			Name:                  tf.InterfaceSliceToStringSlice(c["name"].([]interface{})),
+			NameCase:              optionalBool(c["name_case"]),
+			NameWildcard:          optionalBool(c["name_wildcard"]),
+			ValueCase:             optionalBool(c["value_case"]),
+			ValueExactMatch:       optionalBool(c["value_exact_match"]),
+			ValueIgnoreSegment:    optionalBool(c["value_ignore_segment"]),
+			ValueNormalize:        optionalBool(c["value_normalize"]),
+			ValueRecursive:        optionalBool(c["value_recursive"]),
+			ValueWildcard:         optionalBool(c["value_wildcard"]),
+			UseXForwardForHeaders: optionalBool(c["use_x_forward_for_headers"]),
+		}
+		values := tf.InterfaceSliceToStringSlice(c["value"].([]interface{}))
+		switch {
+		case len(values) == 0:
+		case slices.Contains(customRuleScalarValueConditionTypes, condition.Type):
+			condition.Value = values[0]
+		default:
+			condition.Value = values
+		}
+		payload.Conditions = append(payload.Conditions, condition)
+	}
+
+	return json.Marshal(payload)
+}
+
+// flattenCustomRule converts the custom rule returned by the API into the `rule` block
+func flattenCustomRule(customRule *appsec.GetCustomRuleResponse) ([]interface{}, error) {
+	tags := make([]interface{}, 0, len(customRule.Tag))
+	for _, tag := range customRule.Tag {
+		tags = append(tags, tag)
+	}
+	operation := customRule.Operation
+	if operation == "" {
+		operation = "AND"
+	}
+	rule := map[string]interface{}{
+		"name":                  customRule.Name,
+		"description":           customRule.Description,
+		"tag":                   tags,
+		"operation":             operation,
+		"sampling_rate":         customRule.SamplingRate,
+		"effective_time_period": []interface{}{},
+	}
+	if customRule.EffectiveTimePeriod != nil {
+		rule["effective_time_period"] = []interface{}{map[string]interface{}{
+			"start_date": customRule.EffectiveTimePeriod.StartDate,
+			"end_date":   customRule.EffectiveTimePeriod.EndDate,
+		}}
+	}
+
+	conditions := make([]interface{}, 0, len(customRule.Conditions))
+	for _, c := range customRule.Conditions {
+		names, err := rawStringOrList(c.Name)
+		if err != nil {
+			return nil, fmt.Errorf("condition '%s' has invalid 'name': %s", c.Type, err)
+		}
+		values, err := rawStringOrList(c.Value)
+		if err != nil {
+			return nil, fmt.Errorf("condition '%s' has invalid 'value': %s", c.Type, err)
+		}
+		conditions = append(conditions, map[string]interface{}{
+			"type":                      c.Type,
+			"positive_match":            c.PositiveMatch,
+			"name":                      names,
+			"name_case":                 boolValue(c.NameCase),
+			"name_wildcard":             boolValue(c.NameWildcard),
+			"value":                     values,
+			"value_case":                boolValue(c.ValueCase),
+			"value_exact_match":         boolValue(c.ValueExactMatch),
+			"value_ignore_segment":      boolValue(c.ValueIgnoreSegment),
+			"value_normalize":           boolValue(c.ValueNormalize),
+			"value_recursive":           boolValue(c.ValueRecursive),
+			"value_wildcard":            boolValue(c.ValueWildcard),
+			"use_x_forward_for_headers": boolValue(c.UseXForwardForHeaders),
+		})
+	}
+	rule["condition"] = conditions
+
+	return []interface{}{rule}, nil
+}
+
+// rawStringOrList decodes a JSON value which is either a single string or number, or a list of them. Numbers, such as
+// the values of a request count condition, are formatted as strings.
+func rawStringOrList(raw *json.RawMessage) ([]interface{}, error) {
+	result := []interface{}{}
+	if raw == nil {
+		return result, nil
+	}
+	decoder := json.NewDecoder(bytes.NewReader(*raw))
+	decoder.UseNumber()
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		return nil, err
+	}
+	items, ok := value.([]interface{})
+	if !ok {
+		items = []interface{}{value}
+	}
+	for _, item := range items {
+		str, err := stringValue(item)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, str)
+	}
+	return result, nil
+}
+
+// stringValue formats a decoded JSON string or number as a string
+func stringValue(value interface{}) (string, error) {
+	switch v := value.(type) {
+	case string:
+		return v, nil
+	case json.Number:
+		return v.String(), nil
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), nil
+	default:
+		return "", fmt.Errorf("expected a string or a number, got %v", value)
+	}
+}
+
+// optionalBool returns nil for false so that flags which are not set are omitted from the JSON definition
+func optionalBool(raw interface{}) *bool {
+	if b, ok := raw.(bool); ok && b {
+		return &b
+	}
+	return nil
+}
+
+func boolValue(b *bool) bool {
+	return b != nil && *b
+}
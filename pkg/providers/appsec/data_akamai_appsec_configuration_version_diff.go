@@ -0,0 +1,344 @@
+package appsec
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v9/pkg/appsec"
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/common/tf"
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/meta"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"golang.org/x/exp/slices"
+)
+
+type (
+	// configVersionChange describes a single difference between two versions of a security configuration
+	configVersionChange struct {
+		SecurityPolicyID string `json:"securityPolicyId,omitempty"`
+		Area             string `json:"area"`
+		Key              string `json:"key"`
+		ChangeType       string `json:"changeType"`
+		FromValue        string `json:"fromValue,omitempty"`
+		ToValue          string `json:"toValue,omitempty"`
+	}
+
+	configVersionItemKey struct {
+		securityPolicyID string
+		area             string
+		key              string
+	}
+)
+
+const (
+	configDiffAreaSecurityPolicies   = "security_policies"
+	configDiffAreaWAFRuleActions     = "waf_rule_actions"
+	configDiffAreaAttackGroupActions = "attack_group_actions"
+	configDiffAreaRatePolicies       = "rate_policies"
+	configDiffAreaRatePolicyActions  = "rate_policy_actions"
+	configDiffAreaMatchTargets       = "match_targets"
+	configDiffAreaCustomRules        = "custom_rules"
+	configDiffAreaCustomRuleActions  = "custom_rule_actions"
+	configDiffAreaIPGeoFirewall      = "ip_geo_firewall"
+
+	configDiffChangeAdded    = "added"
+	configDiffChangeRemoved  = "removed"
+	configDiffChangeModified = "modified"
+)
+
+var configDiffAreas = []string{
+	configDiffAreaSecurityPolicies,
+	configDiffAreaWAFRuleActions,
+	configDiffAreaAttackGroupActions,
+	configDiffAreaRatePolicies,
+	configDiffAreaRatePolicyActions,
+	configDiffAreaMatchTargets,
+	configDiffAreaCustomRules,
+	configDiffAreaCustomRuleActions,
+	configDiffAreaIPGeoFirewall,
+}
+
+func dataSourceConfigurationVersionDiff() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceConfigurationVersionDiffRead,
+		Schema: map[string]*schema.Schema{
+			"config_id": {
+				Type:        schema.TypeInt,
+				Required:    true,
+				Description: "Unique identifier of the security configuration",
+			},
+			"from_version": {
+				Type:             schema.TypeInt,
+				Required:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.IntAtLeast(1)),
+				Description:      "Version of the security configuration to compare from, for example the version active in production",
+			},
+			"to_version": {
+				Type:             schema.TypeInt,
+				Required:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.IntAtLeast(1)),
+				Description:      "Version of the security configuration to compare to, for example the version about to be activated",
+			},
+			"areas": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type:             schema.TypeString,
+					ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice(configDiffAreas, false)),
+				},
+				Description: "Protection areas to compare. All areas are compared if not specified",
+			},
+			"changes": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Differences between the two versions, ordered by security policy, area and key",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"security_policy_id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Security policy the change belongs to. Empty for configuration-wide areas",
+						},
+						"area": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Protection area of the change",
+						},
+						"key": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Identifier of the changed item within the area",
+						},
+						"change_type": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Type of the change: added, removed or modified",
+						},
+						"from_value": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "JSON representation of the item in 'from_version'",
+						},
+						"to_value": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "JSON representation of the item in 'to_version'",
+						},
+					},
+				},
+			},
+			"json": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "JSON representation",
+			},
+			"output_text": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Text representation",
+			},
+		},
+	}
+}
+
+func dataSourceConfigurationVersionDiffRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	meta := meta.Must(m)
+	client := inst.Client(meta)
+	logger := meta.Log("APPSEC", "dataSourceConfigurationVersionDiffRead")
+
+	configID, err := tf.GetIntValue("config_id", d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	fromVersion, err := tf.GetIntValue("from_version", d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	toVersion, err := tf.GetIntValue("to_version", d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	areas, err := tf.GetListValue("areas", d)
+	if err != nil && !errors.Is(err, tf.ErrNotFound) {
+		return diag.FromErr(err)
+	}
+
+	fromExport, err := client.GetExportConfiguration(ctx, appsec.GetExportConfigurationRequest{ConfigID: configID, Version: fromVersion})
+	if err != nil {
+		logger.Errorf("calling 'getExportConfiguration': %s", err.Error())
+		return diag.FromErr(err)
+	}
+	toExport, err := client.GetExportConfiguration(ctx, appsec.GetExportConfigurationRequest{ConfigID: configID, Version: toVersion})
+	if err != nil {
+		logger.Errorf("calling 'getExportConfiguration': %s", err.Error())
+		return diag.FromErr(err)
+	}
+
+	changes, err := diffExportConfigurations(fromExport, toExport, tf.InterfaceSliceToStringSlice(areas))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	changesList := make([]interface{}, 0, len(changes))
+	for _, change := range changes {
+		changesList = append(changesList, map[string]interface{}{
+			"security_policy_id": change.SecurityPolicyID,
+			"area":               change.Area,
+			"key":                change.Key,
+			"change_type":        change.ChangeType,
+			"from_value":         change.FromValue,
+			"to_value":           change.ToValue,
+		})
+	}
+	if err := d.Set("changes", changesList); err != nil {
+		return diag.Errorf("%s: %s", tf.ErrValueSet, err.Error())
+	}
+
+	jsonBody, err := json.Marshal(changes)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("json", string(jsonBody)); err != nil {
+		return diag.Errorf("%s: %s", tf.ErrValueSet, err.Error())
+	}
+
+	if len(changes) > 0 {
+		ots := OutputTemplates{}
+		InitTemplates(ots)
+		outputtext, err := RenderTemplates(ots, "configurationVersionDiffDS", changes)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		if err := d.Set("output_text", outputtext); err != nil {
+			return diag.Errorf("%s: %s", tf.ErrValueSet, err.Error())
+		}
+	}
+
+	d.SetId(fmt.Sprintf("%d:%d:%d", configID, fromVersion, toVersion))
+
+	return nil
+}
+
+// diffExportConfigurations compares two exported versions of a security configuration. Only the given areas
+// are compared, or all of them if areas is empty.
+func diffExportConfigurations(from, to *appsec.GetExportConfigurationResponse, areas []string) ([]configVersionChange, error) {
+	fromItems, err := configVersionItems(from)
+	if err != nil {
+		return nil, err
+	}
+	toItems, err := configVersionItems(to)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := make([]configVersionChange, 0)
+	addChange := func(key configVersionItemKey, changeType, fromValue, toValue string) {
+		if len(areas) > 0 && !slices.Contains(areas, key.area) {
+			return
+		}
+		changes = append(changes, configVersionChange{
+			SecurityPolicyID: key.securityPolicyID,
+			Area:             key.area,
+			Key:              key.key,
+			ChangeType:       changeType,
+			FromValue:        fromValue,
+			ToValue:          toValue,
+		})
+	}
+	for key, fromValue := range fromItems {
+		toValue, ok := toItems[key]
+		switch {
+		case !ok:
+			addChange(key, configDiffChangeRemoved, fromValue, "")
+		case toValue != fromValue:
+			addChange(key, configDiffChangeModified, fromValue, toValue)
+		}
+	}
+	for key, toValue := range toItems {
+		if _, ok := fromItems[key]; !ok {
+			addChange(key, configDiffChangeAdded, "", toValue)
+		}
+	}
+
+	sort.Slice(changes, func(i, j int) bool {
+		a, b := changes[i], changes[j]
+		if a.SecurityPolicyID != b.SecurityPolicyID {
+			return a.SecurityPolicyID < b.SecurityPolicyID
+		}
+		if a.Area != b.Area {
+			return slices.Index(configDiffAreas, a.Area) < slices.Index(configDiffAreas, b.Area)
+		}
+		return lessConfigDiffKey(a.Key, b.Key)
+	})
+	return changes, nil
+}
+
+// configVersionItems indexes the compared items of an exported security configuration by security policy,
+// area and key. Items are represented by their JSON encoding.
+func configVersionItems(export *appsec.GetExportConfigurationResponse) (map[configVersionItemKey]string, error) {
+	items := make(map[configVersionItemKey]string)
+	var err error
+	add := func(policyID, area, key string, value interface{}) {
+		if err != nil {
+			return
+		}
+		var b []byte
+		if b, err = json.Marshal(value); err == nil {
+			items[configVersionItemKey{securityPolicyID: policyID, area: area, key: key}] = string(b)
+		}
+	}
+
+	for _, policy := range export.SecurityPolicies {
+		add(policy.ID, configDiffAreaSecurityPolicies, policy.ID, map[string]interface{}{
+			"name":             policy.Name,
+			"securityControls": policy.SecurityControls,
+		})
+		for _, ruleAction := range policy.WebApplicationFirewall.RuleActions {
+			add(policy.ID, configDiffAreaWAFRuleActions, strconv.Itoa(ruleAction.ID), ruleAction)
+		}
+		for _, groupAction := range policy.WebApplicationFirewall.AttackGroupActions {
+			add(policy.ID, configDiffAreaAttackGroupActions, groupAction.Group, groupAction)
+		}
+		if policy.RatePolicyActions != nil {
+			for _, ratePolicyAction := range *policy.RatePolicyActions {
+				add(policy.ID, configDiffAreaRatePolicyActions, strconv.Itoa(ratePolicyAction.ID), ratePolicyAction)
+			}
+		}
+		for _, customRuleAction := range policy.CustomRuleActions {
+			add(policy.ID, configDiffAreaCustomRuleActions, strconv.Itoa(customRuleAction.ID), customRuleAction)
+		}
+		if policy.IPGeoFirewall != nil {
+			add(policy.ID, configDiffAreaIPGeoFirewall, policy.ID, policy.IPGeoFirewall)
+		}
+	}
+
+	for _, target := range export.MatchTargets.WebsiteTargets {
+		add(target.SecurityPolicy.PolicyID, configDiffAreaMatchTargets, strconv.Itoa(target.ID), target)
+	}
+	for _, target := range export.MatchTargets.APITargets {
+		add(target.SecurityPolicy.PolicyID, configDiffAreaMatchTargets, strconv.Itoa(target.TargetID), target)
+	}
+	for _, ratePolicy := range export.RatePolicies {
+		add("", configDiffAreaRatePolicies, strconv.Itoa(ratePolicy.ID), ratePolicy)
+	}
+	for _, customRule := range export.CustomRules {
+		add("", configDiffAreaCustomRules, strconv.Itoa(customRule.ID), customRule)
+	}
+
+	return items, err
+}
+
+// lessConfigDiffKey orders numeric keys by their value and other keys lexically
+func lessConfigDiffKey(a, b string) bool {
+	ai, errA := strconv.Atoi(a)
+	bi, errB := strconv.Atoi(b)
+	if errA == nil && errB == nil {
+		return ai < bi
+	}
+	return a < b
+}
@@ -0,0 +1,122 @@
+package appsec
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v9/pkg/appsec"
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/common/testutils"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func loadExportConfiguration(t *testing.T) *appsec.GetExportConfigurationResponse {
+	export := appsec.GetExportConfigurationResponse{}
+	err := json.Unmarshal(testutils.LoadFixtureBytes(t, "testdata/TestDSExportConfiguration/ExportConfiguration.json"), &export)
+	require.NoError(t, err)
+	return &export
+}
+
+func TestAkamaiConfigurationVersionDiff_data_basic(t *testing.T) {
+	t.Run("ConfigurationVersionDiff", func(t *testing.T) {
+		client := &appsec.Mock{}
+
+		fromExport := loadExportConfiguration(t)
+		toExport := loadExportConfiguration(t)
+		toExport.Version = 8
+		toExport.SecurityPolicies[0].WebApplicationFirewall.RuleActions[0].Action = "deny"
+
+		client.On("GetExportConfiguration",
+			mock.Anything,
+			appsec.GetExportConfigurationRequest{ConfigID: 43253, Version: 7},
+		).Return(fromExport, nil)
+		client.On("GetExportConfiguration",
+			mock.Anything,
+			appsec.GetExportConfigurationRequest{ConfigID: 43253, Version: 8},
+		).Return(toExport, nil)
+
+		useClient(client, func() {
+			resource.Test(t, resource.TestCase{
+				IsUnitTest:               true,
+				ProtoV6ProviderFactories: testutils.NewProtoV6ProviderFactory(NewSubprovider()),
+				Steps: []resource.TestStep{
+					{
+						Config: testutils.LoadFixtureString(t, "testdata/TestDSConfigurationVersionDiff/match_by_id.tf"),
+						Check: resource.ComposeAggregateTestCheckFunc(
+							resource.TestCheckResourceAttr("data.akamai_appsec_configuration_version_diff.test", "id", "43253:7:8"),
+							resource.TestCheckResourceAttr("data.akamai_appsec_configuration_version_diff.test", "changes.#", "1"),
+							resource.TestCheckResourceAttr("data.akamai_appsec_configuration_version_diff.test", "changes.0.security_policy_id", "AAAA_81230"),
+							resource.TestCheckResourceAttr("data.akamai_appsec_configuration_version_diff.test", "changes.0.area", "waf_rule_actions"),
+							resource.TestCheckResourceAttr("data.akamai_appsec_configuration_version_diff.test", "changes.0.key", "950002"),
+							resource.TestCheckResourceAttr("data.akamai_appsec_configuration_version_diff.test", "changes.0.change_type", "modified"),
+						),
+					},
+				},
+			})
+		})
+
+		client.AssertExpectations(t)
+	})
+}
+
+func TestDiffExportConfigurations(t *testing.T) {
+	from := loadExportConfiguration(t)
+	to := loadExportConfiguration(t)
+
+	to.SecurityPolicies[0].WebApplicationFirewall.RuleActions[0].Action = "deny"
+	to.CustomRules = to.CustomRules[:len(to.CustomRules)-1]
+	to.MatchTargets.WebsiteTargets[0].Hostnames = append(to.MatchTargets.WebsiteTargets[0].Hostnames, "new.example.com")
+	to.SecurityPolicies[0].IPGeoFirewall = &appsec.IPGeoFirewall{Block: "blockAllTrafficExceptAllowedIPs"}
+	addedRule := from.CustomRules[0]
+	addedRule.ID = 1
+	to.CustomRules = append(to.CustomRules, addedRule)
+
+	t.Run("all areas", func(t *testing.T) {
+		changes, err := diffExportConfigurations(from, to, nil)
+		require.NoError(t, err)
+
+		type change struct{ policy, area, key, changeType string }
+		var result []change
+		for _, c := range changes {
+			result = append(result, change{c.SecurityPolicyID, c.Area, c.Key, c.ChangeType})
+		}
+		assert.Equal(t, []change{
+			{"", "custom_rules", "1", "added"},
+			{"", "custom_rules", "60036378", "removed"},
+			{"AAAA_81230", "waf_rule_actions", "950002", "modified"},
+			{"AAAA_81230", "match_targets", "3008967", "modified"},
+			{"AAAA_81230", "ip_geo_firewall", "AAAA_81230", "modified"},
+		}, result)
+
+		assert.Contains(t, changes[2].FromValue, `"action":"alert"`)
+		assert.Contains(t, changes[2].ToValue, `"action":"deny"`)
+		assert.Empty(t, changes[0].FromValue)
+		assert.Empty(t, changes[1].ToValue)
+	})
+
+	t.Run("selected areas", func(t *testing.T) {
+		changes, err := diffExportConfigurations(from, to, []string{"ip_geo_firewall"})
+		require.NoError(t, err)
+		require.Len(t, changes, 1)
+		assert.Equal(t, "ip_geo_firewall", changes[0].Area)
+	})
+
+	t.Run("same version", func(t *testing.T) {
+		changes, err := diffExportConfigurations(from, loadExportConfiguration(t), nil)
+		require.NoError(t, err)
+		assert.Empty(t, changes)
+	})
+
+	t.Run("rendered summary", func(t *testing.T) {
+		changes, err := diffExportConfigurations(from, to, []string{"waf_rule_actions"})
+		require.NoError(t, err)
+		ots := OutputTemplates{}
+		InitTemplates(ots)
+		output, err := RenderTemplates(ots, "configurationVersionDiffDS", changes)
+		require.NoError(t, err)
+		assert.Contains(t, output, "ConfigurationVersionDiff")
+		assert.Regexp(t, `AAAA_81230\s*\|\s*waf_rule_actions\s*\|\s*950002\s*\|\s*modified`, output)
+	})
+}
@@ -12,6 +12,10 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
+// GetExportConfiguration returns the export of the given version of a security configuration. API calls are made
+// using the supplied context and the API client obtained from m.
+var GetExportConfiguration = getExportConfiguration
+
 func dataSourceExportConfiguration() *schema.Resource {
 	return &schema.Resource{
 		ReadContext: dataSourceExportConfigurationRead,
@@ -100,3 +104,16 @@ func dataSourceExportConfigurationRead(ctx context.Context, d *schema.ResourceDa
 
 	return nil
 }
+
+func getExportConfiguration(ctx context.Context, configID, version int, m interface{}) (*appsec.GetExportConfigurationResponse, error) {
+	meta := meta.Must(m)
+	client := inst.Client(meta)
+	logger := meta.Log("APPSEC", "getExportConfiguration")
+
+	exportConfiguration, err := client.GetExportConfiguration(ctx, appsec.GetExportConfigurationRequest{ConfigID: configID, Version: version})
+	if err != nil {
+		logger.Errorf("calling 'getExportConfiguration': %s", err.Error())
+		return nil, err
+	}
+	return exportConfiguration, nil
+}
@@ -103,11 +103,13 @@ func (p *Subprovider) SDKResources() map[string]*schema.Resource {
 		"akamai_appsec_security_policy":                          resourceSecurityPolicy(),
 		"akamai_appsec_security_policy_default_protections":      resourceSecurityPolicyDefaultProtections(),
 		"akamai_appsec_security_policy_rename":                   resourceSecurityPolicyRename(),
+		"akamai_appsec_security_policy_settings":                 resourceSecurityPolicySettings(),
 		"akamai_appsec_selected_hostnames":                       resourceSelectedHostname(),
 		"akamai_appsec_siem_settings":                            resourceSiemSettings(),
 		"akamai_appsec_slow_post":                                resourceSlowPostProtectionSetting(),
 		"akamai_appsec_slowpost_protection":                      resourceSlowPostProtection(),
 		"akamai_appsec_threat_intel":                             resourceThreatIntel(),
+		"akamai_appsec_tuning_recommendation_exceptions":         resourceTuningRecommendationExceptions(),
 		"akamai_appsec_version_notes":                            resourceVersionNotes(),
 		"akamai_appsec_waf_mode":                                 resourceWAFMode(),
 		"akamai_appsec_waf_protection":                           resourceWAFProtection(),
@@ -131,6 +133,7 @@ func (p *Subprovider) SDKDataSources() map[string]*schema.Resource {
 		"akamai_appsec_bypass_network_lists":                     dataSourceBypassNetworkLists(),
 		"akamai_appsec_configuration":                            dataSourceConfiguration(),
 		"akamai_appsec_configuration_version":                    dataSourceConfigurationVersion(),
+		"akamai_appsec_configuration_version_diff":               dataSourceConfigurationVersionDiff(),
 		"akamai_appsec_contracts_groups":                         dataSourceContractsGroups(),
 		"akamai_appsec_custom_deny":                              dataSourceCustomDeny(),
 		"akamai_appsec_custom_rule_actions":                      dataSourceCustomRuleActions(),
@@ -4,10 +4,12 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net/http"
 	"strconv"
 	"time"
 
 	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v9/pkg/appsec"
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/common/activation"
 	"github.com/akamai/terraform-provider-akamai/v6/pkg/common/tf"
 	"github.com/akamai/terraform-provider-akamai/v6/pkg/meta"
 	"github.com/hashicorp/go-hclog"
@@ -27,6 +29,7 @@ func resourceActivations() *schema.Resource {
 		DeleteContext: resourceActivationsDelete,
 		CustomizeDiff: customdiff.All(
 			VerifyIDUnchanged,
+			customDiffPlannedVersionFingerprint,
 		),
 		Importer: &schema.ResourceImporter{
 			StateContext: resourceImporter,
@@ -66,6 +69,12 @@ func resourceActivations() *schema.Resource {
 				Computed:    true,
 				Description: "The results of the activation",
 			},
+			"pre_activation_checks": preActivationChecksSchema(),
+			"planned_version_fingerprint": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Fingerprint of the version content recorded at plan time by the 'block_modified_version' pre-activation check",
+			},
 		},
 		Timeouts: &schema.ResourceTimeout{
 			Default: &AppsecResourceTimeout,
@@ -83,7 +92,7 @@ var (
 	ActivationPollInterval = ActivationPollMinimum
 
 	// AppsecResourceTimeout is the default timeout for the resource operations
-	AppsecResourceTimeout = time.Minute * 90
+	AppsecResourceTimeout = activation.DefaultTimeout
 
 	// CreateActivationRetry poll wait time code waits between retries for activation creation
 	CreateActivationRetry = 10 * time.Second
@@ -124,6 +133,11 @@ func resourceActivationsCreate(ctx context.Context, d *schema.ResourceData, m in
 	}
 	notificationEmails := tf.SetToStringSlice(notificationEmailsSet)
 
+	diags := runPreActivationChecks(ctx, client, d, configID, version, network)
+	if diags.HasError() {
+		return diags
+	}
+
 	createActivationRequest := appsec.CreateActivationsRequest{
 		Action:             string(appsec.ActivationTypeActivate),
 		Network:            network,
@@ -150,14 +164,14 @@ func resourceActivationsCreate(ctx context.Context, d *schema.ResourceData, m in
 		ActivationID: activationResp.ActivationID,
 	}
 
-	activation, err := lookupActivation(ctx, client, getActivationRequest)
+	act, err := lookupActivation(ctx, client, getActivationRequest)
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	if err = pollActivation(ctx, client, activation.Status, getActivationRequest); err != nil {
-		return diag.FromErr(err)
+	if _, err = pollActivation(ctx, client, act.Status, getActivationRequest, appsec.StatusActive); err != nil {
+		return activation.Diagnostics("waiting for security configuration activation", err)
 	}
-	return resourceActivationsRead(ctx, d, m)
+	return append(diags, resourceActivationsRead(ctx, d, m)...)
 }
 
 func resourceActivationsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
@@ -206,6 +220,13 @@ func resourceActivationsUpdate(ctx context.Context, d *schema.ResourceData, m in
 	logger := meta.Log("APPSEC", "resourceActivationsUpdate")
 	logger.Debug("in resourceActivationsUpdate")
 
+	if !d.HasChanges("config_id", "version", "network", "note", "notification_emails") {
+		return resourceActivationsRead(ctx, d, m)
+	}
+
+	// Keep the previous state until the activation completes, so that a failed activation is planned again
+	d.Partial(true)
+
 	configID, err := tf.GetIntValue("config_id", d)
 	if err != nil {
 		return diag.FromErr(err)
@@ -235,6 +256,11 @@ func resourceActivationsUpdate(ctx context.Context, d *schema.ResourceData, m in
 	}
 	notificationEmails := tf.SetToStringSlice(notificationEmailsSet)
 
+	diags := runPreActivationChecks(ctx, client, d, configID, version, network)
+	if diags.HasError() {
+		return diags
+	}
+
 	createActivationRequest := appsec.CreateActivationsRequest{
 		Action:             string(appsec.ActivationTypeActivate),
 		Network:            network,
@@ -261,15 +287,16 @@ func resourceActivationsUpdate(ctx context.Context, d *schema.ResourceData, m in
 		ActivationID: activationResp.ActivationID,
 	}
 
-	activation, err := lookupActivation(ctx, client, getActivationRequest)
+	act, err := lookupActivation(ctx, client, getActivationRequest)
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	if err = pollActivation(ctx, client, activation.Status, getActivationRequest); err != nil {
-		return diag.FromErr(err)
+	if _, err = pollActivation(ctx, client, act.Status, getActivationRequest, appsec.StatusActive); err != nil {
+		return activation.Diagnostics("waiting for security configuration activation", err)
 	}
+	d.Partial(false)
 
-	return resourceActivationsRead(ctx, d, m)
+	return append(diags, resourceActivationsRead(ctx, d, m)...)
 }
 
 func resourceActivationsDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
@@ -340,26 +367,16 @@ func resourceActivationsDelete(ctx context.Context, d *schema.ResourceData, m in
 		ActivationID: postresp.ActivationID,
 	}
 
-	activation, err := lookupActivation(ctx, client, getActivationRequest)
+	act, err := lookupActivation(ctx, client, getActivationRequest)
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	for activation.Status != appsec.StatusDeactivated && activation.Status != appsec.StatusAborted && activation.Status != appsec.StatusFailed {
-		select {
-		case <-time.After(tf.MaxDuration(ActivationPollInterval, ActivationPollMinimum)):
-			act, err := client.GetActivations(ctx, getActivationRequest)
-
-			if err != nil {
-				return diag.FromErr(err)
-			}
-			activation = act
-
-		case <-ctx.Done():
-			return diag.Errorf("activation context terminated: %s", ctx.Err())
-		}
+	status, err := pollActivation(ctx, client, act.Status, getActivationRequest, appsec.StatusDeactivated)
+	if err != nil {
+		return activation.Diagnostics("waiting for security configuration deactivation", err)
 	}
 
-	if err := d.Set("status", activation.Status); err != nil {
+	if err := d.Set("status", status); err != nil {
 		return diag.Errorf("%s: %s", tf.ErrValueSet, err.Error())
 	}
 	return nil
@@ -457,80 +474,52 @@ func createActivation(ctx context.Context, client appsec.APPSEC, request appsec.
 		errMsg = "create deactivation failed"
 	}
 
-	createActivationRetry := CreateActivationRetry
-
-	for {
+	var create *appsec.CreateActivationsResponse
+	err := activation.Retry(ctx, activation.NewBackoff(CreateActivationRetry, activation.DefaultCreateRetryMaxInterval), isActivationErrorRetryable, func(ctx context.Context) error {
 		log.Debug("creating activation")
-		create, err := client.CreateActivations(ctx, request, true)
-
-		if err == nil {
-			return create, nil
+		var err error
+		if create, err = client.CreateActivations(ctx, request, true); err != nil {
+			log.Debug(fmt.Sprintf("%s: %s", errMsg, err))
 		}
-		log.Debug("%s: retrying: %w", errMsg, err)
-
-		if !isCreateActivationErrorRetryable(err) {
-			return nil, fmt.Errorf("%s: %s", errMsg, err)
-		}
-
-		select {
-		case <-time.After(createActivationRetry):
-			createActivationRetry = capDuration(createActivationRetry*2, 5*time.Minute)
-			continue
-
-		case <-ctx.Done():
-			return nil, fmt.Errorf("activation context terminated: %w", ctx.Err())
+		return err
+	})
+	if err != nil {
+		if errors.Is(err, activation.ErrContextTerminated) {
+			return nil, err
 		}
+		return nil, fmt.Errorf("%s: %s", errMsg, err)
 	}
-
+	return create, nil
 }
 
-func pollActivation(ctx context.Context, client appsec.APPSEC, activationStatus appsec.StatusValue, getActivationRequest appsec.GetActivationsRequest) error {
-	retriesMax := 5
-	retries5xx := 0
+// pollActivation polls the activation until it reaches the target status, or is aborted or failed, and returns
+// the final status
+func pollActivation(ctx context.Context, client appsec.APPSEC, activationStatus appsec.StatusValue, getActivationRequest appsec.GetActivationsRequest, targetStatus appsec.StatusValue) (appsec.StatusValue, error) {
+	isFinal := func(status appsec.StatusValue) bool {
+		return status == targetStatus || status == appsec.StatusAborted || status == appsec.StatusFailed
+	}
+	if isFinal(activationStatus) {
+		return activationStatus, nil
+	}
 
-	for activationStatus != appsec.StatusActive && activationStatus != appsec.StatusAborted && activationStatus != appsec.StatusFailed {
-		select {
-		case <-time.After(tf.MaxDuration(ActivationPollInterval, ActivationPollMinimum)):
+	err := activation.Poll(ctx, activation.NewPoller(tf.MaxDuration(ActivationPollInterval, ActivationPollMinimum)), activation.DefaultMaxRetries, isActivationErrorRetryable,
+		func(ctx context.Context) (bool, error) {
 			act, err := client.GetActivations(ctx, getActivationRequest)
 			if err != nil {
-				var target = &appsec.Error{}
-				if !errors.As(err, &target) {
-					return fmt.Errorf("error has unexpected type: %T", err)
-				}
-				if isCreateActivationErrorRetryable(target) {
-					retries5xx = retries5xx + 1
-					if retries5xx > retriesMax {
-						return fmt.Errorf("reached max number of 5xx retries: %d", retries5xx)
-					}
-					continue
-				}
-				return err
+				return false, err
 			}
-			retries5xx = 0
 			activationStatus = act.Status
-
-		case <-ctx.Done():
-			return fmt.Errorf("activation context terminated: %s", ctx.Err())
-		}
-	}
-	return nil
+			return isFinal(activationStatus), nil
+		})
+	return activationStatus, err
 }
 
-func isCreateActivationErrorRetryable(err error) bool {
-	var responseErr = &appsec.Error{}
-	if !errors.As(err, &responseErr) {
-		return false
-	}
-	if responseErr.StatusCode < 500 &&
-		responseErr.StatusCode != 422 {
-		return false
-	}
-	return true
+func isActivationErrorRetryable(err error) bool {
+	return activation.IsRetryable(err, func(e *appsec.Error) int { return e.StatusCode }, isRetryableStatusCode)
 }
 
-func capDuration(t time.Duration, tMax time.Duration) time.Duration {
-	if t > tMax {
-		return tMax
-	}
-	return t
+// isRetryableStatusCode reports whether an appsec activation request can be retried. Unlike the other products,
+// conflicts are not retried.
+func isRetryableStatusCode(statusCode int) bool {
+	return statusCode != http.StatusConflict && activation.IsRetryableStatusCode(statusCode)
 }
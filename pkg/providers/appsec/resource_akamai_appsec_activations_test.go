@@ -1,13 +1,25 @@
 package appsec
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
 	"regexp"
 	"testing"
 
 	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v9/pkg/appsec"
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v9/pkg/session"
 	"github.com/akamai/terraform-provider-akamai/v6/pkg/common/testutils"
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/meta"
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 )
@@ -543,103 +555,105 @@ func TestAkamaiActivations_res_basic(t *testing.T) {
 		client.AssertExpectations(t)
 	})
 
-	t.Run("Retry create activation on 500x error", func(t *testing.T) {
-
-		err500x := &appsec.Error{StatusCode: 502}
-
-		client := &appsec.Mock{}
-
-		removeActivationsResponse := appsec.RemoveActivationsResponse{}
-		err := json.Unmarshal(testutils.LoadFixtureBytes(t, "testdata/TestResActivations/ActivationsDelete.json"), &removeActivationsResponse)
-		require.NoError(t, err)
-
-		getActivationsResponse := appsec.GetActivationsResponse{}
-		err = json.Unmarshal(testutils.LoadFixtureBytes(t, "testdata/TestResActivations/Activations.json"), &getActivationsResponse)
-		require.NoError(t, err)
-
-		getActivationsDeleteResponse := appsec.GetActivationsResponse{}
-		err = json.Unmarshal(testutils.LoadFixtureBytes(t, "testdata/TestResActivations/ActivationsDelete.json"), &getActivationsDeleteResponse)
-		require.NoError(t, err)
-
-		createActivationsResponse := appsec.CreateActivationsResponse{}
-		err = json.Unmarshal(testutils.LoadFixtureBytes(t, "testdata/TestResActivations/Activations.json"), &createActivationsResponse)
-		require.NoError(t, err)
-
-		client.On("GetActivations",
-			mock.Anything,
-			appsec.GetActivationsRequest{ActivationID: 547694},
-		).Return(&getActivationsResponse, nil).Times(3)
-
-		client.On("CreateActivations",
-			mock.Anything,
-			appsec.CreateActivationsRequest{
-				Action:             "ACTIVATE",
-				Network:            "STAGING",
-				Note:               "Test Notes",
-				NotificationEmails: []string{"user@example.com"},
-				ActivationConfigs: []struct {
-					ConfigID      int `json:"configId"`
-					ConfigVersion int `json:"configVersion"`
-				}{{ConfigID: 43253, ConfigVersion: 7}}},
-		).Return(nil, err500x).Once()
-
-		client.On("GetActivations",
-			mock.Anything,
-			appsec.GetActivationsRequest{ActivationID: 547694},
-		).Return(&getActivationsDeleteResponse, nil)
-
-		client.On("RemoveActivations",
-			mock.Anything,
-			appsec.RemoveActivationsRequest{
-				ActivationID:       547694,
-				Action:             "DEACTIVATE",
-				Network:            "STAGING",
-				Note:               "Test Notes",
-				NotificationEmails: []string{"user@example.com"},
-				ActivationConfigs: []struct {
-					ConfigID      int `json:"configId"`
-					ConfigVersion int `json:"configVersion"`
-				}{{ConfigID: 43253, ConfigVersion: 7}}},
-		).Return(&removeActivationsResponse, nil)
-
-		client.On("GetActivations",
-			mock.Anything,
-			appsec.GetActivationsRequest{ActivationID: 547695},
-		).Return(&getActivationsDeleteResponse, nil).Once()
-
-		client.On("CreateActivations",
-			mock.Anything,
-			appsec.CreateActivationsRequest{
-				Action:             "ACTIVATE",
-				Network:            "STAGING",
-				Note:               "Test Notes",
-				NotificationEmails: []string{"user@example.com"},
-				ActivationConfigs: []struct {
-					ConfigID      int `json:"configId"`
-					ConfigVersion int `json:"configVersion"`
-				}{{ConfigID: 43253, ConfigVersion: 7}}},
-		).Return(&createActivationsResponse, nil).Once()
-
-		useClient(client, func() {
-			resource.Test(t, resource.TestCase{
-				IsUnitTest:               true,
-				ProtoV6ProviderFactories: testutils.NewProtoV6ProviderFactory(NewSubprovider()),
-				Steps: []resource.TestStep{
-					{
-						Config: testutils.LoadFixtureString(t, "testdata/TestResActivations/match_by_id.tf"),
-						Check: resource.ComposeAggregateTestCheckFunc(
-							resource.TestCheckResourceAttr("akamai_appsec_activations.test", "config_id", "43253"),
-							resource.TestCheckResourceAttr("akamai_appsec_activations.test", "network", "STAGING"),
-							resource.TestCheckResourceAttr("akamai_appsec_activations.test", "note", "Test Notes"),
-						),
+	for name, statusCode := range map[string]int{"500x": http.StatusBadGateway, "429": http.StatusTooManyRequests} {
+		t.Run(fmt.Sprintf("Retry create activation on %s error", name), func(t *testing.T) {
+
+			errRetryable := &appsec.Error{StatusCode: statusCode}
+
+			client := &appsec.Mock{}
+
+			removeActivationsResponse := appsec.RemoveActivationsResponse{}
+			err := json.Unmarshal(testutils.LoadFixtureBytes(t, "testdata/TestResActivations/ActivationsDelete.json"), &removeActivationsResponse)
+			require.NoError(t, err)
+
+			getActivationsResponse := appsec.GetActivationsResponse{}
+			err = json.Unmarshal(testutils.LoadFixtureBytes(t, "testdata/TestResActivations/Activations.json"), &getActivationsResponse)
+			require.NoError(t, err)
+
+			getActivationsDeleteResponse := appsec.GetActivationsResponse{}
+			err = json.Unmarshal(testutils.LoadFixtureBytes(t, "testdata/TestResActivations/ActivationsDelete.json"), &getActivationsDeleteResponse)
+			require.NoError(t, err)
+
+			createActivationsResponse := appsec.CreateActivationsResponse{}
+			err = json.Unmarshal(testutils.LoadFixtureBytes(t, "testdata/TestResActivations/Activations.json"), &createActivationsResponse)
+			require.NoError(t, err)
+
+			client.On("GetActivations",
+				mock.Anything,
+				appsec.GetActivationsRequest{ActivationID: 547694},
+			).Return(&getActivationsResponse, nil).Times(3)
+
+			client.On("CreateActivations",
+				mock.Anything,
+				appsec.CreateActivationsRequest{
+					Action:             "ACTIVATE",
+					Network:            "STAGING",
+					Note:               "Test Notes",
+					NotificationEmails: []string{"user@example.com"},
+					ActivationConfigs: []struct {
+						ConfigID      int `json:"configId"`
+						ConfigVersion int `json:"configVersion"`
+					}{{ConfigID: 43253, ConfigVersion: 7}}},
+			).Return(nil, errRetryable).Once()
+
+			client.On("GetActivations",
+				mock.Anything,
+				appsec.GetActivationsRequest{ActivationID: 547694},
+			).Return(&getActivationsDeleteResponse, nil)
+
+			client.On("RemoveActivations",
+				mock.Anything,
+				appsec.RemoveActivationsRequest{
+					ActivationID:       547694,
+					Action:             "DEACTIVATE",
+					Network:            "STAGING",
+					Note:               "Test Notes",
+					NotificationEmails: []string{"user@example.com"},
+					ActivationConfigs: []struct {
+						ConfigID      int `json:"configId"`
+						ConfigVersion int `json:"configVersion"`
+					}{{ConfigID: 43253, ConfigVersion: 7}}},
+			).Return(&removeActivationsResponse, nil)
+
+			client.On("GetActivations",
+				mock.Anything,
+				appsec.GetActivationsRequest{ActivationID: 547695},
+			).Return(&getActivationsDeleteResponse, nil).Once()
+
+			client.On("CreateActivations",
+				mock.Anything,
+				appsec.CreateActivationsRequest{
+					Action:             "ACTIVATE",
+					Network:            "STAGING",
+					Note:               "Test Notes",
+					NotificationEmails: []string{"user@example.com"},
+					ActivationConfigs: []struct {
+						ConfigID      int `json:"configId"`
+						ConfigVersion int `json:"configVersion"`
+					}{{ConfigID: 43253, ConfigVersion: 7}}},
+			).Return(&createActivationsResponse, nil).Once()
+
+			useClient(client, func() {
+				resource.Test(t, resource.TestCase{
+					IsUnitTest:               true,
+					ProtoV6ProviderFactories: testutils.NewProtoV6ProviderFactory(NewSubprovider()),
+					Steps: []resource.TestStep{
+						{
+							Config: testutils.LoadFixtureString(t, "testdata/TestResActivations/match_by_id.tf"),
+							Check: resource.ComposeAggregateTestCheckFunc(
+								resource.TestCheckResourceAttr("akamai_appsec_activations.test", "config_id", "43253"),
+								resource.TestCheckResourceAttr("akamai_appsec_activations.test", "network", "STAGING"),
+								resource.TestCheckResourceAttr("akamai_appsec_activations.test", "note", "Test Notes"),
+							),
+						},
 					},
-				},
+				})
 			})
-		})
 
-		client.AssertExpectations(t)
+			client.AssertExpectations(t)
 
-	})
+		})
+	}
 
 	t.Run("reactivate config when manually deactivated from UI", func(t *testing.T) {
 		client := &appsec.Mock{}
@@ -822,3 +836,299 @@ func TestAkamaiActivations_res_basic(t *testing.T) {
 	})
 
 }
+
+func TestIsActivationErrorRetryable(t *testing.T) {
+	tests := map[string]struct {
+		err      error
+		expected bool
+	}{
+		"server error":         {&appsec.Error{StatusCode: http.StatusBadGateway}, true},
+		"unprocessable entity": {&appsec.Error{StatusCode: http.StatusUnprocessableEntity}, true},
+		"too many requests":    {&appsec.Error{StatusCode: http.StatusTooManyRequests}, true},
+		"connection reset":     {fmt.Errorf("creating activation: %w", io.EOF), true},
+		"conflict":             {&appsec.Error{StatusCode: http.StatusConflict}, false},
+		"bad request":          {&appsec.Error{StatusCode: http.StatusBadRequest}, false},
+		"other error":          {errors.New("oops"), false},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.expected, isActivationErrorRetryable(test.err))
+		})
+	}
+}
+
+func TestAkamaiActivations_res_preActivationChecks(t *testing.T) {
+	t.Run("activation blocked by unexpected evaluation", func(t *testing.T) {
+		client := &appsec.Mock{}
+
+		client.On("GetExportConfiguration",
+			mock.Anything,
+			appsec.GetExportConfigurationRequest{ConfigID: 43253, Version: 7},
+		).Return(loadExportConfiguration(t), nil)
+
+		useClient(client, func() {
+			resource.Test(t, resource.TestCase{
+				IsUnitTest:               true,
+				ProtoV6ProviderFactories: testutils.NewProtoV6ProviderFactory(NewSubprovider()),
+				Steps: []resource.TestStep{
+					{
+						Config:      testutils.LoadFixtureString(t, "testdata/TestResActivations/pre_activation_checks.tf"),
+						ExpectError: regexp.MustCompile(`pre-activation check 'block_unexpected_evaluation' failed`),
+					},
+				},
+			})
+		})
+
+		client.AssertNotCalled(t, "CreateActivations", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("activation blocked on update is planned again", func(t *testing.T) {
+		client := &appsec.Mock{}
+
+		getActivationsResponse := appsec.GetActivationsResponse{}
+		err := json.Unmarshal(testutils.LoadFixtureBytes(t, "testdata/TestResActivations/Activations.json"), &getActivationsResponse)
+		require.NoError(t, err)
+
+		createActivationsResponse := appsec.CreateActivationsResponse{}
+		err = json.Unmarshal(testutils.LoadFixtureBytes(t, "testdata/TestResActivations/Activations.json"), &createActivationsResponse)
+		require.NoError(t, err)
+
+		removeActivationsResponse := appsec.RemoveActivationsResponse{}
+		err = json.Unmarshal(testutils.LoadFixtureBytes(t, "testdata/TestResActivations/ActivationsDelete.json"), &removeActivationsResponse)
+		require.NoError(t, err)
+
+		getActivationsDeleteResponse := appsec.GetActivationsResponse{}
+		err = json.Unmarshal(testutils.LoadFixtureBytes(t, "testdata/TestResActivations/ActivationsDelete.json"), &getActivationsDeleteResponse)
+		require.NoError(t, err)
+
+		client.On("CreateActivations",
+			mock.Anything,
+			appsec.CreateActivationsRequest{
+				Action:             "ACTIVATE",
+				Network:            "STAGING",
+				Note:               "Test Notes",
+				NotificationEmails: []string{"user@example.com"},
+				ActivationConfigs: []struct {
+					ConfigID      int `json:"configId"`
+					ConfigVersion int `json:"configVersion"`
+				}{{ConfigID: 43253, ConfigVersion: 7}}},
+		).Return(&createActivationsResponse, nil).Once()
+
+		client.On("GetActivations",
+			mock.Anything,
+			appsec.GetActivationsRequest{ActivationID: 547694},
+		).Return(&getActivationsResponse, nil)
+
+		client.On("GetExportConfiguration",
+			mock.Anything,
+			appsec.GetExportConfigurationRequest{ConfigID: 43253, Version: 7},
+		).Return(loadExportConfiguration(t), nil)
+
+		client.On("RemoveActivations",
+			mock.Anything,
+			appsec.RemoveActivationsRequest{
+				ActivationID:       547694,
+				Action:             "DEACTIVATE",
+				Network:            "STAGING",
+				Note:               "Test Notes",
+				NotificationEmails: []string{"user@example.com"},
+				ActivationConfigs: []struct {
+					ConfigID      int `json:"configId"`
+					ConfigVersion int `json:"configVersion"`
+				}{{ConfigID: 43253, ConfigVersion: 7}}},
+		).Return(&removeActivationsResponse, nil)
+
+		client.On("GetActivations",
+			mock.Anything,
+			appsec.GetActivationsRequest{ActivationID: 547695},
+		).Return(&getActivationsDeleteResponse, nil)
+
+		useClient(client, func() {
+			resource.Test(t, resource.TestCase{
+				IsUnitTest:               true,
+				ProtoV6ProviderFactories: testutils.NewProtoV6ProviderFactory(NewSubprovider()),
+				Steps: []resource.TestStep{
+					{
+						Config: testutils.LoadFixtureString(t, "testdata/TestResActivations/match_by_id.tf"),
+					},
+					{
+						Config:      testutils.LoadFixtureString(t, "testdata/TestResActivations/update_pre_activation_checks.tf"),
+						ExpectError: regexp.MustCompile(`pre-activation check 'block_unexpected_evaluation' failed`),
+					},
+					{
+						Config:             testutils.LoadFixtureString(t, "testdata/TestResActivations/update_pre_activation_checks.tf"),
+						PlanOnly:           true,
+						ExpectNonEmptyPlan: true,
+					},
+				},
+			})
+		})
+
+		client.AssertNotCalled(t, "CreateActivations", mock.Anything, mock.MatchedBy(func(req appsec.CreateActivationsRequest) bool {
+			return req.Network == "PRODUCTION"
+		}))
+	})
+}
+
+func TestActivationsUpdateKeepsStateOnFailedActivation(t *testing.T) {
+	m, err := meta.New(session.Must(session.New()), hclog.NewNullLogger(), "")
+	require.NoError(t, err)
+
+	res := resourceActivations()
+	prior := schema.TestResourceDataRaw(t, res.Schema, map[string]interface{}{
+		"config_id":           43253,
+		"version":             7,
+		"network":             "STAGING",
+		"note":                "Test Notes",
+		"notification_emails": []interface{}{"user@example.com"},
+	})
+	prior.SetId("547694")
+	state := prior.State()
+
+	config := terraform.NewResourceConfigRaw(map[string]interface{}{
+		"config_id":           43253,
+		"version":             7,
+		"network":             "PRODUCTION",
+		"note":                "Test Notes update",
+		"notification_emails": []interface{}{"user@example.com"},
+		"pre_activation_checks": []interface{}{map[string]interface{}{
+			"block_pending_rule_upgrade": false,
+			"block_hostname_changes":     false,
+		}},
+	})
+
+	client := &appsec.Mock{}
+	client.On("GetExportConfiguration", mock.Anything, appsec.GetExportConfigurationRequest{ConfigID: 43253, Version: 7}).
+		Return(loadExportConfiguration(t), nil).Once()
+
+	useClient(client, func() {
+		diff, err := res.Diff(context.Background(), state, config, m)
+		require.NoError(t, err)
+		newState, diags := res.Apply(context.Background(), state, diff, m)
+		require.True(t, diags.HasError())
+		assert.Contains(t, diags[0].Summary, "pre-activation check 'block_unexpected_evaluation' failed")
+		assert.Equal(t, "STAGING", newState.Attributes["network"])
+		assert.Equal(t, "Test Notes", newState.Attributes["note"])
+	})
+	client.AssertExpectations(t)
+}
+
+func TestRunPreActivationChecks(t *testing.T) {
+	activationData := func(t *testing.T, checks map[string]interface{}) *schema.ResourceData {
+		return schema.TestResourceDataRaw(t, resourceActivations().Schema, map[string]interface{}{
+			"config_id":             43253,
+			"version":               7,
+			"network":               "PRODUCTION",
+			"notification_emails":   []interface{}{"user@example.com"},
+			"pre_activation_checks": []interface{}{checks},
+		})
+	}
+	pendingUpgrade := &appsec.GetRuleUpgradeResponse{
+		Current: "KRS 1.0",
+		Latest:  "KRS 2.0",
+		KRSToLatestUpdates: &appsec.RulesetUpdateData{
+			NewRules: &appsec.RuleData{{ID: 950002, Title: "System Command Access"}},
+		},
+	}
+
+	tests := map[string]struct {
+		checks         map[string]interface{}
+		init           func(*appsec.Mock, *appsec.GetExportConfigurationResponse)
+		expectedErrors []string
+	}{
+		"allowed evaluation passes": {
+			checks: map[string]interface{}{
+				"allowed_evaluation_policies": []interface{}{"AAAA_81230"},
+				"block_pending_rule_upgrade":  false,
+				"block_hostname_changes":      false,
+			},
+		},
+		"pending rule upgrade fails": {
+			checks: map[string]interface{}{
+				"block_unexpected_evaluation": false,
+				"block_hostname_changes":      false,
+			},
+			init: func(client *appsec.Mock, _ *appsec.GetExportConfigurationResponse) {
+				client.On("GetRuleUpgrade", mock.Anything,
+					appsec.GetRuleUpgradeRequest{ConfigID: 43253, Version: 7, PolicyID: "AAAA_81230"},
+				).Return(pendingUpgrade, nil).Once()
+			},
+			expectedErrors: []string{"block_pending_rule_upgrade"},
+		},
+		"changed hostnames fail": {
+			checks: map[string]interface{}{
+				"block_unexpected_evaluation": false,
+				"block_pending_rule_upgrade":  false,
+			},
+			init: func(client *appsec.Mock, export *appsec.GetExportConfigurationResponse) {
+				client.On("GetConfiguration", mock.Anything, appsec.GetConfigurationRequest{ConfigID: 43253}).
+					Return(&appsec.GetConfigurationResponse{ID: 43253, StagingVersion: 7, ProductionVersion: 5}, nil).Once()
+				active := *export
+				active.SelectedHosts = []string{"rinaldi.sandbox.akamaideveloper.com", "old.example.com"}
+				client.On("GetExportConfiguration", mock.Anything, appsec.GetExportConfigurationRequest{ConfigID: 43253, Version: 5}).
+					Return(&active, nil).Once()
+			},
+			expectedErrors: []string{"block_hostname_changes"},
+		},
+		"all checks fail": {
+			checks: map[string]interface{}{},
+			init: func(client *appsec.Mock, export *appsec.GetExportConfigurationResponse) {
+				client.On("GetRuleUpgrade", mock.Anything,
+					appsec.GetRuleUpgradeRequest{ConfigID: 43253, Version: 7, PolicyID: "AAAA_81230"},
+				).Return(pendingUpgrade, nil).Once()
+				client.On("GetConfiguration", mock.Anything, appsec.GetConfigurationRequest{ConfigID: 43253}).
+					Return(&appsec.GetConfigurationResponse{ID: 43253, ProductionVersion: 5}, nil).Once()
+				active := *export
+				active.SelectedHosts = nil
+				client.On("GetExportConfiguration", mock.Anything, appsec.GetExportConfigurationRequest{ConfigID: 43253, Version: 5}).
+					Return(&active, nil).Once()
+			},
+			expectedErrors: []string{"block_unexpected_evaluation", "block_pending_rule_upgrade", "block_hostname_changes"},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			client := &appsec.Mock{}
+			export := loadExportConfiguration(t)
+			client.On("GetExportConfiguration", mock.Anything, appsec.GetExportConfigurationRequest{ConfigID: 43253, Version: 7}).
+				Return(export, nil).Once()
+			if test.init != nil {
+				test.init(client, export)
+			}
+
+			diags := runPreActivationChecks(context.Background(), client, activationData(t, test.checks), 43253, 7, "PRODUCTION")
+			require.Len(t, diags, len(test.expectedErrors))
+			for i, expected := range test.expectedErrors {
+				assert.Equal(t, diag.Error, diags[i].Severity)
+				assert.Contains(t, diags[i].Summary, expected)
+			}
+			client.AssertExpectations(t)
+		})
+	}
+}
+
+func TestVersionFingerprint(t *testing.T) {
+	export := loadExportConfiguration(t)
+	fingerprint, err := versionFingerprint(export)
+	require.NoError(t, err)
+
+	activated := loadExportConfiguration(t)
+	activated.Production.Status = "Active"
+	activatedFingerprint, err := versionFingerprint(activated)
+	require.NoError(t, err)
+	assert.Equal(t, fingerprint, activatedFingerprint)
+
+	modified := loadExportConfiguration(t)
+	modified.SelectedHosts = append(modified.SelectedHosts, "new.example.com")
+	modifiedFingerprint, err := versionFingerprint(modified)
+	require.NoError(t, err)
+	assert.NotEqual(t, fingerprint, modifiedFingerprint)
+}
+
+func TestSelectedHostnamesChanges(t *testing.T) {
+	added, removed := selectedHostnamesChanges([]string{"a.example.com", "b.example.com"}, []string{"c.example.com", "a.example.com"})
+	assert.Equal(t, []string{"c.example.com"}, added)
+	assert.Equal(t, []string{"b.example.com"}, removed)
+}
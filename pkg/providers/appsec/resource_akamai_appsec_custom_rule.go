@@ -27,6 +27,7 @@ func resourceCustomRule() *schema.Resource {
 		DeleteContext: resourceCustomRuleDelete,
 		CustomizeDiff: customdiff.All(
 			VerifyIDUnchanged,
+			customDiffCustomRule,
 		),
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
@@ -39,11 +40,14 @@ func resourceCustomRule() *schema.Resource {
 			},
 			"custom_rule": {
 				Type:             schema.TypeString,
-				Required:         true,
+				Optional:         true,
+				Computed:         true,
+				ExactlyOneOf:     []string{"custom_rule", "rule"},
 				ValidateDiagFunc: validation.ToDiagFunc(validation.StringIsJSON),
 				DiffSuppressFunc: suppressEquivalentJSONDiffsGeneric,
 				Description:      "JSON-formatted definition of the custom rule",
 			},
+			"rule": customRuleSchema(),
 			"custom_rule_id": {
 				Type:     schema.TypeInt,
 				Computed: true,
@@ -63,12 +67,10 @@ func resourceCustomRuleCreate(ctx context.Context, d *schema.ResourceData, m int
 		return diag.FromErr(err)
 	}
 
-	jsonpostpayload, err := tf.GetStringValue("custom_rule", d)
+	rawJSON, err := customRulePayload(d)
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	jsonPayloadRaw := []byte(jsonpostpayload)
-	rawJSON := (json.RawMessage)(jsonPayloadRaw)
 
 	createCustomRule := appsec.CreateCustomRuleRequest{
 		ConfigID:       configID,
@@ -137,9 +139,32 @@ func resourceCustomRuleRead(ctx context.Context, d *schema.ResourceData, m inter
 	if err := d.Set("custom_rule", string(jsonBody)); err != nil {
 		return diag.Errorf("%s: %s", tf.ErrValueSet, err.Error())
 	}
+
+	// the structured definition is kept in the state only for rules managed with the `rule` block
+	if rules, ok := d.Get("rule").([]interface{}); ok && len(rules) > 0 {
+		rule, err := flattenCustomRule(customrule)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		if err := d.Set("rule", rule); err != nil {
+			return diag.Errorf("%s: %s", tf.ErrValueSet, err.Error())
+		}
+	}
 	return nil
 }
 
+// customRulePayload returns the JSON definition of the custom rule, given either by `custom_rule` or by the `rule` block
+func customRulePayload(d *schema.ResourceData) (json.RawMessage, error) {
+	if rules, ok := d.Get("rule").([]interface{}); ok && len(rules) > 0 {
+		return customRulePayloadFromSchema(d)
+	}
+	jsonpostpayload, err := tf.GetStringValue("custom_rule", d)
+	if err != nil {
+		return nil, err
+	}
+	return json.RawMessage(jsonpostpayload), nil
+}
+
 func resourceCustomRuleUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	meta := meta.Must(m)
 	client := inst.Client(meta)
@@ -161,9 +186,10 @@ func resourceCustomRuleUpdate(ctx context.Context, d *schema.ResourceData, m int
 		return diag.FromErr(err)
 	}
 
-	jsonpostpayload := d.Get("custom_rule")
-	jsonPayloadRaw := []byte(jsonpostpayload.(string))
-	rawJSON := (json.RawMessage)(jsonPayloadRaw)
+	rawJSON, err := customRulePayload(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 
 	updateCustomRule := appsec.UpdateCustomRuleRequest{
 		ConfigID:       configID,
@@ -8,7 +8,9 @@ import (
 
 	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v9/pkg/appsec"
 	"github.com/akamai/terraform-provider-akamai/v6/pkg/common/testutils"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 )
@@ -189,3 +191,274 @@ func TestAkamaiCustomRule_res_error_removing_active_rule(t *testing.T) {
 	})
 
 }
+
+func TestAkamaiCustomRule_res_rule_block(t *testing.T) {
+	t.Run("CustomRule_rule_block", func(t *testing.T) {
+		client := &appsec.Mock{}
+
+		createCustomRuleResponse := appsec.CreateCustomRuleResponse{}
+		err := json.Unmarshal(testutils.LoadFixtureBytes(t, "testdata/TestResCustomRule/CustomRule.json"), &createCustomRuleResponse)
+		require.NoError(t, err)
+
+		getCustomRuleResponse := appsec.GetCustomRuleResponse{}
+		err = json.Unmarshal(testutils.LoadFixtureBytes(t, "testdata/TestResCustomRule/CustomRule.json"), &getCustomRuleResponse)
+		require.NoError(t, err)
+
+		removeCustomRuleResponse := appsec.RemoveCustomRuleResponse{}
+		err = json.Unmarshal(testutils.LoadFixtureBytes(t, "testdata/TestResCustomRule/CustomRulesDeleted.json"), &removeCustomRuleResponse)
+		require.NoError(t, err)
+
+		getCustomRulesAfterDelete := appsec.GetCustomRulesResponse{}
+		err = json.Unmarshal(testutils.LoadFixtureBytes(t, "testdata/TestResCustomRule/CustomRulesForDelete.json"), &getCustomRulesAfterDelete)
+		require.NoError(t, err)
+
+		var expectedPayload interface{}
+		err = json.Unmarshal(testutils.LoadFixtureBytes(t, "testdata/TestResCustomRule/CreateCustomRuleFromBlock.json"), &expectedPayload)
+		require.NoError(t, err)
+
+		client.On("CreateCustomRule",
+			mock.Anything,
+			mock.MatchedBy(func(req appsec.CreateCustomRuleRequest) bool {
+				var payload interface{}
+				if err := json.Unmarshal(req.JsonPayloadRaw, &payload); err != nil {
+					return false
+				}
+				return req.ConfigID == 43253 && assert.ObjectsAreEqual(expectedPayload, payload)
+			}),
+		).Return(&createCustomRuleResponse, nil)
+
+		client.On("GetCustomRule",
+			mock.Anything,
+			appsec.GetCustomRuleRequest{ConfigID: 43253, ID: 661699},
+		).Return(&getCustomRuleResponse, nil)
+
+		client.On("GetCustomRules",
+			mock.Anything,
+			appsec.GetCustomRulesRequest{ConfigID: 43253, ID: 661699},
+		).Return(&getCustomRulesAfterDelete, nil)
+
+		client.On("RemoveCustomRule",
+			mock.Anything,
+			appsec.RemoveCustomRuleRequest{ConfigID: 43253, ID: 661699},
+		).Return(&removeCustomRuleResponse, nil)
+
+		useClient(client, func() {
+			resource.Test(t, resource.TestCase{
+				IsUnitTest:               true,
+				ProtoV6ProviderFactories: testutils.NewProtoV6ProviderFactory(NewSubprovider()),
+				Steps: []resource.TestStep{
+					{
+						Config: testutils.LoadFixtureString(t, "testdata/TestResCustomRule/match_by_rule_block.tf"),
+						Check: resource.ComposeAggregateTestCheckFunc(
+							resource.TestCheckResourceAttr("akamai_appsec_custom_rule.test", "id", "43253:661699"),
+							resource.TestCheckResourceAttr("akamai_appsec_custom_rule.test", "rule.0.operation", "AND"),
+							resource.TestCheckResourceAttr("akamai_appsec_custom_rule.test", "rule.0.condition.#", "3"),
+							resource.TestCheckResourceAttr("akamai_appsec_custom_rule.test", "rule.0.condition.2.value_wildcard", "true"),
+							resource.TestCheckResourceAttrSet("akamai_appsec_custom_rule.test", "custom_rule"),
+						),
+					},
+				},
+			})
+		})
+
+		client.AssertExpectations(t)
+	})
+
+	t.Run("CustomRule_rule_block_invalid_condition_value", func(t *testing.T) {
+		client := &appsec.Mock{}
+
+		useClient(client, func() {
+			resource.Test(t, resource.TestCase{
+				IsUnitTest:               true,
+				ProtoV6ProviderFactories: testutils.NewProtoV6ProviderFactory(NewSubprovider()),
+				Steps: []resource.TestStep{
+					{
+						Config:      testutils.LoadFixtureString(t, "testdata/TestResCustomRule/invalid_condition_value.tf"),
+						ExpectError: regexp.MustCompile(`condition of type 'requestMethodMatch' accepts only`),
+					},
+				},
+			})
+		})
+
+		client.AssertExpectations(t)
+	})
+}
+
+func TestValidateCustomRuleCondition(t *testing.T) {
+	known := func(string) bool { return true }
+	tests := map[string]struct {
+		condition map[string]interface{}
+		withError string
+	}{
+		"valid request methods": {
+			condition: map[string]interface{}{"type": "requestMethodMatch", "value": []interface{}{"GET", "POST"}},
+		},
+		"unknown request method": {
+			condition: map[string]interface{}{"type": "requestMethodMatch", "value": []interface{}{"get"}},
+			withError: "accepts only",
+		},
+		"valid IP addresses and CIDR blocks": {
+			condition: map[string]interface{}{"type": "ipMatch", "value": []interface{}{"1.2.3.4", "10.0.0.0/8", "2001:db8::/32"}},
+		},
+		"invalid IP address": {
+			condition: map[string]interface{}{"type": "ipMatch", "value": []interface{}{"1.2.3.400"}},
+			withError: "accepts only IP addresses and CIDR blocks",
+		},
+		"invalid country code": {
+			condition: map[string]interface{}{"type": "geoMatch", "value": []interface{}{"USA"}},
+			withError: "two-letter country codes",
+		},
+		"invalid AS number": {
+			condition: map[string]interface{}{"type": "asNumberMatch", "value": []interface{}{"AS123"}},
+			withError: "accepts only AS numbers",
+		},
+		"path without leading slash": {
+			condition: map[string]interface{}{"type": "pathMatch", "value": []interface{}{"index.html"}},
+			withError: "starting with '/'",
+		},
+		"missing value": {
+			condition: map[string]interface{}{"type": "hostMatch", "value": []interface{}{}},
+			withError: "requires at least one 'value'",
+		},
+		"value not accepted": {
+			condition: map[string]interface{}{"type": "clientCertPresentMatch", "value": []interface{}{"x"}},
+			withError: "does not accept 'value'",
+		},
+		"more than one scalar value": {
+			condition: map[string]interface{}{"type": "requestProtocolVersionMatch", "value": []interface{}{"HTTP/1.0", "HTTP/1.1"}},
+			withError: "accepts exactly one 'value'",
+		},
+		"missing name": {
+			condition: map[string]interface{}{"type": "requestHeaderMatch", "value": []interface{}{"x"}, "name": []interface{}{}},
+			withError: "requires 'name'",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := validateCustomRuleCondition(test.condition, known)
+			if test.withError != "" {
+				assert.ErrorContains(t, err, test.withError)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+
+	t.Run("unknown values are not validated", func(t *testing.T) {
+		condition := map[string]interface{}{"type": "requestMethodMatch", "value": []interface{}{"FETCH"}}
+		assert.NoError(t, validateCustomRuleCondition(condition, func(key string) bool { return key != "value" }))
+	})
+}
+
+func TestCustomRulePayloadFromSchema(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, resourceCustomRule().Schema, map[string]interface{}{
+		"config_id": 43253,
+		"rule": []interface{}{map[string]interface{}{
+			"name": "rule",
+			"condition": []interface{}{
+				map[string]interface{}{"type": "requestProtocolVersionMatch", "value": []interface{}{"HTTP/0.9"}},
+				map[string]interface{}{"type": "clientCertPresentMatch", "positive_match": false},
+				map[string]interface{}{"type": "cookieMatch", "name": []interface{}{"session"}, "name_wildcard": true, "value": []interface{}{"abc"}},
+			},
+		}},
+	})
+
+	payload, err := customRulePayload(d)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"name": "rule",
+		"operation": "AND",
+		"conditions": [
+			{"type": "requestProtocolVersionMatch", "positiveMatch": true, "value": "HTTP/0.9"},
+			{"type": "clientCertPresentMatch", "positiveMatch": false},
+			{"type": "cookieMatch", "positiveMatch": true, "name": ["session"], "nameWildcard": true, "value": ["abc"]}
+		]
+	}`, string(payload))
+}
+
+func TestFlattenCustomRule(t *testing.T) {
+	customRule := appsec.GetCustomRuleResponse{}
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"name": "rule",
+		"tag": ["a"],
+		"conditions": [
+			{"type": "requestProtocolVersionMatch", "positiveMatch": true, "value": "HTTP/0.9"},
+			{"type": "cookieMatch", "positiveMatch": false, "name": "session", "valueCase": true, "value": ["abc"]}
+		]
+	}`), &customRule))
+
+	rule, err := flattenCustomRule(&customRule)
+	require.NoError(t, err)
+	require.Len(t, rule, 1)
+	flattened := rule[0].(map[string]interface{})
+	assert.Equal(t, "AND", flattened["operation"])
+	assert.Equal(t, []interface{}{"a"}, flattened["tag"])
+
+	conditions := flattened["condition"].([]interface{})
+	require.Len(t, conditions, 2)
+	assert.Equal(t, []interface{}{"HTTP/0.9"}, conditions[0].(map[string]interface{})["value"])
+	cookie := conditions[1].(map[string]interface{})
+	assert.Equal(t, []interface{}{"session"}, cookie["name"])
+	assert.Equal(t, false, cookie["positive_match"])
+	assert.Equal(t, true, cookie["value_case"])
+	assert.Equal(t, false, cookie["value_wildcard"])
+}
+
+func TestRawStringOrList(t *testing.T) {
+	tests := map[string]struct {
+		raw           string
+		expected      []interface{}
+		expectedError string
+	}{
+		"single string": {
+			raw:      `"foo"`,
+			expected: []interface{}{"foo"},
+		},
+		"list of strings": {
+			raw:      `["foo", "bar"]`,
+			expected: []interface{}{"foo", "bar"},
+		},
+		"single number": {
+			raw:      `10`,
+			expected: []interface{}{"10"},
+		},
+		"list of numbers": {
+			raw:      `[1, 2.5, 1e3]`,
+			expected: []interface{}{"1", "2.5", "1e3"},
+		},
+		"mixed list": {
+			raw:      `["foo", 42]`,
+			expected: []interface{}{"foo", "42"},
+		},
+		"invalid value": {
+			raw:           `{"foo": "bar"}`,
+			expectedError: "expected a string or a number",
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			raw := json.RawMessage(test.raw)
+			result, err := rawStringOrList(&raw)
+			if test.expectedError != "" {
+				assert.ErrorContains(t, err, test.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, result)
+		})
+	}
+}
+
+func TestStringValue(t *testing.T) {
+	value, err := stringValue(2.5)
+	require.NoError(t, err)
+	assert.Equal(t, "2.5", value)
+
+	value, err = stringValue(float64(100))
+	require.NoError(t, err)
+	assert.Equal(t, "100", value)
+
+	_, err = stringValue(true)
+	assert.Error(t, err)
+}
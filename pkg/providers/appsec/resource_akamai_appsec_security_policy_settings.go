@@ -0,0 +1,934 @@
+package appsec
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v9/pkg/appsec"
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/common/tf"
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/meta"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// appsec v1
+//
+// https://techdocs.akamai.com/application-security/reference/api
+func resourceSecurityPolicySettings() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceSecurityPolicySettingsCreate,
+		ReadContext:   resourceSecurityPolicySettingsRead,
+		UpdateContext: resourceSecurityPolicySettingsUpdate,
+		DeleteContext: resourceSecurityPolicySettingsDelete,
+		CustomizeDiff: customdiff.All(
+			VerifyIDUnchanged,
+		),
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Schema: map[string]*schema.Schema{
+			"config_id": {
+				Type:        schema.TypeInt,
+				Required:    true,
+				Description: "Unique identifier of the security configuration",
+			},
+			"security_policy_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Unique identifier of the security policy",
+			},
+			"protections": {
+				Type:        schema.TypeList,
+				Required:    true,
+				MaxItems:    1,
+				Description: "Protections enabled for the security policy",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"apply_api_constraints": {
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Description: "Whether API constraints protection is enabled",
+						},
+						"apply_application_layer_controls": {
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Description: "Whether web application firewall protection is enabled",
+						},
+						"apply_botman_controls": {
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Description: "Whether Bot Manager protection is enabled",
+						},
+						"apply_malware_controls": {
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Description: "Whether malware protection is enabled",
+						},
+						"apply_network_layer_controls": {
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Description: "Whether IP/Geo protection is enabled",
+						},
+						"apply_rate_controls": {
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Description: "Whether rate protection is enabled",
+						},
+						"apply_reputation_controls": {
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Description: "Whether reputation protection is enabled",
+						},
+						"apply_slow_post_controls": {
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Description: "Whether slow POST protection is enabled",
+						},
+					},
+				},
+			},
+			"waf_mode": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice([]string{
+					AAG,
+					KRS,
+					AseAuto,
+					AseManual,
+				}, false)),
+				Description: "How Kona Rule Set rules should be upgraded (KRS, AAG, ASE_MANUAL or ASE_AUTO)",
+			},
+			"penalty_box": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Description: "Penalty box settings of the security policy",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"penalty_box_protection": {
+							Type:        schema.TypeBool,
+							Required:    true,
+							Description: "Whether the penalty box is enabled",
+						},
+						"penalty_box_action": {
+							Type:     schema.TypeString,
+							Required: true,
+							ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice([]string{
+								string(appsec.ActionTypeAlert),
+								string(appsec.ActionTypeDeny),
+								string(appsec.ActionTypeNone),
+							}, false)),
+							Description: "Action applied to requests from clients in the penalty box (alert, deny or none)",
+						},
+					},
+				},
+			},
+			"attack_group_action": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Description: "Actions of the attack groups managed by this resource. Condition and exception information is left unchanged",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"attack_group": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "Unique name of the attack group",
+						},
+						"action": {
+							Type:             schema.TypeString,
+							Required:         true,
+							ValidateDiagFunc: ValidateActions,
+							Description:      "Action to be taken when the attack group is triggered",
+						},
+					},
+				},
+			},
+			"rule_action": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Description: "Actions of the rules managed by this resource. Condition and exception information is left unchanged",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"rule_id": {
+							Type:        schema.TypeInt,
+							Required:    true,
+							Description: "Unique identifier of the rule",
+						},
+						"action": {
+							Type:             schema.TypeString,
+							Required:         true,
+							ValidateDiagFunc: ValidateActions,
+							Description:      "Action to be taken when the rule is triggered",
+						},
+					},
+				},
+			},
+			"ip_geo": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Description: "IP/Geo firewall settings of the security policy",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"mode": {
+							Type:     schema.TypeString,
+							Required: true,
+							ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice([]string{
+								Allow,
+								Block,
+							}, false)),
+							Description: "Protection mode (block or allow)",
+						},
+						"geo_network_lists": {
+							Type:     schema.TypeList,
+							Optional: true,
+							Elem: &schema.Schema{
+								Type:             schema.TypeString,
+								ValidateDiagFunc: validateEmptyElementsInList,
+							},
+							Description: "List of IDs of geographic network list to be blocked",
+						},
+						"ip_network_lists": {
+							Type:     schema.TypeList,
+							Optional: true,
+							Elem: &schema.Schema{
+								Type:             schema.TypeString,
+								ValidateDiagFunc: validateEmptyElementsInList,
+							},
+							Description: "List of IDs of IP network list to be blocked",
+						},
+						"asn_network_lists": {
+							Type:     schema.TypeList,
+							Optional: true,
+							Elem: &schema.Schema{
+								Type:             schema.TypeString,
+								ValidateDiagFunc: validateEmptyElementsInList,
+							},
+							Description: "List of IDs of ASN network list to be blocked",
+						},
+						"exception_ip_network_lists": {
+							Type:     schema.TypeList,
+							Optional: true,
+							Elem: &schema.Schema{
+								Type:             schema.TypeString,
+								ValidateDiagFunc: validateEmptyElementsInList,
+							},
+							Description: "List of IDs of network list that are always allowed",
+						},
+						"ukraine_geo_control_action": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Computed:    true,
+							Description: "Action set for Ukraine geo control",
+						},
+					},
+				},
+			},
+			"slow_post": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Description: "Slow POST protection settings of the security policy",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"slow_rate_action": {
+							Type:     schema.TypeString,
+							Required: true,
+							ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice([]string{
+								Alert,
+								Abort,
+							}, false)),
+							Description: "Action to be taken when slow POST protection is triggered",
+						},
+						"slow_rate_threshold_rate": {
+							Type:        schema.TypeInt,
+							Optional:    true,
+							Description: "Average rate (in bytes per second over the specified time period) allowed before the specified action is triggered",
+						},
+						"slow_rate_threshold_period": {
+							Type:        schema.TypeInt,
+							Optional:    true,
+							Description: "Amount of time (in seconds) that the server should allow a request before marking the request as being too slow",
+						},
+						"duration_threshold_timeout": {
+							Type:        schema.TypeInt,
+							Optional:    true,
+							Description: "Maximum amount of time (in seconds) within which the first 8KB of the POST body must be received to avoid triggering the specified action",
+						},
+					},
+				},
+			},
+			"reputation_profile_action": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Description: "Actions of the reputation profiles managed by this resource",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"reputation_profile_id": {
+							Type:        schema.TypeInt,
+							Required:    true,
+							Description: "Unique identifier of the reputation profile",
+						},
+						"action": {
+							Type:             schema.TypeString,
+							Required:         true,
+							ValidateDiagFunc: ValidateActions,
+							Description:      "Action to be taken when the reputation profile is triggered",
+						},
+					},
+				},
+			},
+			"rate_policy_action": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Description: "Actions of the rate policies managed by this resource",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"rate_policy_id": {
+							Type:        schema.TypeInt,
+							Required:    true,
+							Description: "Unique identifier of the rate policy",
+						},
+						"ipv4_action": {
+							Type:             schema.TypeString,
+							Required:         true,
+							ValidateDiagFunc: validateWithBotManActions,
+							Description:      "Action to be taken for requests coming from an IPv4 address",
+						},
+						"ipv6_action": {
+							Type:             schema.TypeString,
+							Required:         true,
+							ValidateDiagFunc: validateWithBotManActions,
+							Description:      "Action to be taken for requests coming from an IPv6 address",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func resourceSecurityPolicySettingsCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	meta := meta.Must(m)
+	logger := meta.Log("APPSEC", "resourceSecurityPolicySettingsCreate")
+	logger.Debugf("in resourceSecurityPolicySettingsCreate")
+
+	configID, err := tf.GetIntValue("config_id", d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	policyID, err := tf.GetStringValue("security_policy_id", d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := updateSecurityPolicySettings(ctx, d, m, configID, policyID); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(fmt.Sprintf("%d:%s", configID, policyID))
+
+	return resourceSecurityPolicySettingsRead(ctx, d, m)
+}
+
+func resourceSecurityPolicySettingsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	meta := meta.Must(m)
+	client := inst.Client(meta)
+	logger := meta.Log("APPSEC", "resourceSecurityPolicySettingsRead")
+	logger.Debugf("in resourceSecurityPolicySettingsRead")
+
+	iDParts, err := splitID(d.Id(), 2, "configID:securityPolicyID")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	configID, err := strconv.Atoi(iDParts[0])
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	version, err := getLatestConfigVersion(ctx, configID, m)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	policyID := iDParts[1]
+
+	protections, err := client.GetPolicyProtections(ctx, appsec.GetPolicyProtectionsRequest{ConfigID: configID, Version: version, PolicyID: policyID})
+	if err != nil {
+		logger.Errorf("calling 'getPolicyProtections': %s", err.Error())
+		return diag.FromErr(err)
+	}
+	wafMode, err := client.GetWAFMode(ctx, appsec.GetWAFModeRequest{ConfigID: configID, Version: version, PolicyID: policyID})
+	if err != nil {
+		logger.Errorf("calling 'getWAFMode': %s", err.Error())
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("config_id", configID); err != nil {
+		return diag.Errorf("%s: %s", tf.ErrValueSet, err.Error())
+	}
+	if err := d.Set("security_policy_id", policyID); err != nil {
+		return diag.Errorf("%s: %s", tf.ErrValueSet, err.Error())
+	}
+	if err := d.Set("protections", []interface{}{map[string]interface{}{
+		"apply_api_constraints":            protections.ApplyAPIConstraints,
+		"apply_application_layer_controls": protections.ApplyApplicationLayerControls,
+		"apply_botman_controls":            protections.ApplyBotmanControls,
+		"apply_malware_controls":           protections.ApplyMalwareControls,
+		"apply_network_layer_controls":     protections.ApplyNetworkLayerControls,
+		"apply_rate_controls":              protections.ApplyRateControls,
+		"apply_reputation_controls":        protections.ApplyReputationControls,
+		"apply_slow_post_controls":         protections.ApplySlowPostControls,
+	}}); err != nil {
+		return diag.Errorf("%s: %s", tf.ErrValueSet, err.Error())
+	}
+	if err := d.Set("waf_mode", wafMode.Mode); err != nil {
+		return diag.Errorf("%s: %s", tf.ErrValueSet, err.Error())
+	}
+
+	// the penalty box, attack groups and rules are only read when managed by this resource
+	if len(d.Get("penalty_box").([]interface{})) > 0 {
+		penaltyBox, err := client.GetPenaltyBox(ctx, appsec.GetPenaltyBoxRequest{ConfigID: configID, Version: version, PolicyID: policyID})
+		if err != nil {
+			logger.Errorf("calling 'getPenaltyBox': %s", err.Error())
+			return diag.FromErr(err)
+		}
+		if err := d.Set("penalty_box", []interface{}{map[string]interface{}{
+			"penalty_box_protection": penaltyBox.PenaltyBoxProtection,
+			"penalty_box_action":     penaltyBox.Action,
+		}}); err != nil {
+			return diag.Errorf("%s: %s", tf.ErrValueSet, err.Error())
+		}
+	}
+
+	groupActions := policySettingsActions(d.Get("attack_group_action"), "attack_group")
+	groups := make([]interface{}, 0, len(groupActions))
+	for _, group := range sortedKeys(groupActions) {
+		attackGroup, err := client.GetAttackGroup(ctx, appsec.GetAttackGroupRequest{ConfigID: configID, Version: version, PolicyID: policyID, Group: group})
+		if err != nil {
+			logger.Errorf("calling 'getAttackGroup': %s", err.Error())
+			return diag.FromErr(err)
+		}
+		groups = append(groups, map[string]interface{}{"attack_group": group, "action": attackGroup.Action})
+	}
+	if err := d.Set("attack_group_action", groups); err != nil {
+		return diag.Errorf("%s: %s", tf.ErrValueSet, err.Error())
+	}
+
+	ruleActions := policySettingsActions(d.Get("rule_action"), "rule_id")
+	rules := make([]interface{}, 0, len(ruleActions))
+	for _, key := range sortedKeys(ruleActions) {
+		ruleID, err := strconv.Atoi(key)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		rule, err := client.GetRule(ctx, appsec.GetRuleRequest{ConfigID: configID, Version: version, PolicyID: policyID, RuleID: ruleID})
+		if err != nil {
+			logger.Errorf("calling 'getRule': %s", err.Error())
+			return diag.FromErr(err)
+		}
+		rules = append(rules, map[string]interface{}{"rule_id": ruleID, "action": rule.Action})
+	}
+	if err := d.Set("rule_action", rules); err != nil {
+		return diag.Errorf("%s: %s", tf.ErrValueSet, err.Error())
+	}
+
+	if len(d.Get("ip_geo").([]interface{})) > 0 {
+		ipGeo, err := client.GetIPGeo(ctx, appsec.GetIPGeoRequest{ConfigID: configID, Version: version, PolicyID: policyID})
+		if err != nil {
+			logger.Errorf("calling 'getIPGeo': %s", err.Error())
+			return diag.FromErr(err)
+		}
+		if err := d.Set("ip_geo", flattenPolicySettingsIPGeo(ipGeo)); err != nil {
+			return diag.Errorf("%s: %s", tf.ErrValueSet, err.Error())
+		}
+	}
+
+	if len(d.Get("slow_post").([]interface{})) > 0 {
+		slowPost, err := client.GetSlowPostProtectionSettings(ctx, appsec.GetSlowPostProtectionSettingsRequest{ConfigID: configID, Version: version, PolicyID: policyID})
+		if err != nil {
+			logger.Errorf("calling 'getSlowPostProtectionSettings': %s", err.Error())
+			return diag.FromErr(err)
+		}
+		block := map[string]interface{}{"slow_rate_action": slowPost.Action}
+		if slowPost.SlowRateThreshold != nil {
+			block["slow_rate_threshold_rate"] = slowPost.SlowRateThreshold.Rate
+			block["slow_rate_threshold_period"] = slowPost.SlowRateThreshold.Period
+		}
+		if slowPost.DurationThreshold != nil {
+			block["duration_threshold_timeout"] = slowPost.DurationThreshold.Timeout
+		}
+		if err := d.Set("slow_post", []interface{}{block}); err != nil {
+			return diag.Errorf("%s: %s", tf.ErrValueSet, err.Error())
+		}
+	}
+
+	profileActions := policySettingsActions(d.Get("reputation_profile_action"), "reputation_profile_id")
+	profiles := make([]interface{}, 0, len(profileActions))
+	if len(profileActions) > 0 {
+		response, err := client.GetReputationProfileActions(ctx, appsec.GetReputationProfileActionsRequest{ConfigID: configID, Version: version, PolicyID: policyID})
+		if err != nil {
+			logger.Errorf("calling 'getReputationProfileActions': %s", err.Error())
+			return diag.FromErr(err)
+		}
+		for _, profile := range response.ReputationProfiles {
+			if _, ok := profileActions[strconv.Itoa(profile.ID)]; ok {
+				profiles = append(profiles, map[string]interface{}{"reputation_profile_id": profile.ID, "action": profile.Action})
+			}
+		}
+	}
+	if err := d.Set("reputation_profile_action", profiles); err != nil {
+		return diag.Errorf("%s: %s", tf.ErrValueSet, err.Error())
+	}
+
+	ratePolicyActions := policySettingsRatePolicyActions(d.Get("rate_policy_action"))
+	ratePolicies := make([]interface{}, 0, len(ratePolicyActions))
+	if len(ratePolicyActions) > 0 {
+		response, err := client.GetRatePolicyActions(ctx, appsec.GetRatePolicyActionsRequest{ConfigID: configID, Version: version, PolicyID: policyID})
+		if err != nil {
+			logger.Errorf("calling 'getRatePolicyActions': %s", err.Error())
+			return diag.FromErr(err)
+		}
+		for _, ratePolicy := range response.RatePolicyActions {
+			if _, ok := ratePolicyActions[ratePolicy.ID]; ok {
+				ratePolicies = append(ratePolicies, map[string]interface{}{
+					"rate_policy_id": ratePolicy.ID,
+					"ipv4_action":    ratePolicy.Ipv4Action,
+					"ipv6_action":    ratePolicy.Ipv6Action,
+				})
+			}
+		}
+	}
+	if err := d.Set("rate_policy_action", ratePolicies); err != nil {
+		return diag.Errorf("%s: %s", tf.ErrValueSet, err.Error())
+	}
+
+	return nil
+}
+
+func resourceSecurityPolicySettingsUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	meta := meta.Must(m)
+	logger := meta.Log("APPSEC", "resourceSecurityPolicySettingsUpdate")
+	logger.Debugf("in resourceSecurityPolicySettingsUpdate")
+
+	iDParts, err := splitID(d.Id(), 2, "configID:securityPolicyID")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	configID, err := strconv.Atoi(iDParts[0])
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	policyID := iDParts[1]
+
+	if err := updateSecurityPolicySettings(ctx, d, m, configID, policyID); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceSecurityPolicySettingsRead(ctx, d, m)
+}
+
+func resourceSecurityPolicySettingsDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	meta := meta.Must(m)
+	client := inst.Client(meta)
+	logger := meta.Log("APPSEC", "resourceSecurityPolicySettingsDelete")
+	logger.Debugf("in resourceSecurityPolicySettingsDelete")
+
+	iDParts, err := splitID(d.Id(), 2, "configID:securityPolicyID")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	configID, err := strconv.Atoi(iDParts[0])
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	version, err := getModifiableConfigVersion(ctx, configID, "securityPolicySettings", m)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	policyID := iDParts[1]
+
+	writer := policySettingsWriter{client: client, configID: configID, version: version, policyID: policyID}
+	if err := writer.updateProtections(ctx, appsec.UpdatePolicyProtectionsRequest{}); err != nil {
+		logger.Errorf("calling 'updatePolicyProtections': %s", err.Error())
+		return diag.FromErr(err)
+	}
+	if len(d.Get("penalty_box").([]interface{})) > 0 {
+		if err := writer.updatePenaltyBox(ctx, false, string(appsec.ActionTypeNone)); err != nil {
+			logger.Errorf("calling 'updatePenaltyBox': %s", err.Error())
+			return diag.FromErr(err)
+		}
+	}
+	for group := range policySettingsActions(d.Get("attack_group_action"), "attack_group") {
+		if err := writer.updateAttackGroupAction(ctx, group, string(appsec.ActionTypeNone)); err != nil {
+			logger.Errorf("calling 'updateAttackGroup': %s", err.Error())
+			return diag.FromErr(err)
+		}
+	}
+	for key := range policySettingsActions(d.Get("rule_action"), "rule_id") {
+		if err := writer.updateRuleAction(ctx, key, string(appsec.ActionTypeNone)); err != nil {
+			logger.Errorf("calling 'updateRule': %s", err.Error())
+			return diag.FromErr(err)
+		}
+	}
+	for key := range policySettingsActions(d.Get("reputation_profile_action"), "reputation_profile_id") {
+		if err := writer.updateReputationProfileAction(ctx, key, string(appsec.ActionTypeNone)); err != nil {
+			logger.Errorf("calling 'updateReputationProfileAction': %s", err.Error())
+			return diag.FromErr(err)
+		}
+	}
+	for ratePolicyID := range policySettingsRatePolicyActions(d.Get("rate_policy_action")) {
+		if err := writer.updateRatePolicyAction(ctx, ratePolicyID, ratePolicyActionNone); err != nil {
+			logger.Errorf("calling 'updateRatePolicyAction': %s", err.Error())
+			return diag.FromErr(err)
+		}
+	}
+
+	return nil
+}
+
+// updateSecurityPolicySettings writes the changed settings of the security policy. All settings are written
+// to the same modifiable version of the configuration, so the configuration is cloned at most once.
+// Attack groups, rules, reputation profiles and rate policies which are no longer managed by the resource are reset
+// to the 'none' action, as when the corresponding resource is destroyed.
+func updateSecurityPolicySettings(ctx context.Context, d *schema.ResourceData, m interface{}, configID int, policyID string) error {
+	meta := meta.Must(m)
+	client := inst.Client(meta)
+
+	version, err := getModifiableConfigVersion(ctx, configID, "securityPolicySettings", m)
+	if err != nil {
+		return err
+	}
+	writer := policySettingsWriter{client: client, configID: configID, version: version, policyID: policyID}
+
+	if d.HasChange("protections") {
+		protections := appsec.UpdatePolicyProtectionsRequest{}
+		if raw, ok := d.Get("protections").([]interface{}); ok && len(raw) > 0 && raw[0] != nil {
+			block := raw[0].(map[string]interface{})
+			protections.ApplyAPIConstraints = block["apply_api_constraints"].(bool)
+			protections.ApplyApplicationLayerControls = block["apply_application_layer_controls"].(bool)
+			protections.ApplyBotmanControls = block["apply_botman_controls"].(bool)
+			protections.ApplyMalwareControls = block["apply_malware_controls"].(bool)
+			protections.ApplyNetworkLayerControls = block["apply_network_layer_controls"].(bool)
+			protections.ApplyRateControls = block["apply_rate_controls"].(bool)
+			protections.ApplyReputationControls = block["apply_reputation_controls"].(bool)
+			protections.ApplySlowPostControls = block["apply_slow_post_controls"].(bool)
+		}
+		if err := writer.updateProtections(ctx, protections); err != nil {
+			return fmt.Errorf("updating protections: %w", err)
+		}
+	}
+
+	if mode, ok := d.GetOk("waf_mode"); ok && d.HasChange("waf_mode") {
+		if _, err := client.UpdateWAFMode(ctx, appsec.UpdateWAFModeRequest{ConfigID: configID, Version: version, PolicyID: policyID, Mode: mode.(string)}); err != nil {
+			return fmt.Errorf("updating WAF mode: %w", err)
+		}
+	}
+
+	if d.HasChange("penalty_box") {
+		if raw, ok := d.Get("penalty_box").([]interface{}); ok && len(raw) > 0 && raw[0] != nil {
+			block := raw[0].(map[string]interface{})
+			if err := writer.updatePenaltyBox(ctx, block["penalty_box_protection"].(bool), block["penalty_box_action"].(string)); err != nil {
+				return fmt.Errorf("updating penalty box: %w", err)
+			}
+		}
+	}
+
+	oldGroups, newGroups := d.GetChange("attack_group_action")
+	for group, action := range changedPolicySettingsActions(policySettingsActions(oldGroups, "attack_group"), policySettingsActions(newGroups, "attack_group")) {
+		if err := writer.updateAttackGroupAction(ctx, group, action); err != nil {
+			return fmt.Errorf("updating action of attack group %s: %w", group, err)
+		}
+	}
+
+	oldRules, newRules := d.GetChange("rule_action")
+	for ruleID, action := range changedPolicySettingsActions(policySettingsActions(oldRules, "rule_id"), policySettingsActions(newRules, "rule_id")) {
+		if err := writer.updateRuleAction(ctx, ruleID, action); err != nil {
+			return fmt.Errorf("updating action of rule %s: %w", ruleID, err)
+		}
+	}
+
+	if d.HasChange("ip_geo") {
+		if raw, ok := d.Get("ip_geo").([]interface{}); ok && len(raw) > 0 && raw[0] != nil {
+			request := expandPolicySettingsIPGeo(raw[0].(map[string]interface{}))
+			request.ConfigID, request.Version, request.PolicyID = configID, version, policyID
+			if _, err := client.UpdateIPGeo(ctx, request); err != nil {
+				return fmt.Errorf("updating IP/Geo firewall: %w", err)
+			}
+		}
+	}
+
+	if d.HasChange("slow_post") {
+		if raw, ok := d.Get("slow_post").([]interface{}); ok && len(raw) > 0 && raw[0] != nil {
+			block := raw[0].(map[string]interface{})
+			request := appsec.UpdateSlowPostProtectionSettingRequest{ConfigID: configID, Version: version, PolicyID: policyID, Action: block["slow_rate_action"].(string)}
+			request.SlowRateThreshold.Rate = block["slow_rate_threshold_rate"].(int)
+			request.SlowRateThreshold.Period = block["slow_rate_threshold_period"].(int)
+			request.DurationThreshold.Timeout = block["duration_threshold_timeout"].(int)
+			if _, err := client.UpdateSlowPostProtectionSetting(ctx, request); err != nil {
+				return fmt.Errorf("updating slow POST protection settings: %w", err)
+			}
+		}
+	}
+
+	oldProfiles, newProfiles := d.GetChange("reputation_profile_action")
+	for profileID, action := range changedPolicySettingsActions(policySettingsActions(oldProfiles, "reputation_profile_id"), policySettingsActions(newProfiles, "reputation_profile_id")) {
+		if err := writer.updateReputationProfileAction(ctx, profileID, action); err != nil {
+			return fmt.Errorf("updating action of reputation profile %s: %w", profileID, err)
+		}
+	}
+
+	oldRatePolicies, newRatePolicies := d.GetChange("rate_policy_action")
+	oldRatePolicyActions, newRatePolicyActions := policySettingsRatePolicyActions(oldRatePolicies), policySettingsRatePolicyActions(newRatePolicies)
+	for ratePolicyID, actions := range newRatePolicyActions {
+		if old, ok := oldRatePolicyActions[ratePolicyID]; ok && old == actions {
+			continue
+		}
+		if err := writer.updateRatePolicyAction(ctx, ratePolicyID, actions); err != nil {
+			return fmt.Errorf("updating action of rate policy %d: %w", ratePolicyID, err)
+		}
+	}
+	for ratePolicyID := range oldRatePolicyActions {
+		if _, ok := newRatePolicyActions[ratePolicyID]; ok {
+			continue
+		}
+		if err := writer.updateRatePolicyAction(ctx, ratePolicyID, ratePolicyActionNone); err != nil {
+			return fmt.Errorf("updating action of rate policy %d: %w", ratePolicyID, err)
+		}
+	}
+
+	return nil
+}
+
+// policySettingsWriter writes security policy settings to a single version of a security configuration
+type policySettingsWriter struct {
+	client   appsec.APPSEC
+	configID int
+	version  int
+	policyID string
+}
+
+func (w policySettingsWriter) updateProtections(ctx context.Context, protections appsec.UpdatePolicyProtectionsRequest) error {
+	protections.ConfigID, protections.Version, protections.PolicyID = w.configID, w.version, w.policyID
+	_, err := w.client.UpdatePolicyProtections(ctx, protections)
+	return err
+}
+
+func (w policySettingsWriter) updatePenaltyBox(ctx context.Context, protection bool, action string) error {
+	_, err := w.client.UpdatePenaltyBox(ctx, appsec.UpdatePenaltyBoxRequest{
+		ConfigID:             w.configID,
+		Version:              w.version,
+		PolicyID:             w.policyID,
+		PenaltyBoxProtection: protection,
+		Action:               action,
+	})
+	return err
+}
+
+// updateAttackGroupAction changes the action of an attack group, keeping its condition and exception information
+func (w policySettingsWriter) updateAttackGroupAction(ctx context.Context, group, action string) error {
+	current, err := w.client.GetAttackGroup(ctx, appsec.GetAttackGroupRequest{ConfigID: w.configID, Version: w.version, PolicyID: w.policyID, Group: group})
+	if err != nil {
+		return err
+	}
+	var conditionException json.RawMessage
+	if !current.IsEmptyConditionException() {
+		if conditionException, err = json.Marshal(current.ConditionException); err != nil {
+			return err
+		}
+	}
+	_, err = w.client.UpdateAttackGroup(ctx, appsec.UpdateAttackGroupRequest{
+		ConfigID:       w.configID,
+		Version:        w.version,
+		PolicyID:       w.policyID,
+		Group:          group,
+		Action:         action,
+		JsonPayloadRaw: conditionException,
+	})
+	return err
+}
+
+// updateRuleAction changes the action of a rule, keeping its condition and exception information
+func (w policySettingsWriter) updateRuleAction(ctx context.Context, key, action string) error {
+	ruleID, err := strconv.Atoi(key)
+	if err != nil {
+		return err
+	}
+	current, err := w.client.GetRule(ctx, appsec.GetRuleRequest{ConfigID: w.configID, Version: w.version, PolicyID: w.policyID, RuleID: ruleID})
+	if err != nil {
+		return err
+	}
+	var conditionException json.RawMessage
+	if !current.IsEmptyConditionException() {
+		if conditionException, err = json.Marshal(current.ConditionException); err != nil {
+			return err
+		}
+	}
+	_, err = w.client.UpdateRule(ctx, appsec.UpdateRuleRequest{
+		ConfigID:       w.configID,
+		Version:        w.version,
+		PolicyID:       w.policyID,
+		RuleID:         ruleID,
+		Action:         action,
+		JsonPayloadRaw: conditionException,
+	})
+	return err
+}
+
+func (w policySettingsWriter) updateReputationProfileAction(ctx context.Context, key, action string) error {
+	profileID, err := strconv.Atoi(key)
+	if err != nil {
+		return err
+	}
+	_, err = w.client.UpdateReputationProfileAction(ctx, appsec.UpdateReputationProfileActionRequest{
+		ConfigID:            w.configID,
+		Version:             w.version,
+		PolicyID:            w.policyID,
+		ReputationProfileID: profileID,
+		Action:              action,
+	})
+	return err
+}
+
+func (w policySettingsWriter) updateRatePolicyAction(ctx context.Context, ratePolicyID int, actions ratePolicyActions) error {
+	_, err := w.client.UpdateRatePolicyAction(ctx, appsec.UpdateRatePolicyActionRequest{
+		ConfigID:     w.configID,
+		Version:      w.version,
+		PolicyID:     w.policyID,
+		RatePolicyID: ratePolicyID,
+		Ipv4Action:   actions.ipv4,
+		Ipv6Action:   actions.ipv6,
+	})
+	return err
+}
+
+// ratePolicyActions holds the actions of a rate policy for IPv4 and IPv6 clients
+type ratePolicyActions struct {
+	ipv4 string
+	ipv6 string
+}
+
+var ratePolicyActionNone = ratePolicyActions{ipv4: string(appsec.ActionTypeNone), ipv6: string(appsec.ActionTypeNone)}
+
+// policySettingsRatePolicyActions indexes the actions of a 'rate_policy_action' set by rate policy
+func policySettingsRatePolicyActions(raw interface{}) map[int]ratePolicyActions {
+	actions := make(map[int]ratePolicyActions)
+	set, ok := raw.(*schema.Set)
+	if !ok {
+		return actions
+	}
+	for _, item := range set.List() {
+		entry := item.(map[string]interface{})
+		actions[entry["rate_policy_id"].(int)] = ratePolicyActions{ipv4: entry["ipv4_action"].(string), ipv6: entry["ipv6_action"].(string)}
+	}
+	return actions
+}
+
+// expandPolicySettingsIPGeo builds the IP/Geo firewall request from an 'ip_geo' block in the same way as the
+// akamai_appsec_ip_geo resource
+func expandPolicySettingsIPGeo(block map[string]interface{}) appsec.UpdateIPGeoRequest {
+	blockedGeoLists, _ := block["geo_network_lists"].([]interface{})
+	blockedIPLists, _ := block["ip_network_lists"].([]interface{})
+	blockedASNLists, _ := block["asn_network_lists"].([]interface{})
+	exceptionIPLists, _ := block["exception_ip_network_lists"].([]interface{})
+	ukraineGeoControlAction, _ := block["ukraine_geo_control_action"].(string)
+
+	var request appsec.UpdateIPGeoRequest
+	switch block["mode"] {
+	case Allow:
+		request.Block = "blockAllTrafficExceptAllowedIPs"
+		request.IPControls = ipControlsFromAllowLists(exceptionIPLists)
+	case Block:
+		request.Block = "blockSpecificIPGeo"
+		request.GeoControls = geoControlsFromBlockLists(blockedGeoLists)
+		request.ASNControls = asnControlsFromBlockLists(blockedASNLists)
+		request.IPControls = ipControlsFromBlockAndAllowLists(blockedIPLists, exceptionIPLists)
+		if ukraineGeoControlAction != "" {
+			request.UkraineGeoControls = &appsec.UkraineGeoControl{Action: ukraineGeoControlAction}
+		}
+	}
+	return request
+}
+
+// flattenPolicySettingsIPGeo returns the 'ip_geo' block of the IP/Geo firewall settings
+func flattenPolicySettingsIPGeo(ipGeo *appsec.GetIPGeoResponse) []interface{} {
+	networkLists := func(lists *appsec.IPGeoNetworkLists) []string {
+		if lists == nil || lists.NetworkList == nil {
+			return []string{}
+		}
+		return lists.NetworkList
+	}
+	block := map[string]interface{}{
+		"geo_network_lists":          []string{},
+		"ip_network_lists":           []string{},
+		"asn_network_lists":          []string{},
+		"exception_ip_network_lists": []string{},
+	}
+	if ipGeo.IPControls != nil {
+		block["exception_ip_network_lists"] = networkLists(ipGeo.IPControls.AllowedIPNetworkLists)
+	}
+	switch ipGeo.Block {
+	case "blockAllTrafficExceptAllowedIPs":
+		block["mode"] = Allow
+	case "blockSpecificIPGeo":
+		block["mode"] = Block
+		if ipGeo.GeoControls != nil {
+			block["geo_network_lists"] = networkLists(ipGeo.GeoControls.BlockedIPNetworkLists)
+		}
+		if ipGeo.IPControls != nil {
+			block["ip_network_lists"] = networkLists(ipGeo.IPControls.BlockedIPNetworkLists)
+		}
+		if ipGeo.ASNControls != nil {
+			block["asn_network_lists"] = networkLists(ipGeo.ASNControls.BlockedIPNetworkLists)
+		}
+		if ipGeo.UkraineGeoControls != nil {
+			block["ukraine_geo_control_action"] = ipGeo.UkraineGeoControls.Action
+		}
+	}
+	return []interface{}{block}
+}
+
+// policySettingsActions indexes the actions of an 'attack_group_action', 'rule_action' or 'reputation_profile_action'
+// set by the given key attribute
+func policySettingsActions(raw interface{}, keyName string) map[string]string {
+	actions := make(map[string]string)
+	set, ok := raw.(*schema.Set)
+	if !ok {
+		return actions
+	}
+	for _, item := range set.List() {
+		entry := item.(map[string]interface{})
+		actions[fmt.Sprint(entry[keyName])] = entry["action"].(string)
+	}
+	return actions
+}
+
+// changedPolicySettingsActions returns the actions to be written to go from the old to the new actions.
+// Items which are no longer present get the 'none' action.
+func changedPolicySettingsActions(oldActions, newActions map[string]string) map[string]string {
+	changed := make(map[string]string)
+	for key, action := range newActions {
+		if oldActions[key] != action {
+			changed[key] = action
+		}
+	}
+	for key := range oldActions {
+		if _, ok := newActions[key]; !ok {
+			changed[key] = string(appsec.ActionTypeNone)
+		}
+	}
+	return changed
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
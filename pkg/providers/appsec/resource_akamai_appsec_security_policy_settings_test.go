@@ -0,0 +1,248 @@
+package appsec
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v9/pkg/appsec"
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v9/pkg/session"
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/common/testutils"
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/meta"
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAkamaiSecurityPolicySettings_res_basic(t *testing.T) {
+	t.Run("match by SecurityPolicySettings ID", func(t *testing.T) {
+		client := &appsec.Mock{}
+
+		config := appsec.GetConfigurationResponse{}
+		err := json.Unmarshal(testutils.LoadFixtureBytes(t, "testdata/TestResConfiguration/LatestConfiguration.json"), &config)
+		require.NoError(t, err)
+
+		protections := appsec.PolicyProtectionsResponse{
+			ApplyApplicationLayerControls: true,
+			ApplyNetworkLayerControls:     true,
+			ApplyRateControls:             true,
+			ApplySlowPostControls:         true,
+		}
+
+		client.On("GetConfiguration", mock.Anything, appsec.GetConfigurationRequest{ConfigID: 43253}).Return(&config, nil)
+
+		client.On("UpdatePolicyProtections", mock.Anything, appsec.UpdatePolicyProtectionsRequest{
+			ConfigID:                      43253,
+			Version:                       7,
+			PolicyID:                      "AAAA_81230",
+			ApplyApplicationLayerControls: true,
+			ApplyNetworkLayerControls:     true,
+			ApplyRateControls:             true,
+			ApplySlowPostControls:         true,
+		}).Return(&protections, nil).Once()
+		client.On("UpdateWAFMode", mock.Anything, appsec.UpdateWAFModeRequest{ConfigID: 43253, Version: 7, PolicyID: "AAAA_81230", Mode: "KRS"}).
+			Return(&appsec.UpdateWAFModeResponse{Mode: "KRS"}, nil).Once()
+		client.On("UpdatePenaltyBox", mock.Anything, appsec.UpdatePenaltyBoxRequest{ConfigID: 43253, Version: 7, PolicyID: "AAAA_81230", PenaltyBoxProtection: true, Action: "deny"}).
+			Return(&appsec.UpdatePenaltyBoxResponse{PenaltyBoxProtection: true, Action: "deny"}, nil).Once()
+		client.On("GetAttackGroup", mock.Anything, appsec.GetAttackGroupRequest{ConfigID: 43253, Version: 7, PolicyID: "AAAA_81230", Group: "SQL"}).
+			Return(&appsec.GetAttackGroupResponse{Action: "alert"}, nil).Once()
+		client.On("UpdateAttackGroup", mock.Anything, appsec.UpdateAttackGroupRequest{ConfigID: 43253, Version: 7, PolicyID: "AAAA_81230", Group: "SQL", Action: "deny"}).
+			Return(&appsec.UpdateAttackGroupResponse{Action: "deny"}, nil).Once()
+		client.On("GetRule", mock.Anything, appsec.GetRuleRequest{ConfigID: 43253, Version: 7, PolicyID: "AAAA_81230", RuleID: 699989}).
+			Return(&appsec.GetRuleResponse{Action: "none"}, nil).Once()
+		client.On("UpdateRule", mock.Anything, appsec.UpdateRuleRequest{ConfigID: 43253, Version: 7, PolicyID: "AAAA_81230", RuleID: 699989, Action: "alert"}).
+			Return(&appsec.UpdateRuleResponse{Action: "alert"}, nil).Once()
+
+		client.On("GetPolicyProtections", mock.Anything, appsec.GetPolicyProtectionsRequest{ConfigID: 43253, Version: 7, PolicyID: "AAAA_81230"}).
+			Return(&protections, nil)
+		client.On("GetWAFMode", mock.Anything, appsec.GetWAFModeRequest{ConfigID: 43253, Version: 7, PolicyID: "AAAA_81230"}).
+			Return(&appsec.GetWAFModeResponse{Mode: "KRS"}, nil)
+		client.On("GetPenaltyBox", mock.Anything, appsec.GetPenaltyBoxRequest{ConfigID: 43253, Version: 7, PolicyID: "AAAA_81230"}).
+			Return(&appsec.GetPenaltyBoxResponse{PenaltyBoxProtection: true, Action: "deny"}, nil)
+		client.On("GetAttackGroup", mock.Anything, appsec.GetAttackGroupRequest{ConfigID: 43253, Version: 7, PolicyID: "AAAA_81230", Group: "SQL"}).
+			Return(&appsec.GetAttackGroupResponse{Action: "deny"}, nil)
+		client.On("GetRule", mock.Anything, appsec.GetRuleRequest{ConfigID: 43253, Version: 7, PolicyID: "AAAA_81230", RuleID: 699989}).
+			Return(&appsec.GetRuleResponse{Action: "alert"}, nil)
+
+		client.On("UpdatePolicyProtections", mock.Anything, appsec.UpdatePolicyProtectionsRequest{ConfigID: 43253, Version: 7, PolicyID: "AAAA_81230"}).
+			Return(&appsec.PolicyProtectionsResponse{}, nil).Once()
+		client.On("UpdatePenaltyBox", mock.Anything, appsec.UpdatePenaltyBoxRequest{ConfigID: 43253, Version: 7, PolicyID: "AAAA_81230", Action: "none"}).
+			Return(&appsec.UpdatePenaltyBoxResponse{Action: "none"}, nil).Once()
+		client.On("UpdateAttackGroup", mock.Anything, appsec.UpdateAttackGroupRequest{ConfigID: 43253, Version: 7, PolicyID: "AAAA_81230", Group: "SQL", Action: "none"}).
+			Return(&appsec.UpdateAttackGroupResponse{Action: "none"}, nil).Once()
+		client.On("UpdateRule", mock.Anything, appsec.UpdateRuleRequest{ConfigID: 43253, Version: 7, PolicyID: "AAAA_81230", RuleID: 699989, Action: "none"}).
+			Return(&appsec.UpdateRuleResponse{Action: "none"}, nil).Once()
+
+		useClient(client, func() {
+			resource.Test(t, resource.TestCase{
+				IsUnitTest:               true,
+				ProtoV6ProviderFactories: testutils.NewProtoV6ProviderFactory(NewSubprovider()),
+				Steps: []resource.TestStep{
+					{
+						Config: testutils.LoadFixtureString(t, "testdata/TestResSecurityPolicySettings/match_by_id.tf"),
+						Check: resource.ComposeAggregateTestCheckFunc(
+							resource.TestCheckResourceAttr("akamai_appsec_security_policy_settings.test", "id", "43253:AAAA_81230"),
+							resource.TestCheckResourceAttr("akamai_appsec_security_policy_settings.test", "protections.0.apply_rate_controls", "true"),
+							resource.TestCheckResourceAttr("akamai_appsec_security_policy_settings.test", "protections.0.apply_botman_controls", "false"),
+							resource.TestCheckResourceAttr("akamai_appsec_security_policy_settings.test", "penalty_box.0.penalty_box_action", "deny"),
+							resource.TestCheckResourceAttr("akamai_appsec_security_policy_settings.test", "attack_group_action.#", "1"),
+							resource.TestCheckResourceAttr("akamai_appsec_security_policy_settings.test", "rule_action.#", "1"),
+						),
+					},
+				},
+			})
+		})
+
+		client.AssertExpectations(t)
+	})
+}
+
+func TestUpdateSecurityPolicySettings(t *testing.T) {
+	const conditionExceptionJSON = `{"action":"alert","conditionException":{"exception":{"specificHeaderCookieParamXmlOrJsonNames":[{"names":["test"],"selector":"REQUEST_HEADERS"}]}}}`
+
+	m, err := meta.New(session.Must(session.New()), hclog.NewNullLogger(), "")
+	require.NoError(t, err)
+
+	config := appsec.GetConfigurationResponse{}
+	err = json.Unmarshal(testutils.LoadFixtureBytes(t, "testdata/TestResConfiguration/LatestConfiguration.json"), &config)
+	require.NoError(t, err)
+
+	t.Run("all settings are written to a single version", func(t *testing.T) {
+		attackGroup := appsec.GetAttackGroupResponse{}
+		err := json.Unmarshal([]byte(conditionExceptionJSON), &attackGroup)
+		require.NoError(t, err)
+
+		client := &appsec.Mock{}
+		client.On("GetConfiguration", mock.Anything, appsec.GetConfigurationRequest{ConfigID: 43253}).Return(&config, nil).Once()
+		client.On("UpdatePolicyProtections", mock.Anything, appsec.UpdatePolicyProtectionsRequest{
+			ConfigID: 43253, Version: 7, PolicyID: "AAAA_81230", ApplyApplicationLayerControls: true,
+		}).Return(&appsec.PolicyProtectionsResponse{ApplyApplicationLayerControls: true}, nil).Once()
+		client.On("UpdateWAFMode", mock.Anything, appsec.UpdateWAFModeRequest{ConfigID: 43253, Version: 7, PolicyID: "AAAA_81230", Mode: "ASE_AUTO"}).
+			Return(&appsec.UpdateWAFModeResponse{}, nil).Once()
+		client.On("GetAttackGroup", mock.Anything, appsec.GetAttackGroupRequest{ConfigID: 43253, Version: 7, PolicyID: "AAAA_81230", Group: "XSS"}).
+			Return(&attackGroup, nil).Once()
+		client.On("UpdateAttackGroup", mock.Anything, mock.MatchedBy(func(req appsec.UpdateAttackGroupRequest) bool {
+			return req.Version == 7 && req.Group == "XSS" && req.Action == "deny" &&
+				jsonEqual(req.JsonPayloadRaw, []byte(`{"exception":{"specificHeaderCookieParamXmlOrJsonNames":[{"names":["test"],"selector":"REQUEST_HEADERS"}]}}`))
+		})).Return(&appsec.UpdateAttackGroupResponse{}, nil).Once()
+
+		d := schema.TestResourceDataRaw(t, resourceSecurityPolicySettings().Schema, map[string]interface{}{
+			"config_id":          43253,
+			"security_policy_id": "AAAA_81230",
+			"waf_mode":           "ASE_AUTO",
+			"protections": []interface{}{map[string]interface{}{
+				"apply_application_layer_controls": true,
+			}},
+			"attack_group_action": []interface{}{map[string]interface{}{
+				"attack_group": "XSS",
+				"action":       "deny",
+			}},
+		})
+
+		useClient(client, func() {
+			require.NoError(t, updateSecurityPolicySettings(context.Background(), d, m, 43253, "AAAA_81230"))
+		})
+		client.AssertExpectations(t)
+	})
+
+	t.Run("IP/Geo, slow POST, reputation profile and rate policy settings are written", func(t *testing.T) {
+		client := &appsec.Mock{}
+		client.On("GetConfiguration", mock.Anything, appsec.GetConfigurationRequest{ConfigID: 43253}).Return(&config, nil).Once()
+		client.On("UpdatePolicyProtections", mock.Anything, appsec.UpdatePolicyProtectionsRequest{
+			ConfigID: 43253, Version: 7, PolicyID: "AAAA_81230", ApplyNetworkLayerControls: true, ApplySlowPostControls: true,
+		}).Return(&appsec.PolicyProtectionsResponse{}, nil).Once()
+		client.On("UpdateIPGeo", mock.Anything, appsec.UpdateIPGeoRequest{
+			ConfigID:    43253,
+			Version:     7,
+			PolicyID:    "AAAA_81230",
+			Block:       "blockSpecificIPGeo",
+			GeoControls: &appsec.IPGeoGeoControls{BlockedIPNetworkLists: &appsec.IPGeoNetworkLists{NetworkList: []string{"40731_BMROLLOUTGEO"}}},
+			IPControls:  &appsec.IPGeoIPControls{AllowedIPNetworkLists: &appsec.IPGeoNetworkLists{NetworkList: []string{"69601_ADYENPRODWHITELIST"}}},
+		}).Return(&appsec.UpdateIPGeoResponse{}, nil).Once()
+		slowPost := appsec.UpdateSlowPostProtectionSettingRequest{ConfigID: 43253, Version: 7, PolicyID: "AAAA_81230", Action: "abort"}
+		slowPost.SlowRateThreshold.Rate, slowPost.SlowRateThreshold.Period = 10, 30
+		client.On("UpdateSlowPostProtectionSetting", mock.Anything, slowPost).Return(&appsec.UpdateSlowPostProtectionSettingResponse{}, nil).Once()
+		client.On("UpdateReputationProfileAction", mock.Anything, appsec.UpdateReputationProfileActionRequest{
+			ConfigID: 43253, Version: 7, PolicyID: "AAAA_81230", ReputationProfileID: 12345, Action: "deny",
+		}).Return(&appsec.UpdateReputationProfileActionResponse{}, nil).Once()
+		client.On("UpdateRatePolicyAction", mock.Anything, appsec.UpdateRatePolicyActionRequest{
+			ConfigID: 43253, Version: 7, PolicyID: "AAAA_81230", RatePolicyID: 135355, Ipv4Action: "deny", Ipv6Action: "alert",
+		}).Return(&appsec.UpdateRatePolicyActionResponse{}, nil).Once()
+
+		d := schema.TestResourceDataRaw(t, resourceSecurityPolicySettings().Schema, map[string]interface{}{
+			"config_id":          43253,
+			"security_policy_id": "AAAA_81230",
+			"protections": []interface{}{map[string]interface{}{
+				"apply_network_layer_controls": true,
+				"apply_slow_post_controls":     true,
+			}},
+			"ip_geo": []interface{}{map[string]interface{}{
+				"mode":                       "block",
+				"geo_network_lists":          []interface{}{"40731_BMROLLOUTGEO"},
+				"exception_ip_network_lists": []interface{}{"69601_ADYENPRODWHITELIST"},
+			}},
+			"slow_post": []interface{}{map[string]interface{}{
+				"slow_rate_action":           "abort",
+				"slow_rate_threshold_rate":   10,
+				"slow_rate_threshold_period": 30,
+			}},
+			"reputation_profile_action": []interface{}{map[string]interface{}{
+				"reputation_profile_id": 12345,
+				"action":                "deny",
+			}},
+			"rate_policy_action": []interface{}{map[string]interface{}{
+				"rate_policy_id": 135355,
+				"ipv4_action":    "deny",
+				"ipv6_action":    "alert",
+			}},
+		})
+
+		useClient(client, func() {
+			require.NoError(t, updateSecurityPolicySettings(context.Background(), d, m, 43253, "AAAA_81230"))
+		})
+		client.AssertExpectations(t)
+	})
+}
+
+func TestPolicySettingsIPGeo(t *testing.T) {
+	block := map[string]interface{}{
+		"mode":                       "block",
+		"geo_network_lists":          []interface{}{"40731_BMROLLOUTGEO"},
+		"ip_network_lists":           []interface{}{"49181_ADTIPBLACKLIST"},
+		"asn_network_lists":          []interface{}{},
+		"exception_ip_network_lists": []interface{}{"69601_ADYENPRODWHITELIST"},
+		"ukraine_geo_control_action": "alert",
+	}
+	request := expandPolicySettingsIPGeo(block)
+	assert.Equal(t, "blockSpecificIPGeo", request.Block)
+	assert.Nil(t, request.ASNControls)
+
+	response := appsec.GetIPGeoResponse{
+		Block:              request.Block,
+		GeoControls:        request.GeoControls,
+		IPControls:         request.IPControls,
+		UkraineGeoControls: request.UkraineGeoControls,
+	}
+	assert.Equal(t, []interface{}{map[string]interface{}{
+		"mode":                       "block",
+		"geo_network_lists":          []string{"40731_BMROLLOUTGEO"},
+		"ip_network_lists":           []string{"49181_ADTIPBLACKLIST"},
+		"asn_network_lists":          []string{},
+		"exception_ip_network_lists": []string{"69601_ADYENPRODWHITELIST"},
+		"ukraine_geo_control_action": "alert",
+	}}, flattenPolicySettingsIPGeo(&response))
+
+	allow := expandPolicySettingsIPGeo(map[string]interface{}{"mode": "allow", "ip_network_lists": []interface{}{"49181_ADTIPBLACKLIST"}})
+	assert.Equal(t, appsec.UpdateIPGeoRequest{Block: "blockAllTrafficExceptAllowedIPs"}, allow)
+}
+
+func TestChangedPolicySettingsActions(t *testing.T) {
+	changed := changedPolicySettingsActions(
+		map[string]string{"SQL": "deny", "XSS": "alert", "CMD": "deny"},
+		map[string]string{"SQL": "deny", "XSS": "deny", "PHP": "alert"},
+	)
+	assert.Equal(t, map[string]string{"XSS": "deny", "PHP": "alert", "CMD": "none"}, changed)
+}
@@ -0,0 +1,795 @@
+package appsec
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v9/pkg/appsec"
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/common/tf"
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/meta"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"golang.org/x/exp/slices"
+)
+
+type (
+	// tuningRecommendation is a tuning recommendation for a single attack group or rule
+	tuningRecommendation struct {
+		ID          string
+		AttackGroup string
+		RuleID      int
+		Description string
+		// EvidenceCount is the number of host, path and user data evidences the recommendation is based on
+		EvidenceCount int
+		Exception     *appsec.AttackGroupException
+	}
+
+	// tuningRecommendationFilter selects tuning recommendations by attack group or rule
+	tuningRecommendationFilter struct {
+		attackGroups     []string
+		ruleIDs          []int
+		minEvidenceCount int
+	}
+
+	// recommendedExceptionApplier adds recommended exceptions to, and removes them from, the condition and
+	// exception information of attack groups and rules
+	recommendedExceptionApplier struct {
+		client     appsec.APPSEC
+		configID   int
+		version    int
+		policyID   string
+		evaluation bool
+	}
+)
+
+const (
+	recommendationExceptionNamesKey = "specificHeaderCookieParamXmlOrJsonNames"
+)
+
+// appsec v1
+//
+// https://techdocs.akamai.com/application-security/reference/api
+func resourceTuningRecommendationExceptions() *schema.Resource {
+	recommendationSchema := map[string]*schema.Schema{
+		"id": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Identifier of the recommendation, composed of the attack group or rule and a hash of the recommended exception",
+		},
+		"attack_group": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Attack group the recommendation applies to",
+		},
+		"rule_id": {
+			Type:        schema.TypeInt,
+			Computed:    true,
+			Description: "Rule the recommendation applies to",
+		},
+		"description": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Description of the recommendation",
+		},
+		"evidence_count": {
+			Type:        schema.TypeInt,
+			Computed:    true,
+			Description: "Number of host, path and user data evidences the recommendation is based on",
+		},
+		"exception": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "JSON-formatted exception recommended for the attack group or rule",
+		},
+	}
+
+	return &schema.Resource{
+		CreateContext: resourceTuningRecommendationExceptionsCreate,
+		ReadContext:   resourceTuningRecommendationExceptionsRead,
+		UpdateContext: resourceTuningRecommendationExceptionsUpdate,
+		DeleteContext: resourceTuningRecommendationExceptionsDelete,
+		CustomizeDiff: customdiff.All(
+			VerifyIDUnchanged,
+			customDiffTuningRecommendationExceptions,
+		),
+		Schema: map[string]*schema.Schema{
+			"config_id": {
+				Type:        schema.TypeInt,
+				Required:    true,
+				Description: "Unique identifier of the security configuration",
+			},
+			"security_policy_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Unique identifier of the security policy",
+			},
+			"ruleset_type": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+				Default:  string(appsec.RulesetTypeActive),
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice([]string{
+					string(appsec.RulesetTypeActive),
+					string(appsec.RulesetTypeEvaluation),
+				}, false)),
+				Description: "Type of the ruleset to which the recommendations are applied",
+			},
+			"recommendation_ids": {
+				Type:         schema.TypeSet,
+				Optional:     true,
+				Elem:         &schema.Schema{Type: schema.TypeString},
+				ExactlyOneOf: []string{"recommendation_ids", "filter"},
+				Description:  "Identifiers of the recommendations to apply, as listed in 'pending_recommendation'",
+			},
+			"filter": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Description: "Applies all recommendations for the given attack groups and rules, including recommendations made later",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"attack_groups": {
+							Type:        schema.TypeSet,
+							Optional:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+							Description: "Attack groups whose recommendations are applied",
+						},
+						"rule_ids": {
+							Type:        schema.TypeSet,
+							Optional:    true,
+							Elem:        &schema.Schema{Type: schema.TypeInt},
+							Description: "Rules whose recommendations are applied",
+						},
+						"min_evidence_count": {
+							Type:             schema.TypeInt,
+							Optional:         true,
+							ValidateDiagFunc: validation.ToDiagFunc(validation.IntAtLeast(0)),
+							Description:      "Minimum number of evidences of the applied recommendations. Recommendations based on fewer evidences have a low confidence and are not applied",
+						},
+					},
+				},
+			},
+			"applied_recommendation": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Resource{Schema: recommendationSchema},
+				Description: "Recommendations applied by this resource. The condition and exception information of their attack groups and rules must not be managed by 'akamai_appsec_attack_group' or 'akamai_appsec_rule' as well, as these overwrite the applied recommendations",
+			},
+			"pending_recommendation": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Resource{Schema: recommendationSchema},
+				Description: "Recommendations currently made for the security policy which are not applied",
+			},
+			"unselected_recommendation_ids": {
+				Type:        schema.TypeSet,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Identifiers of the pending recommendations which are neither selected by 'recommendation_ids' nor matched by 'filter'",
+			},
+		},
+	}
+}
+
+// customDiffTuningRecommendationExceptions plans an update when the selection changes or when pending
+// recommendations match the filter
+func customDiffTuningRecommendationExceptions(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	if d.Id() == "" {
+		return nil
+	}
+	changed := d.HasChanges("recommendation_ids", "filter")
+	if filter, ok := tuningRecommendationFilterFromSchema(d.Get("filter")); ok && !changed {
+		changed = slices.ContainsFunc(recommendationsFromState(d.Get("pending_recommendation")), filter.matches)
+	}
+	if !changed {
+		return nil
+	}
+	for _, attr := range []string{"applied_recommendation", "pending_recommendation", "unselected_recommendation_ids"} {
+		if err := d.SetNewComputed(attr); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func resourceTuningRecommendationExceptionsCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	meta := meta.Must(m)
+	logger := meta.Log("APPSEC", "resourceTuningRecommendationExceptionsCreate")
+	logger.Debugf("in resourceTuningRecommendationExceptionsCreate")
+
+	configID, err := tf.GetIntValue("config_id", d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	policyID, err := tf.GetStringValue("security_policy_id", d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	rulesetType, err := tf.GetStringValue("ruleset_type", d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	applied, err := applyTuningRecommendations(ctx, d, m, configID, policyID, rulesetType, nil)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("applied_recommendation", recommendationsToState(applied)); err != nil {
+		return diag.Errorf("%s: %s", tf.ErrValueSet, err.Error())
+	}
+
+	d.SetId(fmt.Sprintf("%d:%s:%s", configID, policyID, rulesetType))
+
+	return resourceTuningRecommendationExceptionsRead(ctx, d, m)
+}
+
+func resourceTuningRecommendationExceptionsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	meta := meta.Must(m)
+	client := inst.Client(meta)
+	logger := meta.Log("APPSEC", "resourceTuningRecommendationExceptionsRead")
+	logger.Debugf("in resourceTuningRecommendationExceptionsRead")
+
+	iDParts, err := splitID(d.Id(), 3, "configID:securityPolicyID:rulesetType")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	configID, err := strconv.Atoi(iDParts[0])
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	policyID, rulesetType := iDParts[1], iDParts[2]
+
+	version, err := getLatestConfigVersion(ctx, configID, m)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	recommendations, err := getTuningRecommendations(ctx, client, configID, version, policyID, rulesetType)
+	if err != nil {
+		logger.Errorf("calling 'GetTuningRecommendations': %s", err.Error())
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("config_id", configID); err != nil {
+		return diag.Errorf("%s: %s", tf.ErrValueSet, err.Error())
+	}
+	if err := d.Set("security_policy_id", policyID); err != nil {
+		return diag.Errorf("%s: %s", tf.ErrValueSet, err.Error())
+	}
+	if err := d.Set("ruleset_type", rulesetType); err != nil {
+		return diag.Errorf("%s: %s", tf.ErrValueSet, err.Error())
+	}
+
+	// the condition and exception information may be overwritten by other resources managing the same attack
+	// groups or rules, so recommendations missing there are no longer reported as applied
+	var diags diag.Diagnostics
+	applier := recommendedExceptionApplier{
+		client:     client,
+		configID:   configID,
+		version:    version,
+		policyID:   policyID,
+		evaluation: rulesetType == string(appsec.RulesetTypeEvaluation),
+	}
+	var applied []tuningRecommendation
+	for _, recommendation := range recommendationsFromState(d.Get("applied_recommendation")) {
+		isApplied, err := applier.isApplied(ctx, recommendation)
+		if err != nil {
+			logger.Errorf("checking recommendation '%s': %s", recommendation.ID, err.Error())
+			return diag.FromErr(err)
+		}
+		if !isApplied {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Warning,
+				Summary:  fmt.Sprintf("tuning recommendation %s was removed from the condition and exception information", recommendation.ID),
+				Detail:   "The attack group or rule is likely managed by 'akamai_appsec_attack_group' or 'akamai_appsec_rule' as well, which overwrites the applied recommendations.",
+			})
+			continue
+		}
+		applied = append(applied, recommendation)
+	}
+	if err := d.Set("applied_recommendation", recommendationsToState(applied)); err != nil {
+		return diag.Errorf("%s: %s", tf.ErrValueSet, err.Error())
+	}
+
+	var pending []tuningRecommendation
+	for _, recommendation := range recommendations {
+		if !slices.ContainsFunc(applied, func(r tuningRecommendation) bool { return r.ID == recommendation.ID }) {
+			pending = append(pending, recommendation)
+		}
+	}
+	if err := d.Set("pending_recommendation", recommendationsToState(pending)); err != nil {
+		return diag.Errorf("%s: %s", tf.ErrValueSet, err.Error())
+	}
+
+	// recommendations which are neither selected nor matched by the filter are reported, as they would
+	// otherwise go unnoticed
+	selected := tf.SetToStringSlice(d.Get("recommendation_ids").(*schema.Set))
+	filter, hasFilter := tuningRecommendationFilterFromSchema(d.Get("filter"))
+	var unselected []string
+	for _, recommendation := range pending {
+		if slices.Contains(selected, recommendation.ID) || (hasFilter && filter.matches(recommendation)) {
+			continue
+		}
+		unselected = append(unselected, recommendation.ID)
+	}
+	if err := d.Set("unselected_recommendation_ids", unselected); err != nil {
+		return diag.Errorf("%s: %s", tf.ErrValueSet, err.Error())
+	}
+	if len(unselected) > 0 {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  fmt.Sprintf("%d pending tuning recommendations for security policy %s", len(unselected), policyID),
+			Detail:   fmt.Sprintf("The following recommendations are not applied: %s", strings.Join(unselected, ", ")),
+		})
+	}
+	return diags
+}
+
+func resourceTuningRecommendationExceptionsUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	meta := meta.Must(m)
+	logger := meta.Log("APPSEC", "resourceTuningRecommendationExceptionsUpdate")
+	logger.Debugf("in resourceTuningRecommendationExceptionsUpdate")
+
+	iDParts, err := splitID(d.Id(), 3, "configID:securityPolicyID:rulesetType")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	configID, err := strconv.Atoi(iDParts[0])
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	policyID, rulesetType := iDParts[1], iDParts[2]
+
+	oldApplied, _ := d.GetChange("applied_recommendation")
+	applied, err := applyTuningRecommendations(ctx, d, m, configID, policyID, rulesetType, recommendationsFromState(oldApplied))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("applied_recommendation", recommendationsToState(applied)); err != nil {
+		return diag.Errorf("%s: %s", tf.ErrValueSet, err.Error())
+	}
+
+	return resourceTuningRecommendationExceptionsRead(ctx, d, m)
+}
+
+func resourceTuningRecommendationExceptionsDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	meta := meta.Must(m)
+	client := inst.Client(meta)
+	logger := meta.Log("APPSEC", "resourceTuningRecommendationExceptionsDelete")
+	logger.Debugf("in resourceTuningRecommendationExceptionsDelete")
+
+	iDParts, err := splitID(d.Id(), 3, "configID:securityPolicyID:rulesetType")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	configID, err := strconv.Atoi(iDParts[0])
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	policyID, rulesetType := iDParts[1], iDParts[2]
+
+	applied := recommendationsFromState(d.Get("applied_recommendation"))
+	if len(applied) == 0 {
+		return nil
+	}
+	version, err := getModifiableConfigVersion(ctx, configID, "tuningRecommendationExceptions", m)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	applier := recommendedExceptionApplier{
+		client:     client,
+		configID:   configID,
+		version:    version,
+		policyID:   policyID,
+		evaluation: rulesetType == string(appsec.RulesetTypeEvaluation),
+	}
+	for _, recommendation := range applied {
+		if err := applier.remove(ctx, recommendation); err != nil {
+			logger.Errorf("removing recommendation '%s': %s", recommendation.ID, err.Error())
+			return diag.FromErr(err)
+		}
+	}
+	return nil
+}
+
+// applyTuningRecommendations applies the recommendations selected by `recommendation_ids` or `filter` and removes
+// previously applied recommendations which are no longer selected. It returns the recommendations applied after
+// the operation.
+func applyTuningRecommendations(ctx context.Context, d *schema.ResourceData, m interface{}, configID int, policyID, rulesetType string, previouslyApplied []tuningRecommendation) ([]tuningRecommendation, error) {
+	meta := meta.Must(m)
+	client := inst.Client(meta)
+
+	latestVersion, err := getLatestConfigVersion(ctx, configID, m)
+	if err != nil {
+		return nil, err
+	}
+	recommendations, err := getTuningRecommendations(ctx, client, configID, latestVersion, policyID, rulesetType)
+	if err != nil {
+		return nil, err
+	}
+
+	isApplied := func(id string) bool {
+		return slices.ContainsFunc(previouslyApplied, func(r tuningRecommendation) bool { return r.ID == id })
+	}
+	var toApply, toRemove, kept []tuningRecommendation
+	if filter, ok := tuningRecommendationFilterFromSchema(d.Get("filter")); ok {
+		for _, recommendation := range recommendations {
+			if filter.matches(recommendation) && !isApplied(recommendation.ID) {
+				toApply = append(toApply, recommendation)
+			}
+		}
+		for _, recommendation := range previouslyApplied {
+			if filter.matches(recommendation) {
+				kept = append(kept, recommendation)
+			} else {
+				toRemove = append(toRemove, recommendation)
+			}
+		}
+	} else {
+		selected := tf.SetToStringSlice(d.Get("recommendation_ids").(*schema.Set))
+		for _, id := range selected {
+			if isApplied(id) {
+				continue
+			}
+			i := slices.IndexFunc(recommendations, func(r tuningRecommendation) bool { return r.ID == id })
+			if i < 0 {
+				return nil, fmt.Errorf("tuning recommendation '%s' is not available for security policy %s", id, policyID)
+			}
+			toApply = append(toApply, recommendations[i])
+		}
+		for _, recommendation := range previouslyApplied {
+			if slices.Contains(selected, recommendation.ID) {
+				kept = append(kept, recommendation)
+			} else {
+				toRemove = append(toRemove, recommendation)
+			}
+		}
+	}
+
+	if len(toApply) == 0 && len(toRemove) == 0 {
+		return kept, nil
+	}
+	version, err := getModifiableConfigVersion(ctx, configID, "tuningRecommendationExceptions", m)
+	if err != nil {
+		return nil, err
+	}
+	applier := recommendedExceptionApplier{
+		client:     client,
+		configID:   configID,
+		version:    version,
+		policyID:   policyID,
+		evaluation: rulesetType == string(appsec.RulesetTypeEvaluation),
+	}
+	for _, recommendation := range toRemove {
+		if err := applier.remove(ctx, recommendation); err != nil {
+			return nil, err
+		}
+	}
+	applied := kept
+	for _, recommendation := range toApply {
+		if err := applier.apply(ctx, recommendation); err != nil {
+			return nil, err
+		}
+		applied = append(applied, recommendation)
+	}
+	sort.Slice(applied, func(i, j int) bool { return applied[i].ID < applied[j].ID })
+	return applied, nil
+}
+
+// getTuningRecommendations returns the attack group and rule recommendations for the security policy
+func getTuningRecommendations(ctx context.Context, client appsec.APPSEC, configID, version int, policyID, rulesetType string) ([]tuningRecommendation, error) {
+	response, err := client.GetTuningRecommendations(ctx, appsec.GetTuningRecommendationsRequest{
+		ConfigID:    configID,
+		Version:     version,
+		PolicyID:    policyID,
+		RulesetType: appsec.RulesetType(rulesetType),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	recommendations := make([]tuningRecommendation, 0, len(response.AttackGroupRecommendations)+len(response.RuleRecommendations))
+	for _, r := range response.AttackGroupRecommendations {
+		id, err := tuningRecommendationID("attack_group:"+r.Group, r.Exception)
+		if err != nil {
+			return nil, err
+		}
+		recommendations = append(recommendations, tuningRecommendation{ID: id, AttackGroup: r.Group, Description: r.Description, EvidenceCount: evidenceCount(r.Evidence), Exception: r.Exception})
+	}
+	for _, r := range response.RuleRecommendations {
+		id, err := tuningRecommendationID("rule:"+strconv.Itoa(r.RuleId), r.Exception)
+		if err != nil {
+			return nil, err
+		}
+		recommendations = append(recommendations, tuningRecommendation{ID: id, RuleID: r.RuleId, Description: r.Description, EvidenceCount: evidenceCount(r.Evidence), Exception: r.Exception})
+	}
+	sort.Slice(recommendations, func(i, j int) bool { return recommendations[i].ID < recommendations[j].ID })
+	return recommendations, nil
+}
+
+// evidenceCount returns the number of host, path and user data evidences, which indicates the confidence of
+// a recommendation
+func evidenceCount(evidences *appsec.Evidences) int {
+	if evidences == nil {
+		return 0
+	}
+	var count int
+	for _, evidence := range *evidences {
+		count += len(evidence.HostEvidences) + len(evidence.PathEvidences) + len(evidence.UserDataEvidences)
+	}
+	return count
+}
+
+// tuningRecommendationID identifies a recommendation by its target and the recommended exception, as the API
+// does not assign identifiers to recommendations
+func tuningRecommendationID(target string, exception *appsec.AttackGroupException) (string, error) {
+	b, err := json.Marshal(exception)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(b)
+	return fmt.Sprintf("%s:%s", target, hex.EncodeToString(sum[:4])), nil
+}
+
+func tuningRecommendationFilterFromSchema(raw interface{}) (tuningRecommendationFilter, bool) {
+	filters, ok := raw.([]interface{})
+	if !ok || len(filters) == 0 {
+		return tuningRecommendationFilter{}, false
+	}
+	filter := tuningRecommendationFilter{}
+	if filters[0] == nil {
+		return filter, true
+	}
+	values := filters[0].(map[string]interface{})
+	if groups, ok := values["attack_groups"].(*schema.Set); ok {
+		filter.attackGroups = tf.SetToStringSlice(groups)
+	}
+	if ruleIDs, ok := values["rule_ids"].(*schema.Set); ok {
+		for _, id := range ruleIDs.List() {
+			filter.ruleIDs = append(filter.ruleIDs, id.(int))
+		}
+	}
+	if minEvidenceCount, ok := values["min_evidence_count"].(int); ok {
+		filter.minEvidenceCount = minEvidenceCount
+	}
+	return filter, true
+}
+
+// matches reports whether the recommendation is selected by the filter. A filter without any attack group
+// or rule matches all recommendations based on enough evidences.
+func (f tuningRecommendationFilter) matches(recommendation tuningRecommendation) bool {
+	if recommendation.EvidenceCount < f.minEvidenceCount {
+		return false
+	}
+	if len(f.attackGroups) == 0 && len(f.ruleIDs) == 0 {
+		return true
+	}
+	if recommendation.AttackGroup != "" {
+		return slices.Contains(f.attackGroups, recommendation.AttackGroup)
+	}
+	return slices.Contains(f.ruleIDs, recommendation.RuleID)
+}
+
+func recommendationsToState(recommendations []tuningRecommendation) []interface{} {
+	result := make([]interface{}, 0, len(recommendations))
+	for _, recommendation := range recommendations {
+		exception, _ := json.Marshal(recommendation.Exception)
+		result = append(result, map[string]interface{}{
+			"id":             recommendation.ID,
+			"attack_group":   recommendation.AttackGroup,
+			"rule_id":        recommendation.RuleID,
+			"description":    recommendation.Description,
+			"evidence_count": recommendation.EvidenceCount,
+			"exception":      string(exception),
+		})
+	}
+	return result
+}
+
+func recommendationsFromState(raw interface{}) []tuningRecommendation {
+	items, _ := raw.([]interface{})
+	result := make([]tuningRecommendation, 0, len(items))
+	for _, item := range items {
+		values, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		recommendation := tuningRecommendation{
+			ID:          values["id"].(string),
+			AttackGroup: values["attack_group"].(string),
+			RuleID:      values["rule_id"].(int),
+			Description: values["description"].(string),
+		}
+		if count, ok := values["evidence_count"].(int); ok {
+			recommendation.EvidenceCount = count
+		}
+		if exception, ok := values["exception"].(string); ok && exception != "" && exception != "null" {
+			recommendation.Exception = &appsec.AttackGroupException{}
+			if err := json.Unmarshal([]byte(exception), recommendation.Exception); err != nil {
+				recommendation.Exception = nil
+			}
+		}
+		result = append(result, recommendation)
+	}
+	return result
+}
+
+func (a recommendedExceptionApplier) apply(ctx context.Context, recommendation tuningRecommendation) error {
+	return a.update(ctx, recommendation, false)
+}
+
+func (a recommendedExceptionApplier) remove(ctx context.Context, recommendation tuningRecommendation) error {
+	return a.update(ctx, recommendation, true)
+}
+
+// isApplied reports whether the names of the recommended exception are present in the condition and exception
+// information of the attack group or rule
+func (a recommendedExceptionApplier) isApplied(ctx context.Context, recommendation tuningRecommendation) (bool, error) {
+	_, conditionException, err := a.get(ctx, recommendation)
+	if err != nil {
+		return false, err
+	}
+	merged, err := mergeRecommendedException(conditionException, recommendation.Exception, false)
+	if err != nil {
+		return false, err
+	}
+	current, err := mergeRecommendedException(conditionException, nil, false)
+	if err != nil {
+		return false, err
+	}
+	return string(merged) == string(current), nil
+}
+
+// update merges the recommended exception into the condition and exception information of the attack group or
+// rule, or removes it from there, keeping the action unchanged
+func (a recommendedExceptionApplier) update(ctx context.Context, recommendation tuningRecommendation, remove bool) error {
+	action, conditionException, err := a.get(ctx, recommendation)
+	if err != nil {
+		return err
+	}
+	merged, err := mergeRecommendedException(conditionException, recommendation.Exception, remove)
+	if err != nil {
+		return err
+	}
+	if err := validateActionAndConditionException(action, string(merged)); err != nil {
+		return fmt.Errorf("recommendation '%s' cannot be applied: %w", recommendation.ID, err)
+	}
+
+	switch {
+	case recommendation.AttackGroup != "" && a.evaluation:
+		_, err = a.client.UpdateEvalGroup(ctx, appsec.UpdateAttackGroupRequest{ConfigID: a.configID, Version: a.version, PolicyID: a.policyID, Group: recommendation.AttackGroup, Action: action, JsonPayloadRaw: merged})
+	case recommendation.AttackGroup != "":
+		_, err = a.client.UpdateAttackGroup(ctx, appsec.UpdateAttackGroupRequest{ConfigID: a.configID, Version: a.version, PolicyID: a.policyID, Group: recommendation.AttackGroup, Action: action, JsonPayloadRaw: merged})
+	case a.evaluation:
+		_, err = a.client.UpdateEvalRule(ctx, appsec.UpdateEvalRuleRequest{ConfigID: a.configID, Version: a.version, PolicyID: a.policyID, RuleID: recommendation.RuleID, Action: action, JsonPayloadRaw: merged})
+	default:
+		_, err = a.client.UpdateRule(ctx, appsec.UpdateRuleRequest{ConfigID: a.configID, Version: a.version, PolicyID: a.policyID, RuleID: recommendation.RuleID, Action: action, JsonPayloadRaw: merged})
+	}
+	return err
+}
+
+// get returns the action and the JSON-formatted condition and exception information of the attack group or rule
+func (a recommendedExceptionApplier) get(ctx context.Context, recommendation tuningRecommendation) (string, []byte, error) {
+	var action string
+	var conditionException interface{}
+	switch {
+	case recommendation.AttackGroup != "" && a.evaluation:
+		group, err := a.client.GetEvalGroup(ctx, appsec.GetAttackGroupRequest{ConfigID: a.configID, Version: a.version, PolicyID: a.policyID, Group: recommendation.AttackGroup})
+		if err != nil {
+			return "", nil, err
+		}
+		action, conditionException = group.Action, group.ConditionException
+	case recommendation.AttackGroup != "":
+		group, err := a.client.GetAttackGroup(ctx, appsec.GetAttackGroupRequest{ConfigID: a.configID, Version: a.version, PolicyID: a.policyID, Group: recommendation.AttackGroup})
+		if err != nil {
+			return "", nil, err
+		}
+		action, conditionException = group.Action, group.ConditionException
+	case a.evaluation:
+		rule, err := a.client.GetEvalRule(ctx, appsec.GetEvalRuleRequest{ConfigID: a.configID, Version: a.version, PolicyID: a.policyID, RuleID: recommendation.RuleID})
+		if err != nil {
+			return "", nil, err
+		}
+		action, conditionException = rule.Action, rule.ConditionException
+	default:
+		rule, err := a.client.GetRule(ctx, appsec.GetRuleRequest{ConfigID: a.configID, Version: a.version, PolicyID: a.policyID, RuleID: recommendation.RuleID})
+		if err != nil {
+			return "", nil, err
+		}
+		action, conditionException = rule.Action, rule.ConditionException
+	}
+
+	b, err := json.Marshal(conditionException)
+	if err != nil {
+		return "", nil, err
+	}
+	return action, b, nil
+}
+
+// mergeRecommendedException adds the names of the recommended exception to the matching selectors of the
+// condition and exception information, or removes them from there. Other condition and exception information
+// is left untouched. An empty result is returned if no condition or exception information remains.
+func mergeRecommendedException(conditionException []byte, recommended *appsec.AttackGroupException, remove bool) (json.RawMessage, error) {
+	current := map[string]interface{}{}
+	if len(conditionException) > 0 && string(conditionException) != "null" {
+		if err := json.Unmarshal(conditionException, &current); err != nil {
+			return nil, err
+		}
+	}
+	exception, _ := current["exception"].(map[string]interface{})
+	if exception == nil {
+		exception = map[string]interface{}{}
+	}
+	entries, _ := exception[recommendationExceptionNamesKey].([]interface{})
+
+	if recommended != nil && recommended.SpecificHeaderCookieParamXMLOrJSONNames != nil {
+		for _, r := range *recommended.SpecificHeaderCookieParamXMLOrJSONNames {
+			i := slices.IndexFunc(entries, func(e interface{}) bool {
+				entry, ok := e.(map[string]interface{})
+				if !ok {
+					return false
+				}
+				wildcard, _ := entry["wildcard"].(bool)
+				return entry["selector"] == r.Selector && wildcard == r.Wildcard
+			})
+			if i < 0 {
+				if !remove {
+					entry := map[string]interface{}{"names": stringsToInterfaces(r.Names), "selector": r.Selector}
+					if r.Wildcard {
+						entry["wildcard"] = true
+					}
+					entries = append(entries, entry)
+				}
+				continue
+			}
+
+			entry := entries[i].(map[string]interface{})
+			names, _ := entry["names"].([]interface{})
+			for _, name := range r.Names {
+				j := slices.Index(names, interface{}(name))
+				switch {
+				case remove && j >= 0:
+					names = slices.Delete(names, j, j+1)
+				case !remove && j < 0:
+					names = append(names, name)
+				}
+			}
+			if len(names) == 0 {
+				entries = slices.Delete(entries, i, i+1)
+				continue
+			}
+			entry["names"] = names
+		}
+	}
+
+	if len(entries) > 0 {
+		exception[recommendationExceptionNamesKey] = entries
+	} else {
+		delete(exception, recommendationExceptionNamesKey)
+	}
+	if len(exception) > 0 {
+		current["exception"] = exception
+	} else {
+		delete(current, "exception")
+	}
+	if len(current) == 0 {
+		return nil, nil
+	}
+	return json.Marshal(current)
+}
+
+func stringsToInterfaces(values []string) []interface{} {
+	result := make([]interface{}, 0, len(values))
+	for _, value := range values {
+		result = append(result, value)
+	}
+	return result
+}
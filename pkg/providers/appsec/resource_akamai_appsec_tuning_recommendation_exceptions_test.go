@@ -0,0 +1,346 @@
+package appsec
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v9/pkg/appsec"
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v9/pkg/session"
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/common/testutils"
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/common/tf"
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/meta"
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAkamaiTuningRecommendationExceptions_res_basic(t *testing.T) {
+	t.Run("apply recommendations matching filter", func(t *testing.T) {
+		client := &appsec.Mock{}
+
+		config := appsec.GetConfigurationResponse{}
+		err := json.Unmarshal(testutils.LoadFixtureBytes(t, "testdata/TestResConfiguration/LatestConfiguration.json"), &config)
+		require.NoError(t, err)
+
+		recommendations := appsec.GetTuningRecommendationsResponse{}
+		err = json.Unmarshal(testutils.LoadFixtureBytes(t, "testdata/TestDSTuningRecommendations/Recommendations.json"), &recommendations)
+		require.NoError(t, err)
+
+		attackGroup := appsec.GetAttackGroupResponse{}
+		err = json.Unmarshal(testutils.LoadFixtureBytes(t, "testdata/TestResTuningRecommendationExceptions/AttackGroup.json"), &attackGroup)
+		require.NoError(t, err)
+
+		originalConditionException, err := json.Marshal(attackGroup.ConditionException)
+		require.NoError(t, err)
+		appliedConditionException := testutils.LoadFixtureBytes(t, "testdata/TestResTuningRecommendationExceptions/ConditionExceptionApplied.json")
+
+		client.On("GetConfiguration",
+			mock.Anything,
+			appsec.GetConfigurationRequest{ConfigID: 43253},
+		).Return(&config, nil)
+
+		client.On("GetTuningRecommendations",
+			mock.Anything,
+			appsec.GetTuningRecommendationsRequest{ConfigID: 43253, Version: 7, PolicyID: "AAAA_81230", RulesetType: appsec.RulesetTypeActive},
+		).Return(&recommendations, nil)
+
+		appliedAttackGroup := appsec.GetAttackGroupResponse{Action: attackGroup.Action, ConditionException: &appsec.AttackGroupConditionException{}}
+		err = json.Unmarshal(appliedConditionException, appliedAttackGroup.ConditionException)
+		require.NoError(t, err)
+
+		client.On("GetAttackGroup",
+			mock.Anything,
+			appsec.GetAttackGroupRequest{ConfigID: 43253, Version: 7, PolicyID: "AAAA_81230", Group: "XSS"},
+		).Return(&attackGroup, nil).Once()
+
+		client.On("GetAttackGroup",
+			mock.Anything,
+			appsec.GetAttackGroupRequest{ConfigID: 43253, Version: 7, PolicyID: "AAAA_81230", Group: "XSS"},
+		).Return(&appliedAttackGroup, nil)
+
+		client.On("UpdateAttackGroup",
+			mock.Anything,
+			mock.MatchedBy(func(req appsec.UpdateAttackGroupRequest) bool {
+				return req.Group == "XSS" && req.Action == "deny" && jsonEqual(req.JsonPayloadRaw, appliedConditionException)
+			}),
+		).Return(&appsec.UpdateAttackGroupResponse{}, nil).Once()
+
+		client.On("UpdateAttackGroup",
+			mock.Anything,
+			mock.MatchedBy(func(req appsec.UpdateAttackGroupRequest) bool {
+				return req.Group == "XSS" && req.Action == "deny" && jsonEqual(req.JsonPayloadRaw, originalConditionException)
+			}),
+		).Return(&appsec.UpdateAttackGroupResponse{}, nil).Once()
+
+		useClient(client, func() {
+			resource.Test(t, resource.TestCase{
+				IsUnitTest:               true,
+				ProtoV6ProviderFactories: testutils.NewProtoV6ProviderFactory(NewSubprovider()),
+				Steps: []resource.TestStep{
+					{
+						Config: testutils.LoadFixtureString(t, "testdata/TestResTuningRecommendationExceptions/match_by_filter.tf"),
+						Check: resource.ComposeAggregateTestCheckFunc(
+							resource.TestCheckResourceAttr("akamai_appsec_tuning_recommendation_exceptions.test", "id", "43253:AAAA_81230:active"),
+							resource.TestCheckResourceAttr("akamai_appsec_tuning_recommendation_exceptions.test", "applied_recommendation.#", "1"),
+							resource.TestCheckResourceAttr("akamai_appsec_tuning_recommendation_exceptions.test", "applied_recommendation.0.attack_group", "XSS"),
+							resource.TestCheckResourceAttr("akamai_appsec_tuning_recommendation_exceptions.test", "applied_recommendation.0.evidence_count", "4"),
+							resource.TestCheckResourceAttr("akamai_appsec_tuning_recommendation_exceptions.test", "pending_recommendation.#", "0"),
+							resource.TestCheckResourceAttr("akamai_appsec_tuning_recommendation_exceptions.test", "unselected_recommendation_ids.#", "0"),
+						),
+					},
+				},
+			})
+		})
+
+		client.AssertExpectations(t)
+	})
+}
+
+func TestMergeRecommendedException(t *testing.T) {
+	recommended := &appsec.AttackGroupException{}
+	require.NoError(t, json.Unmarshal([]byte(`{"specificHeaderCookieParamXmlOrJsonNames":[{"names":["UTAF-TEST-HEADER"],"selector":"REQUEST_HEADERS","wildcard":true}]}`), recommended))
+
+	tests := map[string]struct {
+		current  string
+		remove   bool
+		expected string
+	}{
+		"apply to empty condition exception": {
+			current:  `null`,
+			expected: `{"exception":{"specificHeaderCookieParamXmlOrJsonNames":[{"names":["UTAF-TEST-HEADER"],"selector":"REQUEST_HEADERS","wildcard":true}]}}`,
+		},
+		"apply to existing selector": {
+			current:  `{"conditions":[{"type":"pathMatch"}],"exception":{"specificHeaderCookieParamXmlOrJsonNames":[{"names":["A"],"selector":"REQUEST_HEADERS","wildcard":true}]}}`,
+			expected: `{"conditions":[{"type":"pathMatch"}],"exception":{"specificHeaderCookieParamXmlOrJsonNames":[{"names":["A","UTAF-TEST-HEADER"],"selector":"REQUEST_HEADERS","wildcard":true}]}}`,
+		},
+		"apply to selector without wildcard": {
+			current:  `{"exception":{"specificHeaderCookieParamXmlOrJsonNames":[{"names":["A"],"selector":"REQUEST_HEADERS"}]}}`,
+			expected: `{"exception":{"specificHeaderCookieParamXmlOrJsonNames":[{"names":["A"],"selector":"REQUEST_HEADERS"},{"names":["UTAF-TEST-HEADER"],"selector":"REQUEST_HEADERS","wildcard":true}]}}`,
+		},
+		"apply twice": {
+			current:  `{"exception":{"specificHeaderCookieParamXmlOrJsonNames":[{"names":["UTAF-TEST-HEADER"],"selector":"REQUEST_HEADERS","wildcard":true}]}}`,
+			expected: `{"exception":{"specificHeaderCookieParamXmlOrJsonNames":[{"names":["UTAF-TEST-HEADER"],"selector":"REQUEST_HEADERS","wildcard":true}]}}`,
+		},
+		"remove keeps other names": {
+			current:  `{"exception":{"headerCookieOrParamValues":["abc"],"specificHeaderCookieParamXmlOrJsonNames":[{"names":["A","UTAF-TEST-HEADER"],"selector":"REQUEST_HEADERS","wildcard":true}]}}`,
+			remove:   true,
+			expected: `{"exception":{"headerCookieOrParamValues":["abc"],"specificHeaderCookieParamXmlOrJsonNames":[{"names":["A"],"selector":"REQUEST_HEADERS","wildcard":true}]}}`,
+		},
+		"remove last name": {
+			current: `{"exception":{"specificHeaderCookieParamXmlOrJsonNames":[{"names":["UTAF-TEST-HEADER"],"selector":"REQUEST_HEADERS","wildcard":true}]}}`,
+			remove:  true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			result, err := mergeRecommendedException([]byte(test.current), recommended, test.remove)
+			require.NoError(t, err)
+			if test.expected == "" {
+				assert.Empty(t, result)
+				return
+			}
+			assert.JSONEq(t, test.expected, string(result))
+		})
+	}
+}
+
+func TestTuningRecommendationFilter(t *testing.T) {
+	group := tuningRecommendation{ID: "attack_group:XSS:1", AttackGroup: "XSS"}
+	rule := tuningRecommendation{ID: "rule:958008:1", RuleID: 958008}
+
+	assert.True(t, tuningRecommendationFilter{}.matches(group))
+	assert.True(t, tuningRecommendationFilter{}.matches(rule))
+	assert.True(t, tuningRecommendationFilter{attackGroups: []string{"XSS"}}.matches(group))
+	assert.False(t, tuningRecommendationFilter{attackGroups: []string{"XSS"}}.matches(rule))
+	assert.True(t, tuningRecommendationFilter{ruleIDs: []int{958008}}.matches(rule))
+	assert.False(t, tuningRecommendationFilter{ruleIDs: []int{958008}}.matches(group))
+
+	confident := tuningRecommendation{ID: "attack_group:XSS:2", AttackGroup: "XSS", EvidenceCount: 4}
+	assert.True(t, tuningRecommendationFilter{attackGroups: []string{"XSS"}, minEvidenceCount: 3}.matches(confident))
+	assert.False(t, tuningRecommendationFilter{attackGroups: []string{"XSS"}, minEvidenceCount: 5}.matches(confident))
+	assert.False(t, tuningRecommendationFilter{minEvidenceCount: 1}.matches(group))
+}
+
+func TestEvidenceCount(t *testing.T) {
+	recommendations := appsec.GetTuningRecommendationsResponse{}
+	require.NoError(t, json.Unmarshal(testutils.LoadFixtureBytes(t, "testdata/TestDSTuningRecommendations/Recommendations.json"), &recommendations))
+
+	assert.Equal(t, 4, evidenceCount(recommendations.AttackGroupRecommendations[0].Evidence))
+	assert.Equal(t, 0, evidenceCount(nil))
+}
+
+func TestTuningRecommendationID(t *testing.T) {
+	exception := &appsec.AttackGroupException{}
+	require.NoError(t, json.Unmarshal([]byte(`{"specificHeaderCookieParamXmlOrJsonNames":[{"names":["A"],"selector":"REQUEST_HEADERS"}]}`), exception))
+
+	id, err := tuningRecommendationID("attack_group:XSS", exception)
+	require.NoError(t, err)
+	assert.Regexp(t, `^attack_group:XSS:[0-9a-f]{8}$`, id)
+
+	again, err := tuningRecommendationID("attack_group:XSS", exception)
+	require.NoError(t, err)
+	assert.Equal(t, id, again)
+
+	other, err := tuningRecommendationID("attack_group:XSS", nil)
+	require.NoError(t, err)
+	assert.NotEqual(t, id, other)
+}
+
+func jsonEqual(a, b []byte) bool {
+	var x, y interface{}
+	if json.Unmarshal(a, &x) != nil || json.Unmarshal(b, &y) != nil {
+		return false
+	}
+	return assert.ObjectsAreEqual(x, y)
+}
+
+func TestApplyTuningRecommendations(t *testing.T) {
+	m, err := meta.New(session.Must(session.New()), hclog.NewNullLogger(), "")
+	require.NoError(t, err)
+
+	config := appsec.GetConfigurationResponse{}
+	err = json.Unmarshal(testutils.LoadFixtureBytes(t, "testdata/TestResConfiguration/LatestConfiguration.json"), &config)
+	require.NoError(t, err)
+	recommendations := appsec.GetTuningRecommendationsResponse{}
+	err = json.Unmarshal(testutils.LoadFixtureBytes(t, "testdata/TestDSTuningRecommendations/Recommendations.json"), &recommendations)
+	require.NoError(t, err)
+	xssID, err := tuningRecommendationID("attack_group:XSS", recommendations.AttackGroupRecommendations[0].Exception)
+	require.NoError(t, err)
+
+	newClient := func() *appsec.Mock {
+		client := &appsec.Mock{}
+		client.On("GetConfiguration", mock.Anything, appsec.GetConfigurationRequest{ConfigID: 43253}).Return(&config, nil)
+		client.On("GetTuningRecommendations", mock.Anything, mock.Anything).Return(&recommendations, nil)
+		return client
+	}
+	resourceData := func(ids ...interface{}) *schema.ResourceData {
+		return schema.TestResourceDataRaw(t, resourceTuningRecommendationExceptions().Schema, map[string]interface{}{
+			"config_id":          43253,
+			"security_policy_id": "AAAA_81230",
+			"recommendation_ids": ids,
+		})
+	}
+
+	t.Run("selected recommendation is applied to the attack group", func(t *testing.T) {
+		client := newClient()
+		client.On("GetAttackGroup", mock.Anything, appsec.GetAttackGroupRequest{ConfigID: 43253, Version: 7, PolicyID: "AAAA_81230", Group: "XSS"}).
+			Return(&appsec.GetAttackGroupResponse{Action: "alert"}, nil).Once()
+		client.On("UpdateAttackGroup", mock.Anything, mock.MatchedBy(func(req appsec.UpdateAttackGroupRequest) bool {
+			return req.Action == "alert" && jsonEqual(req.JsonPayloadRaw, []byte(`{"exception":{"specificHeaderCookieParamXmlOrJsonNames":[{"names":["UTAF-TEST-HEADER"],"selector":"REQUEST_HEADERS","wildcard":true}]}}`))
+		})).Return(&appsec.UpdateAttackGroupResponse{}, nil).Once()
+
+		useClient(client, func() {
+			applied, err := applyTuningRecommendations(context.Background(), resourceData(xssID), m, 43253, "AAAA_81230", "active", nil)
+			require.NoError(t, err)
+			require.Len(t, applied, 1)
+			assert.Equal(t, xssID, applied[0].ID)
+		})
+		client.AssertExpectations(t)
+	})
+
+	t.Run("deselected recommendation is removed from the attack group", func(t *testing.T) {
+		client := newClient()
+		client.On("GetAttackGroup", mock.Anything, mock.Anything).Return(&appsec.GetAttackGroupResponse{
+			Action: "alert",
+			ConditionException: &appsec.AttackGroupConditionException{
+				Exception: recommendations.AttackGroupRecommendations[0].Exception,
+			},
+		}, nil).Once()
+		client.On("UpdateAttackGroup", mock.Anything, mock.MatchedBy(func(req appsec.UpdateAttackGroupRequest) bool {
+			return req.Action == "alert" && len(req.JsonPayloadRaw) == 0
+		})).Return(&appsec.UpdateAttackGroupResponse{}, nil).Once()
+
+		previous := []tuningRecommendation{{ID: xssID, AttackGroup: "XSS", Exception: recommendations.AttackGroupRecommendations[0].Exception}}
+		useClient(client, func() {
+			applied, err := applyTuningRecommendations(context.Background(), resourceData(), m, 43253, "AAAA_81230", "active", previous)
+			require.NoError(t, err)
+			assert.Empty(t, applied)
+		})
+		client.AssertExpectations(t)
+	})
+
+	t.Run("unknown recommendation is rejected", func(t *testing.T) {
+		client := newClient()
+		useClient(client, func() {
+			_, err := applyTuningRecommendations(context.Background(), resourceData("attack_group:XSS:00000000"), m, 43253, "AAAA_81230", "active", nil)
+			assert.ErrorContains(t, err, "tuning recommendation 'attack_group:XSS:00000000' is not available")
+		})
+		client.AssertExpectations(t)
+	})
+}
+
+func TestReadTuningRecommendationExceptions(t *testing.T) {
+	m, err := meta.New(session.Must(session.New()), hclog.NewNullLogger(), "")
+	require.NoError(t, err)
+
+	config := appsec.GetConfigurationResponse{}
+	err = json.Unmarshal(testutils.LoadFixtureBytes(t, "testdata/TestResConfiguration/LatestConfiguration.json"), &config)
+	require.NoError(t, err)
+	recommendation := appsec.AttackGroupRecommendation{}
+	err = json.Unmarshal([]byte(`{"group":"SQL","exception":{"specificHeaderCookieParamXmlOrJsonNames":[{"names":["X-SQL-HEADER"],"selector":"REQUEST_HEADERS"}]}}`), &recommendation)
+	require.NoError(t, err)
+	recommendations := appsec.GetTuningRecommendationsResponse{AttackGroupRecommendations: []appsec.AttackGroupRecommendation{recommendation}}
+	sqlID, err := tuningRecommendationID("attack_group:SQL", recommendation.Exception)
+	require.NoError(t, err)
+
+	applied := appsec.AttackGroupRecommendation{}
+	err = json.Unmarshal([]byte(`{"group":"XSS","exception":{"specificHeaderCookieParamXmlOrJsonNames":[{"names":["UTAF-TEST-HEADER"],"selector":"REQUEST_HEADERS","wildcard":true}]}}`), &applied)
+	require.NoError(t, err)
+	xssID, err := tuningRecommendationID("attack_group:XSS", applied.Exception)
+	require.NoError(t, err)
+
+	resourceData := func() *schema.ResourceData {
+		d := schema.TestResourceDataRaw(t, resourceTuningRecommendationExceptions().Schema, map[string]interface{}{
+			"config_id":          43253,
+			"security_policy_id": "AAAA_81230",
+			"filter":             []interface{}{map[string]interface{}{"attack_groups": []interface{}{"XSS"}}},
+		})
+		d.SetId("43253:AAAA_81230:active")
+		require.NoError(t, d.Set("applied_recommendation", recommendationsToState([]tuningRecommendation{{ID: xssID, AttackGroup: "XSS", Exception: applied.Exception}})))
+		return d
+	}
+	newClient := func(xss *appsec.GetAttackGroupResponse) *appsec.Mock {
+		client := &appsec.Mock{}
+		client.On("GetConfiguration", mock.Anything, appsec.GetConfigurationRequest{ConfigID: 43253}).Return(&config, nil)
+		client.On("GetTuningRecommendations", mock.Anything, mock.Anything).Return(&recommendations, nil)
+		client.On("GetAttackGroup", mock.Anything, appsec.GetAttackGroupRequest{ConfigID: 43253, Version: 7, PolicyID: "AAAA_81230", Group: "XSS"}).
+			Return(xss, nil).Once()
+		return client
+	}
+
+	t.Run("unselected recommendations are reported", func(t *testing.T) {
+		client := newClient(&appsec.GetAttackGroupResponse{
+			Action:             "deny",
+			ConditionException: &appsec.AttackGroupConditionException{Exception: applied.Exception},
+		})
+		d := resourceData()
+		useClient(client, func() {
+			diags := resourceTuningRecommendationExceptionsRead(context.Background(), d, m)
+			require.Len(t, diags, 1)
+			assert.Equal(t, diag.Warning, diags[0].Severity)
+			assert.Contains(t, diags[0].Detail, sqlID)
+		})
+		assert.Equal(t, []string{sqlID}, tf.SetToStringSlice(d.Get("unselected_recommendation_ids").(*schema.Set)))
+		assert.Len(t, d.Get("applied_recommendation"), 1)
+		client.AssertExpectations(t)
+	})
+
+	t.Run("overwritten recommendation is no longer applied", func(t *testing.T) {
+		client := newClient(&appsec.GetAttackGroupResponse{Action: "deny"})
+		d := resourceData()
+		useClient(client, func() {
+			diags := resourceTuningRecommendationExceptionsRead(context.Background(), d, m)
+			require.Len(t, diags, 2)
+			assert.Contains(t, diags[0].Summary, xssID)
+			assert.Contains(t, diags[0].Detail, "akamai_appsec_attack_group")
+		})
+		assert.Empty(t, d.Get("applied_recommendation"))
+		client.AssertExpectations(t)
+	})
+}
@@ -77,6 +77,21 @@ var (
 			b, _ := json.Marshal(i)
 			return string(b), nil
 		},
+		"marshalwithout": func(v interface{}, keys ...string) (string, error) {
+			a, _ := json.Marshal(v)
+
+			var i interface{}
+			if err := json.Unmarshal([]byte(a), &i); err != nil {
+				return "", err
+			}
+			if m, ok := i.(map[string]interface{}); ok {
+				for _, key := range keys {
+					delete(m, key)
+				}
+			}
+			b, _ := json.Marshal(i)
+			return string(b), nil
+		},
 		"marshalconditionexception": func(v interface{}) (string, error) {
 			a, _ := json.Marshal(v)
 
@@ -285,6 +300,7 @@ func InitTemplates(otm map[string]*OutputTemplate) {
 	otm["apiRequestConstraintsDS"] = &OutputTemplate{TemplateName: "apiRequestConstraintsDS", TableTitle: "ID|Action", TemplateType: "TABULAR", TemplateString: "{{range $index, $element := .APIEndpoints}}{{if $index}},{{end}}{{.ID}}|{{.Action}}{{end}}"}
 	otm["configuration"] = &OutputTemplate{TemplateName: "Configurations", TableTitle: "Config_id|Name|Latest_version|Version_active_in_staging|Version_active_in_production", TemplateType: "TABULAR", TemplateString: "{{range $index, $element := .Configurations}}{{if $index}},{{end}}{{.ID}}|{{.Name}}|{{.LatestVersion}}|{{.StagingVersion}}|{{.ProductionVersion}}{{end}}"}
 	otm["configurationVersion"] = &OutputTemplate{TemplateName: "ConfigurationVersion", TableTitle: "Version Number|Staging Status|Production Status", TemplateType: "TABULAR", TemplateString: "{{range $index, $element := .VersionList}}{{if $index}},{{end}}{{.Version}}|{{.Staging.Status}}|{{.Production.Status}}{{end}}"}
+	otm["configurationVersionDiffDS"] = &OutputTemplate{TemplateName: "ConfigurationVersionDiff", TableTitle: "Security Policy|Area|Key|Change", TemplateType: "TABULAR", TemplateString: "{{range $index, $element := .}}{{if $index}},{{end}}{{.SecurityPolicyID}}|{{.Area}}|{{.Key}}|{{.ChangeType}}{{end}}"}
 	otm["contractsgroupsDS"] = &OutputTemplate{TemplateName: "contractsgroupsDS", TableTitle: "ContractID|GroupID|Name", TemplateType: "TABULAR", TemplateString: "{{range $index, $element := .ContractGroups}}{{if $index}},{{end}}{{.ContractID}}|{{.GroupID}}|{{.DisplayName}}{{end}}"}
 	otm["failoverHostnamesDS"] = &OutputTemplate{TemplateName: "failoverHostnamesDS", TableTitle: "Hostname", TemplateType: "TABULAR", TemplateString: "{{range $index, $element := .HostnameList}}{{if $index}},{{end}}{{.Hostname}}{{end}}"}
 	otm["bypassNetworkListsDS"] = &OutputTemplate{TemplateName: "bypassNetworkListsDS", TableTitle: "Network List|ID", TemplateType: "TABULAR", TemplateString: "{{range $index, $element := .NetworkLists}}{{if $index}},{{end}}{{.Name}}|{{.ID}}{{end}}"}
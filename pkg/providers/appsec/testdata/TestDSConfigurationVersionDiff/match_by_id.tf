@@ -0,0 +1,10 @@
+provider "akamai" {
+  edgerc        = "../../common/testutils/edgerc"
+  cache_enabled = false
+}
+
+data "akamai_appsec_configuration_version_diff" "test" {
+  config_id    = 43253
+  from_version = 7
+  to_version   = 8
+}
@@ -0,0 +1,17 @@
+provider "akamai" {
+  edgerc        = "../../common/testutils/edgerc"
+  cache_enabled = false
+}
+
+resource "akamai_appsec_activations" "test" {
+  config_id           = 43253
+  version             = 7
+  network             = "STAGING"
+  note                = "Test Notes"
+  notification_emails = ["user@example.com"]
+
+  pre_activation_checks {
+    block_pending_rule_upgrade = false
+    block_hostname_changes     = false
+  }
+}
@@ -0,0 +1,15 @@
+provider "akamai" {
+  edgerc        = "../../common/testutils/edgerc"
+  cache_enabled = false
+}
+
+resource "akamai_appsec_custom_rule" "test" {
+  config_id = 43253
+  rule {
+    name = "Rule Test New"
+    condition {
+      type  = "requestMethodMatch"
+      value = ["GET", "FETCH"]
+    }
+  }
+}
@@ -0,0 +1,32 @@
+provider "akamai" {
+  edgerc        = "../../common/testutils/edgerc"
+  cache_enabled = false
+}
+
+resource "akamai_appsec_security_policy_settings" "test" {
+  config_id          = 43253
+  security_policy_id = "AAAA_81230"
+  waf_mode           = "KRS"
+
+  protections {
+    apply_application_layer_controls = true
+    apply_network_layer_controls     = true
+    apply_rate_controls              = true
+    apply_slow_post_controls         = true
+  }
+
+  penalty_box {
+    penalty_box_protection = true
+    penalty_box_action     = "deny"
+  }
+
+  attack_group_action {
+    attack_group = "SQL"
+    action       = "deny"
+  }
+
+  rule_action {
+    rule_id = 699989
+    action  = "alert"
+  }
+}
@@ -0,0 +1,12 @@
+provider "akamai" {
+  edgerc        = "../../common/testutils/edgerc"
+  cache_enabled = false
+}
+
+resource "akamai_appsec_tuning_recommendation_exceptions" "test" {
+  config_id          = 43253
+  security_policy_id = "AAAA_81230"
+  filter {
+    attack_groups = ["XSS"]
+  }
+}
@@ -0,0 +1,278 @@
+package botman
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v9/pkg/appsec"
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v9/pkg/botman"
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/common/tf"
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/meta"
+	akamaiappsec "github.com/akamai/terraform-provider-akamai/v6/pkg/providers/appsec"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+type (
+	// botmanExport contains the botman settings of a security configuration version rendered by the export templates
+	botmanExport struct {
+		ConfigID                        int
+		BotAnalyticsCookie              map[string]interface{}
+		ClientSideSecurity              map[string]interface{}
+		TransactionalEndpointProtection map[string]interface{}
+		CustomCode                      map[string]interface{}
+		ChallengeInjectionRules         map[string]interface{}
+		ChallengeInterceptionRules      map[string]interface{}
+		CustomBotCategories             []map[string]interface{}
+		CustomBotCategorySequence       []string
+		CustomBotCategoryItemSequences  []botmanExportItemSequence
+		CustomDefinedBots               []map[string]interface{}
+		RecategorizedAkamaiDefinedBots  []botman.RecategorizedAkamaiDefinedBotResponse
+		CustomClients                   []map[string]interface{}
+		CustomClientSequence            []string
+		ChallengeActions                []map[string]interface{}
+		ConditionalActions              []map[string]interface{}
+		CustomDenyActions               []map[string]interface{}
+		ServeAlternateActions           []map[string]interface{}
+		SecurityPolicies                []botmanExportPolicy
+	}
+
+	// botmanExportItemSequence contains the order of the bots of a custom bot category
+	botmanExportItemSequence struct {
+		CategoryID string
+		BotIDs     []string
+	}
+
+	// botmanExportPolicy contains the botman settings of a security policy
+	botmanExportPolicy struct {
+		ID                                        string
+		BotManagementSettings                     map[string]interface{}
+		BotCategoryException                      map[string]interface{}
+		JavascriptInjection                       map[string]interface{}
+		AkamaiBotCategoryActions                  []map[string]interface{}
+		CustomBotCategoryActions                  []map[string]interface{}
+		BotDetectionActions                       []map[string]interface{}
+		TransactionalEndpoints                    []map[string]interface{}
+		ContentProtectionRules                    []map[string]interface{}
+		ContentProtectionRuleSequence             []string
+		ContentProtectionJavaScriptInjectionRules []map[string]interface{}
+	}
+)
+
+func dataSourceExportConfiguration() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceExportConfigurationRead,
+		Schema: map[string]*schema.Schema{
+			"config_id": {
+				Type:        schema.TypeInt,
+				Required:    true,
+				Description: "Unique identifier of the security configuration",
+			},
+			"version": {
+				Type:        schema.TypeInt,
+				Required:    true,
+				Description: "Version number of the security configuration to be exported",
+			},
+			"search": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type:             schema.TypeString,
+					ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice(exportTemplateNames, false)),
+				},
+				Description: "List of botman resource types to be exported. All botman resources are exported if not set",
+			},
+			"output_text": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Import blocks and resource configurations of the exported botman resources",
+			},
+		},
+	}
+}
+
+func dataSourceExportConfigurationRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	meta := meta.Must(m)
+	logger := meta.Log("botman", "dataSourceExportConfigurationRead")
+	logger.Debugf("in dataSourceExportConfigurationRead")
+
+	configID, err := tf.GetIntValue("config_id", d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	version, err := tf.GetIntValue("version", d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	exportConfiguration, err := getExportConfiguration(ctx, configID, version, m)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	export, err := newBotmanExport(ctx, inst.Client(meta), exportConfiguration, version)
+	if err != nil {
+		logger.Errorf("reading botman settings: %s", err.Error())
+		return diag.FromErr(err)
+	}
+
+	templateNames := exportTemplateNames
+	if search, err := tf.GetListValue("search", d); err == nil && len(search) > 0 {
+		templateNames = make([]string, 0, len(search))
+		for _, name := range search {
+			templateNames = append(templateNames, name.(string))
+		}
+	}
+
+	ots := akamaiappsec.OutputTemplates{}
+	initExportTemplates(ots)
+
+	var outputText string
+	for _, name := range templateNames {
+		text, err := akamaiappsec.RenderTemplates(ots, name, export)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		outputText += text
+	}
+
+	if err := d.Set("output_text", outputText); err != nil {
+		return diag.Errorf("%s: %s", tf.ErrValueSet, err.Error())
+	}
+
+	d.SetId(strconv.Itoa(configID))
+	return nil
+}
+
+// newBotmanExport collects the botman settings of a configuration version. Settings which are not part of the
+// configuration export are read from the botman API for the security policies with bot management.
+func newBotmanExport(ctx context.Context, client botman.BotMan, exportConfiguration *appsec.GetExportConfigurationResponse, version int) (*botmanExport, error) {
+	configID, configVersion := int64(exportConfiguration.ConfigID), int64(version)
+
+	export := &botmanExport{
+		ConfigID:                  exportConfiguration.ConfigID,
+		CustomBotCategories:       exportConfiguration.CustomBotCategories,
+		CustomBotCategorySequence: exportConfiguration.CustomBotCategorySequence,
+		CustomDefinedBots:         exportConfiguration.CustomDefinedBots,
+		CustomClients:             exportConfiguration.CustomClients,
+		CustomClientSequence:      exportConfiguration.CustomClientSequence,
+	}
+	if settings := exportConfiguration.AdvancedSettings; settings != nil {
+		export.BotAnalyticsCookie = settings.BotAnalyticsCookieSettings
+		export.ClientSideSecurity = settings.ClientSideSecuritySettings
+		export.TransactionalEndpointProtection = settings.TransactionalEndpointProtectionSettings
+	}
+	if actions := exportConfiguration.ResponseActions; actions != nil {
+		export.ChallengeActions = actions.ChallengeActions
+		export.ConditionalActions = actions.ConditionalActions
+		export.CustomDenyActions = actions.CustomDenyActions
+		export.ServeAlternateActions = actions.ServeAlternateActions
+		export.ChallengeInjectionRules = actions.ChallengeInjectionRules
+		export.ChallengeInterceptionRules = actions.ChallengeInterceptionRules
+	}
+
+	for _, securityPolicy := range exportConfiguration.SecurityPolicies {
+		botManagement := securityPolicy.BotManagement
+		if botManagement == nil {
+			continue
+		}
+		policy := botmanExportPolicy{
+			ID:                       securityPolicy.ID,
+			BotManagementSettings:    botManagement.BotManagementSettings,
+			JavascriptInjection:      botManagement.JavascriptInjectionRules,
+			AkamaiBotCategoryActions: botManagement.AkamaiBotCategoryActions,
+			CustomBotCategoryActions: botManagement.CustomBotCategoryActions,
+			BotDetectionActions:      botManagement.BotDetectionActions,
+		}
+		if botManagement.TransactionalEndpoints != nil {
+			policy.TransactionalEndpoints = botManagement.TransactionalEndpoints.BotProtection
+		}
+
+		exception, err := client.GetBotCategoryException(ctx, botman.GetBotCategoryExceptionRequest{
+			ConfigID:         configID,
+			Version:          configVersion,
+			SecurityPolicyID: policy.ID,
+		})
+		if err != nil {
+			return nil, err
+		}
+		if len(exception) > 0 {
+			policy.BotCategoryException = exception
+		}
+
+		rules, err := client.GetContentProtectionRuleList(ctx, botman.GetContentProtectionRuleListRequest{
+			ConfigID:         configID,
+			Version:          configVersion,
+			SecurityPolicyID: policy.ID,
+		})
+		if err != nil {
+			return nil, err
+		}
+		policy.ContentProtectionRules = rules.ContentProtectionRules
+
+		sequence, err := client.GetContentProtectionRuleSequence(ctx, botman.GetContentProtectionRuleSequenceRequest{
+			ConfigID:         configID,
+			Version:          configVersion,
+			SecurityPolicyID: policy.ID,
+		})
+		if err != nil {
+			return nil, err
+		}
+		policy.ContentProtectionRuleSequence = sequence.ContentProtectionRuleSequence
+
+		injectionRules, err := client.GetContentProtectionJavaScriptInjectionRuleList(ctx, botman.GetContentProtectionJavaScriptInjectionRuleListRequest{
+			ConfigID:         configID,
+			Version:          configVersion,
+			SecurityPolicyID: policy.ID,
+		})
+		if err != nil {
+			return nil, err
+		}
+		policy.ContentProtectionJavaScriptInjectionRules = injectionRules.ContentProtectionJavaScriptInjectionRules
+
+		export.SecurityPolicies = append(export.SecurityPolicies, policy)
+	}
+
+	// the remaining settings are only available for configurations with bot management
+	if len(export.SecurityPolicies) == 0 {
+		return export, nil
+	}
+
+	customCode, err := client.GetCustomCode(ctx, botman.GetCustomCodeRequest{ConfigID: configID, Version: configVersion})
+	if err != nil {
+		return nil, err
+	}
+	if len(customCode) > 0 {
+		export.CustomCode = customCode
+	}
+
+	recategorizedBots, err := client.GetRecategorizedAkamaiDefinedBotList(ctx, botman.GetRecategorizedAkamaiDefinedBotListRequest{ConfigID: configID, Version: configVersion})
+	if err != nil {
+		return nil, err
+	}
+	export.RecategorizedAkamaiDefinedBots = recategorizedBots.Bots
+
+	for _, category := range export.CustomBotCategories {
+		categoryID, ok := category["categoryId"].(string)
+		if !ok {
+			continue
+		}
+		itemSequence, err := client.GetCustomBotCategoryItemSequence(ctx, botman.GetCustomBotCategoryItemSequenceRequest{
+			ConfigID:   configID,
+			Version:    configVersion,
+			CategoryID: categoryID,
+		})
+		if err != nil {
+			return nil, err
+		}
+		if len(itemSequence.Sequence) > 0 {
+			export.CustomBotCategoryItemSequences = append(export.CustomBotCategoryItemSequences, botmanExportItemSequence{
+				CategoryID: categoryID,
+				BotIDs:     itemSequence.Sequence,
+			})
+		}
+	}
+
+	return export, nil
+}
@@ -0,0 +1,192 @@
+package botman
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v9/pkg/appsec"
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v9/pkg/botman"
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/common/testutils"
+	akamaiappsec "github.com/akamai/terraform-provider-akamai/v6/pkg/providers/appsec"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDataExportConfiguration(t *testing.T) {
+	t.Run("DataExportConfiguration", func(t *testing.T) {
+		mockedBotmanClient := &botman.Mock{}
+		mockBotmanExportCalls(mockedBotmanClient)
+
+		useExportConfiguration(t, func() {
+			useClient(mockedBotmanClient, func() {
+				resource.Test(t, resource.TestCase{
+					IsUnitTest:               true,
+					ProtoV6ProviderFactories: testutils.NewProtoV6ProviderFactory(NewSubprovider()),
+					Steps: []resource.TestStep{
+						{
+							Config: testutils.LoadFixtureString(t, "testdata/TestDataExportConfiguration/basic.tf"),
+							Check: resource.ComposeAggregateTestCheckFunc(
+								resource.TestCheckResourceAttr("data.akamai_botman_export_configuration.test", "id", "43253"),
+								resource.TestCheckResourceAttr("data.akamai_botman_export_configuration.test", "output_text",
+									testutils.LoadFixtureString(t, "testdata/TestDataExportConfiguration/expected_output.txt")),
+							),
+						},
+					},
+				})
+			})
+		})
+
+		mockedBotmanClient.AssertExpectations(t)
+	})
+
+	t.Run("DataExportConfiguration search", func(t *testing.T) {
+		mockedBotmanClient := &botman.Mock{}
+		mockBotmanExportCalls(mockedBotmanClient)
+
+		useExportConfiguration(t, func() {
+			useClient(mockedBotmanClient, func() {
+				resource.Test(t, resource.TestCase{
+					IsUnitTest:               true,
+					ProtoV6ProviderFactories: testutils.NewProtoV6ProviderFactory(NewSubprovider()),
+					Steps: []resource.TestStep{
+						{
+							Config: testutils.LoadFixtureString(t, "testdata/TestDataExportConfiguration/search.tf"),
+							Check: resource.ComposeAggregateTestCheckFunc(
+								resource.TestMatchResourceAttr("data.akamai_botman_export_configuration.test", "output_text",
+									regexp.MustCompile(`resource "akamai_botman_custom_bot_category_action" "custom_bot_category_action_AAAA_81230_fakeb6d7-37dd-4a2e-9e9f-43545caf6a45"`)),
+								resource.TestMatchResourceAttr("data.akamai_botman_export_configuration.test", "output_text",
+									regexp.MustCompile(`resource "akamai_botman_custom_bot_category" "custom_bot_category_fakeb6d7-37dd-4a2e-9e9f-43545caf6a45"`)),
+								resource.TestCheckResourceAttrWith("data.akamai_botman_export_configuration.test", "output_text", func(value string) error {
+									if strings.Contains(value, "akamai_botman_bot_analytics_cookie") {
+										return fmt.Errorf("unexpected akamai_botman_bot_analytics_cookie in output_text")
+									}
+									return nil
+								}),
+							),
+						},
+					},
+				})
+			})
+		})
+
+		mockedBotmanClient.AssertExpectations(t)
+	})
+
+	t.Run("DataExportConfiguration invalid search", func(t *testing.T) {
+		mockedBotmanClient := &botman.Mock{}
+		useClient(mockedBotmanClient, func() {
+			resource.Test(t, resource.TestCase{
+				IsUnitTest:               true,
+				ProtoV6ProviderFactories: testutils.NewProtoV6ProviderFactory(NewSubprovider()),
+				Steps: []resource.TestStep{
+					{
+						Config:      testutils.LoadFixtureString(t, "testdata/TestDataExportConfiguration/invalid_search.tf"),
+						ExpectError: regexp.MustCompile(`expected search.0 to be one of`),
+					},
+				},
+			})
+		})
+		mockedBotmanClient.AssertExpectations(t)
+	})
+}
+
+func TestRenderBotmanExport(t *testing.T) {
+	mockedBotmanClient := &botman.Mock{}
+	mockBotmanExportCalls(mockedBotmanClient)
+
+	export, err := newBotmanExport(context.Background(), mockedBotmanClient, loadExportConfiguration(t), 7)
+	require.NoError(t, err)
+
+	ots := akamaiappsec.OutputTemplates{}
+	initExportTemplates(ots)
+	var output string
+	for _, name := range exportTemplateNames {
+		text, err := akamaiappsec.RenderTemplates(ots, name, export)
+		require.NoError(t, err)
+		output += text
+	}
+
+	assert.Equal(t, testutils.LoadFixtureString(t, "testdata/TestDataExportConfiguration/expected_output.txt"), output)
+	mockedBotmanClient.AssertExpectations(t)
+}
+
+func TestNewBotmanExportWithoutBotManagement(t *testing.T) {
+	mockedBotmanClient := &botman.Mock{}
+	exportConfiguration := &appsec.GetExportConfigurationResponse{ConfigID: 43253}
+
+	export, err := newBotmanExport(context.Background(), mockedBotmanClient, exportConfiguration, 7)
+	require.NoError(t, err)
+	assert.Empty(t, export.SecurityPolicies)
+	mockedBotmanClient.AssertExpectations(t)
+}
+
+func loadExportConfiguration(t *testing.T) *appsec.GetExportConfigurationResponse {
+	exportConfiguration := appsec.GetExportConfigurationResponse{}
+	err := json.Unmarshal(testutils.LoadFixtureBytes(t, "testdata/TestDataExportConfiguration/ExportConfiguration.json"), &exportConfiguration)
+	require.NoError(t, err)
+	return &exportConfiguration
+}
+
+// useExportConfiguration replaces the configuration export with the fixture for the duration of the given func
+func useExportConfiguration(t *testing.T, f func()) {
+	exportConfiguration := loadExportConfiguration(t)
+	orig := getExportConfiguration
+	getExportConfiguration = func(_ context.Context, configID, version int, _ interface{}) (*appsec.GetExportConfigurationResponse, error) {
+		assert.Equal(t, 43253, configID)
+		assert.Equal(t, 7, version)
+		return exportConfiguration, nil
+	}
+	defer func() {
+		getExportConfiguration = orig
+	}()
+	f()
+}
+
+func mockBotmanExportCalls(client *botman.Mock) {
+	client.On("GetBotCategoryException",
+		mock.Anything,
+		botman.GetBotCategoryExceptionRequest{ConfigID: 43253, Version: 7, SecurityPolicyID: "AAAA_81230"},
+	).Return(map[string]interface{}{"akamaiBotCategoryIds": []interface{}{"da005ad3-8bbb-43c8-a783-d97d1fb71ad2"}}, nil)
+	client.On("GetContentProtectionRuleList",
+		mock.Anything,
+		botman.GetContentProtectionRuleListRequest{ConfigID: 43253, Version: 7, SecurityPolicyID: "AAAA_81230"},
+	).Return(&botman.GetContentProtectionRuleListResponse{
+		ContentProtectionRules: []map[string]interface{}{
+			{"contentProtectionRuleId": "fake3eaa-d334-466d-857e-33308ce416be", "contentProtectionRuleName": "Checkout"},
+		},
+	}, nil)
+	client.On("GetContentProtectionRuleSequence",
+		mock.Anything,
+		botman.GetContentProtectionRuleSequenceRequest{ConfigID: 43253, Version: 7, SecurityPolicyID: "AAAA_81230"},
+	).Return(&botman.GetContentProtectionRuleSequenceResponse{
+		ContentProtectionRuleSequence: []string{"fake3eaa-d334-466d-857e-33308ce416be"},
+	}, nil)
+	client.On("GetContentProtectionJavaScriptInjectionRuleList",
+		mock.Anything,
+		botman.GetContentProtectionJavaScriptInjectionRuleListRequest{ConfigID: 43253, Version: 7, SecurityPolicyID: "AAAA_81230"},
+	).Return(&botman.GetContentProtectionJavaScriptInjectionRuleListResponse{}, nil)
+	client.On("GetCustomCode",
+		mock.Anything,
+		botman.GetCustomCodeRequest{ConfigID: 43253, Version: 7},
+	).Return(map[string]interface{}{}, nil)
+	client.On("GetRecategorizedAkamaiDefinedBotList",
+		mock.Anything,
+		botman.GetRecategorizedAkamaiDefinedBotListRequest{ConfigID: 43253, Version: 7},
+	).Return(&botman.GetRecategorizedAkamaiDefinedBotListResponse{
+		Bots: []botman.RecategorizedAkamaiDefinedBotResponse{
+			{BotID: "fakeb7f1-2c3d-4e5f-8a9b-0c1d2e3f4a5b", CategoryID: "fakeb6d7-37dd-4a2e-9e9f-43545caf6a45"},
+		},
+	}, nil)
+	client.On("GetCustomBotCategoryItemSequence",
+		mock.Anything,
+		botman.GetCustomBotCategoryItemSequenceRequest{ConfigID: 43253, Version: 7, CategoryID: "fakeb6d7-37dd-4a2e-9e9f-43545caf6a45"},
+	).Return(&botman.GetCustomBotCategoryItemSequenceResponse{
+		Sequence: []string{"fake50b8-7b5e-4a6f-9a3e-3f0a1e2d4c5b"},
+	}, nil)
+}
@@ -0,0 +1,162 @@
+package botman
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/providers/appsec"
+)
+
+// exportTemplateNames lists the export templates in the order in which they are rendered. Each template is named
+// after the resource it renders.
+var exportTemplateNames = []string{
+	"akamai_botman_bot_analytics_cookie",
+	"akamai_botman_client_side_security",
+	"akamai_botman_transactional_endpoint_protection",
+	"akamai_botman_custom_code",
+	"akamai_botman_custom_bot_category",
+	"akamai_botman_custom_bot_category_sequence",
+	"akamai_botman_custom_bot_category_item_sequence",
+	"akamai_botman_custom_defined_bot",
+	"akamai_botman_recategorized_akamai_defined_bot",
+	"akamai_botman_custom_client",
+	"akamai_botman_custom_client_sequence",
+	"akamai_botman_challenge_action",
+	"akamai_botman_conditional_action",
+	"akamai_botman_custom_deny_action",
+	"akamai_botman_serve_alternate_action",
+	"akamai_botman_challenge_injection_rules",
+	"akamai_botman_challenge_interception_rules",
+	"akamai_botman_bot_management_settings",
+	"akamai_botman_bot_category_exception",
+	"akamai_botman_akamai_bot_category_action",
+	"akamai_botman_custom_bot_category_action",
+	"akamai_botman_bot_detection_action",
+	"akamai_botman_javascript_injection",
+	"akamai_botman_transactional_endpoint",
+	"akamai_botman_content_protection_rule",
+	"akamai_botman_content_protection_rule_sequence",
+	"akamai_botman_content_protection_javascript_injection_rule",
+}
+
+// initExportTemplates populates the given map with the templates rendering botman resources from a botmanExport
+func initExportTemplates(otm appsec.OutputTemplates) {
+	// configuration settings
+	addExportTemplate(otm, "akamai_botman_bot_analytics_cookie", configObjectTemplate("bot_analytics_cookie", "BotAnalyticsCookie"))
+	addExportTemplate(otm, "akamai_botman_client_side_security", configObjectTemplate("client_side_security", "ClientSideSecurity"))
+	addExportTemplate(otm, "akamai_botman_transactional_endpoint_protection", configObjectTemplate("transactional_endpoint_protection", "TransactionalEndpointProtection"))
+	addExportTemplate(otm, "akamai_botman_custom_code", configObjectTemplate("custom_code", "CustomCode"))
+	addExportTemplate(otm, "akamai_botman_challenge_injection_rules", configObjectTemplate("challenge_injection_rules", "ChallengeInjectionRules"))
+	addExportTemplate(otm, "akamai_botman_challenge_interception_rules", configObjectTemplate("challenge_interception_rules", "ChallengeInterceptionRules"))
+
+	// configuration items
+	addExportTemplate(otm, "akamai_botman_custom_bot_category", configItemTemplate("custom_bot_category", "CustomBotCategories", "categoryId", "metadata", "ruleId"))
+	addExportTemplate(otm, "akamai_botman_custom_defined_bot", configItemTemplate("custom_defined_bot", "CustomDefinedBots", "botId"))
+	addExportTemplate(otm, "akamai_botman_custom_client", configItemTemplate("custom_client", "CustomClients", "customClientId"))
+	addExportTemplate(otm, "akamai_botman_challenge_action", configItemTemplate("challenge_action", "ChallengeActions", "actionId"))
+	addExportTemplate(otm, "akamai_botman_conditional_action", configItemTemplate("conditional_action", "ConditionalActions", "actionId"))
+	addExportTemplate(otm, "akamai_botman_custom_deny_action", configItemTemplate("custom_deny_action", "CustomDenyActions", "actionId"))
+	addExportTemplate(otm, "akamai_botman_serve_alternate_action", configItemTemplate("serve_alternate_action", "ServeAlternateActions", "actionId"))
+
+	// sequences and references
+	addExportTemplate(otm, "akamai_botman_custom_bot_category_sequence", `{{ $config := .ConfigID }}{{ with .CustomBotCategorySequence }}`+
+		resourceBlock("custom_bot_category_sequence", "custom_bot_category_sequence", "{{ $config }}", "  category_ids = {{ json . }}\n")+
+		`{{ end }}`)
+	addExportTemplate(otm, "akamai_botman_custom_client_sequence", `{{ $config := .ConfigID }}{{ with .CustomClientSequence }}`+
+		resourceBlock("custom_client_sequence", "custom_client_sequence", "{{ $config }}", "  custom_client_ids = {{ json . }}\n")+
+		`{{ end }}`)
+	addExportTemplate(otm, "akamai_botman_custom_bot_category_item_sequence", `{{ $config := .ConfigID }}{{ range .CustomBotCategoryItemSequences }}`+
+		resourceBlock("custom_bot_category_item_sequence", "custom_bot_category_item_sequence_{{ .CategoryID }}", "{{ $config }}:{{ .CategoryID }}",
+			"  category_id = {{ quote .CategoryID }}\n  bot_ids = {{ json .BotIDs }}\n")+
+		`{{ end }}`)
+	addExportTemplate(otm, "akamai_botman_recategorized_akamai_defined_bot", `{{ $config := .ConfigID }}{{ range .RecategorizedAkamaiDefinedBots }}`+
+		resourceBlock("recategorized_akamai_defined_bot", "recategorized_akamai_defined_bot_{{ .BotID }}", "{{ $config }}:{{ .BotID }}",
+			"  bot_id = {{ quote .BotID }}\n  category_id = {{ quote .CategoryID }}\n")+
+		`{{ end }}`)
+	addExportTemplate(otm, "akamai_botman_content_protection_rule_sequence", `{{ $config := .ConfigID }}{{ range .SecurityPolicies }}{{ $policy := .ID }}{{ with .ContentProtectionRuleSequence }}`+
+		resourceBlock("content_protection_rule_sequence", "content_protection_rule_sequence_{{ $policy }}", "{{ $config }}:{{ $policy }}",
+			"  security_policy_id = {{ quote $policy }}\n  content_protection_rule_ids = {{ json . }}\n")+
+		`{{ end }}{{ end }}`)
+
+	// security policy settings
+	addExportTemplate(otm, "akamai_botman_bot_management_settings", policyObjectTemplate("bot_management_settings", "BotManagementSettings"))
+	addExportTemplate(otm, "akamai_botman_bot_category_exception", policyObjectTemplate("bot_category_exception", "BotCategoryException"))
+	addExportTemplate(otm, "akamai_botman_javascript_injection", policyObjectTemplate("javascript_injection", "JavascriptInjection"))
+
+	// security policy items
+	addExportTemplate(otm, "akamai_botman_akamai_bot_category_action", policyItemTemplate("akamai_bot_category_action", "AkamaiBotCategoryActions", "categoryId", "category_id"))
+	addExportTemplate(otm, "akamai_botman_custom_bot_category_action", policyItemTemplate("custom_bot_category_action", "CustomBotCategoryActions", "categoryId", "category_id"))
+	addExportTemplate(otm, "akamai_botman_bot_detection_action", policyItemTemplate("bot_detection_action", "BotDetectionActions", "detectionId", "detection_id"))
+	addExportTemplate(otm, "akamai_botman_transactional_endpoint", policyItemTemplate("transactional_endpoint", "TransactionalEndpoints", "operationId", "operation_id"))
+	addExportTemplate(otm, "akamai_botman_content_protection_rule", policyItemTemplate("content_protection_rule", "ContentProtectionRules", "contentProtectionRuleId", ""))
+	addExportTemplate(otm, "akamai_botman_content_protection_javascript_injection_rule", policyItemTemplate("content_protection_javascript_injection_rule", "ContentProtectionJavaScriptInjectionRules", "contentProtectionJavaScriptInjectionRuleId", ""))
+}
+
+func addExportTemplate(otm appsec.OutputTemplates, name, templateString string) {
+	otm[name] = &appsec.OutputTemplate{TemplateName: name, TableTitle: name, TemplateType: "TERRAFORM", TemplateString: templateString}
+}
+
+// configObjectTemplate renders a resource managing a single object of the configuration
+func configObjectTemplate(resource, field string) string {
+	return fmt.Sprintf(`{{ $config := .ConfigID }}{{ with .%s }}`, field) +
+		resourceBlock(resource, resource, "{{ $config }}", jsonAttribute(resource, "marshal .")) +
+		`{{ end }}`
+}
+
+// configItemTemplate renders a resource for every item of the configuration stored in field. The item identifier
+// stored under idKey and the other given read-only keys are not part of the rendered JSON.
+func configItemTemplate(resource, field, idKey string, readOnlyKeys ...string) string {
+	id := fmt.Sprintf(`{{ index . %q }}`, idKey)
+	return fmt.Sprintf(`{{ $config := .ConfigID }}{{ range .%s }}`, field) +
+		resourceBlock(resource, resource+"_"+id, "{{ $config }}:"+id, jsonAttribute(resource, marshalWithout(append([]string{idKey}, readOnlyKeys...)...))) +
+		`{{ end }}`
+}
+
+// policyObjectTemplate renders a resource managing a single object of every security policy
+func policyObjectTemplate(resource, field string) string {
+	return fmt.Sprintf(`{{ $config := .ConfigID }}{{ range .SecurityPolicies }}{{ $policy := .ID }}{{ with .%s }}`, field) +
+		resourceBlock(resource, resource+"_{{ $policy }}", "{{ $config }}:{{ $policy }}",
+			"  security_policy_id = {{ quote $policy }}\n"+jsonAttribute(resource, "marshal .")) +
+		`{{ end }}{{ end }}`
+}
+
+// policyItemTemplate renders a resource for every item of a security policy stored in field. The item identifier
+// stored under idKey is not part of the rendered JSON. If idAttribute is set, the identifier is rendered as the value
+// of this attribute.
+func policyItemTemplate(resource, field, idKey, idAttribute string) string {
+	id := fmt.Sprintf(`{{ index . %q }}`, idKey)
+	attributes := "  security_policy_id = {{ quote $policy }}\n"
+	if idAttribute != "" {
+		attributes += fmt.Sprintf("  %s = \"%s\"\n", idAttribute, id)
+	}
+	attributes += jsonAttribute(resource, marshalWithout(idKey))
+	return fmt.Sprintf(`{{ $config := .ConfigID }}{{ range .SecurityPolicies }}{{ $policy := .ID }}{{ range .%s }}`, field) +
+		resourceBlock(resource, resource+"_{{ $policy }}_"+id, "{{ $config }}:{{ $policy }}:"+id, attributes) +
+		`{{ end }}{{ end }}`
+}
+
+// resourceBlock renders an import block and the configuration of a botman resource
+func resourceBlock(resource, name, importID, attributes string) string {
+	return fmt.Sprintf(`
+import {
+  to = akamai_botman_%[1]s.%[2]s
+  id = "%[3]s"
+}
+
+resource "akamai_botman_%[1]s" "%[2]s" {
+  config_id = {{ $config }}
+%[4]s}
+`, resource, name, importID, attributes)
+}
+
+func jsonAttribute(attribute, expression string) string {
+	return fmt.Sprintf("  %s = <<-EOF\n{{ %s }}\nEOF\n", attribute, expression)
+}
+
+func marshalWithout(keys ...string) string {
+	quoted := make([]string, 0, len(keys))
+	for _, key := range keys {
+		quoted = append(quoted, fmt.Sprintf("%q", key))
+	}
+	return "marshalwithout . " + strings.Join(quoted, " ")
+}
@@ -29,6 +29,7 @@ var (
 
 	getLatestConfigVersion     = appsec.GetLatestConfigVersion
 	getModifiableConfigVersion = appsec.GetModifiableConfigVersion
+	getExportConfiguration     = appsec.GetExportConfiguration
 )
 
 var _ subprovider.Subprovider = &Subprovider{}
@@ -122,6 +123,7 @@ func (p *Subprovider) SDKDataSources() map[string]*schema.Resource {
 		"akamai_botman_custom_client_sequence":                       dataSourceCustomClientSequence(),
 		"akamai_botman_custom_defined_bot":                           dataSourceCustomDefinedBot(),
 		"akamai_botman_custom_deny_action":                           dataSourceCustomDenyAction(),
+		"akamai_botman_export_configuration":                         dataSourceExportConfiguration(),
 		"akamai_botman_custom_code":                                  dataSourceCustomCode(),
 		"akamai_botman_javascript_injection":                         dataSourceJavascriptInjection(),
 		"akamai_botman_recategorized_akamai_defined_bot":             dataSourceRecategorizedAkamaiDefinedBot(),
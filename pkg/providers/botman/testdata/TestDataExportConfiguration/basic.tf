@@ -0,0 +1,9 @@
+provider "akamai" {
+  edgerc        = "../../common/testutils/edgerc"
+  cache_enabled = false
+}
+
+data "akamai_botman_export_configuration" "test" {
+  config_id = 43253
+  version   = 7
+}
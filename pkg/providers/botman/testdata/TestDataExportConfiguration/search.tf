@@ -0,0 +1,10 @@
+provider "akamai" {
+  edgerc        = "../../common/testutils/edgerc"
+  cache_enabled = false
+}
+
+data "akamai_botman_export_configuration" "test" {
+  config_id = 43253
+  version   = 7
+  search    = ["akamai_botman_custom_bot_category", "akamai_botman_custom_bot_category_action"]
+}
@@ -0,0 +1,145 @@
+package clientlists
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v9/pkg/clientlists"
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/meta"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// defaultExpiringWithinDays is the number of days used for 'expiring_items' when 'expiring_within_days' is not set
+const defaultExpiringWithinDays = 7
+
+// planItemExpiration validates item expiration dates and, unless 'keep_expired_items' is set, plans the removal
+// of items which have already expired.
+func planItemExpiration(_ context.Context, d *schema.ResourceDiff, m interface{}) error {
+	meta := meta.Must(m)
+	logger := meta.Log("CLIENTLIST", "planItemExpiration")
+
+	rawItems := d.GetRawConfig().GetAttr("items")
+	if !rawItems.IsKnown() || !d.NewValueKnown("type") {
+		return nil
+	}
+	if rawItems.IsNull() || rawItems.LengthInt() == 0 {
+		// 'items' is optional and computed, so removing all items from the configuration has to be planned explicitly
+		if d.NewValueKnown("items") && d.Get("items").(*schema.Set).Len() == 0 {
+			return nil
+		}
+		return d.SetNew("items", []interface{}{})
+	}
+	if !d.NewValueKnown("items") {
+		return nil
+	}
+
+	items := d.Get("items").(*schema.Set).List()
+	if err := checkRequiredExpirationDates(d.Get("type").(string), d.Get("require_expiration_date_for_types").(*schema.Set), items); err != nil {
+		return err
+	}
+
+	active, expired, err := splitExpiredItems(items, time.Now())
+	if err != nil {
+		return err
+	}
+	if d.Get("keep_expired_items").(bool) || len(expired) == 0 {
+		return nil
+	}
+	logger.Debugf("planning removal of expired items: %s", strings.Join(expired, ", "))
+	return d.SetNew("items", active)
+}
+
+// checkRequiredExpirationDates verifies that every item has an expiration date if the list type requires one
+func checkRequiredExpirationDates(listType string, requiredTypes *schema.Set, items []interface{}) error {
+	if requiredTypes == nil || !requiredTypes.Contains(listType) {
+		return nil
+	}
+	missing := make([]string, 0)
+	for _, item := range items {
+		itemMap := item.(map[string]interface{})
+		if itemMap["expiration_date"].(string) == "" {
+			missing = append(missing, itemMap["value"].(string))
+		}
+	}
+	if len(missing) > 0 {
+		sort.Strings(missing)
+		return fmt.Errorf("items of %s client lists require an 'expiration_date'. Items without expiration date: %s", listType, strings.Join(missing, ", "))
+	}
+	return nil
+}
+
+// splitExpiredItems returns the items which have not expired yet and the values of the items which have
+func splitExpiredItems(items []interface{}, now time.Time) ([]interface{}, []string, error) {
+	active := make([]interface{}, 0, len(items))
+	expired := make([]string, 0)
+	for _, item := range items {
+		itemMap := item.(map[string]interface{})
+		expirationDate, err := parseExpirationDate(itemMap["expiration_date"].(string))
+		if err != nil {
+			return nil, nil, fmt.Errorf("item %s: %w", itemMap["value"].(string), err)
+		}
+		if !expirationDate.IsZero() && !expirationDate.After(now) {
+			expired = append(expired, itemMap["value"].(string))
+			continue
+		}
+		active = append(active, item)
+	}
+	sort.Strings(expired)
+	return active, expired, nil
+}
+
+// expiredListItemsWarning returns a warning listing the items of the client list which have expired. It is reported
+// on refresh so that the removal of these items, which is planned unless 'keep_expired_items' is set, is explained
+// in the plan.
+func expiredListItemsWarning(items []clientlists.ListItemContent, now time.Time) diag.Diagnostics {
+	expired := make([]string, 0)
+	for _, item := range items {
+		expirationDate, err := parseExpirationDate(item.ExpirationDate)
+		if err == nil && !expirationDate.IsZero() && !expirationDate.After(now) {
+			expired = append(expired, item.Value)
+		}
+	}
+	if len(expired) == 0 {
+		return nil
+	}
+	sort.Strings(expired)
+	return diag.Diagnostics{
+		diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  "client list items have expired",
+			Detail:   fmt.Sprintf("The following items of the client list have expired and their removal is planned: %s. Remove them from the configuration or update their 'expiration_date'.", strings.Join(expired, ", ")),
+		},
+	}
+}
+
+// expiringItems returns the values of the items which expire within the given period
+func expiringItems(items []clientlists.ListItemContent, now time.Time, within time.Duration) []string {
+	expiring := make([]string, 0)
+	for _, item := range items {
+		expirationDate, err := parseExpirationDate(item.ExpirationDate)
+		if err != nil || expirationDate.IsZero() {
+			continue
+		}
+		if expirationDate.Before(now.Add(within)) {
+			expiring = append(expiring, item.Value)
+		}
+	}
+	sort.Strings(expiring)
+	return expiring
+}
+
+// parseExpirationDate parses an RFC 3339 expiration date. The zero time is returned if no date is set.
+func parseExpirationDate(value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	expirationDate, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid 'expiration_date' %q, expected RFC 3339 format such as 2024-12-31T00:00:00+00:00", value)
+	}
+	return expirationDate, nil
+}
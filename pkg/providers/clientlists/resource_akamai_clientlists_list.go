@@ -7,6 +7,7 @@ import (
 	"net/http"
 	"reflect"
 	"sort"
+	"time"
 
 	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v9/pkg/clientlists"
 	"github.com/akamai/terraform-provider-akamai/v6/pkg/common/tf"
@@ -24,6 +25,7 @@ func resourceClientList() *schema.Resource {
 		UpdateContext: resourceClientListUpdate,
 		DeleteContext: resourceClientListDelete,
 		CustomizeDiff: customdiff.All(
+			planItemExpiration,
 			markVersionComputedIfListModified,
 		),
 		Importer: &schema.ResourceImporter{
@@ -83,6 +85,7 @@ func resourceClientList() *schema.Resource {
 			"items": {
 				Type:        schema.TypeSet,
 				Optional:    true,
+				Computed:    true,
 				Description: "Set of items containing item information.",
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
@@ -106,12 +109,38 @@ func resourceClientList() *schema.Resource {
 						"expiration_date": {
 							Type:        schema.TypeString,
 							Optional:    true,
-							Description: "The item expiration date.",
+							Description: "The item expiration date in RFC 3339 format. Expired items are planned for removal unless 'keep_expired_items' is set. The removal is shown in the plan and reported in a warning.",
 							Default:     "",
 						},
 					},
 				},
 			},
+			"keep_expired_items": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: "Keeps expired items in the plan instead of planning their removal. Without it, the removal of expired items is visible in the plan.",
+			},
+			"require_expiration_date_for_types": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Description: fmt.Sprintf("List types for which every item must have an expiration date. Valid types: %s", getValidListTypes()),
+				Elem: &schema.Schema{
+					Type:             schema.TypeString,
+					ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice(getValidListTypes(), false)),
+				},
+			},
+			"expiring_within_days": {
+				Type:             schema.TypeInt,
+				Optional:         true,
+				Description:      fmt.Sprintf("The number of days within which items are reported in 'expiring_items'. Defaults to %d.", defaultExpiringWithinDays),
+				ValidateDiagFunc: validation.ToDiagFunc(validation.IntAtLeast(1)),
+			},
+			"expiring_items": {
+				Type:        schema.TypeSet,
+				Computed:    true,
+				Description: "Values of the items which expire within 'expiring_within_days'.",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
 		},
 	}
 }
@@ -148,24 +177,33 @@ func resourceClientListRead(ctx context.Context, d *schema.ResourceData, m inter
 		items = append(items, i)
 	}
 
+	expiringWithinDays := d.Get("expiring_within_days").(int)
+	if expiringWithinDays == 0 {
+		expiringWithinDays = defaultExpiringWithinDays
+	}
+
 	fields := map[string]interface{}{
-		"contract_id": list.ContractID,
-		"group_id":    list.GroupID,
-		"name":        list.Name,
-		"type":        list.Type,
-		"notes":       list.Notes,
-		"tags":        list.Tags,
-		"list_id":     list.ListID,
-		"version":     list.Version,
-		"items_count": list.ItemsCount,
-		"items":       items,
+		"contract_id":    list.ContractID,
+		"group_id":       list.GroupID,
+		"name":           list.Name,
+		"type":           list.Type,
+		"notes":          list.Notes,
+		"tags":           list.Tags,
+		"list_id":        list.ListID,
+		"version":        list.Version,
+		"items_count":    list.ItemsCount,
+		"items":          items,
+		"expiring_items": expiringItems(list.Items, time.Now(), time.Duration(expiringWithinDays)*24*time.Hour),
 	}
 
 	if err = tf.SetAttrs(d, fields); err != nil {
 		return diag.Errorf("%s: %s", tf.ErrValueSet, err.Error())
 	}
 
-	return nil
+	if d.Get("keep_expired_items").(bool) {
+		return nil
+	}
+	return expiredListItemsWarning(list.Items, time.Now())
 }
 
 func resourceClientListCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v9/pkg/clientlists"
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/common/activation"
 	"github.com/akamai/terraform-provider-akamai/v6/pkg/common/tf"
 	"github.com/akamai/terraform-provider-akamai/v6/pkg/meta"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
@@ -19,7 +20,11 @@ import (
 
 var (
 	pollActivationInterval = 30 * time.Second
+	createActivationRetry  = 10 * time.Second
 	errActivationFailed    = errors.New("activation failed")
+
+	// activationResourceTimeout is the default timeout for the resource operations
+	activationResourceTimeout = activation.DefaultTimeout
 )
 
 func resourceClientListActivation() *schema.Resource {
@@ -34,6 +39,9 @@ func resourceClientListActivation() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: resourceActivationImport,
 		},
+		Timeouts: &schema.ResourceTimeout{
+			Default: &activationResourceTimeout,
+		},
 		Schema: map[string]*schema.Schema{
 			"list_id": {
 				Type:        schema.TypeString,
@@ -151,7 +159,7 @@ func resourceActivationCreate(ctx context.Context, d *schema.ResourceData, m int
 		},
 	}
 
-	res, err := client.CreateActivation(ctx, req)
+	res, err := createActivation(ctx, client, req)
 	if err != nil {
 		logger.Errorf("calling 'CreateActivation' failed: %s", err.Error())
 		return diag.FromErr(err)
@@ -161,7 +169,7 @@ func resourceActivationCreate(ctx context.Context, d *schema.ResourceData, m int
 
 	_, err = waitForActivationCompletion(ctx, client, res.ActivationID)
 	if err != nil {
-		return diag.FromErr(err)
+		return activation.Diagnostics("waiting for client list activation", err)
 	}
 
 	return resourceActivationRead(ctx, d, m)
@@ -193,7 +201,7 @@ func resourceActivationUpdate(ctx context.Context, d *schema.ResourceData, m int
 			},
 		}
 
-		res, err := client.CreateActivation(ctx, req)
+		res, err := createActivation(ctx, client, req)
 		if err != nil {
 			logger.Errorf("calling 'CreateActivation' failed: %s", err.Error())
 			return diag.FromErr(err)
@@ -203,7 +211,7 @@ func resourceActivationUpdate(ctx context.Context, d *schema.ResourceData, m int
 
 		_, err = waitForActivationCompletion(ctx, client, res.ActivationID)
 		if err != nil {
-			return diag.FromErr(err)
+			return activation.Diagnostics("waiting for client list activation", err)
 		}
 	}
 
@@ -270,24 +278,39 @@ func getResourceAttrs(d *schema.ResourceData) (*resourceAttrs, error) {
 	}, nil
 }
 
+// createActivation creates the activation, retrying transient errors
+func createActivation(ctx context.Context, client clientlists.ClientLists, req clientlists.CreateActivationRequest) (*clientlists.CreateActivationResponse, error) {
+	var res *clientlists.CreateActivationResponse
+	err := activation.Retry(ctx, activation.NewBackoff(createActivationRetry, activation.DefaultCreateRetryMaxInterval), isActivationErrorRetryable, func(ctx context.Context) error {
+		var err error
+		res, err = client.CreateActivation(ctx, req)
+		return err
+	})
+	return res, err
+}
+
 func waitForActivationCompletion(ctx context.Context, client clientlists.ClientLists, activationID int64) (*clientlists.GetActivationResponse, error) {
-	for {
-		select {
-		case <-time.After(pollActivationInterval):
-			activation, err := client.GetActivation(ctx, clientlists.GetActivationRequest{ActivationID: activationID})
+	var result *clientlists.GetActivationResponse
+	err := activation.Poll(ctx, activation.NewPoller(pollActivationInterval), activation.DefaultMaxRetries, isActivationErrorRetryable,
+		func(ctx context.Context) (bool, error) {
+			act, err := client.GetActivation(ctx, clientlists.GetActivationRequest{ActivationID: activationID})
 			if err != nil {
-				return nil, fmt.Errorf("polling activation failed: %s", err)
+				return false, fmt.Errorf("polling activation failed: %w", err)
 			}
-
-			if activation.ActivationStatus == clientlists.Active {
-				return activation, nil
-			} else if activation.ActivationStatus == clientlists.Failed {
-				return nil, errActivationFailed
+			if act.ActivationStatus == clientlists.Failed {
+				return false, errActivationFailed
 			}
-		case <-ctx.Done():
-			return nil, fmt.Errorf("activation context terminated: %s", ctx.Err())
-		}
+			result = act
+			return act.ActivationStatus == clientlists.Active, nil
+		})
+	if err != nil {
+		return nil, err
 	}
+	return result, nil
+}
+
+func isActivationErrorRetryable(err error) bool {
+	return activation.IsRetryable(err, func(e *clientlists.Error) int { return e.StatusCode }, activation.IsRetryableStatusCode)
 }
 
 // Suppress diff on callers field when activation is not required
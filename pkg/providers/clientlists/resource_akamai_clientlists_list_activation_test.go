@@ -2,6 +2,7 @@ package clientlists
 
 import (
 	"fmt"
+	"net/http"
 	"regexp"
 	"strconv"
 	"testing"
@@ -383,6 +384,70 @@ func TestClientListActivationResource(t *testing.T) {
 		client.AssertExpectations(t)
 	})
 
+	t.Run("create activation retries on 429 error", func(t *testing.T) {
+		createActivationRetry = time.Microsecond
+		client := new(clientlists.Mock)
+
+		client.On("CreateActivation", mock.Anything, activationReq).Return(nil, &clientlists.Error{StatusCode: http.StatusTooManyRequests}).Once()
+		activationRes := expectCreateActivation(t, client, activationReq, 2, 33)
+
+		expectReadActivation(t, client,
+			clientlists.GetActivationRequest{ActivationID: activationRes.ActivationID},
+			getActivationAttrs(activationRes, clientlists.Active), 4)
+
+		expectGetClientlist(t, client, "12_AB", 2, 2)
+
+		useClient(client, func() {
+			resource.UnitTest(t, resource.TestCase{
+				ProtoV6ProviderFactories: testutils.NewProtoV6ProviderFactory(NewSubprovider()),
+				Steps: []resource.TestStep{
+					{
+						Config: loadFixtureString(fmt.Sprintf("%s/activation_create.tf", testDir)),
+						Check: checkAttributes(ActivationAttrs{
+							ListID:                 activationRes.ListID,
+							Network:                string(activationRes.Network),
+							NotificationRecipients: activationRes.NotificationRecipients,
+							SiebelTicketID:         activationRes.SiebelTicketID,
+							Comments:               activationRes.Comments,
+							Version:                int(activationRes.Version),
+						}),
+					},
+				},
+			})
+		})
+		client.AssertExpectations(t)
+	})
+
+	t.Run("create activation exceeds timeout of timeouts block", func(t *testing.T) {
+		client := new(clientlists.Mock)
+
+		activationRes := expectCreateActivation(t, client, activationReq, 2, 33)
+
+		// the activation stays pending until the timeout, the refresh before destroy reads it once more
+		client.On("GetActivation", mock.Anything, clientlists.GetActivationRequest{ActivationID: activationRes.ActivationID}).
+			Return(&clientlists.GetActivationResponse{
+				ActivationID:     activationRes.ActivationID,
+				ListID:           activationRes.ListID,
+				Version:          activationRes.Version,
+				ActivationParams: activationReq.ActivationParams,
+				ActivationStatus: clientlists.PendingActivation,
+			}, nil)
+		expectGetClientlist(t, client, "12_AB", 2, 1)
+
+		useClient(client, func() {
+			resource.UnitTest(t, resource.TestCase{
+				ProtoV6ProviderFactories: testutils.NewProtoV6ProviderFactory(NewSubprovider()),
+				Steps: []resource.TestStep{
+					{
+						Config:      loadFixtureString(fmt.Sprintf("%s/activation_timeout.tf", testDir)),
+						ExpectError: regexp.MustCompile("waiting for client list activation: activation context terminated: context\\s+deadline exceeded"),
+					},
+				},
+			})
+		})
+		client.AssertExpectations(t)
+	})
+
 	t.Run("Create activation with missing list id fails", func(t *testing.T) {
 		client := new(clientlists.Mock)
 
@@ -1,15 +1,24 @@
 package clientlists
 
 import (
+	"context"
 	"fmt"
 	"regexp"
 	"strconv"
 	"testing"
+	"time"
 
 	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v9/pkg/clientlists"
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v9/pkg/session"
 	"github.com/akamai/terraform-provider-akamai/v6/pkg/common/testutils"
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/meta"
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
 func TestResourceClientList(t *testing.T) {
@@ -828,6 +837,61 @@ func TestResourceClientList(t *testing.T) {
 		client.AssertExpectations(t)
 	})
 
+	t.Run("Create list prunes expired items", func(t *testing.T) {
+		client := new(clientlists.Mock)
+		items := append([]clientlists.ListItemPayload{},
+			clientlists.ListItemPayload{
+				Value:          "123",
+				ExpirationDate: "2026-12-26T01:00:00+00:00",
+				Tags:           []string{},
+			})
+
+		clientList := expectCreateList(t, client, clientlists.CreateClientListRequest{
+			Name:       "List Name",
+			Notes:      "List Notes",
+			Tags:       []string{"a", "b"},
+			Type:       clientlists.ASN,
+			ContractID: "12_ABC",
+			GroupID:    12,
+			Items:      items,
+		})
+		expectReadList(t, client, clientList.ListContent, mapItemsPayloadToContent(items), 2)
+		expectDeleteList(t, client, clientList.ListContent)
+
+		useClient(client, func() {
+			resource.UnitTest(t, resource.TestCase{
+				ProtoV6ProviderFactories: testutils.NewProtoV6ProviderFactory(NewSubprovider()),
+				Steps: []resource.TestStep{
+					{
+						Config: loadFixtureString(fmt.Sprintf("%s/list_and_expired_items_create.tf", testDir)),
+						Check: resource.ComposeTestCheckFunc(
+							resource.TestCheckResourceAttr("akamai_clientlist_list.test_list", "items.#", "1"),
+							resource.TestCheckResourceAttr("akamai_clientlist_list.test_list", "items.0.value", "123"),
+						),
+					},
+				},
+			})
+		})
+		client.AssertExpectations(t)
+	})
+
+	t.Run("Create list with missing required expiration date fails", func(t *testing.T) {
+		client := new(clientlists.Mock)
+
+		useClient(client, func() {
+			resource.UnitTest(t, resource.TestCase{
+				ProtoV6ProviderFactories: testutils.NewProtoV6ProviderFactory(NewSubprovider()),
+				Steps: []resource.TestStep{
+					{
+						Config:      loadFixtureString(fmt.Sprintf("%s/list_and_items_missing_expiration.tf", testDir)),
+						ExpectError: regexp.MustCompile("items of ASN client lists require an 'expiration_date'. Items without expiration date: 1"),
+					},
+				},
+			})
+		})
+		client.AssertExpectations(t)
+	})
+
 	t.Run("Import clientlist resource", func(t *testing.T) {
 		client := new(clientlists.Mock)
 
@@ -862,3 +926,106 @@ func TestResourceClientList(t *testing.T) {
 		client.AssertExpectations(t)
 	})
 }
+
+func TestSplitExpiredItems(t *testing.T) {
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	items := []interface{}{
+		map[string]interface{}{"value": "1", "expiration_date": "2024-06-01T11:00:00+00:00"},
+		map[string]interface{}{"value": "2", "expiration_date": "2024-06-01T14:00:00+02:00"},
+		map[string]interface{}{"value": "3", "expiration_date": "2024-06-02T00:00:00+00:00"},
+		map[string]interface{}{"value": "4", "expiration_date": ""},
+	}
+
+	active, expired, err := splitExpiredItems(items, now)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"1", "2"}, expired)
+	assert.Equal(t, []interface{}{items[2], items[3]}, active)
+
+	_, _, err = splitExpiredItems([]interface{}{map[string]interface{}{"value": "5", "expiration_date": "2024-06-01"}}, now)
+	assert.ErrorContains(t, err, `item 5: invalid 'expiration_date' "2024-06-01"`)
+}
+
+func TestExpiringItems(t *testing.T) {
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	items := []clientlists.ListItemContent{
+		{Value: "3", ExpirationDate: "2024-06-03T00:00:00+00:00"},
+		{Value: "1", ExpirationDate: "2024-06-07T00:00:00+00:00"},
+		{Value: "2", ExpirationDate: "2024-07-01T00:00:00+00:00"},
+		{Value: "4"},
+	}
+
+	assert.Equal(t, []string{"1", "3"}, expiringItems(items, now, 7*24*time.Hour))
+	assert.Equal(t, []string{"3"}, expiringItems(items, now, 48*time.Hour))
+}
+
+func TestCheckRequiredExpirationDates(t *testing.T) {
+	items := []interface{}{
+		map[string]interface{}{"value": "2", "expiration_date": ""},
+		map[string]interface{}{"value": "1", "expiration_date": ""},
+		map[string]interface{}{"value": "3", "expiration_date": "2024-06-02T00:00:00+00:00"},
+	}
+
+	assert.NoError(t, checkRequiredExpirationDates("IP", schema.NewSet(schema.HashString, []interface{}{"ASN"}), items))
+	assert.NoError(t, checkRequiredExpirationDates("IP", nil, items))
+	assert.EqualError(t, checkRequiredExpirationDates("IP", schema.NewSet(schema.HashString, []interface{}{"IP"}), items),
+		"items of IP client lists require an 'expiration_date'. Items without expiration date: 1, 2")
+}
+
+func TestExpiredListItemsWarning(t *testing.T) {
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	items := []clientlists.ListItemContent{
+		{Value: "3", ExpirationDate: "2024-06-02T00:00:00+00:00"},
+		{Value: "2", ExpirationDate: "2024-06-01T14:00:00+02:00"},
+		{Value: "1", ExpirationDate: "2024-06-01T11:00:00+00:00"},
+		{Value: "4"},
+	}
+
+	diags := expiredListItemsWarning(items, now)
+	require.Len(t, diags, 1)
+	assert.Equal(t, diag.Warning, diags[0].Severity)
+	assert.Contains(t, diags[0].Detail, "have expired and their removal is planned: 1, 2.")
+
+	assert.Nil(t, expiredListItemsWarning(items[:1], now))
+}
+
+func TestResourceClientListReadReportsExpiredItems(t *testing.T) {
+	m, err := meta.New(session.Must(session.New()), hclog.NewNullLogger(), "")
+	require.NoError(t, err)
+
+	list := &clientlists.GetClientListResponse{
+		ListContent: clientlists.ListContent{ListID: "1_AB", Name: "List Name", Type: clientlists.ASN},
+		ContractID:  "12_ABC",
+		GroupID:     12,
+		Items: []clientlists.ListItemContent{
+			{Value: "1", ExpirationDate: "2020-01-01T00:00:00+00:00", Tags: []string{}},
+			{Value: "2", Tags: []string{}},
+		},
+	}
+
+	for name, keepExpiredItems := range map[string]bool{"warning": false, "no warning with keep_expired_items": true} {
+		t.Run(name, func(t *testing.T) {
+			client := new(clientlists.Mock)
+			client.On("GetClientList", mock.Anything, clientlists.GetClientListRequest{ListID: "1_AB", IncludeItems: true}).Return(list, nil).Once()
+
+			rd := schema.TestResourceDataRaw(t, resourceClientList().Schema, map[string]interface{}{
+				"keep_expired_items": keepExpiredItems,
+			})
+			rd.SetId("1_AB")
+
+			var diags diag.Diagnostics
+			useClient(client, func() {
+				diags = resourceClientListRead(context.Background(), rd, m)
+			})
+			require.False(t, diags.HasError(), diags)
+			if keepExpiredItems {
+				assert.Empty(t, diags)
+			} else {
+				require.Len(t, diags, 1)
+				assert.Equal(t, "client list items have expired", diags[0].Summary)
+				assert.Contains(t, diags[0].Detail, "removal is planned: 1.")
+			}
+			assert.Equal(t, 2, rd.Get("items").(*schema.Set).Len())
+			client.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,21 @@
+provider "akamai" {
+  edgerc = "../../common/testutils/edgerc"
+}
+
+resource "akamai_clientlist_list" "test_list" {
+  name                              = "List Name"
+  tags                              = ["a", "b"]
+  notes                             = "List Notes"
+  type                              = "ASN"
+  contract_id                       = "12_ABC"
+  group_id                          = 12
+  require_expiration_date_for_types = ["ASN"]
+
+  items {
+    value = "1"
+  }
+  items {
+    value           = "123"
+    expiration_date = "2026-12-26T01:00:00+00:00"
+  }
+}
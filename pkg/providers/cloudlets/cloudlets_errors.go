@@ -1,7 +1,13 @@
 package cloudlets
 
 import (
+	"context"
 	"errors"
+	"fmt"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v9/pkg/cloudlets"
+	v3 "github.com/akamai/AkamaiOPEN-edgegrid-golang/v9/pkg/cloudlets/v3"
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/common/activation"
 )
 
 var (
@@ -27,3 +33,41 @@ var (
 	// ErrApplicationLoadBalancerActivationContextTerminated is returned on activation context termination
 	ErrApplicationLoadBalancerActivationContextTerminated = errors.New("application load balancer activation context terminated")
 )
+
+// policyActivationWaitError converts an error returned while waiting for a policy activation into the policy activation errors
+func policyActivationWaitError(err error) error {
+	if errors.Is(err, context.DeadlineExceeded) {
+		return ErrPolicyActivationTimeout
+	}
+	if errors.Is(err, context.Canceled) {
+		return ErrPolicyActivationCanceled
+	}
+	return fmt.Errorf("%v: %w", ErrPolicyActivationContextTerminated, err)
+}
+
+// loadBalancerActivationWaitError converts an error returned while waiting for a load balancer activation into the load balancer activation errors
+func loadBalancerActivationWaitError(err error) error {
+	if errors.Is(err, context.DeadlineExceeded) {
+		return ErrApplicationLoadBalancerActivationTimeout
+	}
+	if errors.Is(err, context.Canceled) {
+		return ErrApplicationLoadBalancerActivationCanceled
+	}
+	return fmt.Errorf("%v: %w", ErrApplicationLoadBalancerActivationContextTerminated, err)
+}
+
+// isActivationErrorRetryable reports whether a request sent while waiting for an activation failed with a transient error
+func isActivationErrorRetryable(err error) bool {
+	return activation.IsRetryable(err, func(e *cloudlets.Error) int { return e.StatusCode }, activation.IsRetryableStatusCode) ||
+		activation.IsRetryable(err, func(e *v3.Error) int { return e.Status }, activation.IsRetryableStatusCode)
+}
+
+// retryActivationRequest sends a request while waiting for an activation and resends it on transient errors. An error
+// caused by the context being done is converted with waitError.
+func retryActivationRequest(ctx context.Context, poller *activation.Poller, waitError func(error) error, request func(context.Context) error) error {
+	err := activation.Retry(ctx, poller, isActivationErrorRetryable, request)
+	if errors.Is(err, activation.ErrContextTerminated) {
+		return waitError(err)
+	}
+	return err
+}
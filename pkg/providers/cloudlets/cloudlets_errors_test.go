@@ -0,0 +1,98 @@
+package cloudlets
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v9/pkg/cloudlets"
+	v3 "github.com/akamai/AkamaiOPEN-edgegrid-golang/v9/pkg/cloudlets/v3"
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/common/activation"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestActivationWaitErrors(t *testing.T) {
+	tests := map[string]struct {
+		err                 error
+		expectedPolicy      error
+		expectedLoadBalance error
+	}{
+		"deadline exceeded": {
+			err:                 activation.ContextError(context.DeadlineExceeded),
+			expectedPolicy:      ErrPolicyActivationTimeout,
+			expectedLoadBalance: ErrApplicationLoadBalancerActivationTimeout,
+		},
+		"canceled": {
+			err:                 activation.ContextError(context.Canceled),
+			expectedPolicy:      ErrPolicyActivationCanceled,
+			expectedLoadBalance: ErrApplicationLoadBalancerActivationCanceled,
+		},
+		"other error": {
+			err:                 errors.New("oops"),
+			expectedPolicy:      ErrPolicyActivationContextTerminated,
+			expectedLoadBalance: ErrApplicationLoadBalancerActivationContextTerminated,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.ErrorContains(t, policyActivationWaitError(test.err), test.expectedPolicy.Error())
+			assert.ErrorContains(t, loadBalancerActivationWaitError(test.err), test.expectedLoadBalance.Error())
+		})
+	}
+}
+
+func TestRetryActivationRequest(t *testing.T) {
+	tests := map[string]struct {
+		errs          []error
+		timeout       time.Duration
+		expectedCalls int
+		expectedError error
+	}{
+		"success": {
+			expectedCalls: 1,
+		},
+		"retryable errors are retried": {
+			errs:          []error{&cloudlets.Error{StatusCode: http.StatusServiceUnavailable}, &cloudlets.Error{StatusCode: http.StatusServiceUnavailable}},
+			expectedCalls: 3,
+		},
+		"non-retryable error is returned": {
+			errs:          []error{&v3.Error{Status: http.StatusNotFound}},
+			expectedCalls: 1,
+			expectedError: &v3.Error{Status: http.StatusNotFound},
+		},
+		"retries stop when the context is done": {
+			errs:          []error{&cloudlets.Error{StatusCode: http.StatusServiceUnavailable}, &cloudlets.Error{StatusCode: http.StatusServiceUnavailable}, &cloudlets.Error{StatusCode: http.StatusServiceUnavailable}, &cloudlets.Error{StatusCode: http.StatusServiceUnavailable}},
+			timeout:       time.Millisecond,
+			expectedError: ErrPolicyActivationTimeout,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			poller := activation.NewPoller(time.Millisecond)
+			if test.timeout > 0 {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(ctx, test.timeout)
+				defer cancel()
+				poller = activation.NewPoller(time.Second)
+			}
+			calls := 0
+			err := retryActivationRequest(ctx, poller, policyActivationWaitError, func(context.Context) error {
+				calls++
+				if calls <= len(test.errs) {
+					return test.errs[calls-1]
+				}
+				return nil
+			})
+			if test.expectedError != nil {
+				assert.ErrorIs(t, err, test.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedCalls, calls)
+		})
+	}
+}
@@ -3,6 +3,7 @@ package cloudlets
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 
 	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v9/pkg/cloudlets"
@@ -203,6 +204,23 @@ func dataSourceCloudletsEdgeRedirectorMatchRule() *schema.Resource {
 					},
 				},
 			},
+			"rules_file": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Description: "Path to a CSV file with a header row, or a JSON file with an array of objects, with the rules to add after the match_rules. " +
+					"The columns are: match_url, redirect_url, and optionally name, status_code, use_incoming_query_string, use_relative_url and disabled",
+			},
+			"fail_on_conflicts": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: "If true, the detected conflicts are reported as errors instead of warnings",
+			},
+			"conflicts": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Duplicate or shadowed rules, redirect chains and redirect loops detected in the rules",
+			},
 			"json": {
 				Type:        schema.TypeString,
 				Computed:    true,
@@ -213,8 +231,12 @@ func dataSourceCloudletsEdgeRedirectorMatchRule() *schema.Resource {
 }
 
 func akamaiCloudletsEdgeRedirectorMatchRuleRead(_ context.Context, d *schema.ResourceData, _ interface{}) diag.Diagnostics {
+	rulesFile, err := tf.GetStringValue("rules_file", d)
+	if err != nil && !errors.Is(err, tf.ErrNotFound) {
+		return diag.FromErr(err)
+	}
 	matchRulesList, err := tf.GetListValue("match_rules", d)
-	if err != nil {
+	if err != nil && (!errors.Is(err, tf.ErrNotFound) || rulesFile == "") {
 		return handleEmptyMatchRules(err, d, "data_akamai_cloudlets_edge_redirector_match_rule")
 	}
 
@@ -227,11 +249,50 @@ func akamaiCloudletsEdgeRedirectorMatchRuleRead(_ context.Context, d *schema.Res
 	if err != nil {
 		return diag.Errorf("'match_rules' - %s", err)
 	}
+	sources := make([]edgeRedirectorRuleSource, 0, len(matchRules))
+	for i, rule := range matchRules {
+		sources = append(sources, edgeRedirectorRuleSource{name: rule.(cloudlets.MatchRuleER).Name, location: fmt.Sprintf("match_rules.%d", i)})
+	}
+
+	if rulesFile != "" {
+		fileRules, fileSources, err := loadEdgeRedirectorRulesFile(rulesFile)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		for _, rule := range fileRules {
+			matchRules = append(matchRules, rule)
+		}
+		sources = append(sources, fileSources...)
+	}
 
 	if err := matchRules.Validate(); err != nil {
 		return diag.FromErr(err)
 	}
 
+	diags, err := matchRulesSizeDiagnostics(matchRules)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	conflicts := checkEdgeRedirectorRules(getRulesER(matchRules), sources)
+	severity := diag.Warning
+	if d.Get("fail_on_conflicts").(bool) {
+		severity = diag.Error
+	}
+	for _, conflict := range conflicts {
+		diags = append(diags, diag.Diagnostic{
+			Severity: severity,
+			Summary:  "Conflicting Edge Redirector rules",
+			Detail:   conflict,
+		})
+	}
+	if diags.HasError() {
+		return diags
+	}
+	if err := d.Set("conflicts", conflicts); err != nil {
+		return diag.Errorf("%v: %s", tf.ErrValueSet, err.Error())
+	}
+
 	jsonBody, err := json.MarshalIndent(matchRules, "", "  ")
 	if err != nil {
 		return diag.FromErr(err)
@@ -245,7 +306,15 @@ func akamaiCloudletsEdgeRedirectorMatchRuleRead(_ context.Context, d *schema.Res
 		return diag.FromErr(err)
 	}
 	d.SetId(hashID)
-	return nil
+	return diags
+}
+
+func getRulesER(matchRules cloudlets.MatchRules) []cloudlets.MatchRuleER {
+	result := make([]cloudlets.MatchRuleER, 0, len(matchRules))
+	for _, rule := range matchRules {
+		result = append(result, rule.(cloudlets.MatchRuleER))
+	}
+	return result
 }
 
 func getMatchCriteriaER(matches []interface{}) ([]cloudlets.MatchCriteriaER, error) {
@@ -72,6 +72,27 @@ func TestDataCloudletsEdgeRedirectorMatchRule(t *testing.T) {
 	}
 }
 
+func TestDataCloudletsEdgeRedirectorMatchRuleRulesFile(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testutils.NewProtoV6ProviderFactory(NewSubprovider()),
+		Steps: []resource.TestStep{
+			{
+				Config: testutils.LoadFixtureString(t, "testdata/TestDataCloudletsEdgeRedirectorMatchRule/rules_file.tf"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.akamai_cloudlets_edge_redirector_match_rule.test", "json",
+						testutils.LoadFixtureString(t, "testdata/TestDataCloudletsEdgeRedirectorMatchRule/rules/rules_file_rules.json")),
+					resource.TestCheckResourceAttr("data.akamai_cloudlets_edge_redirector_match_rule.test", "match_rules.#", "1"),
+					resource.TestCheckResourceAttr("data.akamai_cloudlets_edge_redirector_match_rule.test", "conflicts.#", "2"),
+					resource.TestCheckResourceAttr("data.akamai_cloudlets_edge_redirector_match_rule.test", "conflicts.0",
+						`rule "shoes-duplicate" (rules_file line 4) is a duplicate of rule "shoes" (rules_file line 2)`),
+					resource.TestCheckResourceAttr("data.akamai_cloudlets_edge_redirector_match_rule.test", "conflicts.1",
+						`rule "sale" (match_rules.0) redirects to 'https://example.com/shoes', which is redirected again by rule "shoes" (rules_file line 2)`),
+				),
+			},
+		},
+	})
+}
+
 func TestIncorrectDataCloudletsEdgeRedirectorMatchRule(t *testing.T) {
 	tests := map[string]struct {
 		configPath string
@@ -109,6 +130,10 @@ func TestIncorrectDataCloudletsEdgeRedirectorMatchRule(t *testing.T) {
 			configPath: "testdata/TestDataCloudletsEdgeRedirectorMatchRule/matches_with_matches_always.tf",
 			withError:  `(?s)Matches/MatchesAlways: only one of \[ "Matches", "MatchesAlways" \] can be specified`,
 		},
+		"conflicting rules with fail_on_conflicts": {
+			configPath: "testdata/TestDataCloudletsEdgeRedirectorMatchRule/rules_file_fail_on_conflicts.tf",
+			withError:  `rule "shoes-duplicate" \(rules_file line 4\) is a duplicate of rule "shoes" \(rules_file line 2\)`,
+		},
 	}
 	for name, test := range tests {
 		t.Run(name, func(t *testing.T) {
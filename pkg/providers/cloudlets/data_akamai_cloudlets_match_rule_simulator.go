@@ -0,0 +1,258 @@
+package cloudlets
+
+import (
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/common/tf"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func dataSourceCloudletsMatchRuleSimulator() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceCloudletsMatchRuleSimulatorRead,
+		Schema: map[string]*schema.Schema{
+			"match_rules": {
+				Type:     schema.TypeString,
+				Required: true,
+				Description: "Match rules in JSON format, as rendered in the 'json' attribute of the match rule data sources, " +
+					"or a policy version with the 'matchRules' field",
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringIsJSON),
+			},
+			"evaluation_time": {
+				Type:             schema.TypeInt,
+				Optional:         true,
+				Description:      "The time, in seconds since the epoch, used to check the start and end of the rules. Defaults to the current time",
+				ValidateDiagFunc: validation.ToDiagFunc(validation.IntAtLeast(0)),
+			},
+			"request": {
+				Type:        schema.TypeList,
+				Required:    true,
+				MinItems:    1,
+				Description: "Test requests evaluated against the match rules",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "The name identifying the request in the results",
+						},
+						"url": {
+							Type:             schema.TypeString,
+							Required:         true,
+							Description:      "The URL of the request, including the scheme and hostname",
+							ValidateDiagFunc: validation.ToDiagFunc(validation.IsURLWithHTTPorHTTPS),
+						},
+						"method": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Default:     "GET",
+							Description: "The HTTP method of the request",
+						},
+						"headers": {
+							Type:        schema.TypeMap,
+							Optional:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+							Description: "The headers of the request",
+						},
+						"cookies": {
+							Type:        schema.TypeMap,
+							Optional:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+							Description: "The cookies of the request",
+						},
+						"client_ip": {
+							Type:             schema.TypeString,
+							Optional:         true,
+							Description:      "The IP address the request is connecting from",
+							ValidateDiagFunc: validation.ToDiagFunc(validation.IsIPAddress),
+						},
+						"continent": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "The continent code of the client, for the continent match type",
+						},
+						"country_code": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "The ISO 3166 country code of the client, for the countrycode match type",
+						},
+						"region_code": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "The region code of the client, for the regioncode match type",
+						},
+						"proxy": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "The type of proxy the request comes through, for the proxy match type",
+						},
+						"device_characteristics": {
+							Type:        schema.TypeMap,
+							Optional:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+							Description: "The device characteristics of the client, like 'is_mobile' = 'true', for the deviceCharacteristics match type",
+						},
+						"range_value": {
+							Type:             schema.TypeInt,
+							Optional:         true,
+							Description:      "The value from 1 to 100 assigned to the request for the range match type",
+							ValidateDiagFunc: validation.ToDiagFunc(validation.IntBetween(1, 100)),
+						},
+						"expected_rule": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "The name of the rule the request is expected to match",
+						},
+					},
+				},
+			},
+			"results": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The results of the evaluation, in the order of the requests",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The name of the request",
+						},
+						"url": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The URL of the request",
+						},
+						"matched": {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Whether any rule matched the request",
+						},
+						"rule_name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The name of the rule which matched the request",
+						},
+						"rule_position": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The position, starting from 1, of the rule which matched the request. It is 0 if no rule matched",
+						},
+						"redirect_url": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The URL the request is redirected to by the matching rule",
+						},
+						"status_code": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The HTTP status code of the redirect",
+						},
+						"origin_id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The origin the request is forwarded to by the matching rule",
+						},
+						"forward_path": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The path and query string the request is forwarded with by the matching rule",
+						},
+						"as_expected": {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Whether the request matched the expected rule. It is always true if no rule is expected",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceCloudletsMatchRuleSimulatorRead(_ context.Context, d *schema.ResourceData, _ interface{}) diag.Diagnostics {
+	matchRules, err := tf.GetStringValue("match_rules", d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	rules, err := parseSimulatorRules(matchRules)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	evaluationTime := time.Now()
+	if seconds, ok := d.GetOk("evaluation_time"); ok {
+		evaluationTime = time.Unix(int64(seconds.(int)), 0)
+	}
+
+	requests := d.Get("request").([]interface{})
+	results := make([]interface{}, 0, len(requests))
+	for i, r := range requests {
+		requestMap := r.(map[string]interface{})
+		req, err := getSimulatedRequest(requestMap, evaluationTime)
+		if err != nil {
+			return diag.Errorf("'request' %d: %s", i+1, err)
+		}
+
+		result := rules.evaluate(req)
+		expectedRule := getStringValue(requestMap, "expected_rule")
+		results = append(results, map[string]interface{}{
+			"name":          getStringValue(requestMap, "name"),
+			"url":           getStringValue(requestMap, "url"),
+			"matched":       result.matched,
+			"rule_name":     result.ruleName,
+			"rule_position": result.rulePosition,
+			"redirect_url":  result.redirectURL,
+			"status_code":   result.statusCode,
+			"origin_id":     result.originID,
+			"forward_path":  result.forwardPath,
+			"as_expected":   expectedRule == "" || (result.matched && expectedRule == result.ruleName),
+		})
+	}
+	if err := d.Set("results", results); err != nil {
+		return diag.Errorf("%v: %s", tf.ErrValueSet, err.Error())
+	}
+
+	h := sha1.New()
+	h.Write([]byte(matchRules))
+	d.SetId(hex.EncodeToString(h.Sum(nil)))
+	return nil
+}
+
+func getSimulatedRequest(requestMap map[string]interface{}, evaluationTime time.Time) (*simulatedRequest, error) {
+	requestURL, err := url.Parse(getStringValue(requestMap, "url"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid url: %s", err)
+	}
+	return &simulatedRequest{
+		url:                   requestURL,
+		method:                strings.ToUpper(getStringValue(requestMap, "method")),
+		headers:               getStringMap(requestMap, "headers"),
+		cookies:               getStringMap(requestMap, "cookies"),
+		clientIP:              getStringValue(requestMap, "client_ip"),
+		continent:             getStringValue(requestMap, "continent"),
+		countryCode:           getStringValue(requestMap, "country_code"),
+		regionCode:            getStringValue(requestMap, "region_code"),
+		proxy:                 getStringValue(requestMap, "proxy"),
+		deviceCharacteristics: getStringMap(requestMap, "device_characteristics"),
+		rangeValue:            getIntValue(requestMap, "range_value"),
+		time:                  evaluationTime,
+	}, nil
+}
+
+func getStringMap(values map[string]interface{}, name string) map[string]string {
+	result := make(map[string]string)
+	if m, ok := values[name].(map[string]interface{}); ok {
+		for key, value := range m {
+			result[key] = value.(string)
+		}
+	}
+	return result
+}
@@ -0,0 +1,67 @@
+package cloudlets
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/common/testutils"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestDataCloudletsMatchRuleSimulator(t *testing.T) {
+	tests := map[string]struct {
+		configPath string
+		checks     resource.TestCheckFunc
+		withError  *regexp.Regexp
+	}{
+		"edge redirector rules": {
+			configPath: "testdata/TestDataCloudletsMatchRuleSimulator/edge_redirector.tf",
+			checks: resource.ComposeAggregateTestCheckFunc(
+				resource.TestCheckResourceAttr("data.akamai_cloudlets_match_rule_simulator.test", "results.#", "4"),
+				resource.TestCheckResourceAttr("data.akamai_cloudlets_match_rule_simulator.test", "results.0.name", "shoes"),
+				resource.TestCheckResourceAttr("data.akamai_cloudlets_match_rule_simulator.test", "results.0.rule_name", "shoes"),
+				resource.TestCheckResourceAttr("data.akamai_cloudlets_match_rule_simulator.test", "results.0.rule_position", "3"),
+				resource.TestCheckResourceAttr("data.akamai_cloudlets_match_rule_simulator.test", "results.0.redirect_url", "https://WWW.example.com/footwear?size=10"),
+				resource.TestCheckResourceAttr("data.akamai_cloudlets_match_rule_simulator.test", "results.0.status_code", "301"),
+				resource.TestCheckResourceAttr("data.akamai_cloudlets_match_rule_simulator.test", "results.0.as_expected", "true"),
+				resource.TestCheckResourceAttr("data.akamai_cloudlets_match_rule_simulator.test", "results.1.rule_name", "products"),
+				resource.TestCheckResourceAttr("data.akamai_cloudlets_match_rule_simulator.test", "results.1.redirect_url", "https://shop.example.com/item/123"),
+				resource.TestCheckResourceAttr("data.akamai_cloudlets_match_rule_simulator.test", "results.2.rule_name", "mobile"),
+				resource.TestCheckResourceAttr("data.akamai_cloudlets_match_rule_simulator.test", "results.2.redirect_url", "https://m.example.com/"),
+				resource.TestCheckResourceAttr("data.akamai_cloudlets_match_rule_simulator.test", "results.3.rule_name", "default"),
+				resource.TestCheckResourceAttr("data.akamai_cloudlets_match_rule_simulator.test", "results.3.status_code", "307"),
+				resource.TestCheckResourceAttr("data.akamai_cloudlets_match_rule_simulator.test", "results.3.as_expected", "false"),
+			),
+		},
+		"application load balancer rules": {
+			configPath: "testdata/TestDataCloudletsMatchRuleSimulator/load_balancer.tf",
+			checks: resource.ComposeAggregateTestCheckFunc(
+				resource.TestCheckResourceAttr("data.akamai_cloudlets_match_rule_simulator.test", "results.#", "3"),
+				resource.TestCheckResourceAttr("data.akamai_cloudlets_match_rule_simulator.test", "results.0.origin_id", "beta_origin"),
+				resource.TestCheckResourceAttr("data.akamai_cloudlets_match_rule_simulator.test", "results.1.origin_id", "canary_origin"),
+				resource.TestCheckResourceAttr("data.akamai_cloudlets_match_rule_simulator.test", "results.2.origin_id", "default_origin"),
+				resource.TestCheckResourceAttr("data.akamai_cloudlets_match_rule_simulator.test", "results.2.matched", "true"),
+				resource.TestCheckResourceAttr("data.akamai_cloudlets_match_rule_simulator.test", "results.2.redirect_url", ""),
+			),
+		},
+		"invalid match rules": {
+			configPath: "testdata/TestDataCloudletsMatchRuleSimulator/invalid_rules.tf",
+			withError:  regexp.MustCompile(`rule 1 \("invalid"\): match 1: invalid regular expression`),
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			resource.UnitTest(t, resource.TestCase{
+				ProtoV6ProviderFactories: testutils.NewProtoV6ProviderFactory(NewSubprovider()),
+				Steps: []resource.TestStep{
+					{
+						Config:      testutils.LoadFixtureString(t, test.configPath),
+						Check:       test.checks,
+						ExpectError: test.withError,
+					},
+				},
+			})
+		})
+	}
+}
@@ -0,0 +1,212 @@
+package cloudlets
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v9/pkg/cloudlets"
+)
+
+type (
+	// urlRuleIndex finds the first rule matching a URL among the rules which apply to every request with a matching URL,
+	// that is enabled rules without match criteria and start or end time
+	urlRuleIndex struct {
+		exact         map[string]int
+		patterns      []urlRulePattern
+		matchesAlways int
+	}
+
+	urlRulePattern struct {
+		position int
+		pattern  *regexp.Regexp
+		pathOnly bool
+	}
+)
+
+// checkEdgeRedirectorRules detects rules which are never matched, because an earlier rule matches the same URLs,
+// and rules redirecting to a URL which is redirected again, including redirect loops.
+// Only the match URL of rules is taken into account, rules with other match criteria can only be shadowed
+func checkEdgeRedirectorRules(rules []cloudlets.MatchRuleER, sources []edgeRedirectorRuleSource) []string {
+	var conflicts []string
+	index := urlRuleIndex{exact: make(map[string]int), matchesAlways: -1}
+	for i, rule := range rules {
+		if rule.Disabled {
+			continue
+		}
+		if shadowing := index.shadowing(rule); shadowing >= 0 {
+			if isDuplicateRedirect(rules[shadowing], rule) {
+				conflicts = append(conflicts, fmt.Sprintf("%s is a duplicate of %s", sources[i], sources[shadowing]))
+			} else {
+				conflicts = append(conflicts, fmt.Sprintf("%s is never matched, because %s matches the same URLs first", sources[i], sources[shadowing]))
+			}
+		}
+		if isURLOnlyRule(rule) {
+			index.add(i, rule)
+		}
+	}
+
+	next := make([]int, len(rules))
+	for i, rule := range rules {
+		next[i] = -1
+		if !isURLOnlyRule(rule) || rule.MatchURL == "" {
+			continue
+		}
+		if target, ok := redirectTarget(rule); ok {
+			next[i] = index.firstMatch(target)
+		}
+	}
+
+	inLoop := make([]bool, len(rules))
+	for i := range rules {
+		loop := findRedirectLoop(next, i)
+		if loop == nil {
+			continue
+		}
+		for _, position := range loop {
+			inLoop[position] = true
+		}
+		names := make([]string, 0, len(loop)+1)
+		for _, position := range append(loop, i) {
+			names = append(names, sources[position].String())
+		}
+		conflicts = append(conflicts, "redirect loop: "+strings.Join(names, " -> "))
+	}
+	for i, target := range next {
+		if target >= 0 && !inLoop[i] {
+			conflicts = append(conflicts, fmt.Sprintf("%s redirects to '%s', which is redirected again by %s", sources[i], rules[i].RedirectURL, sources[target]))
+		}
+	}
+	return conflicts
+}
+
+// findRedirectLoop returns the rules of the loop starting at the given rule, if the rule is the first rule of the loop
+func findRedirectLoop(next []int, start int) []int {
+	loop := []int{start}
+	for current := next[start]; current >= 0; current = next[current] {
+		if current == start {
+			return loop
+		}
+		if current < start || len(loop) > len(next) {
+			return nil
+		}
+		loop = append(loop, current)
+	}
+	return nil
+}
+
+func isURLOnlyRule(rule cloudlets.MatchRuleER) bool {
+	return !rule.Disabled && len(rule.Matches) == 0 && rule.Start == 0 && rule.End == 0 &&
+		(rule.MatchURL != "" || rule.MatchesAlways)
+}
+
+func isDuplicateRedirect(first, second cloudlets.MatchRuleER) bool {
+	return len(first.Matches) == 0 && len(second.Matches) == 0 &&
+		first.RedirectURL == second.RedirectURL && first.StatusCode == second.StatusCode &&
+		first.UseRelativeURL == second.UseRelativeURL && first.UseIncomingQueryString == second.UseIncomingQueryString
+}
+
+func (idx *urlRuleIndex) add(position int, rule cloudlets.MatchRuleER) {
+	if rule.MatchesAlways {
+		if idx.matchesAlways < 0 {
+			idx.matchesAlways = position
+		}
+		return
+	}
+	normalized := normalizeMatchURL(rule.MatchURL)
+	if strings.HasPrefix(normalized, "/") || strings.Contains(normalized, "*") {
+		matcher, err := compileMatchURL(normalized)
+		if err != nil {
+			return
+		}
+		idx.patterns = append(idx.patterns, urlRulePattern{position: position, pattern: matcher.pattern, pathOnly: matcher.pathOnly})
+	}
+	if _, ok := idx.exact[normalized]; !ok {
+		idx.exact[normalized] = position
+	}
+}
+
+// shadowing returns the position of the first indexed rule matching all requests the given rule matches, or -1
+func (idx *urlRuleIndex) shadowing(rule cloudlets.MatchRuleER) int {
+	first := idx.matchesAlways
+	if rule.MatchURL == "" {
+		return first
+	}
+	if position := idx.firstMatch(normalizeMatchURL(rule.MatchURL)); position >= 0 && (first < 0 || position < first) {
+		first = position
+	}
+	return first
+}
+
+// firstMatch returns the position of the first indexed rule with a match URL matching the normalized URL, or -1.
+// Rules matching always are not considered, as they usually are the default of a policy
+func (idx *urlRuleIndex) firstMatch(normalizedURL string) int {
+	first := -1
+	if position, ok := idx.exact[normalizedURL]; ok {
+		first = position
+	}
+	for _, p := range idx.patterns {
+		if first >= 0 && p.position > first {
+			break
+		}
+		subject := normalizedURL
+		if p.pathOnly {
+			subject = urlPath(normalizedURL)
+		}
+		if p.pattern.MatchString(subject) {
+			return p.position
+		}
+	}
+	return first
+}
+
+// normalizeMatchURL removes the scheme and converts the hostname of the URL to lower case, so that the match URLs
+// of rules and redirect targets can be compared
+func normalizeMatchURL(matchURL string) string {
+	rest := matchURL
+	if _, withoutScheme, ok := strings.Cut(matchURL, "://"); ok {
+		rest = withoutScheme
+	}
+	if rest == "" || strings.HasPrefix(rest, "/") {
+		return rest
+	}
+	host, path, _ := strings.Cut(rest, "/")
+	return strings.ToLower(host) + "/" + path
+}
+
+func urlPath(normalizedURL string) string {
+	if strings.HasPrefix(normalizedURL, "/") {
+		return normalizedURL
+	}
+	if i := strings.Index(normalizedURL, "/"); i >= 0 {
+		return normalizedURL[i:]
+	}
+	return "/"
+}
+
+// redirectTarget returns the normalized URL the rule redirects to. Relative redirects of rules matching a hostname
+// are resolved against this hostname. Redirects referencing the groups of a regular expression cannot be resolved
+func redirectTarget(rule cloudlets.MatchRuleER) (string, bool) {
+	target := rule.RedirectURL
+	if strings.Contains(target, `\`) {
+		return "", false
+	}
+	if i := strings.IndexAny(target, "?#"); i >= 0 {
+		target = target[:i]
+	}
+	switch {
+	case strings.Contains(target, "://"):
+		return normalizeMatchURL(target), true
+	case strings.HasPrefix(target, "/"):
+		source := normalizeMatchURL(rule.MatchURL)
+		if strings.HasPrefix(source, "/") {
+			return target, true
+		}
+		host, _, _ := strings.Cut(source, "/")
+		if strings.Contains(host, "*") {
+			return "", false
+		}
+		return host + target, true
+	}
+	return "", false
+}
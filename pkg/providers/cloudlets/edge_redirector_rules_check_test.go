@@ -0,0 +1,123 @@
+package cloudlets
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v9/pkg/cloudlets"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckEdgeRedirectorRules(t *testing.T) {
+	redirect := func(matchURL, redirectURL string) cloudlets.MatchRuleER {
+		return cloudlets.MatchRuleER{Type: cloudlets.MatchRuleTypeER, MatchURL: matchURL, RedirectURL: redirectURL, StatusCode: 301}
+	}
+
+	tests := map[string]struct {
+		rules             []cloudlets.MatchRuleER
+		expectedConflicts []string
+	}{
+		"no conflicts": {
+			rules: []cloudlets.MatchRuleER{
+				redirect("example.com/a", "https://www.example.com/a"),
+				redirect("example.com/b", "https://www.example.com/b"),
+				{Type: cloudlets.MatchRuleTypeER, MatchesAlways: true, RedirectURL: "https://www.example.com/", StatusCode: 302},
+			},
+		},
+		"duplicate": {
+			rules: []cloudlets.MatchRuleER{
+				redirect("example.com/a", "https://www.example.com/a"),
+				redirect("https://EXAMPLE.com/a", "https://www.example.com/a"),
+			},
+			expectedConflicts: []string{"rule at 1 is a duplicate of rule at 0"},
+		},
+		"same match URL with different redirect": {
+			rules: []cloudlets.MatchRuleER{
+				redirect("example.com/a", "https://www.example.com/a"),
+				redirect("example.com/a", "https://www.example.com/b"),
+			},
+			expectedConflicts: []string{"rule at 1 is never matched, because rule at 0 matches the same URLs first"},
+		},
+		"shadowed by wildcard": {
+			rules: []cloudlets.MatchRuleER{
+				redirect("example.com/shop/*", "https://shop.example.com/"),
+				redirect("example.com/shop/sale", "https://shop.example.com/sale"),
+			},
+			expectedConflicts: []string{"rule at 1 is never matched, because rule at 0 matches the same URLs first"},
+		},
+		"shadowed by path": {
+			rules: []cloudlets.MatchRuleER{
+				redirect("/a", "https://www.example.com/new-a"),
+				redirect("example.com/a", "https://www.example.com/b"),
+			},
+			expectedConflicts: []string{"rule at 1 is never matched, because rule at 0 matches the same URLs first"},
+		},
+		"shadowed by rule matching always": {
+			rules: []cloudlets.MatchRuleER{
+				{Type: cloudlets.MatchRuleTypeER, MatchesAlways: true, RedirectURL: "https://www.example.com/", StatusCode: 302},
+				redirect("example.com/a", "https://www.example.com/a"),
+			},
+			expectedConflicts: []string{"rule at 1 is never matched, because rule at 0 matches the same URLs first"},
+		},
+		"not shadowed by rule with criteria, start time or disabled": {
+			rules: []cloudlets.MatchRuleER{
+				{Type: cloudlets.MatchRuleTypeER, MatchURL: "example.com/a", RedirectURL: "/x", StatusCode: 301,
+					Matches: []cloudlets.MatchCriteriaER{{MatchType: "method", MatchValue: "POST"}}},
+				{Type: cloudlets.MatchRuleTypeER, MatchURL: "example.com/a", RedirectURL: "/y", StatusCode: 301, Start: 1700000000},
+				{Type: cloudlets.MatchRuleTypeER, MatchURL: "example.com/a", RedirectURL: "/z", StatusCode: 301, Disabled: true},
+				redirect("example.com/a", "/b"),
+			},
+		},
+		"redirect chain": {
+			rules: []cloudlets.MatchRuleER{
+				redirect("example.com/a", "https://example.com/b?x=1"),
+				redirect("example.com/b", "https://www.example.com/c"),
+			},
+			expectedConflicts: []string{"rule at 0 redirects to 'https://example.com/b?x=1', which is redirected again by rule at 1"},
+		},
+		"relative redirect chain": {
+			rules: []cloudlets.MatchRuleER{
+				redirect("example.com/a", "/b"),
+				redirect("example.com/b", "https://www.example.com/c"),
+			},
+			expectedConflicts: []string{"rule at 0 redirects to '/b', which is redirected again by rule at 1"},
+		},
+		"redirect loop": {
+			rules: []cloudlets.MatchRuleER{
+				redirect("example.com/a", "https://example.com/b"),
+				redirect("example.com/c", "https://example.com/a"),
+				redirect("example.com/b", "https://example.com/a"),
+			},
+			expectedConflicts: []string{
+				"redirect loop: rule at 0 -> rule at 2 -> rule at 0",
+				"rule at 1 redirects to 'https://example.com/a', which is redirected again by rule at 0",
+			},
+		},
+		"redirect to URL matched by the same rule": {
+			rules: []cloudlets.MatchRuleER{
+				redirect("example.com/*", "https://example.com/home"),
+			},
+			expectedConflicts: []string{"redirect loop: rule at 0 -> rule at 0"},
+		},
+		"redirect to other host": {
+			rules: []cloudlets.MatchRuleER{
+				redirect("example.com/*", "https://www.example.com/home"),
+			},
+		},
+		"redirect with regex groups": {
+			rules: []cloudlets.MatchRuleER{
+				redirect("example.com/*", `https://example.com/\1`),
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			sources := make([]edgeRedirectorRuleSource, 0, len(test.rules))
+			for i := range test.rules {
+				sources = append(sources, edgeRedirectorRuleSource{location: fmt.Sprint(i)})
+			}
+			assert.Equal(t, test.expectedConflicts, checkEdgeRedirectorRules(test.rules, sources))
+		})
+	}
+}
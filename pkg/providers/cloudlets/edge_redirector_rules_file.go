@@ -0,0 +1,226 @@
+package cloudlets
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v9/pkg/cloudlets"
+)
+
+type (
+	// edgeRedirectorFileRule is a single redirect read from a rules file
+	edgeRedirectorFileRule struct {
+		Name                   string `json:"name"`
+		MatchURL               string `json:"match_url"`
+		RedirectURL            string `json:"redirect_url"`
+		StatusCode             int    `json:"status_code"`
+		UseIncomingQueryString bool   `json:"use_incoming_query_string"`
+		UseRelativeURL         string `json:"use_relative_url"`
+		Disabled               bool   `json:"disabled"`
+	}
+
+	// edgeRedirectorRuleSource describes where a rule was defined, for diagnostics
+	edgeRedirectorRuleSource struct {
+		name     string
+		location string
+	}
+)
+
+var (
+	// ErrRulesFile is returned when the rules file cannot be read or contains invalid rules
+	ErrRulesFile = errors.New("rules file")
+
+	edgeRedirectorFileColumns = map[string]bool{
+		"name":                      true,
+		"match_url":                 true,
+		"redirect_url":              true,
+		"status_code":               true,
+		"use_incoming_query_string": true,
+		"use_relative_url":          true,
+		"disabled":                  true,
+	}
+)
+
+// defaultRedirectStatusCode is used for rules of a rules file without a status code
+const defaultRedirectStatusCode = 301
+
+func (s edgeRedirectorRuleSource) String() string {
+	if s.name != "" {
+		return fmt.Sprintf("rule %q (%s)", s.name, s.location)
+	}
+	return "rule at " + s.location
+}
+
+// loadEdgeRedirectorRulesFile reads Edge Redirector rules from a CSV file with a header row,
+// or from a JSON file with an array of objects, both using the same column names
+func loadEdgeRedirectorRulesFile(path string) ([]cloudlets.MatchRuleER, []edgeRedirectorRuleSource, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: %s", ErrRulesFile, err)
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+
+	var rules []edgeRedirectorFileRule
+	var locations []string
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".csv":
+		rules, locations, err = parseEdgeRedirectorCSV(f)
+	case ".json":
+		rules, locations, err = parseEdgeRedirectorJSON(f)
+	default:
+		return nil, nil, fmt.Errorf("%w: unsupported file extension '%s', must be one of: '.csv', '.json'", ErrRulesFile, filepath.Ext(path))
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: %s", ErrRulesFile, err)
+	}
+
+	result := make([]cloudlets.MatchRuleER, 0, len(rules))
+	sources := make([]edgeRedirectorRuleSource, 0, len(rules))
+	for i, rule := range rules {
+		matchRule, err := rule.toMatchRule()
+		if err != nil {
+			return nil, nil, fmt.Errorf("%w: %s: %s", ErrRulesFile, locations[i], err)
+		}
+		result = append(result, matchRule)
+		sources = append(sources, edgeRedirectorRuleSource{name: rule.Name, location: "rules_file " + locations[i]})
+	}
+	return result, sources, nil
+}
+
+func parseEdgeRedirectorCSV(r io.Reader) ([]edgeRedirectorFileRule, []string, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, nil, nil
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	columns := make([]string, 0, len(header))
+	for _, column := range header {
+		column = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(column, "\ufeff")))
+		if !edgeRedirectorFileColumns[column] {
+			return nil, nil, fmt.Errorf("line 1: unknown column '%s'", column)
+		}
+		columns = append(columns, column)
+	}
+
+	var rules []edgeRedirectorFileRule
+	var locations []string
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			return rules, locations, nil
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+		line, _ := reader.FieldPos(0)
+		rule, err := parseEdgeRedirectorCSVRecord(columns, record)
+		if err != nil {
+			return nil, nil, fmt.Errorf("line %d: %s", line, err)
+		}
+		rules = append(rules, rule)
+		locations = append(locations, fmt.Sprintf("line %d", line))
+	}
+}
+
+func parseEdgeRedirectorCSVRecord(columns, record []string) (edgeRedirectorFileRule, error) {
+	var rule edgeRedirectorFileRule
+	for i, column := range columns {
+		value := strings.TrimSpace(record[i])
+		var err error
+		switch column {
+		case "name":
+			rule.Name = value
+		case "match_url":
+			rule.MatchURL = value
+		case "redirect_url":
+			rule.RedirectURL = value
+		case "use_relative_url":
+			rule.UseRelativeURL = value
+		case "status_code":
+			if value != "" {
+				rule.StatusCode, err = strconv.Atoi(value)
+			}
+		case "use_incoming_query_string":
+			rule.UseIncomingQueryString, err = parseCSVBool(value)
+		case "disabled":
+			rule.Disabled, err = parseCSVBool(value)
+		}
+		if err != nil {
+			return rule, fmt.Errorf("invalid value '%s' of column '%s'", value, column)
+		}
+	}
+	return rule, nil
+}
+
+func parseCSVBool(value string) (bool, error) {
+	if value == "" {
+		return false, nil
+	}
+	return strconv.ParseBool(value)
+}
+
+func parseEdgeRedirectorJSON(r io.Reader) ([]edgeRedirectorFileRule, []string, error) {
+	decoder := json.NewDecoder(r)
+	decoder.DisallowUnknownFields()
+	var rules []edgeRedirectorFileRule
+	if err := decoder.Decode(&rules); err != nil {
+		return nil, nil, err
+	}
+	locations := make([]string, 0, len(rules))
+	for i := range rules {
+		locations = append(locations, fmt.Sprintf("item %d", i+1))
+	}
+	return rules, locations, nil
+}
+
+func (r edgeRedirectorFileRule) toMatchRule() (cloudlets.MatchRuleER, error) {
+	if r.MatchURL == "" {
+		return cloudlets.MatchRuleER{}, fmt.Errorf("'match_url' is required")
+	}
+	if r.RedirectURL == "" {
+		return cloudlets.MatchRuleER{}, fmt.Errorf("'redirect_url' is required")
+	}
+	statusCode := r.StatusCode
+	if statusCode == 0 {
+		statusCode = defaultRedirectStatusCode
+	}
+	switch statusCode {
+	case 301, 302, 303, 307, 308:
+	default:
+		return cloudlets.MatchRuleER{}, fmt.Errorf("invalid 'status_code' %d, must be one of: 301, 302, 303, 307, 308", statusCode)
+	}
+	useRelativeURL := r.UseRelativeURL
+	if useRelativeURL == "" && strings.HasPrefix(r.RedirectURL, "/") {
+		useRelativeURL = "copy_scheme_hostname"
+	}
+	switch useRelativeURL {
+	case "", "none", "relative_url", "copy_scheme_hostname":
+	default:
+		return cloudlets.MatchRuleER{}, fmt.Errorf("invalid 'use_relative_url' '%s', must be one of: 'none', 'relative_url', 'copy_scheme_hostname'", useRelativeURL)
+	}
+
+	return cloudlets.MatchRuleER{
+		Name:                     r.Name,
+		Type:                     cloudlets.MatchRuleTypeER,
+		MatchURL:                 r.MatchURL,
+		RedirectURL:              r.RedirectURL,
+		StatusCode:               statusCode,
+		UseRelativeURL:           useRelativeURL,
+		UseIncomingQueryString:   r.UseIncomingQueryString,
+		UseIncomingSchemeAndHost: useRelativeURL == "copy_scheme_hostname",
+		Disabled:                 r.Disabled,
+	}, nil
+}
@@ -0,0 +1,122 @@
+package cloudlets
+
+import (
+	"testing"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v9/pkg/cloudlets"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadEdgeRedirectorRulesFile(t *testing.T) {
+	tests := map[string]struct {
+		path            string
+		expectedRules   []cloudlets.MatchRuleER
+		expectedSources []edgeRedirectorRuleSource
+		expectedError   string
+	}{
+		"csv": {
+			path: "testdata/TestLoadEdgeRedirectorRulesFile/rules.csv",
+			expectedRules: []cloudlets.MatchRuleER{
+				{
+					Name:                   "old-home",
+					Type:                   cloudlets.MatchRuleTypeER,
+					MatchURL:               "example.com/old",
+					RedirectURL:            "https://example.com/new",
+					StatusCode:             302,
+					UseIncomingQueryString: true,
+				},
+				{
+					Type:                     cloudlets.MatchRuleTypeER,
+					MatchURL:                 "example.com/a",
+					RedirectURL:              "/b",
+					StatusCode:               301,
+					UseRelativeURL:           "copy_scheme_hostname",
+					UseIncomingSchemeAndHost: true,
+				},
+				{
+					Name:           "quoted, name",
+					Type:           cloudlets.MatchRuleTypeER,
+					MatchURL:       "/c",
+					RedirectURL:    "/d",
+					StatusCode:     308,
+					UseRelativeURL: "relative_url",
+				},
+			},
+			expectedSources: []edgeRedirectorRuleSource{
+				{name: "old-home", location: "rules_file line 2"},
+				{location: "rules_file line 3"},
+				{name: "quoted, name", location: "rules_file line 4"},
+			},
+		},
+		"json": {
+			path: "testdata/TestLoadEdgeRedirectorRulesFile/rules.json",
+			expectedRules: []cloudlets.MatchRuleER{
+				{
+					Name:                   "old-home",
+					Type:                   cloudlets.MatchRuleTypeER,
+					MatchURL:               "example.com/old",
+					RedirectURL:            "https://example.com/new",
+					StatusCode:             302,
+					UseIncomingQueryString: true,
+				},
+				{
+					Type:                     cloudlets.MatchRuleTypeER,
+					MatchURL:                 "example.com/a",
+					RedirectURL:              "/b",
+					StatusCode:               301,
+					UseRelativeURL:           "copy_scheme_hostname",
+					UseIncomingSchemeAndHost: true,
+					Disabled:                 true,
+				},
+			},
+			expectedSources: []edgeRedirectorRuleSource{
+				{name: "old-home", location: "rules_file item 1"},
+				{location: "rules_file item 2"},
+			},
+		},
+		"invalid status code": {
+			path:          "testdata/TestLoadEdgeRedirectorRulesFile/invalid_status.csv",
+			expectedError: "line 3: invalid 'status_code' 200",
+		},
+		"unknown column": {
+			path:          "testdata/TestLoadEdgeRedirectorRulesFile/unknown_column.csv",
+			expectedError: "line 1: unknown column 'target_url'",
+		},
+		"invalid boolean": {
+			path:          "testdata/TestLoadEdgeRedirectorRulesFile/invalid_bool.csv",
+			expectedError: "line 2: invalid value 'maybe' of column 'use_incoming_query_string'",
+		},
+		"missing redirect URL": {
+			path:          "testdata/TestLoadEdgeRedirectorRulesFile/missing_redirect.json",
+			expectedError: "item 1: 'redirect_url' is required",
+		},
+		"unknown field": {
+			path:          "testdata/TestLoadEdgeRedirectorRulesFile/unknown_field.json",
+			expectedError: `unknown field "query"`,
+		},
+		"unsupported extension": {
+			path:          "testdata/TestLoadEdgeRedirectorRulesFile/rules.txt",
+			expectedError: "unsupported file extension '.txt'",
+		},
+		"missing file": {
+			path:          "testdata/TestLoadEdgeRedirectorRulesFile/missing.csv",
+			expectedError: "no such file or directory",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			rules, sources, err := loadEdgeRedirectorRulesFile(test.path)
+			if test.expectedError != "" {
+				require.Error(t, err)
+				assert.ErrorIs(t, err, ErrRulesFile)
+				assert.Contains(t, err.Error(), test.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedRules, rules)
+			assert.Equal(t, test.expectedSources, sources)
+		})
+	}
+}
@@ -3,9 +3,9 @@ package cloudlets
 import (
 	"crypto/sha1"
 	"encoding/hex"
+	"encoding/json"
 	"errors"
 	"fmt"
-	"io"
 	"strconv"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
@@ -15,26 +15,45 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
+const (
+	// maxMatchRulesSize is the maximum size in bytes of the JSON of the match rules of a policy version accepted by the API
+	maxMatchRulesSize = 5 * 1024 * 1024
+	// matchRulesWarningPercent is the share of maxMatchRulesSize from which a warning about the size of the rules is reported
+	matchRulesWarningPercent = 90
+)
+
 type (
 	// objectMatchValueHandler is type alias function for casting ObjectMatchValue into a specified type
 	objectMatchValueHandler func(map[string]interface{}, string) (interface{}, error)
 )
 
+// matchRulesSizeDiagnostics warns when the JSON of the match rules reaches matchRulesWarningPercent of the size limit
+// of a policy version. Rules exceeding the limit are left to be rejected by the API
+func matchRulesSizeDiagnostics(matchRules cloudlets.MatchRules) (diag.Diagnostics, error) {
+	body, err := json.Marshal(matchRules)
+	if err != nil {
+		return nil, err
+	}
+	if len(body) < maxMatchRulesSize*matchRulesWarningPercent/100 {
+		return nil, nil
+	}
+	return diag.Diagnostics{{
+		Severity: diag.Warning,
+		Summary:  fmt.Sprintf("The match rules have %d bytes, which is close to or above the limit of %d bytes of a policy version", len(body), maxMatchRulesSize),
+	}}, nil
+}
+
+// getMatchRulesHashID returns a hash of the match rules, which changes with any change of the rules
 func getMatchRulesHashID(matchRules cloudlets.MatchRules) (string, error) {
-	id := "id"
-	for _, rule := range matchRules {
-		switch r := rule.(type) {
-		case cloudlets.MatchRuleER:
-			id = id + ":" + r.Name
-		}
+	body, err := json.Marshal(matchRules)
+	if err != nil {
+		return "", err
 	}
 	h := sha1.New()
-	_, err := io.WriteString(h, id)
-	if err != nil {
+	if _, err := h.Write(body); err != nil {
 		return "", err
 	}
-	hashID := hex.EncodeToString(h.Sum(nil))
-	return hashID, nil
+	return hex.EncodeToString(h.Sum(nil)), nil
 }
 
 func getStringValue(matchRuleMap map[string]interface{}, name string) string {
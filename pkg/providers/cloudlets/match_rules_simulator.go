@@ -0,0 +1,650 @@
+package cloudlets
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/netip"
+	"net/url"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type (
+	// simulatedRequest is a test request evaluated against match rules
+	simulatedRequest struct {
+		url                   *url.URL
+		method                string
+		headers               map[string]string
+		cookies               map[string]string
+		clientIP              string
+		continent             string
+		countryCode           string
+		regionCode            string
+		proxy                 string
+		deviceCharacteristics map[string]string
+		rangeValue            int
+		time                  time.Time
+	}
+
+	// simulationResult is the outcome of evaluating a request against match rules
+	simulationResult struct {
+		matched      bool
+		ruleName     string
+		rulePosition int
+		redirectURL  string
+		statusCode   int
+		originID     string
+		forwardPath  string
+	}
+
+	// simulatorRules are match rules prepared for evaluation
+	simulatorRules []simulatorRule
+
+	simulatorRule struct {
+		position int
+		rule     simulatorRuleJSON
+		matchURL *urlMatcher
+		matches  []simulatorMatch
+	}
+
+	simulatorMatch struct {
+		matchType string
+		operator  string
+		negate    bool
+		checkIPs  string
+		name      *regexp.Regexp
+		values    []*regexp.Regexp
+		prefixes  []netip.Prefix
+		rangeFrom float64
+		rangeTo   float64
+		isRange   bool
+		regex     *regexp.Regexp
+	}
+
+	urlMatcher struct {
+		pattern    *regexp.Regexp
+		withScheme bool
+		pathOnly   bool
+		withQuery  bool
+	}
+
+	simulatorRuleJSON struct {
+		Name                     string                  `json:"name"`
+		Type                     string                  `json:"type"`
+		Start                    int64                   `json:"start"`
+		End                      int64                   `json:"end"`
+		Disabled                 bool                    `json:"disabled"`
+		MatchesAlways            bool                    `json:"matchesAlways"`
+		MatchURL                 string                  `json:"matchURL"`
+		Matches                  []simulatorMatchJSON    `json:"matches"`
+		RedirectURL              string                  `json:"redirectURL"`
+		StatusCode               int                     `json:"statusCode"`
+		UseRelativeURL           string                  `json:"useRelativeUrl"`
+		UseIncomingQueryString   bool                    `json:"useIncomingQueryString"`
+		UseIncomingSchemeAndHost bool                    `json:"useIncomingSchemeAndHost"`
+		ForwardSettings          *simulatorForwardConfig `json:"forwardSettings"`
+	}
+
+	simulatorForwardConfig struct {
+		OriginID               string `json:"originId"`
+		PathAndQS              string `json:"pathAndQS"`
+		UseIncomingQueryString bool   `json:"useIncomingQueryString"`
+	}
+
+	simulatorMatchJSON struct {
+		MatchType        string                    `json:"matchType"`
+		MatchValue       string                    `json:"matchValue"`
+		MatchOperator    string                    `json:"matchOperator"`
+		CaseSensitive    bool                      `json:"caseSensitive"`
+		Negate           bool                      `json:"negate"`
+		CheckIPs         string                    `json:"checkIPs"`
+		ObjectMatchValue *simulatorObjectMatchJSON `json:"objectMatchValue"`
+	}
+
+	simulatorObjectMatchJSON struct {
+		Type              string        `json:"type"`
+		Name              string        `json:"name"`
+		NameCaseSensitive bool          `json:"nameCaseSensitive"`
+		NameHasWildcard   bool          `json:"nameHasWildcard"`
+		Value             []interface{} `json:"value"`
+		Options           *struct {
+			Value              []string `json:"value"`
+			ValueHasWildcard   bool     `json:"valueHasWildcard"`
+			ValueCaseSensitive bool     `json:"valueCaseSensitive"`
+			ValueEscaped       bool     `json:"valueEscaped"`
+		} `json:"options"`
+	}
+)
+
+var (
+	// ErrMatchRulesSimulation is returned when match rules cannot be simulated
+	ErrMatchRulesSimulation = errors.New("match rules simulation")
+
+	// namedMatchTypes are match types which compare a named attribute of the request, like a header
+	namedMatchTypes = map[string]bool{
+		"header":                true,
+		"cookie":                true,
+		"query":                 true,
+		"deviceCharacteristics": true,
+	}
+)
+
+// parseSimulatorRules prepares match rules given as a JSON array, as rendered by the match rule data sources,
+// or as a policy version object with the 'matchRules' field, for evaluation
+func parseSimulatorRules(rulesJSON string) (simulatorRules, error) {
+	var rules []simulatorRuleJSON
+	trimmed := strings.TrimSpace(rulesJSON)
+	if strings.HasPrefix(trimmed, "{") {
+		var version struct {
+			MatchRules []simulatorRuleJSON `json:"matchRules"`
+		}
+		if err := json.Unmarshal([]byte(trimmed), &version); err != nil {
+			return nil, fmt.Errorf("%w: invalid match rules: %s", ErrMatchRulesSimulation, err)
+		}
+		rules = version.MatchRules
+	} else if err := json.Unmarshal([]byte(trimmed), &rules); err != nil {
+		return nil, fmt.Errorf("%w: invalid match rules: %s", ErrMatchRulesSimulation, err)
+	}
+
+	result := make(simulatorRules, 0, len(rules))
+	for i, rule := range rules {
+		prepared, err := prepareSimulatorRule(i+1, rule)
+		if err != nil {
+			return nil, fmt.Errorf("%w: rule %d (%q): %s", ErrMatchRulesSimulation, i+1, rule.Name, err)
+		}
+		result = append(result, prepared)
+	}
+	return result, nil
+}
+
+func prepareSimulatorRule(position int, rule simulatorRuleJSON) (simulatorRule, error) {
+	result := simulatorRule{position: position, rule: rule}
+	if rule.MatchURL != "" {
+		matcher, err := compileMatchURL(rule.MatchURL)
+		if err != nil {
+			return result, err
+		}
+		result.matchURL = matcher
+	}
+	for i, m := range rule.Matches {
+		match, err := prepareSimulatorMatch(m)
+		if err != nil {
+			return result, fmt.Errorf("match %d: %s", i+1, err)
+		}
+		result.matches = append(result.matches, match)
+	}
+	return result, nil
+}
+
+func prepareSimulatorMatch(m simulatorMatchJSON) (simulatorMatch, error) {
+	result := simulatorMatch{
+		matchType: m.MatchType,
+		operator:  m.MatchOperator,
+		negate:    m.Negate,
+		checkIPs:  m.CheckIPs,
+	}
+	if result.operator == "" {
+		result.operator = "equals"
+	}
+	switch result.operator {
+	case "equals", "contains", "exists":
+	default:
+		return result, fmt.Errorf("unsupported match operator '%s'", result.operator)
+	}
+
+	switch m.MatchType {
+	case "header", "cookie", "query", "deviceCharacteristics", "hostname", "path", "extension", "protocol", "method",
+		"continent", "countrycode", "regioncode", "proxy", "clientip", "range":
+	case "regex":
+		expr := m.MatchValue
+		if !m.CaseSensitive {
+			expr = "(?i)" + expr
+		}
+		re, err := regexp.Compile(expr)
+		if err != nil {
+			return result, fmt.Errorf("invalid regular expression '%s': %s", m.MatchValue, err)
+		}
+		result.regex = re
+		return result, nil
+	default:
+		return result, fmt.Errorf("unsupported match type '%s'", m.MatchType)
+	}
+
+	omv := m.ObjectMatchValue
+	switch {
+	case omv == nil:
+		return result, result.prepareMatchValue(m)
+	case omv.Type == "range":
+		if len(omv.Value) != 2 {
+			return result, fmt.Errorf("range must have exactly 2 values")
+		}
+		from, errFrom := toFloat(omv.Value[0])
+		to, errTo := toFloat(omv.Value[1])
+		if errFrom != nil || errTo != nil {
+			return result, fmt.Errorf("range values must be numbers")
+		}
+		result.isRange, result.rangeFrom, result.rangeTo = true, from, to
+		return result, nil
+	case omv.Type == "simple":
+		values := make([]string, 0, len(omv.Value))
+		for _, v := range omv.Value {
+			values = append(values, fmt.Sprint(v))
+		}
+		return result, result.prepareValues(values, true, m.CaseSensitive, false)
+	case omv.Type == "object":
+		if namedMatchTypes[m.MatchType] {
+			name, err := compileValuePattern(omv.Name, "equals", omv.NameHasWildcard, omv.NameCaseSensitive && m.MatchType != "header")
+			if err != nil {
+				return result, err
+			}
+			result.name = name
+		}
+		if omv.Options == nil {
+			return result, nil
+		}
+		return result, result.prepareValues(omv.Options.Value, omv.Options.ValueHasWildcard, omv.Options.ValueCaseSensitive, omv.Options.ValueEscaped)
+	default:
+		return result, fmt.Errorf("unsupported object match value type '%s'", omv.Type)
+	}
+}
+
+// prepareMatchValue handles the plain 'matchValue', which may hold several values separated by whitespace.
+// For named match types, each value has the form 'name=value', or just 'name' for the 'exists' operator
+func (m *simulatorMatch) prepareMatchValue(criteria simulatorMatchJSON) error {
+	fields := strings.Fields(criteria.MatchValue)
+	if !namedMatchTypes[m.matchType] {
+		return m.prepareValues(fields, true, criteria.CaseSensitive, false)
+	}
+	if len(fields) == 0 {
+		return fmt.Errorf("match type '%s' requires a name", m.matchType)
+	}
+
+	name, value, hasValue := strings.Cut(criteria.MatchValue, "=")
+	namePattern, err := compileValuePattern(strings.TrimSpace(name), "equals", true, criteria.CaseSensitive && m.matchType != "header")
+	if err != nil {
+		return err
+	}
+	m.name = namePattern
+	if !hasValue || m.operator == "exists" {
+		return nil
+	}
+	return m.prepareValues(strings.Fields(value), true, criteria.CaseSensitive, false)
+}
+
+func (m *simulatorMatch) prepareValues(values []string, wildcard, caseSensitive, escaped bool) error {
+	for _, value := range values {
+		if escaped {
+			if unescaped, err := url.QueryUnescape(value); err == nil {
+				value = unescaped
+			}
+		}
+		if m.matchType == "clientip" {
+			prefix, err := parseIPOrPrefix(value)
+			if err != nil {
+				return err
+			}
+			m.prefixes = append(m.prefixes, prefix)
+			continue
+		}
+		if m.matchType == "extension" {
+			value = strings.TrimPrefix(value, ".")
+		}
+		pattern, err := compileValuePattern(value, m.operator, wildcard, caseSensitive)
+		if err != nil {
+			return err
+		}
+		m.values = append(m.values, pattern)
+	}
+	return nil
+}
+
+// compileValuePattern converts a value with optional '*' and '?' wildcards to a regular expression.
+// Values of the 'contains' operator may match any part of the attribute
+func compileValuePattern(value, operator string, wildcard, caseSensitive bool) (*regexp.Regexp, error) {
+	expr := regexp.QuoteMeta(value)
+	if wildcard {
+		expr = strings.NewReplacer(`\*`, ".*", `\?`, ".").Replace(expr)
+	}
+	if operator != "contains" {
+		expr = "^" + expr + "$"
+	}
+	if !caseSensitive {
+		expr = "(?i)" + expr
+	}
+	return regexp.Compile("(?s)" + expr)
+}
+
+// compileMatchURL compiles the 'matchURL' of a rule. Depending on its form, it is compared with the path,
+// with the hostname and path, or with the scheme, hostname and path of the request.
+// The query string is compared only if the 'matchURL' contains one. Only '*' is treated as a wildcard
+func compileMatchURL(matchURL string) (*urlMatcher, error) {
+	result := &urlMatcher{
+		withScheme: strings.Contains(matchURL, "://"),
+		pathOnly:   strings.HasPrefix(matchURL, "/"),
+		withQuery:  strings.Contains(matchURL, "?"),
+	}
+	prefix, rest := "", matchURL
+	if result.withScheme {
+		scheme, remainder, _ := strings.Cut(matchURL, "://")
+		prefix, rest = strings.ToLower(scheme)+"://", remainder
+	}
+	if !result.pathOnly {
+		host, urlPath, found := strings.Cut(rest, "/")
+		prefix += strings.ToLower(host)
+		rest = ""
+		if found {
+			rest = "/" + urlPath
+		}
+	}
+	expr := strings.ReplaceAll(regexp.QuoteMeta(prefix+rest), `\*`, ".*")
+	pattern, err := regexp.Compile("^" + expr + "$")
+	if err != nil {
+		return nil, fmt.Errorf("invalid match URL '%s': %s", matchURL, err)
+	}
+	result.pattern = pattern
+	return result, nil
+}
+
+func (u *urlMatcher) match(req *simulatedRequest) bool {
+	subject := req.url.EscapedPath()
+	if subject == "" {
+		subject = "/"
+	}
+	if !u.pathOnly {
+		subject = strings.ToLower(req.url.Hostname()) + subject
+	}
+	if u.withScheme {
+		subject = strings.ToLower(req.url.Scheme) + "://" + subject
+	}
+	if u.withQuery && req.url.RawQuery != "" {
+		subject += "?" + req.url.RawQuery
+	}
+	if u.pattern.MatchString(subject) {
+		return true
+	}
+	// a match URL of a site root also matches the hostname without a path
+	isRoot := req.url.EscapedPath() == "" || req.url.EscapedPath() == "/"
+	return !u.pathOnly && !u.withQuery && isRoot && u.pattern.MatchString(strings.TrimSuffix(subject, "/"))
+}
+
+// evaluate returns the result of the first enabled and active rule matching the request
+func (r simulatorRules) evaluate(req *simulatedRequest) simulationResult {
+	for _, rule := range r {
+		groups, ok := rule.match(req)
+		if !ok {
+			continue
+		}
+		return rule.result(req, groups)
+	}
+	return simulationResult{}
+}
+
+// match reports whether the rule applies to the request. All criteria of a rule must match.
+// It also returns the groups captured by a 'regex' criterion, which can be referenced in the redirect URL
+func (r simulatorRule) match(req *simulatedRequest) ([]string, bool) {
+	if r.rule.Disabled {
+		return nil, false
+	}
+	now := req.time.Unix()
+	if (r.rule.Start > 0 && now < r.rule.Start) || (r.rule.End > 0 && now >= r.rule.End) {
+		return nil, false
+	}
+	if r.rule.MatchesAlways {
+		return nil, true
+	}
+	if r.matchURL == nil && len(r.matches) == 0 {
+		return nil, false
+	}
+	if r.matchURL != nil && !r.matchURL.match(req) {
+		return nil, false
+	}
+	var groups []string
+	for _, m := range r.matches {
+		captured, ok := m.match(req)
+		if !ok {
+			return nil, false
+		}
+		if captured != nil {
+			groups = captured
+		}
+	}
+	return groups, true
+}
+
+func (r simulatorRule) result(req *simulatedRequest, groups []string) simulationResult {
+	result := simulationResult{
+		matched:      true,
+		ruleName:     r.rule.Name,
+		rulePosition: r.position,
+		statusCode:   r.rule.StatusCode,
+	}
+	if r.rule.RedirectURL != "" {
+		redirect := substituteGroups(r.rule.RedirectURL, groups)
+		if r.rule.UseRelativeURL == "copy_scheme_hostname" || r.rule.UseIncomingSchemeAndHost {
+			redirect = req.url.Scheme + "://" + req.url.Host + redirect
+		}
+		if r.rule.UseIncomingQueryString {
+			redirect = appendQuery(redirect, req.url.RawQuery)
+		}
+		result.redirectURL = redirect
+	}
+	if fs := r.rule.ForwardSettings; fs != nil {
+		result.originID = fs.OriginID
+		if fs.PathAndQS != "" {
+			result.forwardPath = fs.PathAndQS
+			if fs.UseIncomingQueryString {
+				result.forwardPath = appendQuery(result.forwardPath, req.url.RawQuery)
+			}
+		}
+	}
+	return result
+}
+
+func (m simulatorMatch) match(req *simulatedRequest) ([]string, bool) {
+	if m.regex != nil {
+		groups := m.regex.FindStringSubmatch(req.url.String())
+		if m.negate {
+			return nil, groups == nil
+		}
+		return groups, groups != nil
+	}
+	matched := m.evaluate(req)
+	if m.negate {
+		return nil, !matched
+	}
+	return nil, matched
+}
+
+func (m simulatorMatch) evaluate(req *simulatedRequest) bool {
+	switch m.matchType {
+	case "header", "cookie", "query", "deviceCharacteristics":
+		for _, attr := range req.namedValues(m.matchType) {
+			if m.name != nil && !m.name.MatchString(attr[0]) {
+				continue
+			}
+			if m.operator == "exists" || m.matchValue(attr[1]) {
+				return true
+			}
+		}
+		return false
+	case "clientip":
+		for _, ip := range req.clientIPs(m.checkIPs) {
+			if m.operator == "exists" || m.matchValue(ip) {
+				return true
+			}
+		}
+		return false
+	default:
+		value := req.value(m.matchType)
+		if m.operator == "exists" {
+			return value != ""
+		}
+		return m.matchValue(value)
+	}
+}
+
+func (m simulatorMatch) matchValue(value string) bool {
+	if m.isRange {
+		number, err := strconv.ParseFloat(value, 64)
+		return err == nil && number >= m.rangeFrom && number <= m.rangeTo
+	}
+	if m.matchType == "clientip" {
+		addr, err := netip.ParseAddr(value)
+		if err != nil {
+			return false
+		}
+		for _, prefix := range m.prefixes {
+			if prefix.Contains(addr.Unmap()) {
+				return true
+			}
+		}
+		return false
+	}
+	for _, pattern := range m.values {
+		if pattern.MatchString(value) {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *simulatedRequest) value(matchType string) string {
+	switch matchType {
+	case "hostname":
+		return r.url.Hostname()
+	case "path":
+		if r.url.EscapedPath() == "" {
+			return "/"
+		}
+		return r.url.EscapedPath()
+	case "extension":
+		return strings.TrimPrefix(path.Ext(r.url.Path), ".")
+	case "protocol":
+		return r.url.Scheme
+	case "method":
+		return r.method
+	case "continent":
+		return r.continent
+	case "countrycode":
+		return r.countryCode
+	case "regioncode":
+		return r.regionCode
+	case "proxy":
+		return r.proxy
+	case "range":
+		if r.rangeValue == 0 {
+			return ""
+		}
+		return strconv.Itoa(r.rangeValue)
+	}
+	return ""
+}
+
+// namedValues returns name and value pairs of the request attribute of a named match type
+func (r *simulatedRequest) namedValues(matchType string) [][2]string {
+	var result [][2]string
+	switch matchType {
+	case "query":
+		for name, values := range r.url.Query() {
+			for _, value := range values {
+				result = append(result, [2]string{name, value})
+			}
+		}
+		return result
+	case "header":
+		return mapToPairs(r.headers)
+	case "cookie":
+		return mapToPairs(r.cookies)
+	case "deviceCharacteristics":
+		return mapToPairs(r.deviceCharacteristics)
+	}
+	return nil
+}
+
+// clientIPs returns the addresses checked by the 'clientip' match type. The connecting IP is used
+// unless the criterion checks only the X-Forwarded-For header
+func (r *simulatedRequest) clientIPs(checkIPs string) []string {
+	var result []string
+	if checkIPs == "" || strings.Contains(checkIPs, "CONNECTING_IP") {
+		if r.clientIP != "" {
+			result = append(result, r.clientIP)
+		}
+	}
+	if strings.Contains(checkIPs, "XFF_HEADERS") {
+		for name, value := range r.headers {
+			if !strings.EqualFold(name, "X-Forwarded-For") {
+				continue
+			}
+			for _, ip := range strings.Split(value, ",") {
+				if ip = strings.TrimSpace(ip); ip != "" {
+					result = append(result, ip)
+				}
+			}
+		}
+	}
+	return result
+}
+
+func mapToPairs(values map[string]string) [][2]string {
+	result := make([][2]string, 0, len(values))
+	for name, value := range values {
+		result = append(result, [2]string{name, value})
+	}
+	return result
+}
+
+func parseIPOrPrefix(value string) (netip.Prefix, error) {
+	if strings.Contains(value, "/") {
+		prefix, err := netip.ParsePrefix(value)
+		if err != nil {
+			return netip.Prefix{}, fmt.Errorf("invalid client IP '%s': %s", value, err)
+		}
+		return prefix.Masked(), nil
+	}
+	addr, err := netip.ParseAddr(value)
+	if err != nil {
+		return netip.Prefix{}, fmt.Errorf("invalid client IP '%s': %s", value, err)
+	}
+	addr = addr.Unmap()
+	return netip.PrefixFrom(addr, addr.BitLen()), nil
+}
+
+var groupReference = regexp.MustCompile(`\\(\d)`)
+
+// substituteGroups replaces the '\1' to '\9' references in the redirect URL with the groups captured by a regex match
+func substituteGroups(redirectURL string, groups []string) string {
+	if groups == nil {
+		return redirectURL
+	}
+	return groupReference.ReplaceAllStringFunc(redirectURL, func(ref string) string {
+		index := int(ref[1] - '0')
+		if index < len(groups) {
+			return groups[index]
+		}
+		return ""
+	})
+}
+
+func appendQuery(target, query string) string {
+	if query == "" {
+		return target
+	}
+	if strings.Contains(target, "?") {
+		return target + "&" + query
+	}
+	return target + "?" + query
+}
+
+func toFloat(value interface{}) (float64, error) {
+	switch v := value.(type) {
+	case float64:
+		return v, nil
+	case string:
+		return strconv.ParseFloat(v, 64)
+	}
+	return 0, fmt.Errorf("invalid number: %v", value)
+}
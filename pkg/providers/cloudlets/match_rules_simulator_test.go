@@ -0,0 +1,275 @@
+package cloudlets
+
+import (
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMatchRulesSimulator(t *testing.T) {
+	newRequest := func(rawURL string, modify ...func(*simulatedRequest)) *simulatedRequest {
+		u, err := url.Parse(rawURL)
+		require.NoError(t, err)
+		req := &simulatedRequest{url: u, method: "GET", time: time.Unix(1700000000, 0)}
+		for _, m := range modify {
+			m(req)
+		}
+		return req
+	}
+
+	tests := map[string]struct {
+		rules        string
+		request      *simulatedRequest
+		expectedRule string
+	}{
+		"match URL with wildcard": {
+			rules:        `[{"name": "r1", "matchURL": "example.com/images/*.png"}]`,
+			request:      newRequest("https://EXAMPLE.com/images/a/b.png"),
+			expectedRule: "r1",
+		},
+		"match URL path is case sensitive": {
+			rules:   `[{"name": "r1", "matchURL": "example.com/Images/*"}]`,
+			request: newRequest("https://example.com/images/a.png"),
+		},
+		"match URL with scheme": {
+			rules:        `[{"name": "r1", "matchURL": "http://example.com/*"}, {"name": "r2", "matchURL": "https://example.com/*"}]`,
+			request:      newRequest("https://example.com/a"),
+			expectedRule: "r2",
+		},
+		"match URL of site root": {
+			rules:        `[{"name": "r1", "matchURL": "example.com"}]`,
+			request:      newRequest("https://example.com/"),
+			expectedRule: "r1",
+		},
+		"match URL with path only ignores query": {
+			rules:        `[{"name": "r1", "matchURL": "/a"}]`,
+			request:      newRequest("https://example.com/a?b=c"),
+			expectedRule: "r1",
+		},
+		"first matching rule wins": {
+			rules:        `[{"name": "r1", "matchURL": "/a"}, {"name": "r2", "matchURL": "/*"}]`,
+			request:      newRequest("https://example.com/a"),
+			expectedRule: "r1",
+		},
+		"rule not started yet": {
+			rules:        `[{"name": "r1", "start": 1800000000, "matchesAlways": true}, {"name": "r2", "matchesAlways": true}]`,
+			request:      newRequest("https://example.com/a"),
+			expectedRule: "r2",
+		},
+		"all criteria must match": {
+			rules: `[{"name": "r1", "matches": [
+				{"matchType": "method", "matchValue": "POST"},
+				{"matchType": "path", "matchValue": "/a"}]}]`,
+			request: newRequest("https://example.com/a"),
+		},
+		"match value with multiple values and wildcard": {
+			rules:        `[{"name": "r1", "matches": [{"matchType": "hostname", "matchValue": "example.org *.example.com"}]}]`,
+			request:      newRequest("https://www.example.com/a"),
+			expectedRule: "r1",
+		},
+		"case sensitive match value": {
+			rules:   `[{"name": "r1", "matches": [{"matchType": "path", "matchValue": "/A", "caseSensitive": true}]}]`,
+			request: newRequest("https://example.com/a"),
+		},
+		"case insensitive match value": {
+			rules:        `[{"name": "r1", "matches": [{"matchType": "path", "matchValue": "/A"}]}]`,
+			request:      newRequest("https://example.com/a"),
+			expectedRule: "r1",
+		},
+		"contains operator": {
+			rules:        `[{"name": "r1", "matches": [{"matchType": "path", "matchOperator": "contains", "matchValue": "sale"}]}]`,
+			request:      newRequest("https://example.com/summer-sale/shoes"),
+			expectedRule: "r1",
+		},
+		"negated criterion": {
+			rules:        `[{"name": "r1", "matches": [{"matchType": "extension", "matchValue": "jpg png", "negate": true}]}]`,
+			request:      newRequest("https://example.com/a.gif"),
+			expectedRule: "r1",
+		},
+		"extension": {
+			rules:        `[{"name": "r1", "matches": [{"matchType": "extension", "matchValue": ".gif"}]}]`,
+			request:      newRequest("https://example.com/a.gif"),
+			expectedRule: "r1",
+		},
+		"query in match value": {
+			rules:        `[{"name": "r1", "matches": [{"matchType": "query", "matchValue": "utm_source=news*"}]}]`,
+			request:      newRequest("https://example.com/?a=b&utm_source=newsletter"),
+			expectedRule: "r1",
+		},
+		"header exists": {
+			rules: `[{"name": "r1", "matches": [{"matchType": "header", "matchOperator": "exists", "matchValue": "X-Debug"}]}]`,
+			request: newRequest("https://example.com/", func(r *simulatedRequest) {
+				r.headers = map[string]string{"x-debug": "1"}
+			}),
+			expectedRule: "r1",
+		},
+		"negated cookie exists": {
+			rules: `[{"name": "r1", "matches": [{"matchType": "cookie", "matchOperator": "exists", "negate": true,
+				"objectMatchValue": {"type": "object", "name": "session"}}]}]`,
+			request: newRequest("https://example.com/", func(r *simulatedRequest) {
+				r.cookies = map[string]string{"session": "abc"}
+			}),
+		},
+		"object match value with wildcards in name": {
+			rules: `[{"name": "r1", "matches": [{"matchType": "cookie", "objectMatchValue": {"type": "object",
+				"name": "ab_*", "nameHasWildcard": true, "options": {"value": ["B"], "valueCaseSensitive": true}}}]}]`,
+			request: newRequest("https://example.com/", func(r *simulatedRequest) {
+				r.cookies = map[string]string{"ab_test": "B"}
+			}),
+			expectedRule: "r1",
+		},
+		"object match value is case sensitive": {
+			rules: `[{"name": "r1", "matches": [{"matchType": "cookie", "objectMatchValue": {"type": "object",
+				"name": "ab", "options": {"value": ["B"], "valueCaseSensitive": true}}}]}]`,
+			request: newRequest("https://example.com/", func(r *simulatedRequest) {
+				r.cookies = map[string]string{"ab": "b"}
+			}),
+		},
+		"object match value with escaped value": {
+			rules: `[{"name": "r1", "matches": [{"matchType": "query", "objectMatchValue": {"type": "object",
+				"name": "q", "options": {"value": ["a%20b"], "valueEscaped": true}}}]}]`,
+			request:      newRequest("https://example.com/?q=a%20b"),
+			expectedRule: "r1",
+		},
+		"simple match value": {
+			rules:        `[{"name": "r1", "matches": [{"matchType": "method", "objectMatchValue": {"type": "simple", "value": ["PUT", "GET"]}}]}]`,
+			request:      newRequest("https://example.com/"),
+			expectedRule: "r1",
+		},
+		"client IP in CIDR block": {
+			rules: `[{"name": "r1", "matches": [{"matchType": "clientip", "matchValue": "10.0.0.0/8 192.0.2.1"}]}]`,
+			request: newRequest("https://example.com/", func(r *simulatedRequest) {
+				r.clientIP = "10.1.2.3"
+			}),
+			expectedRule: "r1",
+		},
+		"client IP from X-Forwarded-For": {
+			rules: `[{"name": "r1", "matches": [{"matchType": "clientip", "matchValue": "192.0.2.1", "checkIPs": "XFF_HEADERS"}]}]`,
+			request: newRequest("https://example.com/", func(r *simulatedRequest) {
+				r.clientIP = "192.0.2.1"
+				r.headers = map[string]string{"X-Forwarded-For": "198.51.100.7, 203.0.113.1"}
+			}),
+		},
+		"geo": {
+			rules: `[{"name": "r1", "matches": [{"matchType": "continent", "matchValue": "EU"}, {"matchType": "regioncode", "matchValue": "BY"}]}]`,
+			request: newRequest("https://example.com/", func(r *simulatedRequest) {
+				r.continent, r.regionCode = "EU", "BY"
+			}),
+			expectedRule: "r1",
+		},
+		"device characteristics": {
+			rules: `[{"name": "r1", "matches": [{"matchType": "deviceCharacteristics", "objectMatchValue": {"type": "object",
+				"name": "is_mobile", "options": {"value": ["true"]}}}]}]`,
+			request: newRequest("https://example.com/", func(r *simulatedRequest) {
+				r.deviceCharacteristics = map[string]string{"is_mobile": "TRUE"}
+			}),
+			expectedRule: "r1",
+		},
+		"range": {
+			rules: `[{"name": "r1", "matches": [{"matchType": "range", "objectMatchValue": {"type": "range", "value": [1, 25]}}]},
+				{"name": "r2", "matches": [{"matchType": "range", "objectMatchValue": {"type": "range", "value": [26, 100]}}]}]`,
+			request: newRequest("https://example.com/", func(r *simulatedRequest) {
+				r.rangeValue = 26
+			}),
+			expectedRule: "r2",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			rules, err := parseSimulatorRules(test.rules)
+			require.NoError(t, err)
+
+			result := rules.evaluate(test.request)
+			assert.Equal(t, test.expectedRule != "", result.matched)
+			assert.Equal(t, test.expectedRule, result.ruleName)
+		})
+	}
+}
+
+func TestMatchRulesSimulatorResult(t *testing.T) {
+	tests := map[string]struct {
+		rule     string
+		url      string
+		expected simulationResult
+	}{
+		"redirect with relative URL": {
+			rule: `{"name": "r1", "matchesAlways": true, "redirectURL": "/b?x=1", "statusCode": 301,
+				"useRelativeUrl": "relative_url", "useIncomingQueryString": true}`,
+			url:      "https://example.com/a?y=2",
+			expected: simulationResult{matched: true, ruleName: "r1", rulePosition: 1, redirectURL: "/b?x=1&y=2", statusCode: 301},
+		},
+		"redirect with incoming scheme and hostname": {
+			rule:     `{"name": "r1", "matchesAlways": true, "redirectURL": "/b", "statusCode": 302, "useIncomingSchemeAndHost": true}`,
+			url:      "http://example.com/a?y=2",
+			expected: simulationResult{matched: true, ruleName: "r1", rulePosition: 1, redirectURL: "http://example.com/b", statusCode: 302},
+		},
+		"redirect with groups captured by regex": {
+			rule: `{"name": "r1", "matches": [{"matchType": "regex", "matchValue": "/old/([a-z]+)/(\\d+)"}],
+				"redirectURL": "https://example.com/new/\\2/\\1", "statusCode": 301}`,
+			url:      "https://example.com/old/shoes/42",
+			expected: simulationResult{matched: true, ruleName: "r1", rulePosition: 1, redirectURL: "https://example.com/new/42/shoes", statusCode: 301},
+		},
+		"forward": {
+			rule: `{"name": "r1", "matchesAlways": true,
+				"forwardSettings": {"originId": "o1", "pathAndQS": "/new", "useIncomingQueryString": true}}`,
+			url:      "https://example.com/a?y=2",
+			expected: simulationResult{matched: true, ruleName: "r1", rulePosition: 1, originID: "o1", forwardPath: "/new?y=2"},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			rules, err := parseSimulatorRules("[" + test.rule + "]")
+			require.NoError(t, err)
+			u, err := url.Parse(test.url)
+			require.NoError(t, err)
+
+			assert.Equal(t, test.expected, rules.evaluate(&simulatedRequest{url: u, time: time.Now()}))
+		})
+	}
+}
+
+func TestParseSimulatorRulesErrors(t *testing.T) {
+	tests := map[string]struct {
+		rules         string
+		expectedError string
+	}{
+		"invalid JSON": {
+			rules:         `[{`,
+			expectedError: "invalid match rules",
+		},
+		"unsupported match type": {
+			rules:         `[{"name": "r1", "matches": [{"matchType": "unknown"}]}]`,
+			expectedError: `rule 1 ("r1"): match 1: unsupported match type 'unknown'`,
+		},
+		"unsupported operator": {
+			rules:         `[{"name": "r1", "matches": [{"matchType": "path", "matchOperator": "startsWith"}]}]`,
+			expectedError: "unsupported match operator 'startsWith'",
+		},
+		"invalid client IP": {
+			rules:         `[{"name": "r1", "matches": [{"matchType": "clientip", "matchValue": "10.0.0.0/33"}]}]`,
+			expectedError: "invalid client IP '10.0.0.0/33'",
+		},
+		"invalid range": {
+			rules:         `[{"name": "r1", "matches": [{"matchType": "range", "objectMatchValue": {"type": "range", "value": [1]}}]}]`,
+			expectedError: "range must have exactly 2 values",
+		},
+		"header without name": {
+			rules:         `[{"name": "r1", "matches": [{"matchType": "header"}]}]`,
+			expectedError: "match type 'header' requires a name",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := parseSimulatorRules(test.rules)
+			require.Error(t, err)
+			assert.ErrorIs(t, err, ErrMatchRulesSimulation)
+			assert.Contains(t, err.Error(), test.expectedError)
+		})
+	}
+}
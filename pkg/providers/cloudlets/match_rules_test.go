@@ -2,6 +2,7 @@ package cloudlets
 
 import (
 	"regexp"
+	"strings"
 	"testing"
 
 	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v9/pkg/cloudlets"
@@ -138,3 +139,62 @@ func TestParseObjectMatchValue(t *testing.T) {
 		})
 	}
 }
+
+func TestGetMatchRulesHashID(t *testing.T) {
+	rules := cloudlets.MatchRules{
+		cloudlets.MatchRuleER{Type: cloudlets.MatchRuleTypeER, MatchURL: "example.com/a", RedirectURL: "/b", StatusCode: 301},
+	}
+	hash, err := getMatchRulesHashID(rules)
+	require.NoError(t, err)
+
+	sameHash, err := getMatchRulesHashID(cloudlets.MatchRules{
+		cloudlets.MatchRuleER{Type: cloudlets.MatchRuleTypeER, MatchURL: "example.com/a", RedirectURL: "/b", StatusCode: 301},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, hash, sameHash)
+
+	changedHash, err := getMatchRulesHashID(cloudlets.MatchRules{
+		cloudlets.MatchRuleER{Type: cloudlets.MatchRuleTypeER, MatchURL: "example.com/a", RedirectURL: "/c", StatusCode: 301},
+	})
+	require.NoError(t, err)
+	assert.NotEqual(t, hash, changedHash)
+}
+
+func TestMatchRulesSizeDiagnostics(t *testing.T) {
+	rulesOfSize := func(redirectURLLength int) cloudlets.MatchRules {
+		return cloudlets.MatchRules{
+			cloudlets.MatchRuleER{Type: cloudlets.MatchRuleTypeER, MatchURL: "example.com/a", RedirectURL: "/" + strings.Repeat("b", redirectURLLength), StatusCode: 301},
+		}
+	}
+
+	tests := map[string]struct {
+		rules           cloudlets.MatchRules
+		expectedWarning bool
+	}{
+		"small rules": {
+			rules: rulesOfSize(10),
+		},
+		"rules close to the limit": {
+			rules:           rulesOfSize(maxMatchRulesSize * 95 / 100),
+			expectedWarning: true,
+		},
+		"rules above the limit are left to the API": {
+			rules:           rulesOfSize(maxMatchRulesSize),
+			expectedWarning: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			diags, err := matchRulesSizeDiagnostics(test.rules)
+			require.NoError(t, err)
+			assert.False(t, diags.HasError())
+			if test.expectedWarning {
+				require.Len(t, diags, 1)
+				assert.Contains(t, diags[0].Summary, "close to or above the limit")
+			} else {
+				assert.Empty(t, diags)
+			}
+		})
+	}
+}
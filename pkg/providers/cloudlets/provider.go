@@ -52,8 +52,11 @@ func (p *Subprovider) SDKResources() map[string]*schema.Resource {
 	return map[string]*schema.Resource{
 		"akamai_cloudlets_application_load_balancer":            resourceCloudletsApplicationLoadBalancer(),
 		"akamai_cloudlets_application_load_balancer_activation": resourceCloudletsApplicationLoadBalancerActivation(),
+		"akamai_cloudlets_application_load_balancer_drain":      resourceCloudletsApplicationLoadBalancerDrain(),
 		"akamai_cloudlets_policy":                               resourceCloudletsPolicy(),
 		"akamai_cloudlets_policy_activation":                    resourceCloudletsPolicyActivation(),
+		"akamai_cloudlets_policy_ramp":                          resourceCloudletsPolicyRamp(),
+		"akamai_cloudlets_policy_version_promotion":             resourceCloudletsPolicyVersionPromotion(),
 	}
 }
 
@@ -66,6 +69,7 @@ func (p *Subprovider) SDKDataSources() map[string]*schema.Resource {
 		"akamai_cloudlets_audience_segmentation_match_rule":     dataSourceCloudletsAudienceSegmentationMatchRule(),
 		"akamai_cloudlets_edge_redirector_match_rule":           dataSourceCloudletsEdgeRedirectorMatchRule(),
 		"akamai_cloudlets_forward_rewrite_match_rule":           dataSourceCloudletsForwardRewriteMatchRule(),
+		"akamai_cloudlets_match_rule_simulator":                 dataSourceCloudletsMatchRuleSimulator(),
 		"akamai_cloudlets_phased_release_match_rule":            dataSourceCloudletsPhasedReleaseMatchRule(),
 		"akamai_cloudlets_request_control_match_rule":           dataSourceCloudletsRequestControlMatchRule(),
 		"akamai_cloudlets_visitor_prioritization_match_rule":    dataSourceCloudletsVisitorPrioritizationMatchRule(),
@@ -11,6 +11,7 @@ import (
 
 	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v9/pkg/cloudlets"
 	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v9/pkg/session"
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/common/activation"
 	"github.com/akamai/terraform-provider-akamai/v6/pkg/common/tf"
 	"github.com/akamai/terraform-provider-akamai/v6/pkg/common/timeouts"
 	"github.com/akamai/terraform-provider-akamai/v6/pkg/meta"
@@ -92,7 +93,7 @@ var (
 	ALBActivationPollInterval = ALBActivationPollMinimum
 
 	// ApplicationLoadBalancerActivationResourceTimeout is the default timeout for the resource operations
-	ApplicationLoadBalancerActivationResourceTimeout = time.Minute * 20
+	ApplicationLoadBalancerActivationResourceTimeout = activation.DefaultTimeout
 	// ApplicationLoadBalancerActivationRetryTimeout is the default timeout for the resource activation retries
 	ApplicationLoadBalancerActivationRetryTimeout = time.Minute * 10
 )
@@ -214,6 +215,32 @@ func resourceApplicationLoadBalancerActivationChange(ctx context.Context, rd *sc
 	}
 	version := int64(v)
 
+	act, err := activateLoadBalancerVersion(ctx, logger, client, originID, version, activationNetwork)
+	if err != nil {
+		if !errors.Is(err, ErrApplicationLoadBalancerActivation) {
+			return nil, err
+		}
+		if errOnRestore := tf.RestoreOldValues(rd, []string{"network", "version"}); errOnRestore != nil {
+			return act, fmt.Errorf(`%w
+
+Failed to restore previous local schema values. The schema will remain in tainted state:
+%s`, err, errOnRestore.Error())
+		}
+		return act, err
+	}
+
+	if err := rd.Set("status", act.Status); err != nil {
+		return nil, err
+	}
+	if err := rd.Set("version", act.Version); err != nil {
+		return nil, err
+	}
+	return act, nil
+}
+
+// activateLoadBalancerVersion activates the load balancer version on the network, unless it is already active, and waits until the activation is done.
+// Errors returned when the activation could not be started wrap ErrApplicationLoadBalancerActivation
+func activateLoadBalancerVersion(ctx context.Context, logger log.Interface, client cloudlets.Cloudlets, originID string, version int64, activationNetwork cloudlets.LoadBalancerActivationNetwork) (*cloudlets.LoadBalancerActivation, error) {
 	logger.Debugf("checking if application load balancer version %d is active", version)
 	activations, err := client.ListLoadBalancerActivations(ctx, cloudlets.ListLoadBalancerActivationsRequest{OriginID: originID})
 	if err != nil {
@@ -233,11 +260,19 @@ func resourceApplicationLoadBalancerActivationChange(ctx context.Context, rd *sc
 
 	// at this point, we are sure that the given version is not active
 	logger.Debugf("activating application load balancer version %d", version)
-	pollingActivationTries := ALBActivationPollMinimum
-	var activation *cloudlets.LoadBalancerActivation
-
-	for {
-		activation, err = client.ActivateLoadBalancerVersion(ctx, cloudlets.ActivateLoadBalancerVersionRequest{
+	var act *cloudlets.LoadBalancerActivation
+	var lastErr error
+	retryInterval := ALBActivationPollMinimum
+	err = activation.Retry(ctx, activation.NewBackoff(ALBActivationPollMinimum, ApplicationLoadBalancerActivationRetryTimeout), func(err error) bool {
+		if retryInterval > ApplicationLoadBalancerActivationRetryTimeout ||
+			!strings.Contains(strings.ToLower(err.Error()), ErrApplicationLoadBalancerActivationOriginNotDefined.Error()) {
+			return false
+		}
+		logger.Debugf("retrying ALB activation after %s", retryInterval)
+		retryInterval = 2 * retryInterval
+		return true
+	}, func(ctx context.Context) error {
+		act, lastErr = client.ActivateLoadBalancerVersion(ctx, cloudlets.ActivateLoadBalancerVersionRequest{
 			OriginID: originID,
 			Async:    true,
 			LoadBalancerVersionActivation: cloudlets.LoadBalancerVersionActivation{
@@ -245,50 +280,27 @@ func resourceApplicationLoadBalancerActivationChange(ctx context.Context, rd *sc
 				Version: version,
 			},
 		})
-		if err == nil {
-			break
+		return lastErr
+	})
+	if err != nil {
+		if errors.Is(err, context.DeadlineExceeded) {
+			return nil, fmt.Errorf("timeout waiting for retrying activation: last error: %s", lastErr)
 		}
-
-		select {
-		case <-time.After(pollingActivationTries):
-			logger.Debugf("retrying ALB activation after %s", pollingActivationTries)
-			pollingActivationTries = 2 * pollingActivationTries
-			if pollingActivationTries > ApplicationLoadBalancerActivationRetryTimeout ||
-				!strings.Contains(strings.ToLower(err.Error()), ErrApplicationLoadBalancerActivationOriginNotDefined.Error()) {
-				if errOnRestore := tf.RestoreOldValues(rd, []string{"network", "version"}); errOnRestore != nil {
-					return activation, fmt.Errorf(`%w failed. No changes were written to server:
-%s
-
-Failed to restore previous local schema values. The schema will remain in tainted state:
-%s`, ErrApplicationLoadBalancerActivation, err.Error(), errOnRestore.Error())
-				}
-				return activation, fmt.Errorf("%w failed. No changes were written to server:\n%s", ErrApplicationLoadBalancerActivation, err.Error())
-			}
-			continue
-		case <-ctx.Done():
-			if errors.Is(ctx.Err(), context.DeadlineExceeded) {
-				return nil, fmt.Errorf("timeout waiting for retrying activation: last error: %s", err)
-			}
-			if errors.Is(ctx.Err(), context.Canceled) {
-				return nil, fmt.Errorf("operation canceled while waiting for retrying activation, last error: %s", err)
-			}
-			return nil, fmt.Errorf("operation context terminated: %w", ctx.Err())
+		if errors.Is(err, context.Canceled) {
+			return nil, fmt.Errorf("operation canceled while waiting for retrying activation, last error: %s", lastErr)
 		}
+		if errors.Is(err, activation.ErrContextTerminated) {
+			return nil, fmt.Errorf("operation context terminated: %w", err)
+		}
+		return act, fmt.Errorf("%w failed. No changes were written to server:\n%s", ErrApplicationLoadBalancerActivation, err.Error())
 	}
 
 	// wait until application load balancer activation is done
-	activation, err = waitForLoadBalancerActivation(ctx, client, originID, version, activationNetwork)
+	act, err = waitForLoadBalancerActivation(ctx, client, originID, version, activationNetwork)
 	if err != nil {
 		return nil, fmt.Errorf("error while waiting until load balancer activation status == 'active':\n%s", err.Error())
 	}
-
-	if err := rd.Set("status", activation.Status); err != nil {
-		return nil, err
-	}
-	if err := rd.Set("version", activation.Version); err != nil {
-		return nil, err
-	}
-	return activation, nil
+	return act, nil
 }
 
 func resourceApplicationLoadBalancerActivationRead(ctx context.Context, rd *schema.ResourceData, m interface{}) diag.Diagnostics {
@@ -367,33 +379,28 @@ func getApplicationLoadBalancerActivation(ctx context.Context, client cloudlets.
 
 // waitForLoadBalancerActivation polls server until the activation has active status or until context is closed (because of timeout, cancellation or context termination)
 func waitForLoadBalancerActivation(ctx context.Context, client cloudlets.Cloudlets, originID string, version int64, network cloudlets.LoadBalancerActivationNetwork) (*cloudlets.LoadBalancerActivation, error) {
-	activation, err := getApplicationLoadBalancerActivation(ctx, client, originID, version, network)
-	if err != nil {
+	var act *cloudlets.LoadBalancerActivation
+	getActivation := func(ctx context.Context) (err error) {
+		act, err = getApplicationLoadBalancerActivation(ctx, client, originID, version, network)
+		return err
+	}
+	poller := activation.NewPoller(tf.MaxDuration(ALBActivationPollInterval, ALBActivationPollMinimum))
+	if err := retryActivationRequest(ctx, poller, loadBalancerActivationWaitError, getActivation); err != nil {
 		return nil, err
 	}
-	for activation.Status != cloudlets.LoadBalancerActivationStatusActive {
-		if activation.Status != cloudlets.LoadBalancerActivationStatusPending {
-			return nil, fmt.Errorf("%v: originID: %s, status: %s", ErrApplicationLoadBalancerActivation, activation.OriginID, activation.Status)
+	for act.Status != cloudlets.LoadBalancerActivationStatusActive {
+		if act.Status != cloudlets.LoadBalancerActivationStatusPending {
+			return nil, fmt.Errorf("%v: originID: %s, status: %s", ErrApplicationLoadBalancerActivation, act.OriginID, act.Status)
 		}
-		select {
-		case <-time.After(tf.MaxDuration(ALBActivationPollInterval, ALBActivationPollMinimum)):
-			activation, err = getApplicationLoadBalancerActivation(ctx, client, originID, version, network)
-			if err != nil {
-				return nil, err
-			}
-
-		case <-ctx.Done():
-			if errors.Is(ctx.Err(), context.DeadlineExceeded) {
-				return nil, ErrApplicationLoadBalancerActivationTimeout
-			}
-			if errors.Is(ctx.Err(), context.Canceled) {
-				return nil, ErrApplicationLoadBalancerActivationCanceled
-			}
-			return nil, fmt.Errorf("%v: %w", ErrApplicationLoadBalancerActivationContextTerminated, ctx.Err())
+		if err := poller.Wait(ctx); err != nil {
+			return nil, loadBalancerActivationWaitError(err)
+		}
+		if err := retryActivationRequest(ctx, poller, loadBalancerActivationWaitError, getActivation); err != nil {
+			return nil, err
 		}
 	}
-	if activation.Status == cloudlets.LoadBalancerActivationStatusActive {
-		return activation, nil
+	if act.Status == cloudlets.LoadBalancerActivationStatusActive {
+		return act, nil
 	}
 	// should not reach here
 	return nil, ErrApplicationLoadBalancerActivation
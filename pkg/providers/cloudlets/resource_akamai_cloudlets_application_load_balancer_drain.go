@@ -0,0 +1,439 @@
+package cloudlets
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v9/pkg/cloudlets"
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v9/pkg/session"
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/common/ptr"
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/common/tf"
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/common/timeouts"
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/meta"
+	"github.com/apex/log"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+var (
+	// ErrApplicationLoadBalancerDrain is returned when draining data centers of an application load balancer fails
+	ErrApplicationLoadBalancerDrain = errors.New("application load balancer drain")
+
+	// ApplicationLoadBalancerDrainResourceTimeout is the default timeout for the resource operations
+	ApplicationLoadBalancerDrainResourceTimeout = ApplicationLoadBalancerActivationResourceTimeout
+
+	// drainAttributes lists the attributes which require the drained version to be created again
+	drainAttributes = []string{"version", "drained_origin_ids", "description"}
+
+	// drainComputedAttributes lists the attributes computed from the drained version
+	drainComputedAttributes = []string{"drained_version", "percentages", "warnings"}
+)
+
+func resourceCloudletsApplicationLoadBalancerDrain() *schema.Resource {
+	return &schema.Resource{
+		CustomizeDiff: enforceDrainedVersionChange,
+		CreateContext: resourceALBDrainCreate,
+		ReadContext:   resourceALBDrainRead,
+		UpdateContext: resourceALBDrainUpdate,
+		DeleteContext: resourceALBDrainDelete,
+		Schema:        resourceCloudletsApplicationLoadBalancerDrainSchema(),
+		Timeouts: &schema.ResourceTimeout{
+			Default: &ApplicationLoadBalancerDrainResourceTimeout,
+		},
+	}
+}
+
+func resourceCloudletsApplicationLoadBalancerDrainSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"origin_id": {
+			Type:        schema.TypeString,
+			Required:    true,
+			ForceNew:    true,
+			Description: "The conditional origin's unique identifier of the application load balancer",
+		},
+		"version": {
+			Type:        schema.TypeInt,
+			Required:    true,
+			Description: "The load balancer configuration version with the data center percentages to redistribute",
+		},
+		"drained_origin_ids": {
+			Type:        schema.TypeSet,
+			Optional:    true,
+			Elem:        &schema.Schema{Type: schema.TypeString},
+			Description: "Origin IDs of the data centers to drain. Their percentage is redistributed proportionally across the remaining data centers. When empty, the percentages of the given version are restored",
+		},
+		"description": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "The description of the load balancer configuration version created with the drained data centers",
+		},
+		"network": {
+			Type:             schema.TypeString,
+			Optional:         true,
+			ValidateDiagFunc: validateNetwork,
+			StateFunc:        stateALBActivationNetwork,
+			Description:      "The network on which the resulting version is activated (options are Staging and Production). The version is not activated if not provided",
+		},
+		"drained_version": {
+			Type:        schema.TypeInt,
+			Computed:    true,
+			Description: "The load balancer configuration version with the drained data centers, or the given version when no data center is drained",
+		},
+		"percentages": {
+			Type:        schema.TypeMap,
+			Computed:    true,
+			Elem:        &schema.Schema{Type: schema.TypeFloat},
+			Description: "The percent of traffic sent to each data center in the drained version, by origin ID",
+		},
+		"status": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Activation status of the drained version on the given network",
+		},
+		"warnings": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Describes warnings of the drained load balancer configuration version",
+		},
+		"timeouts": {
+			Type:        schema.TypeList,
+			Optional:    true,
+			MaxItems:    1,
+			Description: "Enables to set timeout for processing",
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"default": {
+						Type:             schema.TypeString,
+						Optional:         true,
+						ValidateDiagFunc: timeouts.ValidateDurationFormat,
+					},
+				},
+			},
+		},
+	}
+}
+
+// enforceDrainedVersionChange marks the drained version as unknown when the version has to be created again,
+// so that resources activating the drained version are planned with the new version
+func enforceDrainedVersionChange(_ context.Context, diff *schema.ResourceDiff, _ interface{}) error {
+	if diff.Id() == "" || !diff.HasChanges(drainAttributes...) {
+		return nil
+	}
+	for _, key := range drainComputedAttributes {
+		if err := diff.SetNewComputed(key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func resourceALBDrainCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	meta := meta.Must(m)
+	logger := meta.Log("Cloudlets", "resourceALBDrainCreate")
+	ctx = session.ContextWithOptions(ctx, session.WithContextLog(logger))
+	client := Client(meta)
+	logger.Debug("Draining load balancer data centers")
+
+	originID, err := tf.GetStringValue("origin_id", d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err = drainLoadBalancerVersion(ctx, client, d, originID); err != nil {
+		return diag.Errorf("%v create: %s", ErrApplicationLoadBalancerDrain, err)
+	}
+	d.SetId(originID)
+
+	if err = activateDrainedVersion(ctx, logger, client, d, originID); err != nil {
+		return diag.Errorf("%v create: %s", ErrApplicationLoadBalancerActivation, err)
+	}
+
+	return resourceALBDrainRead(ctx, d, m)
+}
+
+func resourceALBDrainRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	meta := meta.Must(m)
+	logger := meta.Log("Cloudlets", "resourceALBDrainRead")
+	ctx = session.ContextWithOptions(ctx, session.WithContextLog(logger))
+	client := Client(meta)
+	logger.Debug("Reading drained load balancer version")
+
+	originID := d.Id()
+	version, err := tf.GetIntValueAsInt64("drained_version", d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	loadBalancerVersion, err := client.GetLoadBalancerVersion(ctx, cloudlets.GetLoadBalancerVersionRequest{
+		OriginID:       originID,
+		Version:        version,
+		ShouldValidate: true,
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	attrs := map[string]interface{}{
+		"percentages": dataCenterPercentages(loadBalancerVersion.DataCenters),
+	}
+	var warningsJSON []byte
+	if len(loadBalancerVersion.Warnings) > 0 {
+		warningsJSON, err = json.MarshalIndent(loadBalancerVersion.Warnings, "", "  ")
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	attrs["warnings"] = string(warningsJSON)
+
+	network, err := tf.GetStringValue("network", d)
+	if err != nil && !errors.Is(err, tf.ErrNotFound) {
+		return diag.FromErr(err)
+	}
+	var status cloudlets.LoadBalancerActivationStatus
+	if network != "" {
+		activationNetwork, err := getALBActivationNetwork(network)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		activations, err := client.ListLoadBalancerActivations(ctx, cloudlets.ListLoadBalancerActivationsRequest{OriginID: originID})
+		if err != nil {
+			return diag.Errorf("%v read: %s", ErrApplicationLoadBalancerActivation, err.Error())
+		}
+		for _, act := range activations {
+			if act.Version == version && act.Network == activationNetwork && act.Status == cloudlets.LoadBalancerActivationStatusActive {
+				status = act.Status
+				break
+			}
+		}
+	}
+	attrs["status"] = string(status)
+
+	if err := tf.SetAttrs(d, attrs); err != nil {
+		return diag.FromErr(err)
+	}
+	return nil
+}
+
+func resourceALBDrainUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	meta := meta.Must(m)
+	logger := meta.Log("Cloudlets", "resourceALBDrainUpdate")
+	ctx = session.ContextWithOptions(ctx, session.WithContextLog(logger))
+	client := Client(meta)
+
+	if !d.HasChangeExcept("timeouts") {
+		logger.Debug("Only timeouts were updated, skipping")
+		return nil
+	}
+
+	// on failure, the state is restored so that the drain and the activation are planned again
+	restoredAttributes := append(append([]string{"network", "status"}, drainAttributes...), drainComputedAttributes...)
+
+	originID := d.Id()
+	if d.HasChanges(drainAttributes...) {
+		logger.Debug("Draining load balancer data centers")
+		if err := drainLoadBalancerVersion(ctx, client, d, originID); err != nil {
+			if restoreDiags := diag.FromErr(tf.RestoreOldValues(d, restoredAttributes)); restoreDiags != nil {
+				return append(restoreDiags, diag.Errorf("%v update: %s", ErrApplicationLoadBalancerDrain, err)...)
+			}
+			return diag.Errorf("%v update: %s", ErrApplicationLoadBalancerDrain, err)
+		}
+	}
+
+	if err := activateDrainedVersion(ctx, logger, client, d, originID); err != nil {
+		if restoreDiags := diag.FromErr(tf.RestoreOldValues(d, restoredAttributes)); restoreDiags != nil {
+			return append(restoreDiags, diag.Errorf("%v update: %s", ErrApplicationLoadBalancerActivation, err)...)
+		}
+		return diag.Errorf("%v update: %s", ErrApplicationLoadBalancerActivation, err)
+	}
+
+	return resourceALBDrainRead(ctx, d, m)
+}
+
+// resourceALBDrainDelete only removes the resource from state, because load balancer versions cannot be deleted.
+// To restore the percentages, drained_origin_ids has to be emptied and applied before the resource is removed
+func resourceALBDrainDelete(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	meta := meta.Must(m)
+	logger := meta.Log("Cloudlets", "resourceALBDrainDelete")
+	logger.Debug("Deleting load balancer drain")
+	logger.Info("Cloudlets API does not support load balancer configuration version deletion - resource will only be removed from state")
+	d.SetId("")
+	return nil
+}
+
+// drainLoadBalancerVersion creates a version of the load balancer with the drained data centers, or uses the given version,
+// when no data center has to be drained
+func drainLoadBalancerVersion(ctx context.Context, client cloudlets.Cloudlets, d *schema.ResourceData, originID string) error {
+	version, err := tf.GetIntValueAsInt64("version", d)
+	if err != nil {
+		return err
+	}
+	var drained []string
+	for _, originID := range d.Get("drained_origin_ids").(*schema.Set).List() {
+		drained = append(drained, originID.(string))
+	}
+	sort.Strings(drained)
+
+	base, err := client.GetLoadBalancerVersion(ctx, cloudlets.GetLoadBalancerVersionRequest{
+		OriginID:       originID,
+		Version:        version,
+		ShouldValidate: true,
+	})
+	if err != nil {
+		return err
+	}
+
+	if len(drained) == 0 {
+		if err = d.Set("drained_version", base.Version); err != nil {
+			return fmt.Errorf("%w: %s", tf.ErrValueSet, err.Error())
+		}
+		return nil
+	}
+
+	dataCenters, err := drainDataCenters(base.DataCenters, drained)
+	if err != nil {
+		return err
+	}
+	description, err := tf.GetStringValue("description", d)
+	if err != nil && !errors.Is(err, tf.ErrNotFound) {
+		return err
+	}
+	if description == "" {
+		description = fmt.Sprintf("Drained %s from version %d", strings.Join(drained, ", "), version)
+	}
+
+	created, err := client.CreateLoadBalancerVersion(ctx, cloudlets.CreateLoadBalancerVersionRequest{
+		OriginID: originID,
+		LoadBalancerVersion: cloudlets.LoadBalancerVersion{
+			Description:      description,
+			BalancingType:    base.BalancingType,
+			DataCenters:      dataCenters,
+			LivenessSettings: base.LivenessSettings,
+		},
+	})
+	if err != nil {
+		return err
+	}
+	if err = d.Set("drained_version", created.Version); err != nil {
+		return fmt.Errorf("%w: %s", tf.ErrValueSet, err.Error())
+	}
+	return nil
+}
+
+func activateDrainedVersion(ctx context.Context, logger log.Interface, client cloudlets.Cloudlets, d *schema.ResourceData, originID string) error {
+	network, err := tf.GetStringValue("network", d)
+	if errors.Is(err, tf.ErrNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if !d.IsNewResource() && !d.HasChanges(append([]string{"network"}, drainAttributes...)...) {
+		return nil
+	}
+	activationNetwork, err := getALBActivationNetwork(network)
+	if err != nil {
+		return err
+	}
+	version, err := tf.GetIntValueAsInt64("drained_version", d)
+	if err != nil {
+		return err
+	}
+	_, err = activateLoadBalancerVersion(ctx, logger, client, originID, version, activationNetwork)
+	return err
+}
+
+// drainDataCenters sets the percent of the drained data centers to 0 and redistributes their percentage proportionally
+// across the remaining data centers. Percentages are calculated with a precision of two decimal places, and the rounding
+// remainder is assigned to the data centers with the largest fractional shares, so that the total stays 100%
+func drainDataCenters(dataCenters []cloudlets.DataCenter, drained []string) ([]cloudlets.DataCenter, error) {
+	isDrained := make(map[string]bool, len(drained))
+	for _, originID := range drained {
+		isDrained[originID] = true
+	}
+	for _, originID := range drained {
+		found := false
+		for _, dc := range dataCenters {
+			if dc.OriginID == originID {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("data center with origin ID '%s' does not exist in the load balancer version", originID)
+		}
+	}
+
+	// percentages in hundredths of a percent
+	shares := make([]int64, len(dataCenters))
+	var total, drainedTotal, remainingTotal int64
+	for i, dc := range dataCenters {
+		if dc.Percent != nil {
+			shares[i] = int64(math.Round(*dc.Percent * 100))
+		}
+		total += shares[i]
+		if isDrained[dc.OriginID] {
+			drainedTotal += shares[i]
+		} else {
+			remainingTotal += shares[i]
+		}
+	}
+	if total != 10000 {
+		return nil, fmt.Errorf("the total data center percentage of the load balancer version must be 100%%: total=%s%%", strconv.FormatFloat(float64(total)/100, 'f', -1, 64))
+	}
+	if remainingTotal == 0 {
+		return nil, fmt.Errorf("cannot drain %s: no remaining data center receives traffic", strings.Join(drained, ", "))
+	}
+
+	type remainder struct {
+		index int
+		value int64
+	}
+	var remainders []remainder
+	distributed := int64(0)
+	result := make([]cloudlets.DataCenter, len(dataCenters))
+	newShares := make([]int64, len(dataCenters))
+	for i, dc := range dataCenters {
+		result[i] = dc
+		if isDrained[dc.OriginID] || shares[i] == 0 {
+			continue
+		}
+		added := drainedTotal * shares[i] / remainingTotal
+		newShares[i] = shares[i] + added
+		distributed += added
+		remainders = append(remainders, remainder{index: i, value: drainedTotal * shares[i] % remainingTotal})
+	}
+	sort.SliceStable(remainders, func(i, j int) bool {
+		return remainders[i].value > remainders[j].value
+	})
+	for i := 0; distributed < drainedTotal; i++ {
+		newShares[remainders[i%len(remainders)].index]++
+		distributed++
+	}
+
+	var sum int64
+	for i := range result {
+		sum += newShares[i]
+		result[i].Percent = ptr.To(float64(newShares[i]) / 100)
+	}
+	if sum != 10000 {
+		return nil, fmt.Errorf("the total data center percentage after draining must be 100%%: total=%s%%", strconv.FormatFloat(float64(sum)/100, 'f', -1, 64))
+	}
+	return result, nil
+}
+
+func dataCenterPercentages(dataCenters []cloudlets.DataCenter) map[string]interface{} {
+	percentages := make(map[string]interface{}, len(dataCenters))
+	for _, dc := range dataCenters {
+		var percent float64
+		if dc.Percent != nil {
+			percent = *dc.Percent
+		}
+		percentages[dc.OriginID] = percent
+	}
+	return percentages
+}
@@ -0,0 +1,265 @@
+package cloudlets
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v9/pkg/cloudlets"
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/common/ptr"
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/common/testutils"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResourceCloudletsApplicationLoadBalancerDrain(t *testing.T) {
+	dataCenter := func(originID string, percent float64) cloudlets.DataCenter {
+		return cloudlets.DataCenter{
+			Continent: "NA",
+			Country:   "US",
+			Latitude:  ptr.To(42.36),
+			Longitude: ptr.To(-71.06),
+			OriginID:  originID,
+			Percent:   ptr.To(percent),
+		}
+	}
+	baseVersion := cloudlets.LoadBalancerVersion{
+		BalancingType: cloudlets.BalancingTypeWeighted,
+		Description:   "base",
+		DataCenters:   []cloudlets.DataCenter{dataCenter("dc_a", 50), dataCenter("dc_b", 30), dataCenter("dc_c", 20)},
+		LivenessSettings: &cloudlets.LivenessSettings{
+			Path:     "/status",
+			Port:     443,
+			Protocol: "HTTPS",
+		},
+		OriginID: "alb_1",
+		Version:  2,
+	}
+	drainedDataCenters := []cloudlets.DataCenter{dataCenter("dc_a", 71.43), dataCenter("dc_b", 0), dataCenter("dc_c", 28.57)}
+
+	expectGetVersion := func(m *cloudlets.Mock, version cloudlets.LoadBalancerVersion) *mock.Call {
+		return m.On("GetLoadBalancerVersion", mock.Anything, cloudlets.GetLoadBalancerVersionRequest{
+			OriginID:       "alb_1",
+			Version:        version.Version,
+			ShouldValidate: true,
+		}).Return(&version, nil)
+	}
+	expectCreateDrainedVersion := func(m *cloudlets.Mock, description string, version int64) cloudlets.LoadBalancerVersion {
+		request := cloudlets.LoadBalancerVersion{
+			BalancingType:    baseVersion.BalancingType,
+			Description:      description,
+			DataCenters:      drainedDataCenters,
+			LivenessSettings: baseVersion.LivenessSettings,
+		}
+		response := request
+		response.OriginID = "alb_1"
+		response.Version = version
+		m.On("CreateLoadBalancerVersion", mock.Anything, cloudlets.CreateLoadBalancerVersionRequest{
+			OriginID:            "alb_1",
+			LoadBalancerVersion: request,
+		}).Return(&response, nil).Once()
+		return response
+	}
+
+	tests := map[string]struct {
+		init  func(*cloudlets.Mock)
+		steps []resource.TestStep
+	}{
+		"drain and restore data center": {
+			init: func(m *cloudlets.Mock) {
+				// create
+				expectGetVersion(m, baseVersion)
+				drained := expectCreateDrainedVersion(m, "Drained dc_b from version 2", 3)
+				// read
+				expectGetVersion(m, drained)
+			},
+			steps: []resource.TestStep{
+				{
+					Config: testutils.LoadFixtureString(t, "testdata/TestResourceCloudletsApplicationLoadBalancerDrain/drain.tf"),
+					Check: resource.ComposeAggregateTestCheckFunc(
+						resource.TestCheckResourceAttr("akamai_cloudlets_application_load_balancer_drain.test", "id", "alb_1"),
+						resource.TestCheckResourceAttr("akamai_cloudlets_application_load_balancer_drain.test", "drained_version", "3"),
+						resource.TestCheckResourceAttr("akamai_cloudlets_application_load_balancer_drain.test", "percentages.dc_a", "71.43"),
+						resource.TestCheckResourceAttr("akamai_cloudlets_application_load_balancer_drain.test", "percentages.dc_b", "0"),
+						resource.TestCheckResourceAttr("akamai_cloudlets_application_load_balancer_drain.test", "percentages.dc_c", "28.57"),
+						resource.TestCheckResourceAttr("akamai_cloudlets_application_load_balancer_drain.test", "status", ""),
+					),
+				},
+				{
+					Config: testutils.LoadFixtureString(t, "testdata/TestResourceCloudletsApplicationLoadBalancerDrain/restore.tf"),
+					Check: resource.ComposeAggregateTestCheckFunc(
+						resource.TestCheckResourceAttr("akamai_cloudlets_application_load_balancer_drain.test", "drained_version", "2"),
+						resource.TestCheckResourceAttr("akamai_cloudlets_application_load_balancer_drain.test", "percentages.dc_a", "50"),
+						resource.TestCheckResourceAttr("akamai_cloudlets_application_load_balancer_drain.test", "percentages.dc_b", "30"),
+						resource.TestCheckResourceAttr("akamai_cloudlets_application_load_balancer_drain.test", "percentages.dc_c", "20"),
+					),
+				},
+			},
+		},
+		"drain and activate": {
+			init: func(m *cloudlets.Mock) {
+				// create
+				expectGetVersion(m, baseVersion)
+				drained := expectCreateDrainedVersion(m, "maintenance of dc_b", 3)
+				expectListLoadBalancerActivations(m, "alb_1", 2, "STAGING", cloudlets.LoadBalancerActivationStatusActive, nil).Once()
+				expectActivateLoadBalancerVersion(m, "alb_1", 3, "STAGING", cloudlets.LoadBalancerActivationStatusPending, nil).Once()
+				expectListLoadBalancerActivations(m, "alb_1", 3, "STAGING", cloudlets.LoadBalancerActivationStatusPending, nil).Once()
+				expectListLoadBalancerActivations(m, "alb_1", 3, "STAGING", cloudlets.LoadBalancerActivationStatusActive, nil)
+				// read
+				expectGetVersion(m, drained)
+			},
+			steps: []resource.TestStep{
+				{
+					Config: testutils.LoadFixtureString(t, "testdata/TestResourceCloudletsApplicationLoadBalancerDrain/drain_activation.tf"),
+					Check: resource.ComposeAggregateTestCheckFunc(
+						resource.TestCheckResourceAttr("akamai_cloudlets_application_load_balancer_drain.test", "drained_version", "3"),
+						resource.TestCheckResourceAttr("akamai_cloudlets_application_load_balancer_drain.test", "network", "STAGING"),
+						resource.TestCheckResourceAttr("akamai_cloudlets_application_load_balancer_drain.test", "status", string(cloudlets.LoadBalancerActivationStatusActive)),
+					),
+				},
+			},
+		},
+		"failed activation of drained version is planned again": {
+			init: func(m *cloudlets.Mock) {
+				// create
+				expectGetVersion(m, baseVersion)
+				drained := expectCreateDrainedVersion(m, "Drained dc_b from version 2", 3)
+				// read
+				expectGetVersion(m, drained)
+				// update, the activation fails
+				expectCreateDrainedVersion(m, "maintenance of dc_b", 4)
+				expectListLoadBalancerActivations(m, "alb_1", 2, "STAGING", cloudlets.LoadBalancerActivationStatusActive, nil).Once()
+				expectActivateLoadBalancerVersion(m, "alb_1", 4, "STAGING", "", fmt.Errorf("oops")).Once()
+				// update, retried
+				retried := expectCreateDrainedVersion(m, "maintenance of dc_b", 5)
+				expectListLoadBalancerActivations(m, "alb_1", 2, "STAGING", cloudlets.LoadBalancerActivationStatusActive, nil).Once()
+				expectActivateLoadBalancerVersion(m, "alb_1", 5, "STAGING", cloudlets.LoadBalancerActivationStatusPending, nil).Once()
+				expectListLoadBalancerActivations(m, "alb_1", 5, "STAGING", cloudlets.LoadBalancerActivationStatusActive, nil)
+				// read
+				expectGetVersion(m, retried)
+			},
+			steps: []resource.TestStep{
+				{
+					Config: testutils.LoadFixtureString(t, "testdata/TestResourceCloudletsApplicationLoadBalancerDrain/drain.tf"),
+					Check:  resource.TestCheckResourceAttr("akamai_cloudlets_application_load_balancer_drain.test", "drained_version", "3"),
+				},
+				{
+					Config:      testutils.LoadFixtureString(t, "testdata/TestResourceCloudletsApplicationLoadBalancerDrain/drain_activation.tf"),
+					ExpectError: regexp.MustCompile("oops"),
+				},
+				{
+					Config: testutils.LoadFixtureString(t, "testdata/TestResourceCloudletsApplicationLoadBalancerDrain/drain_activation.tf"),
+					Check: resource.ComposeAggregateTestCheckFunc(
+						resource.TestCheckResourceAttr("akamai_cloudlets_application_load_balancer_drain.test", "drained_version", "5"),
+						resource.TestCheckResourceAttr("akamai_cloudlets_application_load_balancer_drain.test", "network", "STAGING"),
+						resource.TestCheckResourceAttr("akamai_cloudlets_application_load_balancer_drain.test", "status", string(cloudlets.LoadBalancerActivationStatusActive)),
+					),
+				},
+			},
+		},
+		"unknown data center": {
+			init: func(m *cloudlets.Mock) {
+				expectGetVersion(m, baseVersion).Once()
+			},
+			steps: []resource.TestStep{
+				{
+					Config:      testutils.LoadFixtureString(t, "testdata/TestResourceCloudletsApplicationLoadBalancerDrain/drain_unknown_origin.tf"),
+					ExpectError: regexp.MustCompile("data center with origin ID 'dc_x' does not exist in the load balancer version"),
+				},
+			},
+		},
+	}
+
+	// redefining times to run the tests faster
+	ALBActivationPollMinimum = time.Millisecond * 1
+	ALBActivationPollInterval = time.Millisecond * 1
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			client := &cloudlets.Mock{}
+			test.init(client)
+			useClient(client, func() {
+				resource.UnitTest(t, resource.TestCase{
+					ProtoV6ProviderFactories: testutils.NewProtoV6ProviderFactory(NewSubprovider()),
+					IsUnitTest:               true,
+					Steps:                    test.steps,
+				})
+			})
+			client.AssertExpectations(t)
+		})
+	}
+}
+
+func TestDrainDataCenters(t *testing.T) {
+	dataCenters := func(percentages ...float64) []cloudlets.DataCenter {
+		result := make([]cloudlets.DataCenter, 0, len(percentages))
+		for i, percent := range percentages {
+			result = append(result, cloudlets.DataCenter{OriginID: string(rune('a' + i)), Percent: ptr.To(percent)})
+		}
+		return result
+	}
+
+	tests := map[string]struct {
+		dataCenters   []cloudlets.DataCenter
+		drained       []string
+		expected      []cloudlets.DataCenter
+		expectedError string
+	}{
+		"proportional redistribution": {
+			dataCenters: dataCenters(50, 25, 25),
+			drained:     []string{"a"},
+			expected:    dataCenters(0, 50, 50),
+		},
+		"rounding remainder": {
+			dataCenters: dataCenters(50, 30, 20),
+			drained:     []string{"b"},
+			expected:    dataCenters(71.43, 0, 28.57),
+		},
+		"rounding remainder to largest fractions": {
+			dataCenters: dataCenters(1, 33, 33, 33),
+			drained:     []string{"a"},
+			expected:    dataCenters(0, 33.34, 33.33, 33.33),
+		},
+		"data center without traffic stays without traffic": {
+			dataCenters: dataCenters(40, 60, 0),
+			drained:     []string{"a"},
+			expected:    dataCenters(0, 100, 0),
+		},
+		"multiple data centers": {
+			dataCenters: dataCenters(10, 20, 30, 40),
+			drained:     []string{"a", "c"},
+			expected:    dataCenters(0, 33.33, 0, 66.67),
+		},
+		"unknown data center": {
+			dataCenters:   dataCenters(50, 50),
+			drained:       []string{"x"},
+			expectedError: "data center with origin ID 'x' does not exist in the load balancer version",
+		},
+		"all data centers drained": {
+			dataCenters:   dataCenters(50, 50, 0),
+			drained:       []string{"a", "b"},
+			expectedError: "cannot drain a, b: no remaining data center receives traffic",
+		},
+		"invalid total": {
+			dataCenters:   dataCenters(50, 40),
+			drained:       []string{"a"},
+			expectedError: "the total data center percentage of the load balancer version must be 100%: total=90%",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			result, err := drainDataCenters(test.dataCenters, test.drained)
+			if test.expectedError != "" {
+				require.Error(t, err)
+				assert.Equal(t, test.expectedError, err.Error())
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, result)
+		})
+	}
+}
@@ -15,6 +15,7 @@ import (
 	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v9/pkg/cloudlets"
 	v3 "github.com/akamai/AkamaiOPEN-edgegrid-golang/v9/pkg/cloudlets/v3"
 	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v9/pkg/session"
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/common/activation"
 	"github.com/akamai/terraform-provider-akamai/v6/pkg/common/tf"
 	"github.com/akamai/terraform-provider-akamai/v6/pkg/common/timeouts"
 	"github.com/akamai/terraform-provider-akamai/v6/pkg/meta"
@@ -110,7 +111,7 @@ var (
 	MaxListActivationsPollRetries = 5
 
 	// PolicyActivationResourceTimeout is the default timeout for the resource operations
-	PolicyActivationResourceTimeout = time.Minute * 90
+	PolicyActivationResourceTimeout = activation.DefaultTimeout
 
 	// PolicyActivationRetryPollMinimum is the minimum polling interval for retrying policy activation
 	PolicyActivationRetryPollMinimum = time.Second * 15
@@ -124,6 +125,11 @@ var (
 	policyActivationRetryRegexp = regexp.MustCompile(`requested propertyname \\"[A-Za-z0-9.\-_]+\\" does not exist`)
 )
 
+// newPolicyActivationPoller returns the poller used while waiting for policy activations
+func newPolicyActivationPoller() *activation.Poller {
+	return activation.NewPoller(tf.MaxDuration(ActivationPollInterval, ActivationPollMinimum))
+}
+
 func resourcePolicyActivationDelete(ctx context.Context, rd *schema.ResourceData, m interface{}) diag.Diagnostics {
 	meta := meta.Must(m)
 	logger := meta.Log("Cloudlets", "resourcePolicyActivationDelete")
@@ -266,32 +272,8 @@ func resourcePolicyActivationCreate(ctx context.Context, rd *schema.ResourceData
 	}
 
 	// at this point, we are sure that the given version is not active
-	pollingActivationTries := PolicyActivationRetryPollMinimum
-
-	for {
-		err = strategy.activateVersion(ctx, policyID, version)
-		if err == nil {
-			break
-		}
-
-		select {
-		case <-time.After(pollingActivationTries):
-			logger.Debugf("retrying policy activation after %s", pollingActivationTries)
-			if pollingActivationTries > PolicyActivationRetryTimeout || !strategy.shouldRetryActivation(err) {
-				return diag.Errorf("%v create: %s", ErrPolicyActivation, err.Error())
-			}
-
-			pollingActivationTries = 2 * pollingActivationTries
-			continue
-		case <-ctx.Done():
-			if errors.Is(ctx.Err(), context.DeadlineExceeded) {
-				return diag.Errorf("timeout waiting for retrying policy activation: last error: %s", err)
-			}
-			if errors.Is(ctx.Err(), context.Canceled) {
-				return diag.Errorf("operation canceled while waiting for retrying policy activation, last error: %s", err)
-			}
-
-		}
+	if diags := activateVersionWithRetry(ctx, logger, strategy, policyID, version, "create"); diags != nil {
+		return diags
 	}
 
 	// wait until policy activation is done
@@ -309,6 +291,33 @@ func resourcePolicyActivationCreate(ctx context.Context, rd *schema.ResourceData
 	return resourcePolicyActivationRead(ctx, rd, m)
 }
 
+// activateVersionWithRetry activates the policy version, retrying as long as the strategy considers the activation error transient
+func activateVersionWithRetry(ctx context.Context, logger log.Interface, strategy activationStrategy, policyID, version int64, operation string) diag.Diagnostics {
+	var lastErr error
+	retryInterval := PolicyActivationRetryPollMinimum
+	err := activation.Retry(ctx, activation.NewBackoff(PolicyActivationRetryPollMinimum, PolicyActivationRetryTimeout), func(err error) bool {
+		if retryInterval > PolicyActivationRetryTimeout || !strategy.shouldRetryActivation(err) {
+			return false
+		}
+		logger.Debugf("retrying policy activation after %s", retryInterval)
+		retryInterval = 2 * retryInterval
+		return true
+	}, func(ctx context.Context) error {
+		lastErr = strategy.activateVersion(ctx, policyID, version)
+		return lastErr
+	})
+	if err != nil {
+		if errors.Is(err, context.DeadlineExceeded) {
+			return diag.Errorf("timeout waiting for retrying policy activation: last error: %s", lastErr)
+		}
+		if errors.Is(err, context.Canceled) {
+			return diag.Errorf("operation canceled while waiting for retrying policy activation, last error: %s", lastErr)
+		}
+		return diag.Errorf("%v %s: %s", ErrPolicyActivation, operation, err.Error())
+	}
+	return nil
+}
+
 func resourcePolicyActivationRead(ctx context.Context, rd *schema.ResourceData, m interface{}) diag.Diagnostics {
 	meta := meta.Must(m)
 	logger := meta.Log("Cloudlets", "resourcePolicyActivationRead")
@@ -415,6 +424,7 @@ func waitForPolicyActivation(ctx context.Context, client cloudlets.Cloudlets, po
 	}
 	activations = filterActivations(activations, version, additionalProps)
 
+	poller := newPolicyActivationPoller()
 	for len(activations) > 0 {
 		allActive, allRemoved := true, true
 	activations:
@@ -439,26 +449,17 @@ func waitForPolicyActivation(ctx context.Context, client cloudlets.Cloudlets, po
 		if allActive && allRemoved {
 			return activations, nil
 		}
-		select {
-		case <-time.After(tf.MaxDuration(ActivationPollInterval, ActivationPollMinimum)):
-			activations, err = waitForListPolicyActivations(ctx, client, cloudlets.ListPolicyActivationsRequest{
-				PolicyID: policyID,
-				Network:  network,
-			})
-			if err != nil {
-				return nil, err
-			}
-			activations = filterActivations(activations, version, additionalProps)
-
-		case <-ctx.Done():
-			if errors.Is(ctx.Err(), context.DeadlineExceeded) {
-				return nil, ErrPolicyActivationTimeout
-			}
-			if errors.Is(ctx.Err(), context.Canceled) {
-				return nil, ErrPolicyActivationCanceled
-			}
-			return nil, fmt.Errorf("%v: %w", ErrPolicyActivationContextTerminated, ctx.Err())
+		if err := poller.Wait(ctx); err != nil {
+			return nil, policyActivationWaitError(err)
 		}
+		activations, err = waitForListPolicyActivations(ctx, client, cloudlets.ListPolicyActivationsRequest{
+			PolicyID: policyID,
+			Network:  network,
+		})
+		if err != nil {
+			return nil, err
+		}
+		activations = filterActivations(activations, version, additionalProps)
 	}
 
 	if len(activations) == 0 {
@@ -591,6 +592,7 @@ func waitForNotPendingPolicyActivation(ctx context.Context, logger log.Interface
 	if err != nil {
 		return fmt.Errorf("%w: failed to list policy activations for policy %d: %s", ErrPolicyActivation, policyID, err.Error())
 	}
+	poller := newPolicyActivationPoller()
 	for len(activations) > 0 {
 		pending := false
 		for _, act := range activations {
@@ -605,24 +607,15 @@ func waitForNotPendingPolicyActivation(ctx context.Context, logger log.Interface
 		if !pending {
 			break
 		}
-		select {
-		case <-time.After(tf.MaxDuration(ActivationPollInterval, ActivationPollMinimum)):
-			activations, err = waitForListPolicyActivations(ctx, client, cloudlets.ListPolicyActivationsRequest{
-				PolicyID: policyID,
-				Network:  network,
-			})
-			if err != nil {
-				return fmt.Errorf("%w: failed to list policy activations for policy %d: %s", ErrPolicyActivation, policyID, err.Error())
-			}
-
-		case <-ctx.Done():
-			if errors.Is(ctx.Err(), context.DeadlineExceeded) {
-				return ErrPolicyActivationTimeout
-			}
-			if errors.Is(ctx.Err(), context.Canceled) {
-				return ErrPolicyActivationCanceled
-			}
-			return fmt.Errorf("%v: %w", ErrPolicyActivationContextTerminated, ctx.Err())
+		if err := poller.Wait(ctx); err != nil {
+			return policyActivationWaitError(err)
+		}
+		activations, err = waitForListPolicyActivations(ctx, client, cloudlets.ListPolicyActivationsRequest{
+			PolicyID: policyID,
+			Network:  network,
+		})
+		if err != nil {
+			return fmt.Errorf("%w: failed to list policy activations for policy %d: %s", ErrPolicyActivation, policyID, err.Error())
 		}
 	}
 
@@ -632,29 +625,24 @@ func waitForNotPendingPolicyActivation(ctx context.Context, logger log.Interface
 // waitForListPolicyActivations polls server until the ListPolicyActivations returns non-empty list
 func waitForListPolicyActivations(ctx context.Context, client cloudlets.Cloudlets, listPolicyActivationsRequest cloudlets.ListPolicyActivationsRequest) ([]cloudlets.PolicyActivation, error) {
 	listActivationsPollRetries := MaxListActivationsPollRetries
-	activations, err := client.ListPolicyActivations(ctx, listPolicyActivationsRequest)
-	if err != nil {
+	var activations []cloudlets.PolicyActivation
+	listActivations := func(ctx context.Context) (err error) {
+		activations, err = client.ListPolicyActivations(ctx, listPolicyActivationsRequest)
+		return err
+	}
+	poller := newPolicyActivationPoller()
+	if err := retryActivationRequest(ctx, poller, policyActivationWaitError, listActivations); err != nil {
 		return nil, err
 	}
 
 	for len(activations) == 0 && listActivationsPollRetries > 0 {
-		select {
-		case <-time.After(tf.MaxDuration(ActivationPollInterval, ActivationPollMinimum)):
-			activations, err = client.ListPolicyActivations(ctx, listPolicyActivationsRequest)
-			if err != nil {
-				return nil, err
-			}
-			listActivationsPollRetries--
-
-		case <-ctx.Done():
-			if errors.Is(ctx.Err(), context.DeadlineExceeded) {
-				return nil, ErrPolicyActivationTimeout
-			}
-			if errors.Is(ctx.Err(), context.Canceled) {
-				return nil, ErrPolicyActivationCanceled
-			}
-			return nil, fmt.Errorf("%v: %w", ErrPolicyActivationContextTerminated, ctx.Err())
+		if err := poller.Wait(ctx); err != nil {
+			return nil, policyActivationWaitError(err)
+		}
+		if err := retryActivationRequest(ctx, poller, policyActivationWaitError, listActivations); err != nil {
+			return nil, err
 		}
+		listActivationsPollRetries--
 	}
 
 	return activations, nil
@@ -5,7 +5,6 @@ import (
 	"errors"
 	"fmt"
 	"strings"
-	"time"
 
 	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v9/pkg/cloudlets"
 	v3 "github.com/akamai/AkamaiOPEN-edgegrid-golang/v9/pkg/cloudlets/v3"
@@ -79,32 +78,29 @@ func (strategy *v3ActivationStrategy) reactivateVersion(ctx context.Context, pol
 }
 
 func (strategy *v3ActivationStrategy) waitForActivation(ctx context.Context, policyID, _ int64) (string, error) {
+	poller := newPolicyActivationPoller()
 	for {
-		select {
-		case <-time.After(tf.MaxDuration(ActivationPollInterval, ActivationPollMinimum)):
-			activation, err := strategy.client.GetPolicyActivation(ctx, v3.GetPolicyActivationRequest{
+		if err := poller.Wait(ctx); err != nil {
+			return "", policyActivationWaitError(err)
+		}
+		var activation *v3.PolicyActivation
+		err := retryActivationRequest(ctx, poller, policyActivationWaitError, func(ctx context.Context) (err error) {
+			activation, err = strategy.client.GetPolicyActivation(ctx, v3.GetPolicyActivationRequest{
 				PolicyID:     policyID,
 				ActivationID: strategy.activationID,
 			})
-			if err != nil {
-				return "", err
-			}
-			if activation != nil {
-				switch activation.Status {
-				case v3.ActivationStatusSuccess:
-					return strategy.getID(policyID, strategy.network), nil
-				case v3.ActivationStatusFailed:
-					return "", fmt.Errorf("activation failed for policy %d", policyID)
-				}
-			}
-		case <-ctx.Done():
-			if errors.Is(ctx.Err(), context.DeadlineExceeded) {
-				return "", ErrPolicyActivationTimeout
-			}
-			if errors.Is(ctx.Err(), context.Canceled) {
-				return "", ErrPolicyActivationCanceled
+			return err
+		})
+		if err != nil {
+			return "", err
+		}
+		if activation != nil {
+			switch activation.Status {
+			case v3.ActivationStatusSuccess:
+				return strategy.getID(policyID, strategy.network), nil
+			case v3.ActivationStatusFailed:
+				return "", fmt.Errorf("activation failed for policy %d", policyID)
 			}
-			return "", fmt.Errorf("%v: %w", ErrPolicyActivationContextTerminated, ctx.Err())
 		}
 	}
 }
@@ -0,0 +1,630 @@
+package cloudlets
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v9/pkg/cloudlets"
+	v3 "github.com/akamai/AkamaiOPEN-edgegrid-golang/v9/pkg/cloudlets/v3"
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v9/pkg/session"
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/common/ptr"
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/common/tf"
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/common/timeouts"
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/meta"
+	"github.com/apex/log"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+type (
+	// rampVersionStrategy reads and creates the policy versions of a ramp for either non-shared or shared policies
+	rampVersionStrategy interface {
+		getMatchRules(ctx context.Context, policyID, version int64) ([]interface{}, error)
+		createVersion(ctx context.Context, d *schema.ResourceData, policyID int64, matchRules []interface{}, description string) (int64, error)
+		readVersion(ctx context.Context, d *schema.ResourceData, policyID, version int64) (bool, error)
+	}
+
+	v2RampStrategy struct {
+		client          cloudlets.Cloudlets
+		matchRuleFormat cloudlets.MatchRuleFormat
+	}
+
+	v3RampStrategy struct {
+		client v3.Cloudlets
+	}
+)
+
+var (
+	// ErrPolicyRamp is returned when policy ramp fails
+	ErrPolicyRamp = errors.New("policy ramp")
+
+	// PolicyRampResourceTimeout is the default timeout for the resource operations
+	PolicyRampResourceTimeout = PolicyActivationResourceTimeout
+
+	// rampStepAttributes are the attributes which result in a new policy version when changed
+	rampStepAttributes = []string{"current_step", "percent", "base_version", "rule_name", "description"}
+)
+
+func resourceCloudletsPolicyRamp() *schema.Resource {
+	return &schema.Resource{
+		CustomizeDiff: planRampStep,
+		CreateContext: resourcePolicyRampCreate,
+		ReadContext:   resourcePolicyRampRead,
+		UpdateContext: resourcePolicyRampUpdate,
+		DeleteContext: resourcePolicyRampDelete,
+		Schema:        resourceCloudletsPolicyRampSchema(),
+		Timeouts: &schema.ResourceTimeout{
+			Default: &PolicyRampResourceTimeout,
+		},
+	}
+}
+
+func resourceCloudletsPolicyRampSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"policy_id": {
+			Type:        schema.TypeInt,
+			Required:    true,
+			ForceNew:    true,
+			Description: "ID of the phased release or visitor prioritization policy to ramp",
+		},
+		"base_version": {
+			Type:        schema.TypeInt,
+			Required:    true,
+			Description: "Version of the policy with the match rules used for every step of the ramp",
+		},
+		"rule_name": {
+			Type:        schema.TypeString,
+			Required:    true,
+			Description: "Name of the match rule whose percentage is ramped. Phased release rules ramp the percent of traffic sent to the origin, visitor prioritization rules ramp the pass through percent",
+		},
+		"steps": {
+			Type:     schema.TypeList,
+			Required: true,
+			MinItems: 1,
+			Elem: &schema.Schema{
+				Type:             schema.TypeFloat,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.FloatBetween(0, 100)),
+			},
+			Description: "The sequence of percentages the rule goes through, e.g. [1, 5, 25, 100]. Phased release rules only accept whole percentages",
+		},
+		"step_interval": {
+			Type:             schema.TypeString,
+			Optional:         true,
+			ValidateDiagFunc: timeouts.ValidateDurationFormat,
+			Description:      "Minimum time between two steps, e.g. '24h'. The next step is planned once the interval has elapsed since the previous step. When not provided, every apply advances the ramp by one step",
+		},
+		"freeze": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+			Description: "Stops advancing the ramp and keeps the current percentage",
+		},
+		"rollback": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+			Description: "Sets the percentage of the rule to 0 and stops advancing the ramp. The ramp resumes from the current step once unset",
+		},
+		"description": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "Description of the policy versions created for the steps. Defaults to a description with the step and percentage",
+		},
+		"network": {
+			Type:             schema.TypeString,
+			Required:         true,
+			ValidateDiagFunc: tf.ValidateNetwork,
+			StateFunc:        statePolicyActivationNetwork,
+			Description:      "The network on which the version of every step is activated (options are Staging and Production)",
+		},
+		"associated_properties": {
+			Type:        schema.TypeSet,
+			Optional:    true,
+			Elem:        &schema.Schema{Type: schema.TypeString},
+			Description: "Set of property IDs to link to the policy on activation. It is required for non-shared policies",
+		},
+		"is_shared": {
+			Type:        schema.TypeBool,
+			Computed:    true,
+			Description: "Indicates if the ramped policy is a shared policy",
+		},
+		"current_step": {
+			Type:        schema.TypeInt,
+			Computed:    true,
+			Description: "Index of the step the ramp is at, starting at 0",
+		},
+		"percent": {
+			Type:        schema.TypeFloat,
+			Computed:    true,
+			Description: "The percentage of the rule in the current policy version, 0 when rolled back",
+		},
+		"version": {
+			Type:        schema.TypeInt,
+			Computed:    true,
+			Description: "Version of the policy created for the current step",
+		},
+		"stepped_at": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The time the ramp reached the current step in RFC 3339 format",
+		},
+		"status": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Activation status of the current version on the given network",
+		},
+		"warnings": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "A JSON encoded list of warnings returned for the match rules of the current version",
+		},
+		"timeouts": {
+			Type:        schema.TypeList,
+			Optional:    true,
+			MaxItems:    1,
+			Description: "Enables to set timeout for processing",
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"default": {
+						Type:             schema.TypeString,
+						Optional:         true,
+						ValidateDiagFunc: timeouts.ValidateDurationFormat,
+					},
+				},
+			},
+		},
+	}
+}
+
+// planRampStep evaluates the schedule of the ramp at plan time and plans the step and percentage to apply
+func planRampStep(_ context.Context, diff *schema.ResourceDiff, _ interface{}) error {
+	if !diff.NewValueKnown("steps") {
+		for _, key := range []string{"current_step", "percent", "version", "stepped_at", "status", "warnings"} {
+			if err := diff.SetNewComputed(key); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	steps := diff.Get("steps").([]interface{})
+	rollback := diff.Get("rollback").(bool)
+
+	var step int
+	if diff.Id() != "" {
+		step = diff.Get("current_step").(int)
+		// the ramp resumes from the current step after a rollback is undone
+		if !rollback && !diff.Get("freeze").(bool) && !diff.HasChange("rollback") {
+			var interval time.Duration
+			if value := diff.Get("step_interval").(string); value != "" {
+				var err error
+				if interval, err = time.ParseDuration(value); err != nil {
+					return err
+				}
+			}
+			steppedAt, err := time.Parse(time.RFC3339, diff.Get("stepped_at").(string))
+			if err != nil {
+				return fmt.Errorf("invalid 'stepped_at' value: %s", err)
+			}
+			step = nextRampStep(step, len(steps), interval, steppedAt, time.Now())
+		}
+		step = min(step, len(steps)-1)
+	}
+
+	percent := steps[step].(float64)
+	if rollback {
+		percent = 0
+	}
+
+	if diff.Id() == "" || step != diff.Get("current_step").(int) {
+		if err := diff.SetNew("current_step", step); err != nil {
+			return err
+		}
+		if err := diff.SetNewComputed("stepped_at"); err != nil {
+			return err
+		}
+	}
+	if diff.Id() == "" || percent != diff.Get("percent").(float64) {
+		if err := diff.SetNew("percent", percent); err != nil {
+			return err
+		}
+	}
+	if diff.Id() == "" {
+		return nil
+	}
+
+	if diff.HasChanges(rampStepAttributes...) {
+		for _, key := range []string{"version", "status", "warnings"} {
+			if err := diff.SetNewComputed(key); err != nil {
+				return err
+			}
+		}
+	} else if diff.HasChanges("network", "associated_properties") {
+		return diff.SetNewComputed("status")
+	}
+	return nil
+}
+
+// nextRampStep returns the step the ramp advances to. Without an interval the ramp advances on every apply, otherwise
+// once the interval has elapsed since the current step was reached. The ramp never skips steps
+func nextRampStep(current, stepCount int, interval time.Duration, steppedAt, now time.Time) int {
+	if current >= stepCount-1 {
+		return current
+	}
+	if interval > 0 && now.Sub(steppedAt) < interval {
+		return current
+	}
+	return current + 1
+}
+
+func resourcePolicyRampCreate(ctx context.Context, rd *schema.ResourceData, m interface{}) diag.Diagnostics {
+	meta := meta.Must(m)
+	logger := meta.Log("Cloudlets", "resourcePolicyRampCreate")
+	ctx = session.ContextWithOptions(ctx, session.WithContextLog(logger))
+
+	logger.Debug("Creating policy ramp")
+
+	policyID, err := tf.GetIntValueAsInt64("policy_id", rd)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	activationStrategy, isShared, err := discoverActivationStrategy(ctx, policyID, meta, logger)
+	if err != nil {
+		return diag.Errorf("%v create: %s", ErrPolicyRamp, err)
+	}
+	if err = rd.Set("is_shared", isShared); err != nil {
+		return diag.Errorf("%v: %s", tf.ErrValueSet, err.Error())
+	}
+
+	version, err := createRampVersion(ctx, getRampVersionStrategy(rd, meta), rd, policyID)
+	if err != nil {
+		return diag.Errorf("%v create: %s", ErrPolicyRamp, err)
+	}
+	rd.SetId(strconv.FormatInt(policyID, 10))
+	if err = rd.Set("stepped_at", time.Now().UTC().Format(time.RFC3339)); err != nil {
+		return diag.Errorf("%v: %s", tf.ErrValueSet, err.Error())
+	}
+
+	if diags := activateRampVersion(ctx, logger, activationStrategy, rd, policyID, version, "create"); diags != nil {
+		return diags
+	}
+
+	return resourcePolicyRampRead(ctx, rd, m)
+}
+
+func resourcePolicyRampRead(ctx context.Context, rd *schema.ResourceData, m interface{}) diag.Diagnostics {
+	meta := meta.Must(m)
+	logger := meta.Log("Cloudlets", "resourcePolicyRampRead")
+	ctx = session.ContextWithOptions(ctx, session.WithContextLog(logger))
+
+	logger.Debug("Reading policy ramp")
+
+	policyID, err := tf.GetIntValueAsInt64("policy_id", rd)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	version, err := tf.GetIntValueAsInt64("version", rd)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	network, err := tf.GetStringValue("network", rd)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	found, err := getRampVersionStrategy(rd, meta).readVersion(ctx, rd, policyID, version)
+	if err != nil {
+		return diag.Errorf("%v read: %s", ErrPolicyRamp, err)
+	}
+	if !found {
+		logger.Warnf("Version %d of policy %d was not found, removing the ramp from state", version, policyID)
+		rd.SetId("")
+		return nil
+	}
+
+	attrs, err := getActivationStrategy(rd, meta, logger).readActivationFromServer(ctx, policyID, network)
+	if err != nil {
+		return diag.Errorf("%v read: %s", ErrPolicyRamp, err)
+	}
+	var status string
+	if attrs != nil && attrs["version"] == version {
+		status = fmt.Sprint(attrs["status"])
+	}
+	if err = rd.Set("status", status); err != nil {
+		return diag.Errorf("%v: %s", tf.ErrValueSet, err.Error())
+	}
+
+	return nil
+}
+
+func resourcePolicyRampUpdate(ctx context.Context, rd *schema.ResourceData, m interface{}) diag.Diagnostics {
+	meta := meta.Must(m)
+	logger := meta.Log("Cloudlets", "resourcePolicyRampUpdate")
+	ctx = session.ContextWithOptions(ctx, session.WithContextLog(logger))
+
+	if !rd.HasChanges(append(rampStepAttributes, "network", "associated_properties")...) {
+		logger.Debug("No change affecting the policy version or its activation, skipping")
+		return nil
+	}
+
+	policyID, err := tf.GetIntValueAsInt64("policy_id", rd)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	var version int64
+	if rd.HasChanges(rampStepAttributes...) {
+		version, err = createRampVersion(ctx, getRampVersionStrategy(rd, meta), rd, policyID)
+		if err != nil {
+			if restoreDiags := diag.FromErr(tf.RestoreOldValues(rd, rampStepAttributes)); restoreDiags != nil {
+				return append(restoreDiags, diag.Errorf("%v update: %s", ErrPolicyRamp, err)...)
+			}
+			return diag.Errorf("%v update: %s", ErrPolicyRamp, err)
+		}
+		if rd.HasChange("current_step") {
+			if err = rd.Set("stepped_at", time.Now().UTC().Format(time.RFC3339)); err != nil {
+				return diag.Errorf("%v: %s", tf.ErrValueSet, err.Error())
+			}
+		}
+	} else if version, err = tf.GetIntValueAsInt64("version", rd); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if diags := activateRampVersion(ctx, logger, getActivationStrategy(rd, meta, logger), rd, policyID, version, "update"); diags != nil {
+		// the step is not reached until its version is active, so it is planned again
+		if restoreDiags := diag.FromErr(tf.RestoreOldValues(rd, append(rampStepAttributes, "stepped_at"))); restoreDiags != nil {
+			return append(restoreDiags, diags...)
+		}
+		return diags
+	}
+
+	return resourcePolicyRampRead(ctx, rd, m)
+}
+
+func resourcePolicyRampDelete(_ context.Context, rd *schema.ResourceData, m interface{}) diag.Diagnostics {
+	meta := meta.Must(m)
+	logger := meta.Log("Cloudlets", "resourcePolicyRampDelete")
+	logger.Debug("Deleting policy ramp")
+
+	// the versions created for the steps remain in the policy, the last one stays active
+	logger.Infof("Removing ramp of policy %s from state, the policy versions and activations are kept", rd.Id())
+	rd.SetId("")
+	return nil
+}
+
+func getRampVersionStrategy(rd *schema.ResourceData, m meta.Meta) rampVersionStrategy {
+	if rd.Get("is_shared").(bool) {
+		return &v3RampStrategy{client: ClientV3(m)}
+	}
+	return &v2RampStrategy{client: Client(m)}
+}
+
+// createRampVersion creates a new version of the policy with the match rules of the base version and the percentage
+// of the ramped rule set to the planned percent
+func createRampVersion(ctx context.Context, strategy rampVersionStrategy, rd *schema.ResourceData, policyID int64) (int64, error) {
+	baseVersion, err := tf.GetIntValueAsInt64("base_version", rd)
+	if err != nil {
+		return 0, err
+	}
+	ruleName, err := tf.GetStringValue("rule_name", rd)
+	if err != nil {
+		return 0, err
+	}
+	description, err := tf.GetStringValue("description", rd)
+	if err != nil && !errors.Is(err, tf.ErrNotFound) {
+		return 0, err
+	}
+	step := rd.Get("current_step").(int)
+	stepCount := len(rd.Get("steps").([]interface{}))
+	percent := rd.Get("percent").(float64)
+	if description == "" {
+		description = fmt.Sprintf("Ramp of rule '%s' to %s%% (step %d of %d)", ruleName, strconv.FormatFloat(percent, 'f', -1, 64), step+1, stepCount)
+		if rd.Get("rollback").(bool) {
+			description = fmt.Sprintf("Rollback of rule '%s' to 0%%", ruleName)
+		}
+	}
+
+	matchRules, err := strategy.getMatchRules(ctx, policyID, baseVersion)
+	if err != nil {
+		return 0, fmt.Errorf("could not get version %d of policy %d: %s", baseVersion, policyID, err)
+	}
+	if err = setRulePercent(matchRules, ruleName, percent); err != nil {
+		return 0, fmt.Errorf("version %d of policy %d: %s", baseVersion, policyID, err)
+	}
+
+	version, err := strategy.createVersion(ctx, rd, policyID, matchRules, description)
+	if err != nil {
+		return 0, fmt.Errorf("could not create version of policy %d: %s", policyID, err)
+	}
+	if err = rd.Set("version", version); err != nil {
+		return 0, fmt.Errorf("%w: %s", tf.ErrValueSet, err.Error())
+	}
+	return version, nil
+}
+
+// activateRampVersion activates the version of the current step on the network, unless it is already active
+func activateRampVersion(ctx context.Context, logger log.Interface, strategy activationStrategy, rd *schema.ResourceData, policyID, version int64, operation string) diag.Diagnostics {
+	network, err := tf.GetStringValue("network", rd)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if err = strategy.setupCloudletSpecificData(rd, network); err != nil {
+		return diag.Errorf("%v %s: %s", ErrPolicyActivation, operation, err)
+	}
+
+	isActive, _, err := strategy.isVersionAlreadyActive(ctx, policyID, version)
+	if err != nil {
+		return diag.Errorf("%v %s: %s", ErrPolicyActivation, operation, err)
+	}
+	if isActive {
+		logger.Debugf("This policy (ID=%d, version=%d) is already active.", policyID, version)
+		return nil
+	}
+
+	if diags := activateVersionWithRetry(ctx, logger, strategy, policyID, version, operation); diags != nil {
+		return diags
+	}
+	if _, err = strategy.waitForActivation(ctx, policyID, version); err != nil {
+		return diag.Errorf("%v %s: %s", ErrPolicyActivation, operation, err)
+	}
+	return nil
+}
+
+// setRulePercent sets the percentage of the match rule with the given name. Phased release (cdMatchRule) rules use
+// the percent of the forward settings, visitor prioritization (vpMatchRule) rules the pass through percent
+func setRulePercent(matchRules []interface{}, ruleName string, percent float64) error {
+	var found bool
+	for _, item := range matchRules {
+		rule, ok := item.(map[string]interface{})
+		if !ok || rule["name"] != ruleName {
+			continue
+		}
+		if found {
+			return fmt.Errorf("more than one match rule is named '%s'", ruleName)
+		}
+		found = true
+
+		switch ruleType := rule["type"]; ruleType {
+		case string(cloudlets.MatchRuleTypePR):
+			if percent != math.Trunc(percent) {
+				return fmt.Errorf("phased release rule '%s' only supports whole percentages: %v", ruleName, percent)
+			}
+			forwardSettings, ok := rule["forwardSettings"].(map[string]interface{})
+			if !ok {
+				return fmt.Errorf("phased release rule '%s' has no forward settings", ruleName)
+			}
+			forwardSettings["percent"] = int(percent)
+		case string(cloudlets.MatchRuleTypeVP):
+			rule["passThroughPercent"] = percent
+		default:
+			return fmt.Errorf("match rule '%s' is of type '%v': only phased release (%s) and visitor prioritization (%s) rules can be ramped",
+				ruleName, ruleType, cloudlets.MatchRuleTypePR, cloudlets.MatchRuleTypeVP)
+		}
+	}
+	if !found {
+		return fmt.Errorf("match rule '%s' does not exist", ruleName)
+	}
+	return nil
+}
+
+// toGenericMatchRules converts typed match rules to their JSON representation, so that they can be modified
+// regardless of the policy type
+func toGenericMatchRules(matchRules interface{}) ([]interface{}, error) {
+	rulesJSON, err := json.Marshal(matchRules)
+	if err != nil {
+		return nil, fmt.Errorf("marshalling match rules: %s", err)
+	}
+	var rules []interface{}
+	if err = json.Unmarshal(rulesJSON, &rules); err != nil {
+		return nil, fmt.Errorf("unmarshalling match rules: %s", err)
+	}
+	return rules, nil
+}
+
+func fromGenericMatchRules(rules []interface{}, matchRules json.Unmarshaler) error {
+	rulesJSON, err := json.Marshal(rules)
+	if err != nil {
+		return fmt.Errorf("marshalling match rules: %s", err)
+	}
+	if err = matchRules.UnmarshalJSON(rulesJSON); err != nil {
+		return fmt.Errorf("unmarshalling match rules: %s", err)
+	}
+	return nil
+}
+
+func (strategy *v2RampStrategy) getMatchRules(ctx context.Context, policyID, version int64) ([]interface{}, error) {
+	policyVersion, err := strategy.client.GetPolicyVersion(ctx, cloudlets.GetPolicyVersionRequest{PolicyID: policyID, Version: version})
+	if err != nil {
+		return nil, err
+	}
+	strategy.matchRuleFormat = policyVersion.MatchRuleFormat
+	return toGenericMatchRules(policyVersion.MatchRules)
+}
+
+func (strategy *v2RampStrategy) createVersion(ctx context.Context, d *schema.ResourceData, policyID int64, rules []interface{}, description string) (int64, error) {
+	matchRules := make(cloudlets.MatchRules, 0, len(rules))
+	if err := fromGenericMatchRules(rules, &matchRules); err != nil {
+		return 0, err
+	}
+	created, err := strategy.client.CreatePolicyVersion(ctx, cloudlets.CreatePolicyVersionRequest{
+		CreatePolicyVersion: cloudlets.CreatePolicyVersion{
+			Description:     description,
+			MatchRuleFormat: strategy.matchRuleFormat,
+			MatchRules:      matchRules,
+		},
+		PolicyID: policyID,
+	})
+	if err != nil {
+		return 0, err
+	}
+	if err = setWarnings(d, created.Warnings); err != nil {
+		return 0, fmt.Errorf("%w: %s", tf.ErrValueSet, err.Error())
+	}
+	return created.Version, nil
+}
+
+func (strategy *v2RampStrategy) readVersion(ctx context.Context, d *schema.ResourceData, policyID, version int64) (bool, error) {
+	policyVersion, err := strategy.client.GetPolicyVersion(ctx, cloudlets.GetPolicyVersionRequest{PolicyID: policyID, Version: version, OmitRules: true})
+	if err != nil {
+		var apiError *cloudlets.Error
+		if errors.As(err, &apiError) && apiError.StatusCode == http.StatusNotFound {
+			return false, nil
+		}
+		return false, err
+	}
+	if err = setWarnings(d, policyVersion.Warnings); err != nil {
+		return false, fmt.Errorf("%w: %s", tf.ErrValueSet, err.Error())
+	}
+	return true, nil
+}
+
+func (strategy *v3RampStrategy) getMatchRules(ctx context.Context, policyID, version int64) ([]interface{}, error) {
+	policyVersion, err := strategy.client.GetPolicyVersion(ctx, v3.GetPolicyVersionRequest{PolicyID: policyID, PolicyVersion: version})
+	if err != nil {
+		return nil, err
+	}
+	return toGenericMatchRules(policyVersion.MatchRules)
+}
+
+func (strategy *v3RampStrategy) createVersion(ctx context.Context, d *schema.ResourceData, policyID int64, rules []interface{}, description string) (int64, error) {
+	matchRules := make(v3.MatchRules, 0, len(rules))
+	if err := fromGenericMatchRules(rules, &matchRules); err != nil {
+		return 0, err
+	}
+	created, err := strategy.client.CreatePolicyVersion(ctx, v3.CreatePolicyVersionRequest{
+		CreatePolicyVersion: v3.CreatePolicyVersion{
+			MatchRules:  matchRules,
+			Description: ptr.To(description),
+		},
+		PolicyID: policyID,
+	})
+	if err != nil {
+		return 0, err
+	}
+	if err = setWarnings(d, created.MatchRulesWarnings); err != nil {
+		return 0, fmt.Errorf("%w: %s", tf.ErrValueSet, err.Error())
+	}
+	return created.PolicyVersion, nil
+}
+
+func (strategy *v3RampStrategy) readVersion(ctx context.Context, d *schema.ResourceData, policyID, version int64) (bool, error) {
+	policyVersion, err := strategy.client.GetPolicyVersion(ctx, v3.GetPolicyVersionRequest{PolicyID: policyID, PolicyVersion: version})
+	if err != nil {
+		var apiError *v3.Error
+		if errors.As(err, &apiError) && apiError.Status == http.StatusNotFound {
+			return false, nil
+		}
+		return false, err
+	}
+	if err = setWarnings(d, policyVersion.MatchRulesWarnings); err != nil {
+		return false, fmt.Errorf("%w: %s", tf.ErrValueSet, err.Error())
+	}
+	return true, nil
+}
@@ -0,0 +1,316 @@
+package cloudlets
+
+import (
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v9/pkg/cloudlets"
+	v3 "github.com/akamai/AkamaiOPEN-edgegrid-golang/v9/pkg/cloudlets/v3"
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/common/ptr"
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/common/testutils"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResourceCloudletsPolicyRamp(t *testing.T) {
+	rulesWithPercent := func(percent int) v3.MatchRules {
+		return v3.MatchRules{
+			&v3.MatchRulePR{
+				Name:            "canary",
+				Type:            v3.MatchRuleTypePR,
+				ID:              7,
+				MatchURL:        "https://www.example.com/beta/*",
+				ForwardSettings: v3.ForwardSettingsPR{OriginID: "origin-beta", Percent: percent},
+			},
+			&v3.MatchRulePR{
+				Name:            "stable",
+				Type:            v3.MatchRuleTypePR,
+				ID:              8,
+				MatchesAlways:   true,
+				ForwardSettings: v3.ForwardSettingsPR{OriginID: "origin-stable", Percent: 100},
+			},
+		}
+	}
+	checkRamp := func(step, percent, version string) resource.TestCheckFunc {
+		return resource.ComposeAggregateTestCheckFunc(
+			resource.TestCheckResourceAttr("akamai_cloudlets_policy_ramp.test", "id", "3000"),
+			resource.TestCheckResourceAttr("akamai_cloudlets_policy_ramp.test", "is_shared", "true"),
+			resource.TestCheckResourceAttr("akamai_cloudlets_policy_ramp.test", "current_step", step),
+			resource.TestCheckResourceAttr("akamai_cloudlets_policy_ramp.test", "percent", percent),
+			resource.TestCheckResourceAttr("akamai_cloudlets_policy_ramp.test", "version", version),
+			resource.TestCheckResourceAttr("akamai_cloudlets_policy_ramp.test", "status", string(v3.ActivationStatusSuccess)),
+			resource.TestCheckResourceAttrSet("akamai_cloudlets_policy_ramp.test", "stepped_at"),
+		)
+	}
+
+	tests := map[string]struct {
+		init  func(*cloudlets.Mock, *v3.Mock, *v3.Policy)
+		steps []resource.TestStep
+	}{
+		"ramp across applies, roll back, resume and freeze": {
+			init: func(m2 *cloudlets.Mock, m3 *v3.Mock, policy *v3.Policy) {
+				expectToDiscoverPolicyAsV3(m2, m3, 3000)
+				expectGetRampPolicy(m3, policy)
+				expectGetRampBaseVersion(m3, rulesWithPercent(5))
+				expectCreateRampVersion(m3, rulesWithPercent(10), "Ramp of rule 'canary' to 10% (step 1 of 3)", 2)
+				expectRampActivation(m3, policy, 2)
+				expectCreateRampVersion(m3, rulesWithPercent(50), "Ramp of rule 'canary' to 50% (step 2 of 3)", 3)
+				expectRampActivation(m3, policy, 3)
+				expectCreateRampVersion(m3, rulesWithPercent(0), "Rollback of rule 'canary' to 0%", 4)
+				expectRampActivation(m3, policy, 4)
+				expectCreateRampVersion(m3, rulesWithPercent(50), "Ramp of rule 'canary' to 50% (step 2 of 3)", 5)
+				expectRampActivation(m3, policy, 5)
+			},
+			steps: []resource.TestStep{
+				{
+					Config:             testutils.LoadFixtureString(t, "testdata/TestResourceCloudletsPolicyRamp/ramp.tf"),
+					Check:              checkRamp("0", "10", "2"),
+					ExpectNonEmptyPlan: true,
+				},
+				{
+					Config:             testutils.LoadFixtureString(t, "testdata/TestResourceCloudletsPolicyRamp/ramp.tf"),
+					Check:              checkRamp("1", "50", "3"),
+					ExpectNonEmptyPlan: true,
+				},
+				{
+					Config: testutils.LoadFixtureString(t, "testdata/TestResourceCloudletsPolicyRamp/ramp_rollback.tf"),
+					Check:  checkRamp("1", "0", "4"),
+				},
+				{
+					Config:             testutils.LoadFixtureString(t, "testdata/TestResourceCloudletsPolicyRamp/ramp.tf"),
+					Check:              checkRamp("1", "50", "5"),
+					ExpectNonEmptyPlan: true,
+				},
+				{
+					Config: testutils.LoadFixtureString(t, "testdata/TestResourceCloudletsPolicyRamp/ramp_frozen.tf"),
+					Check:  checkRamp("1", "50", "5"),
+				},
+			},
+		},
+		"failed activation keeps the step to be applied again": {
+			init: func(m2 *cloudlets.Mock, m3 *v3.Mock, policy *v3.Policy) {
+				expectToDiscoverPolicyAsV3(m2, m3, 3000)
+				expectGetRampPolicy(m3, policy)
+				expectGetRampBaseVersion(m3, rulesWithPercent(5))
+				expectCreateRampVersion(m3, rulesWithPercent(10), "Ramp of rule 'canary' to 10% (step 1 of 3)", 2)
+				expectRampActivation(m3, policy, 2)
+				expectCreateRampVersion(m3, rulesWithPercent(50), "Ramp of rule 'canary' to 50% (step 2 of 3)", 3)
+				expectActivateV3PolicyVersion(m3, 3000, 3, 300, v3.StagingNetwork, nil).Once()
+				expectWaitForV3Activation(m3, 3000, 300, []v3.ActivationStatus{v3.ActivationStatusFailed}, nil)
+				expectCreateRampVersion(m3, rulesWithPercent(50), "Ramp of rule 'canary' to 50% (step 2 of 3)", 4)
+				expectRampActivation(m3, policy, 4)
+			},
+			steps: []resource.TestStep{
+				{
+					Config:             testutils.LoadFixtureString(t, "testdata/TestResourceCloudletsPolicyRamp/ramp.tf"),
+					Check:              checkRamp("0", "10", "2"),
+					ExpectNonEmptyPlan: true,
+				},
+				{
+					Config:      testutils.LoadFixtureString(t, "testdata/TestResourceCloudletsPolicyRamp/ramp.tf"),
+					ExpectError: regexp.MustCompile("activation failed for policy 3000"),
+				},
+				{
+					Config:             testutils.LoadFixtureString(t, "testdata/TestResourceCloudletsPolicyRamp/ramp.tf"),
+					Check:              checkRamp("1", "50", "4"),
+					ExpectNonEmptyPlan: true,
+				},
+			},
+		},
+		"scheduled ramp waits for the interval": {
+			init: func(m2 *cloudlets.Mock, m3 *v3.Mock, policy *v3.Policy) {
+				expectToDiscoverPolicyAsV3(m2, m3, 3000)
+				expectGetRampPolicy(m3, policy)
+				expectGetRampBaseVersion(m3, rulesWithPercent(5))
+				expectCreateRampVersion(m3, rulesWithPercent(10), "Ramp of rule 'canary' to 10% (step 1 of 3)", 2)
+				expectRampActivation(m3, policy, 2)
+			},
+			steps: []resource.TestStep{
+				{
+					Config: testutils.LoadFixtureString(t, "testdata/TestResourceCloudletsPolicyRamp/ramp_schedule.tf"),
+					Check:  checkRamp("0", "10", "2"),
+				},
+				{
+					Config:   testutils.LoadFixtureString(t, "testdata/TestResourceCloudletsPolicyRamp/ramp_schedule.tf"),
+					PlanOnly: true,
+				},
+			},
+		},
+		"missing rule": {
+			init: func(m2 *cloudlets.Mock, m3 *v3.Mock, _ *v3.Policy) {
+				expectToDiscoverPolicyAsV3(m2, m3, 3000)
+				expectGetRampBaseVersion(m3, rulesWithPercent(5))
+			},
+			steps: []resource.TestStep{
+				{
+					Config:      testutils.LoadFixtureString(t, "testdata/TestResourceCloudletsPolicyRamp/ramp_missing_rule.tf"),
+					ExpectError: regexp.MustCompile("version 1 of policy 3000: match rule 'missing' does not exist"),
+				},
+			},
+		},
+	}
+
+	// redefining times to accelerate tests
+	ActivationPollMinimum, ActivationPollInterval, PolicyActivationRetryPollMinimum = time.Millisecond, time.Millisecond, time.Millisecond
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			clientV2 := &cloudlets.Mock{}
+			clientV3 := &v3.Mock{}
+			test.init(clientV2, clientV3, &v3.Policy{ID: 3000})
+			useClientV2AndV3(clientV2, clientV3, func() {
+				resource.UnitTest(t, resource.TestCase{
+					ProtoV6ProviderFactories: testutils.NewProtoV6ProviderFactory(NewSubprovider()),
+					IsUnitTest:               true,
+					Steps:                    test.steps,
+				})
+			})
+			clientV2.AssertExpectations(t)
+			clientV3.AssertExpectations(t)
+		})
+	}
+}
+
+func TestNextRampStep(t *testing.T) {
+	steppedAt := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := map[string]struct {
+		current  int
+		interval time.Duration
+		now      time.Time
+		expected int
+	}{
+		"advances on every apply without interval": {
+			current:  0,
+			now:      steppedAt,
+			expected: 1,
+		},
+		"stays at the last step": {
+			current:  2,
+			now:      steppedAt.Add(48 * time.Hour),
+			expected: 2,
+		},
+		"waits for the interval": {
+			current:  1,
+			interval: 24 * time.Hour,
+			now:      steppedAt.Add(23 * time.Hour),
+			expected: 1,
+		},
+		"advances once the interval elapsed": {
+			current:  1,
+			interval: 24 * time.Hour,
+			now:      steppedAt.Add(24 * time.Hour),
+			expected: 2,
+		},
+		"does not skip steps": {
+			current:  0,
+			interval: time.Hour,
+			now:      steppedAt.Add(10 * time.Hour),
+			expected: 1,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.expected, nextRampStep(test.current, 3, test.interval, steppedAt, test.now))
+		})
+	}
+}
+
+func TestSetRulePercent(t *testing.T) {
+	rules := func() []interface{} {
+		return []interface{}{
+			map[string]interface{}{"name": "canary", "type": "cdMatchRule", "forwardSettings": map[string]interface{}{"originId": "beta", "percent": 5.0}},
+			map[string]interface{}{"name": "waiting room", "type": "vpMatchRule", "passThroughPercent": 10.0},
+			map[string]interface{}{"name": "redirect", "type": "erMatchRule"},
+			map[string]interface{}{"name": "twice", "type": "vpMatchRule"},
+			map[string]interface{}{"name": "twice", "type": "vpMatchRule"},
+		}
+	}
+
+	tests := map[string]struct {
+		ruleName      string
+		percent       float64
+		check         func(*testing.T, []interface{})
+		expectedError string
+	}{
+		"phased release rule": {
+			ruleName: "canary",
+			percent:  25,
+			check: func(t *testing.T, rules []interface{}) {
+				assert.Equal(t, 25, rules[0].(map[string]interface{})["forwardSettings"].(map[string]interface{})["percent"])
+			},
+		},
+		"visitor prioritization rule": {
+			ruleName: "waiting room",
+			percent:  12.5,
+			check: func(t *testing.T, rules []interface{}) {
+				assert.Equal(t, 12.5, rules[1].(map[string]interface{})["passThroughPercent"])
+			},
+		},
+		"fraction for phased release rule": {
+			ruleName:      "canary",
+			percent:       12.5,
+			expectedError: "phased release rule 'canary' only supports whole percentages: 12.5",
+		},
+		"unsupported rule type": {
+			ruleName:      "redirect",
+			percent:       10,
+			expectedError: "match rule 'redirect' is of type 'erMatchRule': only phased release (cdMatchRule) and visitor prioritization (vpMatchRule) rules can be ramped",
+		},
+		"ambiguous rule name": {
+			ruleName:      "twice",
+			percent:       10,
+			expectedError: "more than one match rule is named 'twice'",
+		},
+		"missing rule": {
+			ruleName:      "missing",
+			percent:       10,
+			expectedError: "match rule 'missing' does not exist",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			matchRules := rules()
+			err := setRulePercent(matchRules, test.ruleName, test.percent)
+			if test.expectedError != "" {
+				require.Error(t, err)
+				assert.Equal(t, test.expectedError, err.Error())
+				return
+			}
+			require.NoError(t, err)
+			test.check(t, matchRules)
+		})
+	}
+}
+
+// expectGetRampPolicy returns the policy with its current activations, which are updated on every activation
+func expectGetRampPolicy(m *v3.Mock, policy *v3.Policy) *mock.Call {
+	return m.On("GetPolicy", mock.Anything, v3.GetPolicyRequest{PolicyID: 3000}).Return(policy, nil)
+}
+
+func expectGetRampBaseVersion(m *v3.Mock, rules v3.MatchRules) *mock.Call {
+	return m.On("GetPolicyVersion", mock.Anything, v3.GetPolicyVersionRequest{PolicyID: 3000, PolicyVersion: 1}).
+		Return(&v3.PolicyVersion{PolicyID: 3000, PolicyVersion: 1, MatchRules: rules}, nil)
+}
+
+func expectCreateRampVersion(m *v3.Mock, rules v3.MatchRules, description string, version int64) {
+	m.On("CreatePolicyVersion", mock.Anything, v3.CreatePolicyVersionRequest{
+		CreatePolicyVersion: v3.CreatePolicyVersion{MatchRules: rules, Description: ptr.To(description)},
+		PolicyID:            3000,
+	}).Return(&v3.PolicyVersion{PolicyID: 3000, PolicyVersion: version, Description: ptr.To(description)}, nil).Once()
+	m.On("GetPolicyVersion", mock.Anything, v3.GetPolicyVersionRequest{PolicyID: 3000, PolicyVersion: version}).
+		Return(&v3.PolicyVersion{PolicyID: 3000, PolicyVersion: version}, nil)
+}
+
+func expectRampActivation(m *v3.Mock, policy *v3.Policy, version int64) {
+	expectActivateV3PolicyVersion(m, 3000, version, version*100, v3.StagingNetwork, nil).Once().
+		Run(func(_ mock.Arguments) {
+			policy.CurrentActivations = prepareActivatedResponseForNetwork(3000, version, v3.StagingNetwork)
+		})
+	expectWaitForV3Activation(m, 3000, version*100, []v3.ActivationStatus{v3.ActivationStatusSuccess}, nil)
+}
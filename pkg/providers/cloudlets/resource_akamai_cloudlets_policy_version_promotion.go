@@ -0,0 +1,615 @@
+package cloudlets
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+
+	v3 "github.com/akamai/AkamaiOPEN-edgegrid-golang/v9/pkg/cloudlets/v3"
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v9/pkg/session"
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/common/ptr"
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/common/tf"
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/common/timeouts"
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/meta"
+	"github.com/apex/log"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+type (
+	// matchRulesTransformation describes the changes applied to the match rules copied from the source policy
+	matchRulesTransformation struct {
+		hostnames []hostnameSubstitution
+		origins   map[string]string
+	}
+
+	hostnameSubstitution struct {
+		pattern *regexp.Regexp
+		to      string
+	}
+)
+
+var (
+	// ErrPolicyVersionPromotion is returned when policy version promotion fails
+	ErrPolicyVersionPromotion = errors.New("policy version promotion")
+
+	// PolicyVersionPromotionResourceTimeout is the default timeout for the resource operations
+	PolicyVersionPromotionResourceTimeout = PolicyActivationResourceTimeout
+
+	// promotionAttributes lists the attributes which require the match rules to be promoted again
+	promotionAttributes = []string{"source_version", "description", "hostname_substitution", "origin_substitution"}
+
+	// promotionComputedAttributes lists the attributes computed by the promotion and the activation of the target version
+	promotionComputedAttributes = []string{"target_version", "promotions", "warnings", "status"}
+)
+
+func resourceCloudletsPolicyVersionPromotion() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourcePolicyVersionPromotionCreate,
+		ReadContext:   resourcePolicyVersionPromotionRead,
+		UpdateContext: resourcePolicyVersionPromotionUpdate,
+		DeleteContext: resourcePolicyVersionPromotionDelete,
+		CustomizeDiff: enforcePromotedVersionChange,
+		Schema:        resourceCloudletsPolicyVersionPromotionSchema(),
+		Timeouts: &schema.ResourceTimeout{
+			Default: &PolicyVersionPromotionResourceTimeout,
+		},
+	}
+}
+
+func resourceCloudletsPolicyVersionPromotionSchema() map[string]*schema.Schema {
+	substitution := func(description string) *schema.Resource {
+		return &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"from": {
+					Type:        schema.TypeString,
+					Required:    true,
+					Description: fmt.Sprintf("The %s used in the match rules of the source policy", description),
+				},
+				"to": {
+					Type:        schema.TypeString,
+					Required:    true,
+					Description: fmt.Sprintf("The %s used in the match rules of the target policy instead", description),
+				},
+			},
+		}
+	}
+
+	return map[string]*schema.Schema{
+		"source_policy_id": {
+			Type:        schema.TypeInt,
+			Required:    true,
+			ForceNew:    true,
+			Description: "ID of the shared policy to copy the match rules from",
+		},
+		"source_version": {
+			Type:        schema.TypeInt,
+			Required:    true,
+			Description: "Version of the source policy to promote",
+		},
+		"target_policy_id": {
+			Type:        schema.TypeInt,
+			Required:    true,
+			ForceNew:    true,
+			Description: "ID of the shared policy in which a new version with the promoted match rules is created. It has to be a policy of the same cloudlet type as the source policy",
+		},
+		"description": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "Description of the created policy version. Defaults to a description referencing the source policy version",
+		},
+		"hostname_substitution": {
+			Type:        schema.TypeList,
+			Optional:    true,
+			Elem:        substitution("hostname"),
+			Description: "Hostnames to replace in the match URLs, redirect URLs and match values of the promoted match rules. Hostnames are compared case-insensitively",
+		},
+		"origin_substitution": {
+			Type:        schema.TypeList,
+			Optional:    true,
+			Elem:        substitution("origin ID"),
+			Description: "Origin IDs to replace in the forward settings of the promoted match rules",
+		},
+		"network": {
+			Type:             schema.TypeString,
+			Optional:         true,
+			ValidateDiagFunc: tf.ValidateNetwork,
+			StateFunc:        statePolicyActivationNetwork,
+			Description:      "The network on which the promoted version is activated (options are Staging and Production). The version is not activated if not provided",
+		},
+		"target_version": {
+			Type:        schema.TypeInt,
+			Computed:    true,
+			Description: "Version of the target policy created with the promoted match rules",
+		},
+		"status": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Activation status of the target version on the given network",
+		},
+		"warnings": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "A JSON encoded list of warnings returned for the match rules of the target version",
+		},
+		"promotions": {
+			Type:        schema.TypeList,
+			Computed:    true,
+			Description: "Audit log of the promotions performed by this resource",
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"source_policy_id": {
+						Type:        schema.TypeInt,
+						Computed:    true,
+						Description: "ID of the policy the match rules were copied from",
+					},
+					"source_version": {
+						Type:        schema.TypeInt,
+						Computed:    true,
+						Description: "Version of the policy the match rules were copied from",
+					},
+					"target_policy_id": {
+						Type:        schema.TypeInt,
+						Computed:    true,
+						Description: "ID of the policy the match rules were copied to",
+					},
+					"target_version": {
+						Type:        schema.TypeInt,
+						Computed:    true,
+						Description: "Version of the policy the match rules were copied to",
+					},
+					"network": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "The network the target version was activated on, empty if it was not activated",
+					},
+					"promoted_at": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "The time of the promotion in RFC 3339 format",
+					},
+				},
+			},
+		},
+		"timeouts": {
+			Type:        schema.TypeList,
+			Optional:    true,
+			MaxItems:    1,
+			Description: "Enables to set timeout for processing",
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"default": {
+						Type:             schema.TypeString,
+						Optional:         true,
+						ValidateDiagFunc: timeouts.ValidateDurationFormat,
+					},
+				},
+			},
+		},
+	}
+}
+
+// enforcePromotedVersionChange marks the computed attributes as unknown when the match rules are promoted again
+// or the target version is activated on another network
+func enforcePromotedVersionChange(_ context.Context, diff *schema.ResourceDiff, _ interface{}) error {
+	if diff.Id() == "" || !diff.HasChanges(append(promotionAttributes, "network")...) {
+		return nil
+	}
+	for _, key := range promotionComputedAttributes {
+		if err := diff.SetNewComputed(key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func resourcePolicyVersionPromotionCreate(ctx context.Context, rd *schema.ResourceData, m interface{}) diag.Diagnostics {
+	meta := meta.Must(m)
+	logger := meta.Log("Cloudlets", "resourcePolicyVersionPromotionCreate")
+	ctx = session.ContextWithOptions(ctx, session.WithContextLog(logger))
+	client := ClientV3(meta)
+
+	logger.Debug("Creating policy version promotion")
+
+	sourcePolicyID, err := tf.GetIntValueAsInt64("source_policy_id", rd)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	targetPolicyID, err := tf.GetIntValueAsInt64("target_policy_id", rd)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err = checkPromotedPolicies(ctx, client, sourcePolicyID, targetPolicyID); err != nil {
+		return diag.Errorf("%v create: %s", ErrPolicyVersionPromotion, err)
+	}
+
+	network, err := tf.GetStringValue("network", rd)
+	if err != nil && !errors.Is(err, tf.ErrNotFound) {
+		return diag.FromErr(err)
+	}
+
+	targetVersion, err := promotePolicyVersion(ctx, client, rd, sourcePolicyID, targetPolicyID)
+	if err != nil {
+		return diag.Errorf("%v create: %s", ErrPolicyVersionPromotion, err)
+	}
+
+	// the resource is created only once the promoted version is activated, a version which failed to activate is
+	// removed so that it is not left behind by the next attempt
+	if network != "" {
+		if diags := activatePromotedVersion(ctx, logger, client, targetPolicyID, targetVersion, network, "create"); diags != nil {
+			return append(diags, discardPromotedVersion(ctx, logger, client, targetPolicyID, targetVersion)...)
+		}
+	}
+	rd.SetId(fmt.Sprintf("%d:%d", sourcePolicyID, targetPolicyID))
+
+	if err = addPromotion(rd, sourcePolicyID, targetPolicyID, targetVersion, network); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourcePolicyVersionPromotionRead(ctx, rd, m)
+}
+
+func resourcePolicyVersionPromotionRead(ctx context.Context, rd *schema.ResourceData, m interface{}) diag.Diagnostics {
+	meta := meta.Must(m)
+	logger := meta.Log("Cloudlets", "resourcePolicyVersionPromotionRead")
+	ctx = session.ContextWithOptions(ctx, session.WithContextLog(logger))
+	client := ClientV3(meta)
+
+	logger.Debug("Reading policy version promotion")
+
+	targetPolicyID, err := tf.GetIntValueAsInt64("target_policy_id", rd)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	targetVersion, err := tf.GetIntValueAsInt64("target_version", rd)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	policyVersion, err := client.GetPolicyVersion(ctx, v3.GetPolicyVersionRequest{PolicyID: targetPolicyID, PolicyVersion: targetVersion})
+	if err != nil {
+		var v3Error *v3.Error
+		if errors.As(err, &v3Error) && v3Error.Status == http.StatusNotFound {
+			logger.Warnf("Version %d of policy %d was not found, removing the promotion from state", targetVersion, targetPolicyID)
+			rd.SetId("")
+			return nil
+		}
+		return diag.Errorf("%v read: %s", ErrPolicyVersionPromotion, err)
+	}
+	if err = setWarnings(rd, policyVersion.MatchRulesWarnings); err != nil {
+		return diag.FromErr(err)
+	}
+
+	network, err := tf.GetStringValue("network", rd)
+	if err != nil && !errors.Is(err, tf.ErrNotFound) {
+		return diag.FromErr(err)
+	}
+	var status string
+	if network != "" {
+		strategy := &v3ActivationStrategy{client: client, logger: logger}
+		attrs, err := strategy.readActivationFromServer(ctx, targetPolicyID, network)
+		if err != nil {
+			return diag.Errorf("%v read: %s", ErrPolicyVersionPromotion, err)
+		}
+		if attrs != nil && attrs["version"] == targetVersion {
+			status = fmt.Sprint(attrs["status"])
+		}
+	}
+	if err = rd.Set("status", status); err != nil {
+		return diag.Errorf("%v: %s", tf.ErrValueSet, err.Error())
+	}
+
+	return nil
+}
+
+func resourcePolicyVersionPromotionUpdate(ctx context.Context, rd *schema.ResourceData, m interface{}) diag.Diagnostics {
+	meta := meta.Must(m)
+	logger := meta.Log("Cloudlets", "resourcePolicyVersionPromotionUpdate")
+	ctx = session.ContextWithOptions(ctx, session.WithContextLog(logger))
+	client := ClientV3(meta)
+
+	if !rd.HasChangeExcept("timeouts") {
+		logger.Debug("Only timeouts were updated, skipping")
+		return nil
+	}
+
+	sourcePolicyID, err := tf.GetIntValueAsInt64("source_policy_id", rd)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	targetPolicyID, err := tf.GetIntValueAsInt64("target_policy_id", rd)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	network, err := tf.GetStringValue("network", rd)
+	if err != nil && !errors.Is(err, tf.ErrNotFound) {
+		return diag.FromErr(err)
+	}
+
+	// computed attributes are unknown during the update, the target version is taken from the state
+	oldTargetVersion, _ := rd.GetChange("target_version")
+	targetVersion := int64(oldTargetVersion.(int))
+
+	// on failure, the state is restored so that the promotion and the activation are planned again
+	restoredAttributes := append(append([]string{"network"}, promotionAttributes...), promotionComputedAttributes...)
+
+	promoted := rd.HasChanges(promotionAttributes...)
+	if promoted {
+		targetVersion, err = promotePolicyVersion(ctx, client, rd, sourcePolicyID, targetPolicyID)
+		if err != nil {
+			if restoreDiags := diag.FromErr(tf.RestoreOldValues(rd, restoredAttributes)); restoreDiags != nil {
+				return append(restoreDiags, diag.Errorf("%v update: %s", ErrPolicyVersionPromotion, err)...)
+			}
+			return diag.Errorf("%v update: %s", ErrPolicyVersionPromotion, err)
+		}
+	} else if err = rd.Set("target_version", targetVersion); err != nil {
+		return diag.Errorf("%v: %s", tf.ErrValueSet, err.Error())
+	}
+
+	activated := network != "" && (promoted || rd.HasChange("network"))
+	if activated {
+		if diags := activatePromotedVersion(ctx, logger, client, targetPolicyID, targetVersion, network, "update"); diags != nil {
+			if promoted {
+				diags = append(diags, discardPromotedVersion(ctx, logger, client, targetPolicyID, targetVersion)...)
+			}
+			if restoreDiags := diag.FromErr(tf.RestoreOldValues(rd, restoredAttributes)); restoreDiags != nil {
+				return append(restoreDiags, diags...)
+			}
+			return diags
+		}
+	}
+
+	if promoted || activated {
+		if err = addPromotion(rd, sourcePolicyID, targetPolicyID, targetVersion, network); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return resourcePolicyVersionPromotionRead(ctx, rd, m)
+}
+
+func resourcePolicyVersionPromotionDelete(_ context.Context, rd *schema.ResourceData, m interface{}) diag.Diagnostics {
+	meta := meta.Must(m)
+	logger := meta.Log("Cloudlets", "resourcePolicyVersionPromotionDelete")
+	logger.Debug("Deleting policy version promotion")
+
+	// policy versions may be immutable once activated, so the promoted version is kept in the target policy
+	logger.Infof("Removing promotion %s from state, the promoted version remains in the target policy", rd.Id())
+	rd.SetId("")
+	return nil
+}
+
+// checkPromotedPolicies verifies that both policies are shared policies of the same cloudlet type
+func checkPromotedPolicies(ctx context.Context, client v3.Cloudlets, sourcePolicyID, targetPolicyID int64) error {
+	source, err := client.GetPolicy(ctx, v3.GetPolicyRequest{PolicyID: sourcePolicyID})
+	if err != nil {
+		return fmt.Errorf("could not get source policy %d as shared policy: %s", sourcePolicyID, err)
+	}
+	target, err := client.GetPolicy(ctx, v3.GetPolicyRequest{PolicyID: targetPolicyID})
+	if err != nil {
+		return fmt.Errorf("could not get target policy %d as shared policy: %s", targetPolicyID, err)
+	}
+	if source.CloudletType != target.CloudletType {
+		return fmt.Errorf("cloudlet type of source policy %d ('%s') does not match cloudlet type of target policy %d ('%s')",
+			sourcePolicyID, source.CloudletType, targetPolicyID, target.CloudletType)
+	}
+	return nil
+}
+
+// promotePolicyVersion creates a new version of the target policy with the transformed match rules of the source policy version
+func promotePolicyVersion(ctx context.Context, client v3.Cloudlets, rd *schema.ResourceData, sourcePolicyID, targetPolicyID int64) (int64, error) {
+	sourceVersion, err := tf.GetIntValueAsInt64("source_version", rd)
+	if err != nil {
+		return 0, err
+	}
+	description, err := tf.GetStringValue("description", rd)
+	if err != nil && !errors.Is(err, tf.ErrNotFound) {
+		return 0, err
+	}
+	if description == "" {
+		description = fmt.Sprintf("Promoted from policy %d version %d", sourcePolicyID, sourceVersion)
+	}
+	transformation, err := getMatchRulesTransformation(rd)
+	if err != nil {
+		return 0, err
+	}
+
+	source, err := client.GetPolicyVersion(ctx, v3.GetPolicyVersionRequest{PolicyID: sourcePolicyID, PolicyVersion: sourceVersion})
+	if err != nil {
+		return 0, fmt.Errorf("could not get version %d of source policy %d: %s", sourceVersion, sourcePolicyID, err)
+	}
+	matchRules, err := transformation.apply(source.MatchRules)
+	if err != nil {
+		return 0, err
+	}
+
+	created, err := client.CreatePolicyVersion(ctx, v3.CreatePolicyVersionRequest{
+		CreatePolicyVersion: v3.CreatePolicyVersion{
+			MatchRules:  matchRules,
+			Description: ptr.To(description),
+		},
+		PolicyID: targetPolicyID,
+	})
+	if err != nil {
+		return 0, fmt.Errorf("could not create version of target policy %d: %s", targetPolicyID, err)
+	}
+	if err = rd.Set("target_version", created.PolicyVersion); err != nil {
+		return 0, fmt.Errorf("%w: %s", tf.ErrValueSet, err.Error())
+	}
+	if err = setWarnings(rd, created.MatchRulesWarnings); err != nil {
+		return 0, fmt.Errorf("%w: %s", tf.ErrValueSet, err.Error())
+	}
+	return created.PolicyVersion, nil
+}
+
+// activatePromotedVersion activates the target version on the network using the shared policy activation strategy
+func activatePromotedVersion(ctx context.Context, logger log.Interface, client v3.Cloudlets, policyID, version int64, network, operation string) diag.Diagnostics {
+	strategy := &v3ActivationStrategy{client: client, logger: logger}
+	net, err := strategy.parseNetwork(network)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	strategy.network = net
+
+	isActive, _, err := strategy.isVersionAlreadyActive(ctx, policyID, version)
+	if err != nil {
+		return diag.Errorf("%v %s: %s", ErrPolicyActivation, operation, err)
+	}
+	if isActive {
+		logger.Debugf("This policy (ID=%d, version=%d) is already active.", policyID, version)
+		return nil
+	}
+
+	if diags := activateVersionWithRetry(ctx, logger, strategy, policyID, version, operation); diags != nil {
+		return diags
+	}
+	if _, err = strategy.waitForActivation(ctx, policyID, version); err != nil {
+		return diag.Errorf("%v %s: %s", ErrPolicyActivation, operation, err)
+	}
+	return nil
+}
+
+// discardPromotedVersion deletes the promoted version which failed to activate. A version which cannot be deleted
+// is left in the target policy with a warning.
+func discardPromotedVersion(ctx context.Context, logger log.Interface, client v3.Cloudlets, policyID, version int64) diag.Diagnostics {
+	logger.Debugf("Deleting version %d of policy %d which failed to activate", version, policyID)
+	if err := client.DeletePolicyVersion(ctx, v3.DeletePolicyVersionRequest{PolicyID: policyID, PolicyVersion: version}); err != nil {
+		return diag.Diagnostics{{
+			Severity: diag.Warning,
+			Summary:  fmt.Sprintf("version %d of policy %d which failed to activate could not be deleted", version, policyID),
+			Detail:   err.Error(),
+		}}
+	}
+	return nil
+}
+
+func addPromotion(rd *schema.ResourceData, sourcePolicyID, targetPolicyID, targetVersion int64, network string) error {
+	sourceVersion, err := tf.GetIntValueAsInt64("source_version", rd)
+	if err != nil {
+		return err
+	}
+	if network != "" {
+		network = statePolicyActivationNetwork(network)
+	}
+	// promotions are unknown during the update, the audit log is continued from the state
+	oldPromotions, _ := rd.GetChange("promotions")
+	promotions := append(oldPromotions.([]interface{}), map[string]interface{}{
+		"source_policy_id": sourcePolicyID,
+		"source_version":   sourceVersion,
+		"target_policy_id": targetPolicyID,
+		"target_version":   targetVersion,
+		"network":          network,
+		"promoted_at":      time.Now().UTC().Format(time.RFC3339),
+	})
+	if err = rd.Set("promotions", promotions); err != nil {
+		return fmt.Errorf("%w: %s", tf.ErrValueSet, err.Error())
+	}
+	return nil
+}
+
+func getMatchRulesTransformation(rd *schema.ResourceData) (*matchRulesTransformation, error) {
+	transformation := &matchRulesTransformation{origins: make(map[string]string)}
+	for _, item := range rd.Get("hostname_substitution").([]interface{}) {
+		substitution := item.(map[string]interface{})
+		transformation.addHostname(substitution["from"].(string), substitution["to"].(string))
+	}
+	for _, item := range rd.Get("origin_substitution").([]interface{}) {
+		substitution := item.(map[string]interface{})
+		from := substitution["from"].(string)
+		if _, ok := transformation.origins[from]; ok {
+			return nil, fmt.Errorf("origin '%s' is substituted more than once", from)
+		}
+		transformation.origins[from] = substitution["to"].(string)
+	}
+	return transformation, nil
+}
+
+func (t *matchRulesTransformation) addHostname(from, to string) {
+	t.hostnames = append(t.hostnames, hostnameSubstitution{
+		pattern: regexp.MustCompile(`(?i)` + regexp.QuoteMeta(from)),
+		to:      to,
+	})
+}
+
+// apply returns a copy of the match rules with the hostnames and origins substituted. The IDs of the rules are removed,
+// as they are assigned by the target policy
+func (t *matchRulesTransformation) apply(matchRules v3.MatchRules) (v3.MatchRules, error) {
+	rulesJSON, err := json.Marshal(matchRules)
+	if err != nil {
+		return nil, fmt.Errorf("marshalling match rules: %s", err)
+	}
+	var rules []interface{}
+	if err = json.Unmarshal(rulesJSON, &rules); err != nil {
+		return nil, fmt.Errorf("unmarshalling match rules: %s", err)
+	}
+	for i, rule := range rules {
+		if ruleMap, ok := rule.(map[string]interface{}); ok {
+			delete(ruleMap, "id")
+		}
+		rules[i] = t.transformValue("", rule)
+	}
+	if rulesJSON, err = json.Marshal(rules); err != nil {
+		return nil, fmt.Errorf("marshalling match rules: %s", err)
+	}
+	result := make(v3.MatchRules, 0, len(rules))
+	if err = json.Unmarshal(rulesJSON, &result); err != nil {
+		return nil, fmt.Errorf("unmarshalling match rules: %s", err)
+	}
+	return result, nil
+}
+
+func (t *matchRulesTransformation) transformValue(key string, value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for k, item := range v {
+			v[k] = t.transformValue(k, item)
+		}
+	case []interface{}:
+		for i, item := range v {
+			v[i] = t.transformValue(key, item)
+		}
+	case string:
+		if key == "originId" {
+			if to, ok := t.origins[v]; ok {
+				return to
+			}
+			return v
+		}
+		for _, hostname := range t.hostnames {
+			v = hostname.substitute(v)
+		}
+		return v
+	}
+	return value
+}
+
+// substitute replaces the hostname if it appears as a whole hostname, that is at the beginning of the value or after
+// a scheme or a separator, and followed by a port, path, query, fragment or the end of the hostname
+func (s hostnameSubstitution) substitute(value string) string {
+	var result strings.Builder
+	last := 0
+	for _, match := range s.pattern.FindAllStringIndex(value, -1) {
+		start, end := match[0], match[1]
+		before := value[:start]
+		if start > 0 && !strings.HasSuffix(before, "://") && !strings.ContainsAny(before[start-1:], " \t\n,;|") {
+			continue
+		}
+		if end < len(value) && !strings.ContainsAny(value[end:end+1], "/:?# \t\n,;|") {
+			continue
+		}
+		result.WriteString(value[last:start])
+		result.WriteString(s.to)
+		last = end
+	}
+	if last == 0 {
+		return value
+	}
+	result.WriteString(value[last:])
+	return result.String()
+}
@@ -0,0 +1,390 @@
+package cloudlets
+
+import (
+	"net/http"
+	"regexp"
+	"testing"
+	"time"
+
+	v3 "github.com/akamai/AkamaiOPEN-edgegrid-golang/v9/pkg/cloudlets/v3"
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/common/ptr"
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/common/testutils"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResourceCloudletsPolicyVersionPromotion(t *testing.T) {
+	sourceRules := v3.MatchRules{
+		&v3.MatchRuleFR{
+			Name:            "forward",
+			Type:            v3.MatchRuleTypeFR,
+			ID:              10,
+			MatchURL:        "https://staging.example.com/api/*",
+			ForwardSettings: v3.ForwardSettingsFR{OriginID: "origin-staging", PathAndQS: "/v2/api"},
+		},
+	}
+	promotedRules := v3.MatchRules{
+		&v3.MatchRuleFR{
+			Name:            "forward",
+			Type:            v3.MatchRuleTypeFR,
+			MatchURL:        "https://www.example.com/api/*",
+			ForwardSettings: v3.ForwardSettingsFR{OriginID: "origin-production", PathAndQS: "/v2/api"},
+		},
+	}
+	checkPromotion := func(targetVersion string) resource.TestCheckFunc {
+		return resource.ComposeAggregateTestCheckFunc(
+			resource.TestCheckResourceAttr("akamai_cloudlets_policy_version_promotion.test", "id", "1000:2000"),
+			resource.TestCheckResourceAttr("akamai_cloudlets_policy_version_promotion.test", "target_version", targetVersion),
+			resource.TestCheckResourceAttr("akamai_cloudlets_policy_version_promotion.test", "status", ""),
+			resource.TestCheckResourceAttr("akamai_cloudlets_policy_version_promotion.test", "warnings", ""),
+		)
+	}
+
+	tests := map[string]struct {
+		init  func(*v3.Mock)
+		steps []resource.TestStep
+	}{
+		"promote version with substitutions": {
+			init: func(m *v3.Mock) {
+				expectGetPromotedPolicies(m, v3.CloudletTypeFR, v3.CloudletTypeFR)
+				expectGetSourcePolicyVersion(m, 3, sourceRules)
+				expectCreatePromotedPolicyVersion(m, promotedRules, "Promoted from policy 1000 version 3", 5)
+				expectReadPromotedPolicyVersion(m, 5)
+			},
+			steps: []resource.TestStep{
+				{
+					Config: testutils.LoadFixtureString(t, "testdata/TestResourceCloudletsPolicyVersionPromotion/promotion.tf"),
+					Check: resource.ComposeAggregateTestCheckFunc(
+						checkPromotion("5"),
+						resource.TestCheckResourceAttr("akamai_cloudlets_policy_version_promotion.test", "promotions.#", "1"),
+						resource.TestCheckResourceAttr("akamai_cloudlets_policy_version_promotion.test", "promotions.0.source_policy_id", "1000"),
+						resource.TestCheckResourceAttr("akamai_cloudlets_policy_version_promotion.test", "promotions.0.source_version", "3"),
+						resource.TestCheckResourceAttr("akamai_cloudlets_policy_version_promotion.test", "promotions.0.target_policy_id", "2000"),
+						resource.TestCheckResourceAttr("akamai_cloudlets_policy_version_promotion.test", "promotions.0.target_version", "5"),
+						resource.TestCheckResourceAttr("akamai_cloudlets_policy_version_promotion.test", "promotions.0.network", ""),
+						resource.TestCheckResourceAttrSet("akamai_cloudlets_policy_version_promotion.test", "promotions.0.promoted_at"),
+					),
+				},
+			},
+		},
+		"promote new source version": {
+			init: func(m *v3.Mock) {
+				expectGetPromotedPolicies(m, v3.CloudletTypeFR, v3.CloudletTypeFR)
+				expectGetSourcePolicyVersion(m, 3, sourceRules)
+				expectCreatePromotedPolicyVersion(m, promotedRules, "Promoted from policy 1000 version 3", 5).Once()
+				expectReadPromotedPolicyVersion(m, 5)
+				// update
+				expectGetSourcePolicyVersion(m, 4, sourceRules)
+				expectCreatePromotedPolicyVersion(m, promotedRules, "Promoted from policy 1000 version 4", 6).Once()
+				expectReadPromotedPolicyVersion(m, 6)
+			},
+			steps: []resource.TestStep{
+				{
+					Config: testutils.LoadFixtureString(t, "testdata/TestResourceCloudletsPolicyVersionPromotion/promotion.tf"),
+					Check:  checkPromotion("5"),
+				},
+				{
+					Config: testutils.LoadFixtureString(t, "testdata/TestResourceCloudletsPolicyVersionPromotion/promotion_update_version.tf"),
+					Check: resource.ComposeAggregateTestCheckFunc(
+						checkPromotion("6"),
+						resource.TestCheckResourceAttr("akamai_cloudlets_policy_version_promotion.test", "promotions.#", "2"),
+						resource.TestCheckResourceAttr("akamai_cloudlets_policy_version_promotion.test", "promotions.0.target_version", "5"),
+						resource.TestCheckResourceAttr("akamai_cloudlets_policy_version_promotion.test", "promotions.1.source_version", "4"),
+						resource.TestCheckResourceAttr("akamai_cloudlets_policy_version_promotion.test", "promotions.1.target_version", "6"),
+					),
+				},
+			},
+		},
+		"activate promoted version on added network": {
+			init: func(m *v3.Mock) {
+				expectGetPromotedPolicies(m, v3.CloudletTypeFR, v3.CloudletTypeFR)
+				expectGetSourcePolicyVersion(m, 3, sourceRules)
+				expectCreatePromotedPolicyVersion(m, promotedRules, "Promoted from policy 1000 version 3", 5).Once()
+				expectReadPromotedPolicyVersion(m, 5)
+				// update
+				expectGetV3Policy(m, 2000, v3.CurrentActivations{}, nil).Once()
+				expectActivateV3PolicyVersion(m, 2000, 5, 111, v3.ProductionNetwork, nil).Once()
+				expectWaitForV3Activation(m, 2000, 111, []v3.ActivationStatus{v3.ActivationStatusSuccess}, nil)
+				expectGetV3Policy(m, 2000, prepareActivatedResponseForNetwork(2000, 5, v3.ProductionNetwork), nil)
+			},
+			steps: []resource.TestStep{
+				{
+					Config: testutils.LoadFixtureString(t, "testdata/TestResourceCloudletsPolicyVersionPromotion/promotion.tf"),
+					Check:  checkPromotion("5"),
+				},
+				{
+					Config: testutils.LoadFixtureString(t, "testdata/TestResourceCloudletsPolicyVersionPromotion/promotion_update_network.tf"),
+					ConfigPlanChecks: resource.ConfigPlanChecks{
+						PreApply: []plancheck.PlanCheck{
+							plancheck.ExpectUnknownValue("akamai_cloudlets_policy_version_promotion.test", tfjsonpath.New("target_version")),
+							plancheck.ExpectUnknownValue("akamai_cloudlets_policy_version_promotion.test", tfjsonpath.New("promotions")),
+							plancheck.ExpectUnknownValue("akamai_cloudlets_policy_version_promotion.test", tfjsonpath.New("status")),
+						},
+					},
+					Check: resource.ComposeAggregateTestCheckFunc(
+						resource.TestCheckResourceAttr("akamai_cloudlets_policy_version_promotion.test", "target_version", "5"),
+						resource.TestCheckResourceAttr("akamai_cloudlets_policy_version_promotion.test", "status", string(v3.ActivationStatusSuccess)),
+						resource.TestCheckResourceAttr("akamai_cloudlets_policy_version_promotion.test", "promotions.#", "2"),
+						resource.TestCheckResourceAttr("akamai_cloudlets_policy_version_promotion.test", "promotions.0.network", ""),
+						resource.TestCheckResourceAttr("akamai_cloudlets_policy_version_promotion.test", "promotions.1.target_version", "5"),
+						resource.TestCheckResourceAttr("akamai_cloudlets_policy_version_promotion.test", "promotions.1.network", "prod"),
+					),
+				},
+			},
+		},
+		"promote and activate version": {
+			init: func(m *v3.Mock) {
+				expectGetPromotedPolicies(m, v3.CloudletTypeFR, v3.CloudletTypeFR)
+				expectGetSourcePolicyVersion(m, 3, sourceRules)
+				rules := v3.MatchRules{
+					&v3.MatchRuleFR{
+						Name:            "forward",
+						Type:            v3.MatchRuleTypeFR,
+						MatchURL:        "https://staging.example.com/api/*",
+						ForwardSettings: v3.ForwardSettingsFR{OriginID: "origin-staging", PathAndQS: "/v2/api"},
+					},
+				}
+				expectCreatePromotedPolicyVersion(m, rules, "release 1.2", 5)
+				expectGetV3Policy(m, 2000, v3.CurrentActivations{}, nil).Once()
+				expectActivateV3PolicyVersion(m, 2000, 5, 111, v3.ProductionNetwork, nil).Once()
+				expectWaitForV3Activation(m, 2000, 111, []v3.ActivationStatus{v3.ActivationStatusInProgress, v3.ActivationStatusSuccess}, nil)
+				expectReadPromotedPolicyVersion(m, 5)
+				expectGetV3Policy(m, 2000, prepareActivatedResponseForNetwork(2000, 5, v3.ProductionNetwork), nil)
+			},
+			steps: []resource.TestStep{
+				{
+					Config: testutils.LoadFixtureString(t, "testdata/TestResourceCloudletsPolicyVersionPromotion/promotion_activation.tf"),
+					Check: resource.ComposeAggregateTestCheckFunc(
+						resource.TestCheckResourceAttr("akamai_cloudlets_policy_version_promotion.test", "target_version", "5"),
+						resource.TestCheckResourceAttr("akamai_cloudlets_policy_version_promotion.test", "network", "prod"),
+						resource.TestCheckResourceAttr("akamai_cloudlets_policy_version_promotion.test", "status", string(v3.ActivationStatusSuccess)),
+						resource.TestCheckResourceAttr("akamai_cloudlets_policy_version_promotion.test", "promotions.0.network", "prod"),
+					),
+				},
+			},
+		},
+		"activation failed": {
+			init: func(m *v3.Mock) {
+				expectGetPromotedPolicies(m, v3.CloudletTypeFR, v3.CloudletTypeFR)
+				expectGetSourcePolicyVersion(m, 3, sourceRules)
+				expectCreatePromotedPolicyVersion(m, mock.Anything, "release 1.2", 5)
+				expectGetV3Policy(m, 2000, v3.CurrentActivations{}, nil).Once()
+				expectActivateV3PolicyVersion(m, 2000, 5, 111, v3.ProductionNetwork, nil).Once()
+				expectWaitForV3Activation(m, 2000, 111, []v3.ActivationStatus{v3.ActivationStatusFailed}, nil)
+				// the version which failed to activate is removed and the promotion is not stored
+				expectDeletePromotedPolicyVersion(m, 5, nil)
+			},
+			steps: []resource.TestStep{
+				{
+					Config:      testutils.LoadFixtureString(t, "testdata/TestResourceCloudletsPolicyVersionPromotion/promotion_activation.tf"),
+					ExpectError: regexp.MustCompile("policy activation create: activation failed for policy 2000"),
+				},
+			},
+		},
+		"failed activation on added network is planned again": {
+			init: func(m *v3.Mock) {
+				expectGetPromotedPolicies(m, v3.CloudletTypeFR, v3.CloudletTypeFR)
+				expectGetSourcePolicyVersion(m, 3, sourceRules)
+				expectCreatePromotedPolicyVersion(m, promotedRules, "Promoted from policy 1000 version 3", 5).Once()
+				expectReadPromotedPolicyVersion(m, 5)
+				// update
+				expectGetV3Policy(m, 2000, v3.CurrentActivations{}, nil)
+				expectActivateV3PolicyVersion(m, 2000, 5, 111, v3.ProductionNetwork, nil).Once()
+				expectWaitForV3Activation(m, 2000, 111, []v3.ActivationStatus{v3.ActivationStatusFailed}, nil)
+			},
+			steps: []resource.TestStep{
+				{
+					Config: testutils.LoadFixtureString(t, "testdata/TestResourceCloudletsPolicyVersionPromotion/promotion.tf"),
+					Check:  checkPromotion("5"),
+				},
+				{
+					Config:      testutils.LoadFixtureString(t, "testdata/TestResourceCloudletsPolicyVersionPromotion/promotion_update_network.tf"),
+					ExpectError: regexp.MustCompile("policy activation update: activation failed for policy 2000"),
+				},
+				{
+					Config:             testutils.LoadFixtureString(t, "testdata/TestResourceCloudletsPolicyVersionPromotion/promotion_update_network.tf"),
+					PlanOnly:           true,
+					ExpectNonEmptyPlan: true,
+				},
+			},
+		},
+		"failed activation of promoted version is planned again": {
+			init: func(m *v3.Mock) {
+				expectGetPromotedPolicies(m, v3.CloudletTypeFR, v3.CloudletTypeFR)
+				expectGetSourcePolicyVersion(m, 3, sourceRules)
+				expectCreatePromotedPolicyVersion(m, promotedRules, "Promoted from policy 1000 version 3", 5).Once()
+				expectReadPromotedPolicyVersion(m, 5)
+				// update
+				expectGetSourcePolicyVersion(m, 4, sourceRules)
+				expectCreatePromotedPolicyVersion(m, promotedRules, "Promoted from policy 1000 version 4", 6).Once()
+				expectGetV3Policy(m, 2000, v3.CurrentActivations{}, nil)
+				expectActivateV3PolicyVersion(m, 2000, 6, 111, v3.ProductionNetwork, nil).Once()
+				expectWaitForV3Activation(m, 2000, 111, []v3.ActivationStatus{v3.ActivationStatusFailed}, nil)
+				expectDeletePromotedPolicyVersion(m, 6, nil)
+			},
+			steps: []resource.TestStep{
+				{
+					Config: testutils.LoadFixtureString(t, "testdata/TestResourceCloudletsPolicyVersionPromotion/promotion.tf"),
+					Check:  checkPromotion("5"),
+				},
+				{
+					Config:      testutils.LoadFixtureString(t, "testdata/TestResourceCloudletsPolicyVersionPromotion/promotion_update_version_activation.tf"),
+					ExpectError: regexp.MustCompile("policy activation update: activation failed for policy 2000"),
+				},
+				{
+					Config:             testutils.LoadFixtureString(t, "testdata/TestResourceCloudletsPolicyVersionPromotion/promotion_update_version_activation.tf"),
+					PlanOnly:           true,
+					ExpectNonEmptyPlan: true,
+				},
+			},
+		},
+		"different cloudlet types": {
+			init: func(m *v3.Mock) {
+				expectGetPromotedPolicies(m, v3.CloudletTypeFR, v3.CloudletTypeER)
+			},
+			steps: []resource.TestStep{
+				{
+					Config:      testutils.LoadFixtureString(t, "testdata/TestResourceCloudletsPolicyVersionPromotion/promotion.tf"),
+					ExpectError: regexp.MustCompile(`cloudlet type of source policy 1000 \('FR'\) does not match cloudlet type of target policy 2000 \('ER'\)`),
+				},
+			},
+		},
+		"source policy is not shared": {
+			init: func(m *v3.Mock) {
+				m.On("GetPolicy", mock.Anything, v3.GetPolicyRequest{PolicyID: 1000}).Return(nil, &v3.Error{Status: http.StatusNotFound}).Once()
+			},
+			steps: []resource.TestStep{
+				{
+					Config:      testutils.LoadFixtureString(t, "testdata/TestResourceCloudletsPolicyVersionPromotion/promotion.tf"),
+					ExpectError: regexp.MustCompile("could not get source policy 1000 as shared policy"),
+				},
+			},
+		},
+	}
+
+	// redefining times to accelerate tests
+	ActivationPollMinimum, ActivationPollInterval, PolicyActivationRetryPollMinimum = time.Millisecond, time.Millisecond, time.Millisecond
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			client := &v3.Mock{}
+			test.init(client)
+			useClientV3(client, func() {
+				resource.UnitTest(t, resource.TestCase{
+					ProtoV6ProviderFactories: testutils.NewProtoV6ProviderFactory(NewSubprovider()),
+					IsUnitTest:               true,
+					Steps:                    test.steps,
+				})
+			})
+			client.AssertExpectations(t)
+		})
+	}
+}
+
+func TestMatchRulesTransformation(t *testing.T) {
+	tests := map[string]struct {
+		hostnames map[string]string
+		origins   map[string]string
+		rules     v3.MatchRules
+		expected  v3.MatchRules
+	}{
+		"no substitutions removes rule IDs": {
+			rules: v3.MatchRules{
+				&v3.MatchRuleER{Type: v3.MatchRuleTypeER, ID: 1, MatchURL: "staging.example.com/a", RedirectURL: "/b", StatusCode: 301},
+			},
+			expected: v3.MatchRules{
+				&v3.MatchRuleER{Type: v3.MatchRuleTypeER, MatchURL: "staging.example.com/a", RedirectURL: "/b", StatusCode: 301},
+			},
+		},
+		"hostnames in URLs and match values": {
+			hostnames: map[string]string{"staging.example.com": "www.example.com"},
+			rules: v3.MatchRules{
+				&v3.MatchRuleER{
+					Type:        v3.MatchRuleTypeER,
+					MatchURL:    "https://Staging.Example.com/a",
+					RedirectURL: "https://staging.example.com:8443/b?from=staging.example.com",
+					StatusCode:  301,
+					Matches: []v3.MatchCriteriaER{
+						{MatchType: "header", ObjectMatchValue: &v3.ObjectMatchValueObject{
+							Type: v3.Object, Name: "Host", Options: &v3.Options{Value: []string{"staging.example.com", "api.staging.example.com", "staging.example.com.au"}},
+						}},
+						{MatchType: "hostname", MatchValue: "staging.example.com, other.com"},
+					},
+				},
+			},
+			expected: v3.MatchRules{
+				&v3.MatchRuleER{
+					Type:        v3.MatchRuleTypeER,
+					MatchURL:    "https://www.example.com/a",
+					RedirectURL: "https://www.example.com:8443/b?from=staging.example.com",
+					StatusCode:  301,
+					Matches: []v3.MatchCriteriaER{
+						{MatchType: "header", ObjectMatchValue: &v3.ObjectMatchValueObject{
+							Type: v3.Object, Name: "Host", Options: &v3.Options{Value: []string{"www.example.com", "api.staging.example.com", "staging.example.com.au"}},
+						}},
+						{MatchType: "hostname", MatchValue: "www.example.com, other.com"},
+					},
+				},
+			},
+		},
+		"origins": {
+			origins: map[string]string{"origin-staging": "origin-production"},
+			rules: v3.MatchRules{
+				&v3.MatchRuleFR{Type: v3.MatchRuleTypeFR, Name: "origin-staging", ForwardSettings: v3.ForwardSettingsFR{OriginID: "origin-staging"}},
+				&v3.MatchRuleFR{Type: v3.MatchRuleTypeFR, ForwardSettings: v3.ForwardSettingsFR{OriginID: "origin-staging-2"}},
+			},
+			expected: v3.MatchRules{
+				&v3.MatchRuleFR{Type: v3.MatchRuleTypeFR, Name: "origin-staging", ForwardSettings: v3.ForwardSettingsFR{OriginID: "origin-production"}},
+				&v3.MatchRuleFR{Type: v3.MatchRuleTypeFR, ForwardSettings: v3.ForwardSettingsFR{OriginID: "origin-staging-2"}},
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			transformation := &matchRulesTransformation{origins: test.origins}
+			for from, to := range test.hostnames {
+				transformation.addHostname(from, to)
+			}
+			rules, err := transformation.apply(test.rules)
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, rules)
+		})
+	}
+}
+
+func expectGetPromotedPolicies(m *v3.Mock, sourceType, targetType v3.CloudletType) {
+	m.On("GetPolicy", mock.Anything, v3.GetPolicyRequest{PolicyID: 1000}).Return(&v3.Policy{ID: 1000, CloudletType: sourceType}, nil).Once()
+	m.On("GetPolicy", mock.Anything, v3.GetPolicyRequest{PolicyID: 2000}).Return(&v3.Policy{ID: 2000, CloudletType: targetType}, nil).Once()
+}
+
+func expectGetSourcePolicyVersion(m *v3.Mock, version int64, rules v3.MatchRules) *mock.Call {
+	return m.On("GetPolicyVersion", mock.Anything, v3.GetPolicyVersionRequest{PolicyID: 1000, PolicyVersion: version}).
+		Return(&v3.PolicyVersion{PolicyID: 1000, PolicyVersion: version, MatchRules: rules}, nil).Once()
+}
+
+func expectCreatePromotedPolicyVersion(m *v3.Mock, rules interface{}, description string, version int64) *mock.Call {
+	request := interface{}(mock.Anything)
+	if matchRules, ok := rules.(v3.MatchRules); ok {
+		request = v3.CreatePolicyVersionRequest{
+			CreatePolicyVersion: v3.CreatePolicyVersion{MatchRules: matchRules, Description: ptr.To(description)},
+			PolicyID:            2000,
+		}
+	}
+	return m.On("CreatePolicyVersion", mock.Anything, request).
+		Return(&v3.PolicyVersion{PolicyID: 2000, PolicyVersion: version, Description: ptr.To(description)}, nil)
+}
+
+func expectReadPromotedPolicyVersion(m *v3.Mock, version int64) *mock.Call {
+	return m.On("GetPolicyVersion", mock.Anything, v3.GetPolicyVersionRequest{PolicyID: 2000, PolicyVersion: version}).
+		Return(&v3.PolicyVersion{PolicyID: 2000, PolicyVersion: version}, nil)
+}
+
+func expectDeletePromotedPolicyVersion(m *v3.Mock, version int64, err error) *mock.Call {
+	return m.On("DeletePolicyVersion", mock.Anything, v3.DeletePolicyVersionRequest{PolicyID: 2000, PolicyVersion: version}).
+		Return(err).Once()
+}
@@ -0,0 +1,14 @@
+provider "akamai" {
+  edgerc = "../../common/testutils/edgerc"
+}
+
+data "akamai_cloudlets_edge_redirector_match_rule" "test" {
+  rules_file = "testdata/TestDataCloudletsEdgeRedirectorMatchRule/rules/redirects.csv"
+
+  match_rules {
+    name         = "sale"
+    match_url    = "example.com/sale"
+    redirect_url = "https://example.com/shoes"
+    status_code  = 302
+  }
+}
@@ -0,0 +1,15 @@
+provider "akamai" {
+  edgerc = "../../common/testutils/edgerc"
+}
+
+data "akamai_cloudlets_edge_redirector_match_rule" "test" {
+  fail_on_conflicts = true
+  rules_file        = "testdata/TestDataCloudletsEdgeRedirectorMatchRule/rules/redirects.csv"
+
+  match_rules {
+    name         = "sale"
+    match_url    = "example.com/sale"
+    redirect_url = "https://example.com/shoes"
+    status_code  = 302
+  }
+}
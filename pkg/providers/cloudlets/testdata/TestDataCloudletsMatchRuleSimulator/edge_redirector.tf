@@ -0,0 +1,31 @@
+provider "akamai" {
+  edgerc = "../../common/testutils/edgerc"
+}
+
+data "akamai_cloudlets_match_rule_simulator" "test" {
+  match_rules     = file("testdata/TestDataCloudletsMatchRuleSimulator/rules/er_rules.json")
+  evaluation_time = 1700000000
+
+  request {
+    name          = "shoes"
+    url           = "https://WWW.example.com/shoes/red?size=10"
+    expected_rule = "shoes"
+  }
+
+  request {
+    url = "https://www.example.com/products/123"
+  }
+
+  request {
+    url          = "https://www.example.com/about"
+    headers      = { "user-agent" = "Mozilla/5.0 (Linux; Android 14) Mobile" }
+    country_code = "DE"
+  }
+
+  request {
+    url           = "https://www.example.com/about"
+    headers       = { "user-agent" = "Mozilla/5.0 (Linux; Android 14) Mobile" }
+    country_code  = "US"
+    expected_rule = "mobile"
+  }
+}
@@ -0,0 +1,22 @@
+provider "akamai" {
+  edgerc = "../../common/testutils/edgerc"
+}
+
+data "akamai_cloudlets_match_rule_simulator" "test" {
+  match_rules = jsonencode([
+    {
+      name = "invalid"
+      type = "erMatchRule"
+      matches = [
+        {
+          matchType  = "regex"
+          matchValue = "(unclosed"
+        }
+      ]
+    }
+  ])
+
+  request {
+    url = "https://www.example.com/"
+  }
+}
@@ -0,0 +1,22 @@
+provider "akamai" {
+  edgerc = "../../common/testutils/edgerc"
+}
+
+data "akamai_cloudlets_match_rule_simulator" "test" {
+  match_rules = file("testdata/TestDataCloudletsMatchRuleSimulator/rules/alb_rules.json")
+
+  request {
+    url     = "https://www.example.com/"
+    cookies = { beta = "yes" }
+  }
+
+  request {
+    url         = "https://www.example.com/"
+    range_value = 5
+  }
+
+  request {
+    url         = "https://www.example.com/"
+    range_value = 50
+  }
+}
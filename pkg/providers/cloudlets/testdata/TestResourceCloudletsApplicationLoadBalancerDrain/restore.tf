@@ -0,0 +1,8 @@
+provider "akamai" {
+  edgerc = "../../common/testutils/edgerc"
+}
+
+resource "akamai_cloudlets_application_load_balancer_drain" "test" {
+  origin_id = "alb_1"
+  version   = 2
+}
@@ -0,0 +1,12 @@
+provider "akamai" {
+  edgerc = "../../common/testutils/edgerc"
+}
+
+resource "akamai_cloudlets_policy_ramp" "test" {
+  policy_id    = 3000
+  base_version = 1
+  rule_name    = "canary"
+  steps        = [10, 50, 100]
+  network      = "staging"
+  rollback     = true
+}
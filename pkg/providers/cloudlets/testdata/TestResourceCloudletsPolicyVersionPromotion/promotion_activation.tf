@@ -0,0 +1,11 @@
+provider "akamai" {
+  edgerc = "../../common/testutils/edgerc"
+}
+
+resource "akamai_cloudlets_policy_version_promotion" "test" {
+  source_policy_id = 1000
+  source_version   = 3
+  target_policy_id = 2000
+  description      = "release 1.2"
+  network          = "prod"
+}
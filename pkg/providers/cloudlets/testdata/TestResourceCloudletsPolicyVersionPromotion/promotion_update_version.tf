@@ -0,0 +1,19 @@
+provider "akamai" {
+  edgerc = "../../common/testutils/edgerc"
+}
+
+resource "akamai_cloudlets_policy_version_promotion" "test" {
+  source_policy_id = 1000
+  source_version   = 4
+  target_policy_id = 2000
+
+  hostname_substitution {
+    from = "staging.example.com"
+    to   = "www.example.com"
+  }
+
+  origin_substitution {
+    from = "origin-staging"
+    to   = "origin-production"
+  }
+}
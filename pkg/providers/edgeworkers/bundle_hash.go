@@ -12,8 +12,10 @@ import (
 )
 
 type bundleFile struct {
-	Name    string
-	Content []byte
+	Name     string
+	Content  []byte
+	Size     int64
+	Typeflag byte
 }
 
 func hashTarFiles(tr *tar.Reader) ([]bundleFile, error) {
@@ -32,8 +34,10 @@ func hashTarFiles(tr *tar.Reader) ([]bundleFile, error) {
 			return nil, err
 		}
 		filesHashes = append(filesHashes, bundleFile{
-			Name:    header.Name,
-			Content: hash.Sum(nil),
+			Name:     header.Name,
+			Content:  hash.Sum(nil),
+			Size:     header.Size,
+			Typeflag: header.Typeflag,
 		})
 	}
 
@@ -0,0 +1,261 @@
+package edgeworkers
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v9/pkg/edgeworkers"
+)
+
+// ErrInvalidBundle is returned when the local checks of an EdgeWorker bundle fail
+var ErrInvalidBundle = errors.New("local bundle is not valid")
+
+// bundleLimits holds the bundle size limits of a resource tier
+type bundleLimits struct {
+	CompressedSize   int64
+	UncompressedSize int64
+}
+
+var (
+	// disallowedBundleFileExtensions lists the extensions of files which cannot be executed by EdgeWorkers
+	disallowedBundleFileExtensions = []string{
+		".bat", ".bin", ".class", ".cmd", ".dll", ".dylib", ".exe", ".gz", ".jar", ".sh", ".so", ".tar", ".tgz", ".zip",
+	}
+
+	// limitUnitBytes holds the number of bytes of the size units of resource tier limits
+	limitUnitBytes = map[string]int64{
+		"BYTE":      1,
+		"BYTES":     1,
+		"B":         1,
+		"KILOBYTE":  1 << 10,
+		"KILOBYTES": 1 << 10,
+		"KB":        1 << 10,
+		"KIB":       1 << 10,
+		"MEGABYTE":  1 << 20,
+		"MEGABYTES": 1 << 20,
+		"MB":        1 << 20,
+		"MIB":       1 << 20,
+	}
+
+	apiVersionRegexp = regexp.MustCompile(`^\d+\.\d+$`)
+)
+
+// getBundleLimits returns the bundle size limits defined by the given resource tier. A limit which is not defined
+// by the resource tier is zero and is not checked locally.
+func getBundleLimits(tier *edgeworkers.ResourceTier) bundleLimits {
+	var limits bundleLimits
+	if tier == nil {
+		return limits
+	}
+	for _, limit := range tier.EdgeWorkerLimits {
+		name := strings.ToLower(limit.LimitName)
+		if !strings.Contains(name, "bundle") || !strings.Contains(name, "size") {
+			continue
+		}
+		unit, ok := limitUnitBytes[strings.ToUpper(limit.LimitUnit)]
+		if !ok {
+			continue
+		}
+		switch {
+		case strings.Contains(name, "uncompressed"):
+			limits.UncompressedSize = limit.LimitValue * unit
+		case strings.Contains(name, "compressed"):
+			limits.CompressedSize = limit.LimitValue * unit
+		}
+	}
+	return limits
+}
+
+// findResourceTier returns the resource tier with the given ID. The resource tier of an existing EdgeWorker is
+// fetched directly, otherwise it is looked up in the resource tiers of the contracts available to the user.
+func findResourceTier(ctx context.Context, client edgeworkers.Edgeworkers, edgeWorkerID, resourceTierID int) (*edgeworkers.ResourceTier, error) {
+	if edgeWorkerID != 0 {
+		tier, err := client.GetResourceTier(ctx, edgeworkers.GetResourceTierRequest{EdgeWorkerID: edgeWorkerID})
+		if err != nil {
+			return nil, err
+		}
+		if tier.ID == resourceTierID {
+			return tier, nil
+		}
+	}
+
+	contracts, err := client.ListContracts(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, contractID := range contracts.ContractIDs {
+		tiers, err := client.ListResourceTiers(ctx, edgeworkers.ListResourceTiersRequest{
+			ContractID: strings.TrimPrefix(contractID, "ctr_"),
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, tier := range tiers.ResourceTiers {
+			if tier.ID == resourceTierID {
+				return &tier, nil
+			}
+		}
+	}
+	return nil, fmt.Errorf("resource tier %d not found", resourceTierID)
+}
+
+// lintBundle checks the bundle locally before it is uploaded. It returns an error listing all issues found: an invalid
+// bundle.json, bundle size exceeding the given limits, disallowed file types and duplicate paths.
+func lintBundle(bundle []byte, limits bundleLimits) error {
+	var issues []string
+
+	if size := int64(len(bundle)); limits.CompressedSize > 0 && size > limits.CompressedSize {
+		issues = append(issues, fmt.Sprintf("compressed bundle size of %d bytes exceeds the limit of %d bytes of the resource tier",
+			size, limits.CompressedSize))
+	}
+
+	files, err := readBundleFiles(bundle)
+	if err != nil {
+		return err
+	}
+
+	var uncompressedSize int64
+	paths := make(map[string]int, len(files))
+	for _, f := range files {
+		if f.Typeflag == tar.TypeDir {
+			continue
+		}
+		name := bundlePath(f.Name)
+		paths[name]++
+		uncompressedSize += f.Size
+
+		if f.Typeflag != tar.TypeReg {
+			issues = append(issues, fmt.Sprintf("'%s' is not a regular file", name))
+			continue
+		}
+		ext := strings.ToLower(path.Ext(name))
+		for _, disallowed := range disallowedBundleFileExtensions {
+			if ext == disallowed {
+				issues = append(issues, fmt.Sprintf("'%s' has a disallowed file type '%s'", name, ext))
+				break
+			}
+		}
+	}
+
+	if limits.UncompressedSize > 0 && uncompressedSize > limits.UncompressedSize {
+		issues = append(issues, fmt.Sprintf("uncompressed bundle size of %d bytes exceeds the limit of %d bytes of the resource tier",
+			uncompressedSize, limits.UncompressedSize))
+	}
+
+	duplicates := make([]string, 0)
+	for name, count := range paths {
+		if count > 1 {
+			duplicates = append(duplicates, name)
+		}
+	}
+	sort.Strings(duplicates)
+	for _, name := range duplicates {
+		issues = append(issues, fmt.Sprintf("'%s' is included %d times", name, paths[name]))
+	}
+
+	if paths[bundleManifestName] > 0 {
+		content, err := readTarFile(bundle, bundleManifestName)
+		if err != nil {
+			return fmt.Errorf("%w: %s", ErrInvalidBundle, err)
+		}
+		issues = append(issues, lintBundleManifest(content)...)
+	}
+
+	if len(issues) > 0 {
+		return fmt.Errorf("%w:\n  - %s", ErrInvalidBundle, strings.Join(issues, "\n  - "))
+	}
+	return nil
+}
+
+// bundleLayoutWarnings returns warnings about main.js or bundle.json missing in the root of the bundle. They are not
+// reported as errors, as such bundles were accepted by the previous versions of the provider.
+func bundleLayoutWarnings(bundle []byte) ([]string, error) {
+	files, err := readBundleFiles(bundle)
+	if err != nil {
+		return nil, err
+	}
+	paths := make(map[string]struct{}, len(files))
+	for _, f := range files {
+		paths[bundlePath(f.Name)] = struct{}{}
+	}
+
+	var warnings []string
+	for _, name := range []string{"main.js", bundleManifestName} {
+		if _, ok := paths[name]; !ok {
+			warnings = append(warnings, fmt.Sprintf("%s is missing in the root of the bundle", name))
+		}
+	}
+	return warnings, nil
+}
+
+// readBundleFiles returns the headers of the files of the gzipped tar bundle
+func readBundleFiles(bundle []byte) ([]bundleFile, error) {
+	gr, err := gzip.NewReader(bytes.NewReader(bundle))
+	if err != nil {
+		return nil, fmt.Errorf("%w: bundle is not a gzipped tar archive: %s", ErrInvalidBundle, err)
+	}
+	gr.Multistream(false)
+	files, err := hashTarFiles(tar.NewReader(gr))
+	if err != nil {
+		return nil, fmt.Errorf("%w: bundle is not a gzipped tar archive: %s", ErrInvalidBundle, err)
+	}
+	return files, nil
+}
+
+// lintBundleManifest checks the values of bundle.json
+func lintBundleManifest(content []byte) []string {
+	var manifest map[string]interface{}
+	if err := json.Unmarshal(content, &manifest); err != nil {
+		return []string{fmt.Sprintf("%s is not a valid JSON object: %s", bundleManifestName, err)}
+	}
+
+	var issues []string
+	version, ok := manifest["edgeworker-version"].(string)
+	if !ok || version == "" {
+		issues = append(issues, fmt.Sprintf("%s does not contain 'edgeworker-version'", bundleManifestName))
+	}
+	if apiVersion, ok := manifest["api-version"]; ok {
+		value, isString := apiVersion.(string)
+		if !isString || !apiVersionRegexp.MatchString(value) {
+			issues = append(issues, fmt.Sprintf("'api-version' of %s must be a version string, such as \"0.3\"", bundleManifestName))
+		}
+	}
+	return issues
+}
+
+// readTarFile returns the content of the file with the given path from the gzipped tar bundle
+func readTarFile(bundle []byte, name string) ([]byte, error) {
+	gr, err := gzip.NewReader(bytes.NewReader(bundle))
+	if err != nil {
+		return nil, err
+	}
+	gr.Multistream(false)
+	tr := tar.NewReader(gr)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			return nil, fmt.Errorf("file '%s' not found in bundle", name)
+		}
+		if err != nil {
+			return nil, err
+		}
+		if bundlePath(header.Name) == name {
+			return io.ReadAll(tr)
+		}
+	}
+}
+
+// bundlePath returns the path of a bundle file relative to the root of the bundle
+func bundlePath(name string) string {
+	return strings.TrimPrefix(path.Clean("/"+name), "/")
+}
@@ -0,0 +1,234 @@
+package edgeworkers
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/rand"
+	"testing"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v9/pkg/edgeworkers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLintBundle(t *testing.T) {
+	validManifest := []byte(`{"edgeworker-version": "1.0", "api-version": "0.3", "description": "test"}`)
+	mainJS := []byte("export function onClientRequest(request) {}")
+
+	tests := map[string]struct {
+		headers        []*tar.Header
+		contents       [][]byte
+		limits         bundleLimits
+		expectedIssues []string
+	}{
+		"valid bundle": {
+			headers:  []*tar.Header{regularFile("bundle.json"), regularFile("main.js"), regularFile("lib/utils.js")},
+			contents: [][]byte{validManifest, mainJS, []byte("export const answer = 42;")},
+		},
+		"valid bundle with directories and relative paths": {
+			headers: []*tar.Header{
+				{Name: "./", Typeflag: tar.TypeDir, Mode: 0755},
+				regularFile("./bundle.json"),
+				regularFile("./main.js"),
+			},
+			contents: [][]byte{nil, validManifest, mainJS},
+		},
+		"missing main.js and bundle.json": {
+			headers:  []*tar.Header{regularFile("lib/main.js")},
+			contents: [][]byte{mainJS},
+		},
+		"invalid bundle.json": {
+			headers:        []*tar.Header{regularFile("bundle.json"), regularFile("main.js")},
+			contents:       [][]byte{[]byte(`{"edgeworker-version": `), mainJS},
+			expectedIssues: []string{"bundle.json is not a valid JSON object"},
+		},
+		"bundle.json without edgeworker-version and with invalid api-version": {
+			headers:  []*tar.Header{regularFile("bundle.json"), regularFile("main.js")},
+			contents: [][]byte{[]byte(`{"api-version": 3}`), mainJS},
+			expectedIssues: []string{
+				"bundle.json does not contain 'edgeworker-version'",
+				`'api-version' of bundle.json must be a version string, such as "0.3"`,
+			},
+		},
+		"disallowed file types": {
+			headers: []*tar.Header{
+				regularFile("bundle.json"),
+				regularFile("main.js"),
+				regularFile("tools/build.SH"),
+				{Name: "lib/link.js", Typeflag: tar.TypeSymlink, Linkname: "../main.js", Mode: 0777},
+			},
+			contents: [][]byte{validManifest, mainJS, []byte("#!/bin/sh"), nil},
+			expectedIssues: []string{
+				"'tools/build.SH' has a disallowed file type '.sh'",
+				"'lib/link.js' is not a regular file",
+			},
+		},
+		"duplicate paths": {
+			headers:        []*tar.Header{regularFile("bundle.json"), regularFile("main.js"), regularFile("./main.js")},
+			contents:       [][]byte{validManifest, mainJS, mainJS},
+			expectedIssues: []string{"'main.js' is included 2 times"},
+		},
+		"uncompressed size exceeds the limit": {
+			headers:        []*tar.Header{regularFile("bundle.json"), regularFile("main.js"), regularFile("data.json")},
+			contents:       [][]byte{validManifest, mainJS, bytes.Repeat([]byte(" "), 6<<20)},
+			limits:         bundleLimits{CompressedSize: 1 << 20, UncompressedSize: 5 << 20},
+			expectedIssues: []string{"uncompressed bundle size of 6291573 bytes exceeds the limit of 5242880 bytes of the resource tier"},
+		},
+		"compressed size exceeds the limit": {
+			headers:        []*tar.Header{regularFile("bundle.json"), regularFile("main.js"), regularFile("random.txt")},
+			contents:       [][]byte{validManifest, mainJS, randomBytes(t, 2<<20)},
+			limits:         bundleLimits{CompressedSize: 1 << 20, UncompressedSize: 5 << 20},
+			expectedIssues: []string{"compressed bundle size of", "exceeds the limit of 1048576 bytes of the resource tier"},
+		},
+		"size is not checked without limits": {
+			headers:  []*tar.Header{regularFile("bundle.json"), regularFile("main.js"), regularFile("random.txt")},
+			contents: [][]byte{validManifest, mainJS, randomBytes(t, 2<<20)},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			bundle := prepareBundleWithHeaders(t, test.headers, test.contents)
+
+			err := lintBundle(bundle, test.limits)
+			if len(test.expectedIssues) == 0 {
+				assert.NoError(t, err)
+				return
+			}
+			require.ErrorIs(t, err, ErrInvalidBundle)
+			for _, issue := range test.expectedIssues {
+				assert.Contains(t, err.Error(), issue)
+			}
+		})
+	}
+
+	t.Run("not a gzipped tar archive", func(t *testing.T) {
+		err := lintBundle([]byte("main.js"), bundleLimits{})
+		assert.ErrorIs(t, err, ErrInvalidBundle)
+	})
+
+	t.Run("test bundles are valid", func(t *testing.T) {
+		for _, path := range []string{bundlePathForCreate, bundlePathForLocalUpdate} {
+			bundle, err := convertLocalBundleFileIntoBytes(path)
+			require.NoError(t, err)
+			assert.NoError(t, lintBundle(bundle, bundleLimits{}), path)
+		}
+	})
+
+	t.Run("bundle with files in a directory is accepted", func(t *testing.T) {
+		bundle, err := convertLocalBundleFileIntoBytes(bundlePathForUpdate)
+		require.NoError(t, err)
+		assert.NoError(t, lintBundle(bundle, bundleLimits{}))
+
+		hash, err := getSHAFromBundle(&edgeworkers.Bundle{Reader: bytes.NewBuffer(bundle)})
+		require.NoError(t, err)
+		assert.Equal(t, bundleHashForUpdate, hash)
+	})
+}
+
+func TestBundleLayoutWarnings(t *testing.T) {
+	mainJS := []byte("export function onClientRequest(request) {}")
+
+	t.Run("main.js and bundle.json in the root", func(t *testing.T) {
+		bundle := prepareBundleWithHeaders(t, []*tar.Header{regularFile("./bundle.json"), regularFile("main.js")}, [][]byte{[]byte("{}"), mainJS})
+		warnings, err := bundleLayoutWarnings(bundle)
+		require.NoError(t, err)
+		assert.Empty(t, warnings)
+	})
+
+	t.Run("main.js and bundle.json in a directory", func(t *testing.T) {
+		bundle, err := convertLocalBundleFileIntoBytes(bundlePathForUpdate)
+		require.NoError(t, err)
+		warnings, err := bundleLayoutWarnings(bundle)
+		require.NoError(t, err)
+		assert.Equal(t, []string{
+			"main.js is missing in the root of the bundle",
+			"bundle.json is missing in the root of the bundle",
+		}, warnings)
+	})
+}
+
+func TestGetBundleLimits(t *testing.T) {
+	tier := &edgeworkers.ResourceTier{
+		ID:   100,
+		Name: "Basic Compute",
+		EdgeWorkerLimits: []edgeworkers.EdgeWorkerLimit{
+			{LimitName: "Maximum CPU time during initialization", LimitValue: 30, LimitUnit: "MILLISECOND"},
+			{LimitName: "Maximum compressed size of the code bundle", LimitValue: 1024, LimitUnit: "KB"},
+			{LimitName: "Maximum uncompressed size of the code bundle", LimitValue: 5242880, LimitUnit: "BYTE"},
+			{LimitName: "Maximum response size for HTTP sub-requests", LimitValue: 1048576, LimitUnit: "BYTE"},
+		},
+	}
+	assert.Equal(t, bundleLimits{CompressedSize: 1 << 20, UncompressedSize: 5 << 20}, getBundleLimits(tier))
+	assert.Equal(t, bundleLimits{}, getBundleLimits(&edgeworkers.ResourceTier{ID: 200}))
+	assert.Equal(t, bundleLimits{}, getBundleLimits(nil))
+}
+
+func TestFindResourceTier(t *testing.T) {
+	basicCompute := edgeworkers.ResourceTier{ID: 100, Name: "Basic Compute"}
+	dynamicCompute := edgeworkers.ResourceTier{ID: 200, Name: "Dynamic Compute"}
+
+	t.Run("resource tier of an existing edgeworker", func(t *testing.T) {
+		client := &edgeworkers.Mock{}
+		client.On("GetResourceTier", mock.Anything, edgeworkers.GetResourceTierRequest{EdgeWorkerID: 123}).Return(&basicCompute, nil).Once()
+
+		tier, err := findResourceTier(context.Background(), client, 123, 100)
+		require.NoError(t, err)
+		assert.Equal(t, &basicCompute, tier)
+		client.AssertExpectations(t)
+	})
+
+	t.Run("resource tier found in the contracts", func(t *testing.T) {
+		client := &edgeworkers.Mock{}
+		client.On("ListContracts", mock.Anything).Return(&edgeworkers.ListContractsResponse{ContractIDs: []string{"ctr_1-ABC", "1-DEF"}}, nil).Once()
+		client.On("ListResourceTiers", mock.Anything, edgeworkers.ListResourceTiersRequest{ContractID: "1-ABC"}).
+			Return(&edgeworkers.ListResourceTiersResponse{ResourceTiers: []edgeworkers.ResourceTier{basicCompute}}, nil).Once()
+		client.On("ListResourceTiers", mock.Anything, edgeworkers.ListResourceTiersRequest{ContractID: "1-DEF"}).
+			Return(&edgeworkers.ListResourceTiersResponse{ResourceTiers: []edgeworkers.ResourceTier{basicCompute, dynamicCompute}}, nil).Once()
+
+		tier, err := findResourceTier(context.Background(), client, 0, 200)
+		require.NoError(t, err)
+		assert.Equal(t, &dynamicCompute, tier)
+		client.AssertExpectations(t)
+	})
+
+	t.Run("resource tier not found", func(t *testing.T) {
+		client := &edgeworkers.Mock{}
+		client.On("ListContracts", mock.Anything).Return(&edgeworkers.ListContractsResponse{ContractIDs: []string{"1-ABC"}}, nil).Once()
+		client.On("ListResourceTiers", mock.Anything, edgeworkers.ListResourceTiersRequest{ContractID: "1-ABC"}).
+			Return(&edgeworkers.ListResourceTiersResponse{ResourceTiers: []edgeworkers.ResourceTier{basicCompute}}, nil).Once()
+
+		_, err := findResourceTier(context.Background(), client, 0, 300)
+		assert.EqualError(t, err, "resource tier 300 not found")
+		client.AssertExpectations(t)
+	})
+}
+
+func regularFile(name string) *tar.Header {
+	return &tar.Header{Name: name, Typeflag: tar.TypeReg, Mode: 0644}
+}
+
+func prepareBundleWithHeaders(t *testing.T, headers []*tar.Header, contents [][]byte) []byte {
+	var buf bytes.Buffer
+	gw := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gw)
+	for i, header := range headers {
+		header.Size = int64(len(contents[i]))
+		require.NoError(t, tw.WriteHeader(header))
+		_, err := tw.Write(contents[i])
+		require.NoError(t, err)
+	}
+	require.NoError(t, tw.Close())
+	require.NoError(t, gw.Close())
+	return buf.Bytes()
+}
+
+func randomBytes(t *testing.T, size int) []byte {
+	content := make([]byte, size)
+	_, err := rand.Read(content)
+	require.NoError(t, err)
+	return content
+}
@@ -0,0 +1,161 @@
+package edgeworkers
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+const bundleManifestName = "bundle.json"
+
+var (
+	// ErrSourceDirNotDirectory is returned when source_dir does not point at a directory
+	ErrSourceDirNotDirectory = errors.New("source_dir is not a directory")
+	// ErrSourceDirUnsupportedFile is returned when source_dir contains a file which cannot be packaged
+	ErrSourceDirUnsupportedFile = errors.New("unsupported file in source_dir")
+	// ErrBundleManifest is returned when the bundle.json of the source directory cannot be generated
+	ErrBundleManifest = errors.New("cannot generate bundle.json")
+)
+
+// bundleManifest holds the bundle.json values which are set by the resource configuration. Empty values are not set.
+type bundleManifest struct {
+	Version     string
+	Description string
+}
+
+// packSourceDir packages the files of the given directory into a gzipped tar bundle. The bundle only depends on the
+// names and contents of the files: they are stored in lexical order, with fixed permissions, modification times and
+// owners, so packaging the same sources always produces the same bundle. The extra files, given by their path in the
+// bundle, are added to the bundle and replace the directory files with the same path. If any value of the manifest is
+// set, it is written to the bundle.json of the directory, which is created if it does not exist.
+func packSourceDir(dir string, manifest bundleManifest, extraFiles map[string]string) ([]byte, error) {
+	info, err := os.Stat(dir)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read source_dir (%s): %w", dir, err)
+	}
+	if !info.IsDir() {
+		return nil, fmt.Errorf("%w: %s", ErrSourceDirNotDirectory, dir)
+	}
+
+	files, err := readSourceFiles(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	for name, content := range extraFiles {
+		cleanName := path.Clean(name)
+		if path.IsAbs(name) || cleanName == "." || cleanName == ".." || strings.HasPrefix(cleanName, "../") {
+			return nil, fmt.Errorf("%w: '%s' is not a relative path within the bundle", ErrSourceDirUnsupportedFile, name)
+		}
+		files[cleanName] = []byte(content)
+	}
+
+	if manifest.Version != "" || manifest.Description != "" {
+		content, err := manifest.apply(files[bundleManifestName])
+		if err != nil {
+			return nil, err
+		}
+		files[bundleManifestName] = content
+	}
+
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var buf bytes.Buffer
+	gw := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gw)
+	for _, name := range names {
+		header := &tar.Header{
+			Typeflag: tar.TypeReg,
+			Name:     name,
+			Mode:     0644,
+			Size:     int64(len(files[name])),
+			ModTime:  time.Unix(0, 0),
+			Format:   tar.FormatUSTAR,
+		}
+		if err := tw.WriteHeader(header); err != nil {
+			return nil, fmt.Errorf("cannot package '%s': %w", name, err)
+		}
+		if _, err := tw.Write(files[name]); err != nil {
+			return nil, fmt.Errorf("cannot package '%s': %w", name, err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return nil, err
+	}
+	if err := gw.Close(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// readSourceFiles returns the contents of all regular files under the given directory by their slash-separated path
+// relative to it
+func readSourceFiles(dir string) (map[string][]byte, error) {
+	files := make(map[string][]byte)
+	err := filepath.WalkDir(dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() {
+			return nil
+		}
+		if !entry.Type().IsRegular() {
+			return fmt.Errorf("%w: %s", ErrSourceDirUnsupportedFile, path)
+		}
+		name, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		files[filepath.ToSlash(name)] = content
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("cannot read source_dir (%s): %w", dir, err)
+	}
+	return files, nil
+}
+
+// apply sets the values of the manifest in the given bundle.json content. Other values of the existing content are
+// preserved.
+func (m bundleManifest) apply(content []byte) ([]byte, error) {
+	values := make(map[string]interface{})
+	if content != nil {
+		if err := json.Unmarshal(content, &values); err != nil {
+			return nil, fmt.Errorf("%w: existing %s is not valid: %s", ErrBundleManifest, bundleManifestName, err)
+		}
+	}
+	if m.Version != "" {
+		values["edgeworker-version"] = m.Version
+	}
+	if m.Description != "" {
+		values["description"] = m.Description
+	}
+	if _, ok := values["edgeworker-version"]; !ok {
+		return nil, fmt.Errorf("%w: bundle_version is required when source_dir has no %s", ErrBundleManifest, bundleManifestName)
+	}
+
+	result, err := json.MarshalIndent(values, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrBundleManifest, err)
+	}
+	return result, nil
+}
@@ -0,0 +1,202 @@
+package edgeworkers
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v9/pkg/edgeworkers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const sourceDirPath = "testdata/TestResEdgeWorkersEdgeWorker/source_dir"
+
+func TestPackSourceDir(t *testing.T) {
+	t.Run("hash is the same as for the prebuilt bundle with the same files", func(t *testing.T) {
+		bundle, err := packSourceDir(sourceDirPath, bundleManifest{}, nil)
+		require.NoError(t, err)
+
+		hash, err := getSHAFromBundle(&edgeworkers.Bundle{Reader: bytes.NewReader(bundle)})
+		require.NoError(t, err)
+		assert.Equal(t, bundleHashForCreate, hash)
+	})
+
+	t.Run("bundle does not depend on modification times and file order", func(t *testing.T) {
+		dir := t.TempDir()
+		writeSourceFiles(t, dir, map[string]string{
+			"main.js":       "export function onClientRequest(request) {}",
+			"lib/utils.js":  "export const answer = 42;",
+			"bundle.json":   `{"edgeworker-version": "1.0"}`,
+			"lib/z/last.js": "",
+		})
+		first, err := packSourceDir(dir, bundleManifest{}, nil)
+		require.NoError(t, err)
+
+		later := time.Now().Add(time.Hour)
+		require.NoError(t, os.Chtimes(filepath.Join(dir, "main.js"), later, later))
+		second, err := packSourceDir(dir, bundleManifest{}, nil)
+		require.NoError(t, err)
+		assert.Equal(t, first, second)
+
+		files := unpackBundle(t, first)
+		assert.Equal(t, []string{"bundle.json", "lib/utils.js", "lib/z/last.js", "main.js"}, files.names)
+		for _, header := range files.headers {
+			assert.Equal(t, int64(0644), header.Mode)
+			assert.Equal(t, time.Unix(0, 0), header.ModTime)
+			assert.Equal(t, 0, header.Uid)
+			assert.Equal(t, 0, header.Gid)
+			assert.Empty(t, header.Uname)
+			assert.Empty(t, header.Gname)
+		}
+	})
+
+	t.Run("bundle changes with file content", func(t *testing.T) {
+		dir := t.TempDir()
+		writeSourceFiles(t, dir, map[string]string{
+			"main.js":     "export function onClientRequest(request) {}",
+			"bundle.json": `{"edgeworker-version": "1.0"}`,
+		})
+		first, err := packSourceDir(dir, bundleManifest{}, nil)
+		require.NoError(t, err)
+
+		writeSourceFiles(t, dir, map[string]string{"main.js": "export function onClientResponse(request, response) {}"})
+		second, err := packSourceDir(dir, bundleManifest{}, nil)
+		require.NoError(t, err)
+
+		firstHash, err := getSHAFromBundle(&edgeworkers.Bundle{Reader: bytes.NewReader(first)})
+		require.NoError(t, err)
+		secondHash, err := getSHAFromBundle(&edgeworkers.Bundle{Reader: bytes.NewReader(second)})
+		require.NoError(t, err)
+		assert.NotEqual(t, firstHash, secondHash)
+	})
+
+	t.Run("bundle.json is generated", func(t *testing.T) {
+		dir := t.TempDir()
+		writeSourceFiles(t, dir, map[string]string{"main.js": "export function onClientRequest(request) {}"})
+
+		bundle, err := packSourceDir(dir, bundleManifest{Version: "1.2", Description: "generated"}, nil)
+		require.NoError(t, err)
+
+		files := unpackBundle(t, bundle)
+		assert.Equal(t, []string{"bundle.json", "main.js"}, files.names)
+		assert.JSONEq(t, `{"edgeworker-version": "1.2", "description": "generated"}`, files.contents["bundle.json"])
+	})
+
+	t.Run("bundle.json values are updated", func(t *testing.T) {
+		dir := t.TempDir()
+		writeSourceFiles(t, dir, map[string]string{
+			"main.js":     "export function onClientRequest(request) {}",
+			"bundle.json": `{"edgeworker-version": "1.0", "description": "original", "misc": {"team": "edge"}}`,
+		})
+
+		bundle, err := packSourceDir(dir, bundleManifest{Version: "2.0"}, nil)
+		require.NoError(t, err)
+
+		files := unpackBundle(t, bundle)
+		assert.JSONEq(t, `{"edgeworker-version": "2.0", "description": "original", "misc": {"team": "edge"}}`, files.contents["bundle.json"])
+	})
+
+	t.Run("bundle.json without version", func(t *testing.T) {
+		dir := t.TempDir()
+		writeSourceFiles(t, dir, map[string]string{"main.js": "export function onClientRequest(request) {}"})
+
+		_, err := packSourceDir(dir, bundleManifest{Description: "no version"}, nil)
+		assert.ErrorIs(t, err, ErrBundleManifest)
+	})
+
+	t.Run("invalid bundle.json", func(t *testing.T) {
+		dir := t.TempDir()
+		writeSourceFiles(t, dir, map[string]string{"bundle.json": "{"})
+
+		_, err := packSourceDir(dir, bundleManifest{Version: "1.0"}, nil)
+		assert.ErrorIs(t, err, ErrBundleManifest)
+	})
+
+	t.Run("extra files are added", func(t *testing.T) {
+		dir := t.TempDir()
+		writeSourceFiles(t, dir, map[string]string{
+			"main.js":          "export function onClientRequest(request) {}",
+			"edgekv_tokens.js": "var edgekv_access_tokens = {};",
+		})
+
+		bundle, err := packSourceDir(dir, bundleManifest{Version: "1.0"}, map[string]string{
+			"edgekv_tokens.js": "var edgekv_access_tokens = {\"namespace-default\": {\"name\": \"token\"}};",
+			"./lib/config.js":  "export const config = {};",
+		})
+		require.NoError(t, err)
+
+		files := unpackBundle(t, bundle)
+		assert.Equal(t, []string{"bundle.json", "edgekv_tokens.js", "lib/config.js", "main.js"}, files.names)
+		assert.Equal(t, "var edgekv_access_tokens = {\"namespace-default\": {\"name\": \"token\"}};", files.contents["edgekv_tokens.js"])
+	})
+
+	t.Run("extra file outside of the bundle", func(t *testing.T) {
+		dir := t.TempDir()
+		writeSourceFiles(t, dir, map[string]string{"main.js": "export function onClientRequest(request) {}"})
+
+		for _, name := range []string{"../main.js", "/etc/passwd", "."} {
+			_, err := packSourceDir(dir, bundleManifest{}, map[string]string{name: "content"})
+			assert.ErrorIs(t, err, ErrSourceDirUnsupportedFile, name)
+		}
+	})
+
+	t.Run("source_dir is a file", func(t *testing.T) {
+		_, err := packSourceDir(filepath.Join(sourceDirPath, "main.js"), bundleManifest{}, nil)
+		assert.ErrorIs(t, err, ErrSourceDirNotDirectory)
+	})
+
+	t.Run("source_dir does not exist", func(t *testing.T) {
+		_, err := packSourceDir(filepath.Join(t.TempDir(), "missing"), bundleManifest{}, nil)
+		assert.ErrorIs(t, err, os.ErrNotExist)
+	})
+
+	t.Run("symbolic links are not supported", func(t *testing.T) {
+		dir := t.TempDir()
+		writeSourceFiles(t, dir, map[string]string{"main.js": "export function onClientRequest(request) {}"})
+		require.NoError(t, os.Symlink(filepath.Join(dir, "main.js"), filepath.Join(dir, "link.js")))
+
+		_, err := packSourceDir(dir, bundleManifest{}, nil)
+		assert.ErrorIs(t, err, ErrSourceDirUnsupportedFile)
+	})
+}
+
+type bundleContent struct {
+	names    []string
+	headers  []*tar.Header
+	contents map[string]string
+}
+
+func unpackBundle(t *testing.T, bundle []byte) bundleContent {
+	gr, err := gzip.NewReader(bytes.NewReader(bundle))
+	require.NoError(t, err)
+	tr := tar.NewReader(gr)
+
+	result := bundleContent{contents: make(map[string]string)}
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		content, err := io.ReadAll(tr)
+		require.NoError(t, err)
+		result.names = append(result.names, header.Name)
+		result.headers = append(result.headers, header)
+		result.contents[header.Name] = string(content)
+	}
+	return result
+}
+
+func writeSourceFiles(t *testing.T, dir string, files map[string]string) {
+	for name, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	}
+}
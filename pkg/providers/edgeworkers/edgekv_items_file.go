@@ -0,0 +1,180 @@
+package edgeworkers
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+var (
+	// ErrItemsFileFormat is returned when the format of the items file is not supported
+	ErrItemsFileFormat = errors.New("unsupported format of items file")
+	// ErrItemsFileContent is returned when the items cannot be read from the items file
+	ErrItemsFileContent = errors.New("invalid content of items file")
+)
+
+// itemsFileParsers holds the parsers of the supported items files by their extension
+var itemsFileParsers = map[string]func([]byte) (map[string]string, error){
+	".json": parseJSONItems,
+	".yaml": parseYAMLItems,
+	".yml":  parseYAMLItems,
+	".csv":  parseCSVItems,
+}
+
+// loadItemsFile returns the EdgeKV items stored in the given file or, if path points at a directory, in all the
+// supported files of the directory. Subdirectories and files with other extensions are skipped. Keys defined in more
+// than one file are reported as an error.
+func loadItemsFile(path string) (map[string]string, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read items_file (%s): %w", path, err)
+	}
+	if !info.IsDir() {
+		items, err := parseItemsFile(path)
+		if err != nil {
+			return nil, err
+		}
+		if len(items) == 0 {
+			return nil, fmt.Errorf("%w: %s does not contain any items", ErrItemsFileContent, path)
+		}
+		return items, nil
+	}
+
+	entries, err := os.ReadDir(path)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read items_file (%s): %w", path, err)
+	}
+	items := make(map[string]string)
+	sources := make(map[string]string)
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		if _, ok := itemsFileParsers[strings.ToLower(filepath.Ext(entry.Name()))]; !ok {
+			continue
+		}
+		file := filepath.Join(path, entry.Name())
+		fileItems, err := parseItemsFile(file)
+		if err != nil {
+			return nil, err
+		}
+		for key, value := range fileItems {
+			if source, ok := sources[key]; ok {
+				return nil, fmt.Errorf("%w: item '%s' is defined in both %s and %s", ErrItemsFileContent, key, source, file)
+			}
+			sources[key] = file
+			items[key] = value
+		}
+	}
+	if len(items) == 0 {
+		return nil, fmt.Errorf("%w: directory %s does not contain any items", ErrItemsFileContent, path)
+	}
+	return items, nil
+}
+
+// parseItemsFile returns the items of a single file, parsed according to its extension
+func parseItemsFile(path string) (map[string]string, error) {
+	parse, ok := itemsFileParsers[strings.ToLower(filepath.Ext(path))]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s, expected one of .json, .yaml, .yml or .csv", ErrItemsFileFormat, path)
+	}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read items_file (%s): %w", path, err)
+	}
+	items, err := parse(content)
+	if err != nil {
+		return nil, fmt.Errorf("%w (%s): %s", ErrItemsFileContent, path, err)
+	}
+	return items, nil
+}
+
+// parseJSONItems reads the items from a JSON object. Values which are not strings are stored as JSON.
+func parseJSONItems(content []byte) (map[string]string, error) {
+	var values map[string]interface{}
+	decoder := json.NewDecoder(bytes.NewReader(content))
+	decoder.UseNumber()
+	if err := decoder.Decode(&values); err != nil {
+		return nil, fmt.Errorf("expected a JSON object: %s", err)
+	}
+	if values == nil {
+		return nil, fmt.Errorf("expected a JSON object, got null")
+	}
+	return encodeItemValues(values)
+}
+
+// parseYAMLItems reads the items from a YAML mapping. Values which are not strings are stored as JSON.
+func parseYAMLItems(content []byte) (map[string]string, error) {
+	var values map[string]interface{}
+	if err := yaml.Unmarshal(content, &values); err != nil {
+		return nil, fmt.Errorf("expected a YAML mapping: %s", err)
+	}
+	return encodeItemValues(values)
+}
+
+// parseCSVItems reads the items from CSV records consisting of a key and a value. The first record is skipped if
+// it is a 'key,value' header.
+func parseCSVItems(content []byte) (map[string]string, error) {
+	reader := csv.NewReader(bytes.NewReader(content))
+	reader.FieldsPerRecord = 2
+	items := make(map[string]string)
+	for line := 1; ; line++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if line == 1 && strings.EqualFold(record[0], "key") && strings.EqualFold(record[1], "value") {
+			continue
+		}
+		if record[0] == "" {
+			return nil, fmt.Errorf("record on line %d has an empty key", line)
+		}
+		if _, ok := items[record[0]]; ok {
+			return nil, fmt.Errorf("item '%s' is defined more than once", record[0])
+		}
+		items[record[0]] = record[1]
+	}
+	return items, nil
+}
+
+// encodeItemValues converts the given values into item values. Strings are kept as they are, while objects, arrays,
+// numbers and booleans are encoded to JSON.
+func encodeItemValues(values map[string]interface{}) (map[string]string, error) {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	items := make(map[string]string, len(values))
+	for _, key := range keys {
+		if key == "" {
+			return nil, fmt.Errorf("item keys cannot be empty")
+		}
+		switch value := values[key].(type) {
+		case nil:
+			return nil, fmt.Errorf("item '%s' has no value", key)
+		case string:
+			items[key] = value
+		default:
+			encoded, err := json.Marshal(value)
+			if err != nil {
+				return nil, fmt.Errorf("cannot encode value of item '%s' to JSON: %s", key, err)
+			}
+			items[key] = string(encoded)
+		}
+	}
+	return items, nil
+}
@@ -0,0 +1,90 @@
+package edgeworkers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadItemsFile(t *testing.T) {
+	tests := map[string]struct {
+		path          string
+		expectedItems map[string]string
+		expectedError string
+	}{
+		"json file": {
+			path: "testdata/TestLoadItemsFile/items.json",
+			expectedItems: map[string]string{
+				"plain":  "value",
+				"object": `{"a":[1,"two"],"b":2}`,
+				"number": "1.50",
+				"flag":   "false",
+			},
+		},
+		"yaml file": {
+			path: "testdata/TestLoadItemsFile/items.yaml",
+			expectedItems: map[string]string{
+				"plain":  "value",
+				"object": `{"a":[1,"two"],"b":2}`,
+				"flag":   "true",
+			},
+		},
+		"csv file with header": {
+			path: "testdata/TestLoadItemsFile/items.csv",
+			expectedItems: map[string]string{
+				"plain":  "value",
+				"quoted": `a, "quoted" value`,
+			},
+		},
+		"directory": {
+			path: "testdata/TestLoadItemsFile/dir",
+			expectedItems: map[string]string{
+				"json_key": "from json",
+				"yaml_key": "from yaml",
+				"csv_key":  "from csv",
+			},
+		},
+		"directory with duplicate keys": {
+			path:          "testdata/TestLoadItemsFile/duplicates",
+			expectedError: "item 'key' is defined in both",
+		},
+		"json array": {
+			path:          "testdata/TestLoadItemsFile/array.json",
+			expectedError: "expected a JSON object",
+		},
+		"empty json object": {
+			path:          "testdata/TestLoadItemsFile/empty.json",
+			expectedError: "does not contain any items",
+		},
+		"null value": {
+			path:          "testdata/TestLoadItemsFile/null_value.yaml",
+			expectedError: "item 'key' has no value",
+		},
+		"csv record without value": {
+			path:          "testdata/TestLoadItemsFile/one_column.csv",
+			expectedError: "wrong number of fields",
+		},
+		"unsupported extension": {
+			path:          "testdata/TestLoadItemsFile/items.properties",
+			expectedError: "unsupported format of items file",
+		},
+		"missing file": {
+			path:          "testdata/TestLoadItemsFile/missing.json",
+			expectedError: "cannot read items_file",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			items, err := loadItemsFile(test.path)
+			if test.expectedError != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), test.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedItems, items)
+		})
+	}
+}
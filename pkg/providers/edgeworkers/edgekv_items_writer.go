@@ -0,0 +1,90 @@
+package edgeworkers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v9/pkg/edgeworkers"
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/common/activation"
+)
+
+var (
+	// edgeKVWriteChunkSize defines the number of item writes sent before pausing for edgeKVWriteChunkInterval
+	edgeKVWriteChunkSize = 100
+	// edgeKVWriteChunkInterval defines the pause between chunks of item writes
+	edgeKVWriteChunkInterval = time.Second
+	// edgeKVWriteRetryInterval and edgeKVWriteRetryMaxInterval define the backoff of throttled item writes
+	edgeKVWriteRetryInterval    = 2 * time.Second
+	edgeKVWriteRetryMaxInterval = time.Minute
+)
+
+// edgeKVItemsWriter writes the items of a single EdgeKV group. Writes are sent in chunks of edgeKVWriteChunkSize
+// with a pause between the chunks, and writes rejected because of rate limiting or server errors are retried with
+// a backoff until they succeed or the context is done.
+type edgeKVItemsWriter struct {
+	client edgeworkers.Edgeworkers
+	params edgeworkers.ItemsRequestParams
+	writes int
+}
+
+func newEdgeKVItemsWriter(client edgeworkers.Edgeworkers, attrs *edgeKVGroupItemsAttrs) *edgeKVItemsWriter {
+	return &edgeKVItemsWriter{
+		client: client,
+		params: edgeworkers.ItemsRequestParams{
+			Network:     attrs.network,
+			NamespaceID: attrs.namespace,
+			GroupID:     attrs.groupName,
+		},
+	}
+}
+
+// upsert creates or updates the item with the given key
+func (w *edgeKVItemsWriter) upsert(ctx context.Context, key, value string) error {
+	return w.write(ctx, func(ctx context.Context) error {
+		_, err := w.client.UpsertItem(ctx, edgeworkers.UpsertItemRequest{
+			ItemID:             key,
+			ItemData:           edgeworkers.Item(value),
+			ItemsRequestParams: w.params,
+		})
+		return err
+	})
+}
+
+// delete removes the item with the given key. An item which does not exist anymore, e.g. because an earlier
+// attempt succeeded, is not reported as an error.
+func (w *edgeKVItemsWriter) delete(ctx context.Context, key string) error {
+	var retried bool
+	return w.write(ctx, func(ctx context.Context) error {
+		_, err := w.client.DeleteItem(ctx, edgeworkers.DeleteItemRequest{
+			ItemID:             key,
+			ItemsRequestParams: w.params,
+		})
+		if retried && errors.Is(err, edgeworkers.ErrNotFound) {
+			return nil
+		}
+		retried = true
+		return err
+	})
+}
+
+func (w *edgeKVItemsWriter) write(ctx context.Context, op func(context.Context) error) error {
+	if w.writes > 0 && w.writes%edgeKVWriteChunkSize == 0 {
+		if err := activation.NewPoller(edgeKVWriteChunkInterval).Wait(ctx); err != nil {
+			return err
+		}
+	}
+	w.writes++
+	return activation.Retry(ctx, activation.NewBackoff(edgeKVWriteRetryInterval, edgeKVWriteRetryMaxInterval), isThrottledEdgeKVRequest, op)
+}
+
+// isThrottledEdgeKVRequest reports whether the EdgeKV request failed because of rate limiting or a server error
+// and can be sent again later
+func isThrottledEdgeKVRequest(err error) bool {
+	var e *edgeworkers.Error
+	if !errors.As(err, &e) {
+		return false
+	}
+	return e.Status == http.StatusTooManyRequests || e.Status >= http.StatusInternalServerError
+}
@@ -0,0 +1,185 @@
+package edgeworkers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v9/pkg/edgeworkers"
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/common/ptr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEdgeKVItemsWriter(t *testing.T) {
+	attrs := edgeKVConfigurationForTests{
+		namespaceID: "test_namespace",
+		network:     "staging",
+		groupID:     "1234",
+	}
+	groupAttrs := &edgeKVGroupItemsAttrs{
+		namespace: attrs.namespaceID,
+		network:   attrs.network,
+		groupName: attrs.groupID,
+	}
+	throttled := &edgeworkers.Error{Status: http.StatusTooManyRequests, Title: "Too Many Requests"}
+
+	tests := map[string]struct {
+		init          func(*edgeworkers.Mock)
+		write         func(context.Context, *edgeKVItemsWriter) error
+		expectedError string
+	}{
+		"writes in chunks": {
+			init: func(m *edgeworkers.Mock) {
+				for i := 1; i <= 5; i++ {
+					mockUpsertItem(m, attrs, fmt.Sprintf("key%d", i), fmt.Sprintf("value%d", i), 1)
+				}
+			},
+			write: func(ctx context.Context, w *edgeKVItemsWriter) error {
+				for i := 1; i <= 5; i++ {
+					if err := w.upsert(ctx, fmt.Sprintf("key%d", i), fmt.Sprintf("value%d", i)); err != nil {
+						return err
+					}
+				}
+				return nil
+			},
+		},
+		"retries throttled upsert": {
+			init: func(m *edgeworkers.Mock) {
+				m.On("UpsertItem", mock.Anything, edgeworkers.UpsertItemRequest{
+					ItemID:   "key1",
+					ItemData: "value1",
+					ItemsRequestParams: edgeworkers.ItemsRequestParams{
+						NamespaceID: attrs.namespaceID,
+						Network:     attrs.network,
+						GroupID:     attrs.groupID,
+					},
+				}).Return(nil, throttled).Twice()
+				mockUpsertItem(m, attrs, "key1", "value1", 1)
+			},
+			write: func(ctx context.Context, w *edgeKVItemsWriter) error {
+				return w.upsert(ctx, "key1", "value1")
+			},
+		},
+		"does not retry rejected upsert": {
+			init: func(m *edgeworkers.Mock) {
+				m.On("UpsertItem", mock.Anything, mock.Anything).
+					Return(nil, &edgeworkers.Error{Status: http.StatusBadRequest, Title: "Bad Request"}).Once()
+			},
+			write: func(ctx context.Context, w *edgeKVItemsWriter) error {
+				return w.upsert(ctx, "key1", "value1")
+			},
+			expectedError: "Bad Request",
+		},
+		"retried delete of removed item": {
+			init: func(m *edgeworkers.Mock) {
+				request := edgeworkers.DeleteItemRequest{
+					ItemID: "key1",
+					ItemsRequestParams: edgeworkers.ItemsRequestParams{
+						NamespaceID: attrs.namespaceID,
+						Network:     attrs.network,
+						GroupID:     attrs.groupID,
+					},
+				}
+				m.On("DeleteItem", mock.Anything, request).
+					Return(nil, &edgeworkers.Error{Status: http.StatusBadGateway}).Once()
+				m.On("DeleteItem", mock.Anything, request).
+					Return(nil, &edgeworkers.Error{Status: http.StatusNotFound, ErrorCode: "EKV_9000"}).Once()
+			},
+			write: func(ctx context.Context, w *edgeKVItemsWriter) error {
+				return w.delete(ctx, "key1")
+			},
+		},
+		"delete of missing item": {
+			init: func(m *edgeworkers.Mock) {
+				m.On("DeleteItem", mock.Anything, mock.Anything).
+					Return(nil, &edgeworkers.Error{Status: http.StatusNotFound, ErrorCode: "EKV_9000"}).Once()
+			},
+			write: func(ctx context.Context, w *edgeKVItemsWriter) error {
+				return w.delete(ctx, "key1")
+			},
+			expectedError: "EKV_9000",
+		},
+		"throttled until timeout": {
+			init: func(m *edgeworkers.Mock) {
+				m.On("UpsertItem", mock.Anything, mock.Anything).Return(nil, throttled)
+			},
+			write: func(ctx context.Context, w *edgeKVItemsWriter) error {
+				ctx, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+				defer cancel()
+				return w.upsert(ctx, "key1", "value1")
+			},
+			expectedError: "context deadline exceeded",
+		},
+	}
+
+	chunkSize, chunkInterval := edgeKVWriteChunkSize, edgeKVWriteChunkInterval
+	retryInterval, retryMaxInterval := edgeKVWriteRetryInterval, edgeKVWriteRetryMaxInterval
+	edgeKVWriteChunkSize, edgeKVWriteChunkInterval = 2, time.Millisecond
+	edgeKVWriteRetryInterval, edgeKVWriteRetryMaxInterval = time.Millisecond, 5*time.Millisecond
+	defer func() {
+		edgeKVWriteChunkSize, edgeKVWriteChunkInterval = chunkSize, chunkInterval
+		edgeKVWriteRetryInterval, edgeKVWriteRetryMaxInterval = retryInterval, retryMaxInterval
+	}()
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			client := &edgeworkers.Mock{}
+			test.init(client)
+
+			err := test.write(context.Background(), newEdgeKVItemsWriter(client, groupAttrs))
+			if test.expectedError != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), test.expectedError)
+			} else {
+				require.NoError(t, err)
+			}
+			client.AssertExpectations(t)
+		})
+	}
+}
+
+func TestWaitForConsistentEdgeKVDatabaseThrottled(t *testing.T) {
+	pollForConsistentEdgeKVDatabaseInterval = time.Microsecond
+	attrs := edgeKVConfigurationForTests{
+		namespaceID: "test_namespace",
+		network:     "staging",
+		groupID:     "1234",
+	}
+	client := &edgeworkers.Mock{}
+	getItemRequest := func(key string) edgeworkers.GetItemRequest {
+		return edgeworkers.GetItemRequest{
+			ItemID: key,
+			ItemsRequestParams: edgeworkers.ItemsRequestParams{
+				NamespaceID: attrs.namespaceID,
+				Network:     attrs.network,
+				GroupID:     attrs.groupID,
+			},
+		}
+	}
+
+	// poll #1: key1 is consistent, key2 gets throttled
+	mockGetItem(client, attrs, "key1", "value1", 1)
+	client.On("GetItem", mock.Anything, getItemRequest("key2")).
+		Return(nil, &edgeworkers.Error{Status: http.StatusTooManyRequests}).Once()
+	// poll #2: key2 is consistent, deleted key3 is still present
+	mockGetItem(client, attrs, "key2", "value2", 1)
+	client.On("GetItem", mock.Anything, getItemRequest("key3")).Return(ptr.To(edgeworkers.Item("value3")), nil).Once()
+	// poll #3: key3 is deleted
+	mockErrGetItem(client, attrs, "key3", 1)
+
+	err := waitForConsistentEdgeKVDatabase(context.Background(), client, []string{"key3"}, &edgeKVGroupItemsAttrs{
+		namespace: attrs.namespaceID,
+		network:   attrs.network,
+		groupName: attrs.groupID,
+		items: map[string]interface{}{
+			"key1": "value1",
+			"key2": "value2",
+		},
+	})
+	require.NoError(t, err)
+	client.AssertExpectations(t)
+}
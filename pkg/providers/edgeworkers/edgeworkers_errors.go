@@ -1,7 +1,12 @@
 package edgeworkers
 
 import (
+	"context"
 	"errors"
+	"fmt"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v9/pkg/edgeworkers"
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/common/activation"
 )
 
 var (
@@ -30,3 +35,40 @@ var (
 	// ErrEdgeworkerDeactivationContextTerminated is returned on deactivation context termination
 	ErrEdgeworkerDeactivationContextTerminated = errors.New("edgeworker deactivation context terminated")
 )
+
+// activationWaitError converts an error returned while waiting for an edgeworker activation into the activation errors
+func activationWaitError(err error) error {
+	if errors.Is(err, context.DeadlineExceeded) {
+		return ErrEdgeworkerActivationTimeout
+	}
+	if errors.Is(err, context.Canceled) {
+		return ErrEdgeworkerActivationCancelled
+	}
+	return fmt.Errorf("%v: %w", ErrEdgeworkerActivationContextTerminated, err)
+}
+
+// deactivationWaitError converts an error returned while waiting for an edgeworker deactivation into the deactivation errors
+func deactivationWaitError(err error) error {
+	if errors.Is(err, context.DeadlineExceeded) {
+		return ErrEdgeworkerDeactivationTimeout
+	}
+	if errors.Is(err, context.Canceled) {
+		return ErrEdgeworkerDeactivationCancelled
+	}
+	return fmt.Errorf("%v: %w", ErrEdgeworkerDeactivationContextTerminated, err)
+}
+
+// isActivationErrorRetryable reports whether a request sent while waiting for an activation failed with a transient error
+func isActivationErrorRetryable(err error) bool {
+	return activation.IsRetryable(err, func(e *edgeworkers.Error) int { return e.Status }, activation.IsRetryableStatusCode)
+}
+
+// retryActivationRequest sends a request while waiting for an activation or deactivation and resends it on transient
+// errors. An error caused by the context being done is converted with waitError.
+func retryActivationRequest(ctx context.Context, poller *activation.Poller, waitError func(error) error, request func(context.Context) error) error {
+	err := activation.Retry(ctx, poller, isActivationErrorRetryable, request)
+	if errors.Is(err, activation.ErrContextTerminated) {
+		return waitError(err)
+	}
+	return err
+}
@@ -0,0 +1,97 @@
+package edgeworkers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v9/pkg/edgeworkers"
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/common/activation"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestActivationWaitErrors(t *testing.T) {
+	tests := map[string]struct {
+		err                  error
+		expectedActivation   error
+		expectedDeactivation error
+	}{
+		"deadline exceeded": {
+			err:                  activation.ContextError(context.DeadlineExceeded),
+			expectedActivation:   ErrEdgeworkerActivationTimeout,
+			expectedDeactivation: ErrEdgeworkerDeactivationTimeout,
+		},
+		"canceled": {
+			err:                  activation.ContextError(context.Canceled),
+			expectedActivation:   ErrEdgeworkerActivationCancelled,
+			expectedDeactivation: ErrEdgeworkerDeactivationCancelled,
+		},
+		"other error": {
+			err:                  errors.New("oops"),
+			expectedActivation:   ErrEdgeworkerActivationContextTerminated,
+			expectedDeactivation: ErrEdgeworkerDeactivationContextTerminated,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.ErrorContains(t, activationWaitError(test.err), test.expectedActivation.Error())
+			assert.ErrorContains(t, deactivationWaitError(test.err), test.expectedDeactivation.Error())
+		})
+	}
+}
+
+func TestRetryActivationRequest(t *testing.T) {
+	tests := map[string]struct {
+		errs          []error
+		timeout       time.Duration
+		expectedCalls int
+		expectedError error
+	}{
+		"success": {
+			expectedCalls: 1,
+		},
+		"retryable errors are retried": {
+			errs:          []error{&edgeworkers.Error{Status: http.StatusServiceUnavailable}, &edgeworkers.Error{Status: http.StatusServiceUnavailable}},
+			expectedCalls: 3,
+		},
+		"non-retryable error is returned": {
+			errs:          []error{&edgeworkers.Error{Status: http.StatusNotFound}},
+			expectedCalls: 1,
+			expectedError: &edgeworkers.Error{Status: http.StatusNotFound},
+		},
+		"retries stop when the context is done": {
+			errs:          []error{&edgeworkers.Error{Status: http.StatusServiceUnavailable}, &edgeworkers.Error{Status: http.StatusServiceUnavailable}, &edgeworkers.Error{Status: http.StatusServiceUnavailable}, &edgeworkers.Error{Status: http.StatusServiceUnavailable}},
+			timeout:       time.Millisecond,
+			expectedError: ErrEdgeworkerActivationTimeout,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			poller := activation.NewPoller(time.Millisecond)
+			if test.timeout > 0 {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(ctx, test.timeout)
+				defer cancel()
+				poller = activation.NewPoller(time.Second)
+			}
+			calls := 0
+			err := retryActivationRequest(ctx, poller, activationWaitError, func(context.Context) error {
+				calls++
+				if calls <= len(test.errs) {
+					return test.errs[calls-1]
+				}
+				return nil
+			})
+			if test.expectedError != nil {
+				assert.ErrorIs(t, err, test.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedCalls, calls)
+		})
+	}
+}
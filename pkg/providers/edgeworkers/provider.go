@@ -60,6 +60,7 @@ func (p *Subprovider) Client(meta meta.Meta) edgeworkers.Edgeworkers {
 func (p *Subprovider) SDKResources() map[string]*schema.Resource {
 	return map[string]*schema.Resource{
 		"akamai_edgekv":                 resourceEdgeKV(),
+		"akamai_edgekv_access_token":    resourceEdgeKVAccessToken(),
 		"akamai_edgekv_group_items":     resourceEdgeKVGroupItems(),
 		"akamai_edgeworkers_activation": resourceEdgeworkersActivation(),
 		"akamai_edgeworker":             resourceEdgeWorker(),
@@ -0,0 +1,532 @@
+package edgeworkers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v9/pkg/edgeworkers"
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v9/pkg/session"
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/common/activation"
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/common/tf"
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/common/timeouts"
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/meta"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const (
+	tokenActivationStatusComplete = "COMPLETE"
+	tokenActivationStatusError    = "ERROR"
+
+	// edgeKVAccessTokenRotationSuffix is appended to the name of every other issued token, as the new token of a
+	// rotation is issued while the old one with the same name still exists
+	edgeKVAccessTokenRotationSuffix = "-rot"
+
+	// edgeKVAccessTokenMaxNameLength is the maximum length of the name of an EdgeKV access token
+	edgeKVAccessTokenMaxNameLength = 32
+)
+
+var (
+	// ErrEdgeKVAccessTokenActivation is returned when the activation of an EdgeKV access token fails
+	ErrEdgeKVAccessTokenActivation = errors.New("edgekv access token activation failed")
+
+	// pollForEdgeKVAccessTokenActivationInterval defines the interval between checks of the token activation status
+	pollForEdgeKVAccessTokenActivationInterval = 10 * time.Second
+
+	// edgeKVAccessTokenComputedAttributes lists the attributes which change when the token is rotated
+	edgeKVAccessTokenComputedAttributes = []string{
+		"uuid", "expiry", "issue_date", "latest_refresh_date", "next_scheduled_refresh_date", "token_activation_status",
+	}
+)
+
+func resourceEdgeKVAccessToken() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceEdgeKVAccessTokenCreate,
+		ReadContext:   resourceEdgeKVAccessTokenRead,
+		UpdateContext: resourceEdgeKVAccessTokenUpdate,
+		DeleteContext: resourceEdgeKVAccessTokenDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		CustomizeDiff: edgeKVAccessTokenCustomDiff,
+		Timeouts: &schema.ResourceTimeout{
+			Default: &timeouts.SDKDefaultTimeout,
+		},
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringLenBetween(1, edgeKVAccessTokenMaxNameLength)),
+				Description:      "The name of the access token.",
+			},
+			"token_name": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The name of the issued access token. It is 'name', or 'name' with the '-rot' suffix for every other rotation.",
+			},
+			"allow_on_staging": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				ForceNew:    true,
+				Description: "Whether the token can be used on the staging network.",
+			},
+			"allow_on_production": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				ForceNew:    true,
+				Description: "Whether the token can be used on the production network.",
+			},
+			"namespace_permissions": {
+				Type:        schema.TypeSet,
+				Required:    true,
+				ForceNew:    true,
+				MinItems:    1,
+				Description: "The namespaces the token has access to, with the permissions granted for each of them.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"namespace_name": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "The name of the EdgeKV namespace.",
+						},
+						"permissions": {
+							Type:        schema.TypeSet,
+							Required:    true,
+							MinItems:    1,
+							Description: "The permissions granted for the namespace: 'r' for read, 'w' for write and 'd' for delete access.",
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+								ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice([]string{
+									string(edgeworkers.PermissionRead),
+									string(edgeworkers.PermissionWrite),
+									string(edgeworkers.PermissionDelete),
+								}, false)),
+							},
+						},
+					},
+				},
+			},
+			"restrict_to_edgeworker_ids": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "The IDs of the EdgeWorkers allowed to use the token. If not set, all EdgeWorkers can use it.",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"rotate_before_expiry": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateDiagFunc: timeouts.ValidateDurationFormat,
+				Description:      "The period before the expiry of the token, such as '720h', in which the token is rotated on the next apply. A new token is issued under 'token_name' before the old one is revoked.",
+			},
+			"uuid": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The unique identifier of the access token.",
+			},
+			"cpcode": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The CP code associated with the access token.",
+			},
+			"expiry": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The expiry date of the access token.",
+			},
+			"issue_date": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The date on which the access token was issued.",
+			},
+			"latest_refresh_date": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The date on which the access token was last refreshed.",
+			},
+			"next_scheduled_refresh_date": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The date of the next scheduled refresh of the access token.",
+			},
+			"token_activation_status": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The activation status of the access token.",
+			},
+			"edgekv_tokens_js": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The content of the 'edgekv_tokens.js' file referencing the token for each of its namespaces, to be included in the EdgeWorker bundle.",
+			},
+			"timeouts": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Description: "Enables to set timeout for processing",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"default": {
+							Type:             schema.TypeString,
+							Optional:         true,
+							ValidateDiagFunc: timeouts.ValidateDurationFormat,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func resourceEdgeKVAccessTokenCreate(ctx context.Context, rd *schema.ResourceData, m interface{}) diag.Diagnostics {
+	meta := meta.Must(m)
+	logger := meta.Log("EdgeKV", "resourceEdgeKVAccessTokenCreate")
+	ctx = session.ContextWithOptions(ctx, session.WithContextLog(logger))
+	client := inst.Client(meta)
+	logger.Debug("Creating EdgeKV access token")
+
+	name, err := tf.GetStringValue("name", rd)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	name, err = createEdgeKVAccessToken(ctx, client, rd, name)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	rd.SetId(name)
+
+	return resourceEdgeKVAccessTokenRead(ctx, rd, m)
+}
+
+func resourceEdgeKVAccessTokenRead(ctx context.Context, rd *schema.ResourceData, m interface{}) diag.Diagnostics {
+	meta := meta.Must(m)
+	logger := meta.Log("EdgeKV", "resourceEdgeKVAccessTokenRead")
+	ctx = session.ContextWithOptions(ctx, session.WithContextLog(logger))
+	client := inst.Client(meta)
+	logger.Debug("Reading EdgeKV access token")
+
+	token, err := client.GetEdgeKVAccessToken(ctx, edgeworkers.GetEdgeKVAccessTokenRequest{
+		TokenName: rd.Id(),
+	})
+	if err != nil {
+		if isEdgeKVAccessTokenNotFound(err) {
+			logger.Warnf("EdgeKV access token '%s' does not exist anymore, removing it from the state", rd.Id())
+			rd.SetId("")
+			return nil
+		}
+		return diag.Errorf("could not get EdgeKV access token '%s': %s", rd.Id(), err)
+	}
+
+	tokensJS, err := renderEdgeKVTokensJS(token.Name, token.NamespacePermissions)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	attrs := map[string]interface{}{
+		"token_name":                  token.Name,
+		"allow_on_staging":            token.AllowOnStaging,
+		"allow_on_production":         token.AllowOnProduction,
+		"namespace_permissions":       flattenNamespacePermissions(token.NamespacePermissions),
+		"restrict_to_edgeworker_ids":  token.RestrictToEdgeWorkerIDs,
+		"uuid":                        token.UUID,
+		"cpcode":                      token.CPCode,
+		"expiry":                      token.Expiry,
+		"issue_date":                  token.IssueDate,
+		"latest_refresh_date":         token.LatestRefreshDate,
+		"next_scheduled_refresh_date": token.NextScheduledRefreshDate,
+		"token_activation_status":     token.TokenActivationStatus,
+		"edgekv_tokens_js":            tokensJS,
+	}
+	// the name is only missing after an import
+	if _, ok := rd.GetOk("name"); !ok {
+		attrs["name"] = strings.TrimSuffix(token.Name, edgeKVAccessTokenRotationSuffix)
+	}
+	if err = tf.SetAttrs(rd, attrs); err != nil {
+		return diag.Errorf("could not set attributes: %s", err)
+	}
+
+	return nil
+}
+
+func resourceEdgeKVAccessTokenUpdate(ctx context.Context, rd *schema.ResourceData, m interface{}) diag.Diagnostics {
+	meta := meta.Must(m)
+	logger := meta.Log("EdgeKV", "resourceEdgeKVAccessTokenUpdate")
+	ctx = session.ContextWithOptions(ctx, session.WithContextLog(logger))
+	client := inst.Client(meta)
+
+	// all other arguments force a new token, so the token only changes when it is rotated
+	if !rd.HasChange("uuid") {
+		logger.Debug("Only rotate_before_expiry or timeouts were updated, skipping")
+		return nil
+	}
+
+	// the new token is issued and stored in the state before the old one is revoked, so that a token is valid at all times
+	oldName := rd.Id()
+	newName, err := tf.GetStringValue("token_name", rd)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	logger.Debugf("Rotating EdgeKV access token '%s' to '%s'", oldName, newName)
+	if _, err = createEdgeKVAccessToken(ctx, client, rd, newName); err != nil {
+		return diag.Errorf("could not issue EdgeKV access token '%s' for rotation of '%s': %s", newName, oldName, err)
+	}
+	rd.SetId(newName)
+	diags := resourceEdgeKVAccessTokenRead(ctx, rd, m)
+	if diags.HasError() {
+		return diags
+	}
+
+	if _, err = client.DeleteEdgeKVAccessToken(ctx, edgeworkers.DeleteEdgeKVAccessTokenRequest{
+		TokenName: oldName,
+	}); err != nil && !isEdgeKVAccessTokenNotFound(err) {
+		return append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  fmt.Sprintf("could not revoke EdgeKV access token '%s' after its rotation", oldName),
+			Detail:   fmt.Sprintf("The token was replaced by '%s' but is still valid until it expires or is revoked: %s", newName, err),
+		})
+	}
+
+	return diags
+}
+
+func resourceEdgeKVAccessTokenDelete(ctx context.Context, rd *schema.ResourceData, m interface{}) diag.Diagnostics {
+	meta := meta.Must(m)
+	logger := meta.Log("EdgeKV", "resourceEdgeKVAccessTokenDelete")
+	ctx = session.ContextWithOptions(ctx, session.WithContextLog(logger))
+	client := inst.Client(meta)
+	logger.Debug("Revoking EdgeKV access token")
+
+	_, err := client.DeleteEdgeKVAccessToken(ctx, edgeworkers.DeleteEdgeKVAccessTokenRequest{
+		TokenName: rd.Id(),
+	})
+	if err != nil && !isEdgeKVAccessTokenNotFound(err) {
+		return diag.Errorf("could not revoke EdgeKV access token '%s': %s", rd.Id(), err)
+	}
+
+	rd.SetId("")
+	return nil
+}
+
+// createEdgeKVAccessToken issues a token with the given name and the other arguments of the resource data, and waits
+// until its activation completes
+func createEdgeKVAccessToken(ctx context.Context, client edgeworkers.Edgeworkers, rd *schema.ResourceData, name string) (string, error) {
+	allowOnStaging, err := tf.GetBoolValue("allow_on_staging", rd)
+	if err != nil && !errors.Is(err, tf.ErrNotFound) {
+		return "", err
+	}
+	allowOnProduction, err := tf.GetBoolValue("allow_on_production", rd)
+	if err != nil && !errors.Is(err, tf.ErrNotFound) {
+		return "", err
+	}
+	permissions, err := tf.GetSetValue("namespace_permissions", rd)
+	if err != nil {
+		return "", err
+	}
+	edgeWorkerIDs, err := tf.GetSetValue("restrict_to_edgeworker_ids", rd)
+	if err != nil && !errors.Is(err, tf.ErrNotFound) {
+		return "", err
+	}
+
+	// omitting the IDs authorizes all EdgeWorkers, while an empty list would authorize none
+	var restrictToEdgeWorkerIDs []string
+	if edgeWorkerIDs.Len() > 0 {
+		restrictToEdgeWorkerIDs = tf.SetToStringSlice(edgeWorkerIDs)
+	}
+
+	token, err := client.CreateEdgeKVAccessToken(ctx, edgeworkers.CreateEdgeKVAccessTokenRequest{
+		Name:                    name,
+		AllowOnStaging:          allowOnStaging,
+		AllowOnProduction:       allowOnProduction,
+		NamespacePermissions:    expandNamespacePermissions(permissions.List()),
+		RestrictToEdgeWorkerIDs: restrictToEdgeWorkerIDs,
+	})
+	if err != nil {
+		return "", fmt.Errorf("could not create EdgeKV access token '%s': %s", name, err)
+	}
+
+	if token.TokenActivationStatus != tokenActivationStatusComplete {
+		if err = waitForEdgeKVAccessTokenActivation(ctx, client, token.Name); err != nil {
+			return "", err
+		}
+	}
+	return token.Name, nil
+}
+
+// waitForEdgeKVAccessTokenActivation waits until the activation status of the token is COMPLETE
+func waitForEdgeKVAccessTokenActivation(ctx context.Context, client edgeworkers.Edgeworkers, name string) error {
+	err := activation.Poll(ctx, activation.NewPoller(pollForEdgeKVAccessTokenActivationInterval), activation.DefaultMaxRetries, isThrottledEdgeKVRequest,
+		func(ctx context.Context) (bool, error) {
+			token, err := client.GetEdgeKVAccessToken(ctx, edgeworkers.GetEdgeKVAccessTokenRequest{TokenName: name})
+			if err != nil {
+				return false, err
+			}
+			switch token.TokenActivationStatus {
+			case tokenActivationStatusComplete:
+				return true, nil
+			case tokenActivationStatusError:
+				return false, fmt.Errorf("%w: token '%s' has status %s", ErrEdgeKVAccessTokenActivation, name, token.TokenActivationStatus)
+			}
+			return false, nil
+		})
+	if err != nil {
+		return fmt.Errorf("waiting for activation of EdgeKV access token '%s': %w", name, err)
+	}
+	return nil
+}
+
+// edgeKVAccessTokenCustomDiff checks that the token is allowed on at least one network, sets the name and renders the
+// edgekv_tokens.js of a new token, and marks the token for rotation under the alternate name when its expiry is within
+// 'rotate_before_expiry'
+func edgeKVAccessTokenCustomDiff(_ context.Context, diff *schema.ResourceDiff, _ interface{}) error {
+	if diff.NewValueKnown("allow_on_staging") && diff.NewValueKnown("allow_on_production") &&
+		!diff.Get("allow_on_staging").(bool) && !diff.Get("allow_on_production").(bool) {
+		return fmt.Errorf("at least one of 'allow_on_staging' or 'allow_on_production' must be set to true")
+	}
+	if diff.NewValueKnown("name") && diff.Get("rotate_before_expiry").(string) != "" &&
+		len(diff.Get("name").(string))+len(edgeKVAccessTokenRotationSuffix) > edgeKVAccessTokenMaxNameLength {
+		return fmt.Errorf("'name' must be at most %d characters long when 'rotate_before_expiry' is set",
+			edgeKVAccessTokenMaxNameLength-len(edgeKVAccessTokenRotationSuffix))
+	}
+
+	if diff.Id() == "" || diff.HasChanges("name", "namespace_permissions") {
+		if !diff.NewValueKnown("name") {
+			for _, attr := range []string{"token_name", "edgekv_tokens_js"} {
+				if err := diff.SetNewComputed(attr); err != nil {
+					return fmt.Errorf("cannot set new computed for '%s': %s", attr, err)
+				}
+			}
+			return nil
+		}
+		name := diff.Get("name").(string)
+		if err := diff.SetNew("token_name", name); err != nil {
+			return err
+		}
+		return setNewEdgeKVTokensJS(diff, name)
+	}
+
+	rotate, err := edgeKVAccessTokenNeedsRotation(diff.Get("expiry").(string), diff.Get("rotate_before_expiry").(string), time.Now())
+	if err != nil {
+		return err
+	}
+	if rotate {
+		for _, attr := range edgeKVAccessTokenComputedAttributes {
+			if err := diff.SetNewComputed(attr); err != nil {
+				return fmt.Errorf("cannot set new computed for '%s': %s", attr, err)
+			}
+		}
+		name := rotatedEdgeKVAccessTokenName(diff.Get("name").(string), diff.Id())
+		if err := diff.SetNew("token_name", name); err != nil {
+			return err
+		}
+		return setNewEdgeKVTokensJS(diff, name)
+	}
+	return nil
+}
+
+// setNewEdgeKVTokensJS sets the edgekv_tokens.js referencing the token with the given name, or marks it as computed
+// when the namespaces are not known yet
+func setNewEdgeKVTokensJS(diff *schema.ResourceDiff, name string) error {
+	if !diff.NewValueKnown("namespace_permissions") {
+		return diff.SetNewComputed("edgekv_tokens_js")
+	}
+	permissions, ok := diff.Get("namespace_permissions").(*schema.Set)
+	if !ok {
+		return fmt.Errorf("could not get 'namespace_permissions' attribute")
+	}
+	tokensJS, err := renderEdgeKVTokensJS(name, expandNamespacePermissions(permissions.List()))
+	if err != nil {
+		return err
+	}
+	return diff.SetNew("edgekv_tokens_js", tokensJS)
+}
+
+// rotatedEdgeKVAccessTokenName returns the name of the token replacing the token with the current name on rotation.
+// Rotations alternate between the configured name and the name with the rotation suffix.
+func rotatedEdgeKVAccessTokenName(name, current string) string {
+	if current == name {
+		return name + edgeKVAccessTokenRotationSuffix
+	}
+	return name
+}
+
+// edgeKVAccessTokenNeedsRotation reports whether a token with the given expiry is due for rotation at the given time
+func edgeKVAccessTokenNeedsRotation(expiry, rotateBeforeExpiry string, now time.Time) (bool, error) {
+	if expiry == "" || rotateBeforeExpiry == "" {
+		return false, nil
+	}
+	window, err := time.ParseDuration(rotateBeforeExpiry)
+	if err != nil {
+		return false, fmt.Errorf("could not parse 'rotate_before_expiry': %s", err)
+	}
+	for _, layout := range []string{time.RFC3339, "2006-01-02"} {
+		if expiryTime, err := time.Parse(layout, expiry); err == nil {
+			return !now.Add(window).Before(expiryTime), nil
+		}
+	}
+	return false, fmt.Errorf("could not parse expiry date '%s' of the access token", expiry)
+}
+
+// renderEdgeKVTokensJS returns the content of the edgekv_tokens.js file, which the EdgeKV library of an EdgeWorker
+// uses to find the access token of each namespace
+func renderEdgeKVTokensJS(name string, permissions edgeworkers.NamespacePermissions) (string, error) {
+	tokens := make(map[string]map[string]string, len(permissions))
+	for namespace := range permissions {
+		tokens["namespace-"+namespace] = map[string]string{"name": name}
+	}
+	content, err := json.MarshalIndent(tokens, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("could not render edgekv_tokens.js: %s", err)
+	}
+	return fmt.Sprintf("var edgekv_access_tokens = %s;\nexport { edgekv_access_tokens };\n", content), nil
+}
+
+func expandNamespacePermissions(permissions []interface{}) edgeworkers.NamespacePermissions {
+	result := make(edgeworkers.NamespacePermissions, len(permissions))
+	for _, p := range permissions {
+		entry, ok := p.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		namespace, _ := entry["namespace_name"].(string)
+		var granted []edgeworkers.Permission
+		if set, ok := entry["permissions"].(*schema.Set); ok {
+			for _, permission := range tf.SetToStringSlice(set) {
+				granted = append(granted, edgeworkers.Permission(permission))
+			}
+		}
+		sort.Slice(granted, func(i, j int) bool { return granted[i] < granted[j] })
+		result[namespace] = granted
+	}
+	return result
+}
+
+func flattenNamespacePermissions(permissions edgeworkers.NamespacePermissions) []interface{} {
+	result := make([]interface{}, 0, len(permissions))
+	for _, namespace := range sortedItemKeys(permissions) {
+		granted := make([]string, 0, len(permissions[namespace]))
+		for _, permission := range permissions[namespace] {
+			granted = append(granted, string(permission))
+		}
+		result = append(result, map[string]interface{}{
+			"namespace_name": namespace,
+			"permissions":    granted,
+		})
+	}
+	return result
+}
+
+// isEdgeKVAccessTokenNotFound reports whether the token does not exist, e.g. because it expired or was revoked
+func isEdgeKVAccessTokenNotFound(err error) bool {
+	var e *edgeworkers.Error
+	return errors.As(err, &e) && e.Status == http.StatusNotFound
+}
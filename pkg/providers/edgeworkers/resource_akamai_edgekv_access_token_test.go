@@ -0,0 +1,272 @@
+package edgeworkers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v9/pkg/edgeworkers"
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/common/testutils"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const testEdgeKVTokensJS = `var edgekv_access_tokens = {
+  "namespace-default": {
+    "name": "%[1]s"
+  },
+  "namespace-marketing": {
+    "name": "%[1]s"
+  }
+};
+export { edgekv_access_tokens };
+`
+
+func TestResourceEdgeKVAccessToken(t *testing.T) {
+	pollForEdgeKVAccessTokenActivationInterval = time.Microsecond
+
+	createRequest := edgeworkers.CreateEdgeKVAccessTokenRequest{
+		Name:           "test_token",
+		AllowOnStaging: true,
+		NamespacePermissions: edgeworkers.NamespacePermissions{
+			"marketing": {edgeworkers.PermissionRead, edgeworkers.PermissionWrite},
+			"default":   {edgeworkers.PermissionRead},
+		},
+		RestrictToEdgeWorkerIDs: []string{"1234"},
+	}
+	rotateRequest := createRequest
+	rotateRequest.Name = "test_token-rot"
+	newToken := func(name, uuid, expiry, status string) *edgeworkers.GetEdgeKVAccessTokenResponse {
+		return &edgeworkers.GetEdgeKVAccessTokenResponse{
+			AllowOnStaging:           true,
+			CPCode:                   "123456",
+			Expiry:                   expiry,
+			IssueDate:                "2024-10-01",
+			Name:                     name,
+			NamespacePermissions:     createRequest.NamespacePermissions,
+			NextScheduledRefreshDate: "2025-01-01",
+			RestrictToEdgeWorkerIDs:  []string{"1234"},
+			TokenActivationStatus:    status,
+			UUID:                     uuid,
+		}
+	}
+	checkToken := func(name, uuid, expiry string) resource.TestCheckFunc {
+		return resource.ComposeAggregateTestCheckFunc(
+			resource.TestCheckResourceAttr("akamai_edgekv_access_token.test", "id", name),
+			resource.TestCheckResourceAttr("akamai_edgekv_access_token.test", "name", "test_token"),
+			resource.TestCheckResourceAttr("akamai_edgekv_access_token.test", "token_name", name),
+			resource.TestCheckResourceAttr("akamai_edgekv_access_token.test", "uuid", uuid),
+			resource.TestCheckResourceAttr("akamai_edgekv_access_token.test", "expiry", expiry),
+			resource.TestCheckResourceAttr("akamai_edgekv_access_token.test", "cpcode", "123456"),
+			resource.TestCheckResourceAttr("akamai_edgekv_access_token.test", "allow_on_staging", "true"),
+			resource.TestCheckResourceAttr("akamai_edgekv_access_token.test", "allow_on_production", "false"),
+			resource.TestCheckResourceAttr("akamai_edgekv_access_token.test", "namespace_permissions.#", "2"),
+			resource.TestCheckResourceAttr("akamai_edgekv_access_token.test", "restrict_to_edgeworker_ids.#", "1"),
+			resource.TestCheckResourceAttr("akamai_edgekv_access_token.test", "token_activation_status", "COMPLETE"),
+			resource.TestCheckResourceAttr("akamai_edgekv_access_token.test", "edgekv_tokens_js", fmt.Sprintf(testEdgeKVTokensJS, name)),
+		)
+	}
+
+	t.Run("create, wait for activation and import", func(t *testing.T) {
+		client := &edgeworkers.Mock{}
+		token := newToken("test_token", "fake-uuid-1", "2099-01-01", "IN_PROGRESS")
+		created := edgeworkers.CreateEdgeKVAccessTokenResponse(*token)
+		client.On("CreateEdgeKVAccessToken", mock.Anything, createRequest).Return(&created, nil).Once()
+		client.On("GetEdgeKVAccessToken", mock.Anything, edgeworkers.GetEdgeKVAccessTokenRequest{TokenName: "test_token"}).
+			Return(newToken("test_token", "fake-uuid-1", "2099-01-01", "IN_PROGRESS"), nil).Once()
+		client.On("GetEdgeKVAccessToken", mock.Anything, edgeworkers.GetEdgeKVAccessTokenRequest{TokenName: "test_token"}).
+			Return(newToken("test_token", "fake-uuid-1", "2099-01-01", "COMPLETE"), nil)
+		client.On("DeleteEdgeKVAccessToken", mock.Anything, edgeworkers.DeleteEdgeKVAccessTokenRequest{TokenName: "test_token"}).
+			Return(&edgeworkers.DeleteEdgeKVAccessTokenResponse{Name: "test_token", UUID: "fake-uuid-1"}, nil).Once()
+
+		useClient(client, func() {
+			resource.UnitTest(t, resource.TestCase{
+				ProtoV6ProviderFactories: testutils.NewProtoV6ProviderFactory(NewSubprovider()),
+				Steps: []resource.TestStep{
+					{
+						Config: testutils.LoadFixtureString(t, "testdata/TestResourceEdgeKVAccessToken/basic.tf"),
+						Check:  checkToken("test_token", "fake-uuid-1", "2099-01-01"),
+					},
+					{
+						ImportState:       true,
+						ImportStateId:     "test_token",
+						ResourceName:      "akamai_edgekv_access_token.test",
+						ImportStateVerify: true,
+					},
+				},
+			})
+		})
+		client.AssertExpectations(t)
+	})
+
+	t.Run("rotate before expiry", func(t *testing.T) {
+		client := &edgeworkers.Mock{}
+		expiry := time.Now().Add(24 * time.Hour).Format("2006-01-02")
+		token := newToken("test_token", "fake-uuid-1", expiry, "COMPLETE")
+		created := edgeworkers.CreateEdgeKVAccessTokenResponse(*token)
+		client.On("CreateEdgeKVAccessToken", mock.Anything, createRequest).Return(&created, nil).Once()
+		client.On("GetEdgeKVAccessToken", mock.Anything, edgeworkers.GetEdgeKVAccessTokenRequest{TokenName: "test_token"}).
+			Return(token, nil)
+		// rotation issues the new token before revoking the old one
+		rotatedToken := newToken("test_token-rot", "fake-uuid-2", "2099-01-01", "COMPLETE")
+		rotated := edgeworkers.CreateEdgeKVAccessTokenResponse(*rotatedToken)
+		createRotated := client.On("CreateEdgeKVAccessToken", mock.Anything, rotateRequest).Return(&rotated, nil).Once()
+		client.On("GetEdgeKVAccessToken", mock.Anything, edgeworkers.GetEdgeKVAccessTokenRequest{TokenName: "test_token-rot"}).
+			Return(rotatedToken, nil)
+		client.On("DeleteEdgeKVAccessToken", mock.Anything, edgeworkers.DeleteEdgeKVAccessTokenRequest{TokenName: "test_token"}).
+			Return(&edgeworkers.DeleteEdgeKVAccessTokenResponse{Name: "test_token", UUID: "fake-uuid-1"}, nil).
+			NotBefore(createRotated).Once()
+		// destroy
+		client.On("DeleteEdgeKVAccessToken", mock.Anything, edgeworkers.DeleteEdgeKVAccessTokenRequest{TokenName: "test_token-rot"}).
+			Return(&edgeworkers.DeleteEdgeKVAccessTokenResponse{Name: "test_token-rot", UUID: "fake-uuid-2"}, nil).Once()
+
+		useClient(client, func() {
+			resource.UnitTest(t, resource.TestCase{
+				ProtoV6ProviderFactories: testutils.NewProtoV6ProviderFactory(NewSubprovider()),
+				Steps: []resource.TestStep{
+					{
+						Config: testutils.LoadFixtureString(t, "testdata/TestResourceEdgeKVAccessToken/basic.tf"),
+						Check:  checkToken("test_token", "fake-uuid-1", expiry),
+					},
+					{
+						Config: testutils.LoadFixtureString(t, "testdata/TestResourceEdgeKVAccessToken/rotate.tf"),
+						Check:  checkToken("test_token-rot", "fake-uuid-2", "2099-01-01"),
+					},
+				},
+			})
+		})
+		client.AssertExpectations(t)
+	})
+
+	t.Run("token allowed on no network", func(t *testing.T) {
+		client := &edgeworkers.Mock{}
+		useClient(client, func() {
+			resource.UnitTest(t, resource.TestCase{
+				ProtoV6ProviderFactories: testutils.NewProtoV6ProviderFactory(NewSubprovider()),
+				Steps: []resource.TestStep{
+					{
+						Config:      testutils.LoadFixtureString(t, "testdata/TestResourceEdgeKVAccessToken/no_network.tf"),
+						ExpectError: regexp.MustCompile("at least one of 'allow_on_staging' or 'allow_on_production' must be set to true"),
+					},
+				},
+			})
+		})
+		client.AssertExpectations(t)
+	})
+
+	t.Run("invalid permission", func(t *testing.T) {
+		client := &edgeworkers.Mock{}
+		useClient(client, func() {
+			resource.UnitTest(t, resource.TestCase{
+				ProtoV6ProviderFactories: testutils.NewProtoV6ProviderFactory(NewSubprovider()),
+				Steps: []resource.TestStep{
+					{
+						Config:      testutils.LoadFixtureString(t, "testdata/TestResourceEdgeKVAccessToken/invalid_permission.tf"),
+						ExpectError: regexp.MustCompile(`expected .*permissions.* to be one of \["r" "w" "d"\]`),
+					},
+				},
+			})
+		})
+		client.AssertExpectations(t)
+	})
+}
+
+func TestEdgeKVAccessTokenNeedsRotation(t *testing.T) {
+	now := time.Date(2024, 10, 1, 12, 0, 0, 0, time.UTC)
+	tests := map[string]struct {
+		expiry             string
+		rotateBeforeExpiry string
+		expected           bool
+		expectedError      string
+	}{
+		"rotation not configured": {
+			expiry:   "2024-10-02",
+			expected: false,
+		},
+		"expiry outside of window": {
+			expiry:             "2024-12-01",
+			rotateBeforeExpiry: "720h",
+			expected:           false,
+		},
+		"expiry within window": {
+			expiry:             "2024-10-20",
+			rotateBeforeExpiry: "720h",
+			expected:           true,
+		},
+		"expired": {
+			expiry:             "2024-09-01",
+			rotateBeforeExpiry: "1h",
+			expected:           true,
+		},
+		"expiry with time": {
+			expiry:             "2024-10-01T13:30:00Z",
+			rotateBeforeExpiry: "1h",
+			expected:           false,
+		},
+		"invalid expiry": {
+			expiry:             "soon",
+			rotateBeforeExpiry: "1h",
+			expectedError:      "could not parse expiry date 'soon'",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			rotate, err := edgeKVAccessTokenNeedsRotation(test.expiry, test.rotateBeforeExpiry, now)
+			if test.expectedError != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), test.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, rotate)
+		})
+	}
+}
+
+func TestRenderEdgeKVTokensJS(t *testing.T) {
+	tokensJS, err := renderEdgeKVTokensJS("test_token", edgeworkers.NamespacePermissions{
+		"marketing": {edgeworkers.PermissionRead, edgeworkers.PermissionWrite},
+		"default":   {edgeworkers.PermissionRead},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, testEdgeKVTokensJS, tokensJS)
+}
+
+func TestWaitForEdgeKVAccessTokenActivation(t *testing.T) {
+	pollForEdgeKVAccessTokenActivationInterval = time.Microsecond
+	request := edgeworkers.GetEdgeKVAccessTokenRequest{TokenName: "test_token"}
+
+	t.Run("activation completes after throttled check", func(t *testing.T) {
+		client := &edgeworkers.Mock{}
+		client.On("GetEdgeKVAccessToken", mock.Anything, request).
+			Return(&edgeworkers.GetEdgeKVAccessTokenResponse{TokenActivationStatus: "IN_PROGRESS"}, nil).Once()
+		client.On("GetEdgeKVAccessToken", mock.Anything, request).
+			Return(nil, &edgeworkers.Error{Status: http.StatusTooManyRequests}).Once()
+		client.On("GetEdgeKVAccessToken", mock.Anything, request).
+			Return(&edgeworkers.GetEdgeKVAccessTokenResponse{TokenActivationStatus: "COMPLETE"}, nil).Once()
+
+		require.NoError(t, waitForEdgeKVAccessTokenActivation(context.Background(), client, "test_token"))
+		client.AssertExpectations(t)
+	})
+
+	t.Run("activation fails", func(t *testing.T) {
+		client := &edgeworkers.Mock{}
+		client.On("GetEdgeKVAccessToken", mock.Anything, request).
+			Return(&edgeworkers.GetEdgeKVAccessTokenResponse{TokenActivationStatus: "ERROR"}, nil).Once()
+
+		err := waitForEdgeKVAccessTokenActivation(context.Background(), client, "test_token")
+		assert.ErrorIs(t, err, ErrEdgeKVAccessTokenActivation)
+		client.AssertExpectations(t)
+	})
+}
+
+func TestRotatedEdgeKVAccessTokenName(t *testing.T) {
+	assert.Equal(t, "test_token-rot", rotatedEdgeKVAccessTokenName("test_token", "test_token"))
+	assert.Equal(t, "test_token", rotatedEdgeKVAccessTokenName("test_token", "test_token-rot"))
+}
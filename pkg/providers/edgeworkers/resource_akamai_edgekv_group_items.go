@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sort"
 	"strings"
 	"time"
 
@@ -13,6 +14,7 @@ import (
 	"github.com/akamai/terraform-provider-akamai/v6/pkg/common/tf"
 	"github.com/akamai/terraform-provider-akamai/v6/pkg/common/timeouts"
 	"github.com/akamai/terraform-provider-akamai/v6/pkg/meta"
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
@@ -27,6 +29,7 @@ func resourceEdgeKVGroupItems() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
+		CustomizeDiff: edgeKVGroupItemsCustomDiff,
 		Timeouts: &schema.ResourceTimeout{
 			Default: &timeouts.SDKDefaultTimeout,
 		},
@@ -55,11 +58,35 @@ func resourceEdgeKVGroupItems() *schema.Resource {
 			},
 			"items": {
 				Type:             schema.TypeMap,
-				Required:         true,
+				Optional:         true,
+				Computed:         true,
+				ExactlyOneOf:     []string{"items", "items_file", "sensitive_items"},
 				ValidateDiagFunc: tf.ValidateMapMinimalLength(1),
 				Description:      "A map of items within the specified group. Each item consists of an item key and a value.",
 				Elem:             &schema.Schema{Type: schema.TypeString},
 			},
+			"sensitive_items": {
+				Type:             schema.TypeMap,
+				Optional:         true,
+				Computed:         true,
+				Sensitive:        true,
+				ValidateDiagFunc: tf.ValidateMapMinimalLength(1),
+				Description:      "A map of items within the specified group, whose values are not shown in plans. Each item consists of an item key and a value.",
+				Elem:             &schema.Schema{Type: schema.TypeString},
+			},
+			"items_file": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringIsNotEmpty),
+				Description:      "The path to a JSON, YAML or CSV file, or to a directory of such files, with the items of the group. Values which are not strings are stored as JSON.",
+			},
+			"sensitive": {
+				Type:          schema.TypeBool,
+				Optional:      true,
+				Default:       false,
+				ConflictsWith: []string{"items"},
+				Description:   "Whether the items loaded from 'items_file' are stored in 'sensitive_items', so that their values are not shown in plans.",
+			},
 			"timeouts": {
 				Type:        schema.TypeList,
 				Optional:    true,
@@ -91,21 +118,13 @@ func resourceEdgeKVGroupItemsCreate(ctx context.Context, rd *schema.ResourceData
 		return diag.Errorf("could not get attributes: %s", err)
 	}
 
-	for key, valueRaw := range attrs.items {
-		value, ok := valueRaw.(string)
+	writer := newEdgeKVItemsWriter(client, attrs)
+	for _, key := range sortedItemKeys(attrs.items) {
+		value, ok := attrs.items[key].(string)
 		if !ok {
-			return diag.Errorf("could not cast value of type %T into string", value)
+			return diag.Errorf("could not cast value of type %T into string", attrs.items[key])
 		}
-		_, err = client.UpsertItem(ctx, edgeworkers.UpsertItemRequest{
-			ItemID:   key,
-			ItemData: edgeworkers.Item(value),
-			ItemsRequestParams: edgeworkers.ItemsRequestParams{
-				Network:     attrs.network,
-				NamespaceID: attrs.namespace,
-				GroupID:     attrs.groupName,
-			},
-		})
-		if err != nil {
+		if err = writer.upsert(ctx, key, value); err != nil {
 			return diag.Errorf("could not upsert an item with key '%s': %s", key, err)
 		}
 	}
@@ -157,7 +176,13 @@ func resourceEdgeKVGroupItemsRead(ctx context.Context, rd *schema.ResourceData,
 	attrs["namespace_name"] = namespace
 	attrs["network"] = network
 	attrs["group_name"] = groupName
-	attrs["items"] = itemsMap
+	if sensitiveItems(rd) {
+		attrs["sensitive_items"] = itemsMap
+		attrs["items"] = nil
+	} else {
+		attrs["items"] = itemsMap
+		attrs["sensitive_items"] = nil
+	}
 
 	if err = tf.SetAttrs(rd, attrs); err != nil {
 		return diag.Errorf("could not set attributes: %s", err)
@@ -178,7 +203,7 @@ func resourceEdgeKVGroupItemsUpdate(ctx context.Context, rd *schema.ResourceData
 		return nil
 	}
 
-	if !rd.HasChanges("items") {
+	if !rd.HasChanges("items", "sensitive_items") {
 		return resourceEdgeKVGroupItemsRead(ctx, rd, m)
 	}
 
@@ -200,24 +225,16 @@ func resourceEdgeKVGroupItemsUpdate(ctx context.Context, rd *schema.ResourceData
 
 	remoteStateItemsArray := []string(*remoteStateItems)
 	var deletedItems []string
+	writer := newEdgeKVItemsWriter(client, attrs)
 
 	// first loop for creating items, where the loop iterates through items specified in config
-	for key, valueRaw := range attrs.items {
-		value, ok := valueRaw.(string)
+	for _, key := range sortedItemKeys(attrs.items) {
+		value, ok := attrs.items[key].(string)
 		if !ok {
-			return diag.Errorf("could not cast value of type %T to string", valueRaw)
+			return diag.Errorf("could not cast value of type %T to string", attrs.items[key])
 		}
 		if !collections.StringInSlice(remoteStateItemsArray, key) {
-			_, err = client.UpsertItem(ctx, edgeworkers.UpsertItemRequest{
-				ItemID:   key,
-				ItemData: edgeworkers.Item(value),
-				ItemsRequestParams: edgeworkers.ItemsRequestParams{
-					Network:     attrs.network,
-					NamespaceID: attrs.namespace,
-					GroupID:     attrs.groupName,
-				},
-			})
-			if err != nil {
+			if err = writer.upsert(ctx, key, value); err != nil {
 				return diag.Errorf("could not upsert an item with key '%s': %s", key, err)
 			}
 		}
@@ -231,28 +248,11 @@ func resourceEdgeKVGroupItemsUpdate(ctx context.Context, rd *schema.ResourceData
 				return diag.Errorf("could not cast value of type %T to string", val)
 			}
 
-			_, err = client.UpsertItem(ctx, edgeworkers.UpsertItemRequest{
-				ItemID:   remoteStateItemKey,
-				ItemData: edgeworkers.Item(strVal),
-				ItemsRequestParams: edgeworkers.ItemsRequestParams{
-					Network:     attrs.network,
-					NamespaceID: attrs.namespace,
-					GroupID:     attrs.groupName,
-				},
-			})
-			if err != nil {
+			if err = writer.upsert(ctx, remoteStateItemKey, strVal); err != nil {
 				return diag.Errorf("could not upsert an item with key '%s': %s", remoteStateItemKey, err)
 			}
 		} else {
-			_, err = client.DeleteItem(ctx, edgeworkers.DeleteItemRequest{
-				ItemID: remoteStateItemKey,
-				ItemsRequestParams: edgeworkers.ItemsRequestParams{
-					Network:     attrs.network,
-					NamespaceID: attrs.namespace,
-					GroupID:     attrs.groupName,
-				},
-			})
-			if err != nil {
+			if err = writer.delete(ctx, remoteStateItemKey); err != nil {
 				return diag.Errorf("could not delete an item with key '%s': %s", remoteStateItemKey, err)
 			}
 			deletedItems = append(deletedItems, remoteStateItemKey)
@@ -294,19 +294,12 @@ func resourceEdgeKVGroupItemsDelete(ctx context.Context, rd *schema.ResourceData
 		return diag.Errorf("in order to delete whole group of items, number of items in the configuration and remote state should be the same")
 	}
 
-	for key := range attrs.items {
+	writer := newEdgeKVItemsWriter(client, attrs)
+	for _, key := range sortedItemKeys(attrs.items) {
 		if !collections.StringInSlice(remoteStateItemsArray, key) {
 			return diag.Errorf("item with key '%s' does not exist in the remote state of the database", key)
 		}
-		_, err = client.DeleteItem(ctx, edgeworkers.DeleteItemRequest{
-			ItemID: key,
-			ItemsRequestParams: edgeworkers.ItemsRequestParams{
-				Network:     attrs.network,
-				NamespaceID: attrs.namespace,
-				GroupID:     attrs.groupName,
-			},
-		})
-		if err != nil {
+		if err = writer.delete(ctx, key); err != nil {
 			return diag.Errorf("could not delete an item with key '%s': %s", key, err)
 		}
 	}
@@ -376,64 +369,73 @@ func waitForEdgeKVGroupDeletion(ctx context.Context, client edgeworkers.Edgework
 }
 
 // waitForConsistentEdgeKVDatabase waits until all items specified in the config are propagated in the remote state, as well as all
-// the deleted items from the config are removed from the remote state.
+// the deleted items from the config are removed from the remote state. Every poll checks all the items which are not yet consistent.
+// When the requests get throttled, the remaining items are checked on the next poll.
 func waitForConsistentEdgeKVDatabase(ctx context.Context, client edgeworkers.Edgeworkers, deletedItems []string, attrs *edgeKVGroupItemsAttrs) error {
+	pendingItems := make(map[string]string, len(attrs.items))
 	for itemKey, itemRaw := range attrs.items {
 		itemVal, ok := itemRaw.(string)
 		if !ok {
 			return fmt.Errorf("could not cast value of type '%T' into string", itemRaw)
 		}
+		pendingItems[itemKey] = itemVal
+	}
+	pendingDeletedItems := append([]string{}, deletedItems...)
 
-		var isPresent bool
-		for !isPresent {
-			select {
-			case <-time.After(pollForConsistentEdgeKVDatabaseInterval):
-				stateVal, err := client.GetItem(ctx, edgeworkers.GetItemRequest{
-					ItemID: itemKey,
-					ItemsRequestParams: edgeworkers.ItemsRequestParams{
-						Network:     attrs.network,
-						NamespaceID: attrs.namespace,
-						GroupID:     attrs.groupName,
-					},
-				})
-				if err != nil && !errors.Is(err, edgeworkers.ErrNotFound) {
-					return fmt.Errorf("could not get an item with key '%s': %s", itemKey, err)
-				} else if err == nil && string(*stateVal) == itemVal {
-					isPresent = true
-				}
-			case <-ctx.Done():
-				return fmt.Errorf("retry timeout reached for get an item")
+	for len(pendingItems) > 0 || len(pendingDeletedItems) > 0 {
+		select {
+		case <-time.After(pollForConsistentEdgeKVDatabaseInterval):
+		case <-ctx.Done():
+			return fmt.Errorf("retry timeout reached for get an item")
+		}
+
+		var throttled bool
+		for _, itemKey := range sortedItemKeys(pendingItems) {
+			stateVal, err := client.GetItem(ctx, getItemRequest(itemKey, attrs))
+			if isThrottledEdgeKVRequest(err) {
+				throttled = true
+				break
+			}
+			if err != nil && !errors.Is(err, edgeworkers.ErrNotFound) {
+				return fmt.Errorf("could not get an item with key '%s': %s", itemKey, err)
+			} else if err == nil && string(*stateVal) == pendingItems[itemKey] {
+				delete(pendingItems, itemKey)
 			}
 		}
-	}
+		if throttled {
+			continue
+		}
 
-	for _, itemKey := range deletedItems {
-		var isDeleted bool
-		for !isDeleted {
-			select {
-			case <-time.After(pollForConsistentEdgeKVDatabaseInterval):
-				_, err := client.GetItem(ctx, edgeworkers.GetItemRequest{
-					ItemID: itemKey,
-					ItemsRequestParams: edgeworkers.ItemsRequestParams{
-						Network:     attrs.network,
-						NamespaceID: attrs.namespace,
-						GroupID:     attrs.groupName,
-					},
-				})
-				if err != nil && !errors.Is(err, edgeworkers.ErrNotFound) {
-					return fmt.Errorf("could not get an item with key '%s': %s", itemKey, err)
-				} else if err != nil && errors.Is(err, edgeworkers.ErrNotFound) {
-					isDeleted = true
-				}
-			case <-ctx.Done():
-				return fmt.Errorf("retry timeout reached for get an item")
+		var stillPresent []string
+		for i, itemKey := range pendingDeletedItems {
+			_, err := client.GetItem(ctx, getItemRequest(itemKey, attrs))
+			if isThrottledEdgeKVRequest(err) {
+				stillPresent = append(stillPresent, pendingDeletedItems[i:]...)
+				break
+			}
+			if err != nil && !errors.Is(err, edgeworkers.ErrNotFound) {
+				return fmt.Errorf("could not get an item with key '%s': %s", itemKey, err)
+			} else if err == nil {
+				stillPresent = append(stillPresent, itemKey)
 			}
 		}
+		pendingDeletedItems = stillPresent
 	}
 
 	return nil
 }
 
+func getItemRequest(itemKey string, attrs *edgeKVGroupItemsAttrs) edgeworkers.GetItemRequest {
+	return edgeworkers.GetItemRequest{
+		ItemID: itemKey,
+		ItemsRequestParams: edgeworkers.ItemsRequestParams{
+			Network:     attrs.network,
+			NamespaceID: attrs.namespace,
+			GroupID:     attrs.groupName,
+		},
+	}
+}
+
 // edgeKVGroupItemsAttrs represents attributes for edgeKV_group_items resource
 type edgeKVGroupItemsAttrs struct {
 	namespace, groupName string
@@ -458,9 +460,23 @@ func getAttributes(rd *schema.ResourceData) (*edgeKVGroupItemsAttrs, error) {
 		return nil, fmt.Errorf("could not get 'group_name' attribute: %s", err)
 	}
 
-	items, err := tf.GetMapValue("items", rd)
-	if err != nil {
-		return nil, fmt.Errorf("could not get 'items' attribute: %s", err)
+	itemsKey := "items"
+	if sensitiveItems(rd) {
+		itemsKey = "sensitive_items"
+	}
+	var items map[string]interface{}
+	if path, ok := rd.Get("items_file").(string); ok && path != "" {
+		// the file is loaded again, as the items are not known at plan time when the path is computed
+		fileItems, err := loadItemsFile(path)
+		if err != nil {
+			return nil, err
+		}
+		items = make(map[string]interface{}, len(fileItems))
+		for key, value := range fileItems {
+			items[key] = value
+		}
+	} else if items, err = tf.GetMapValue(itemsKey, rd); err != nil {
+		return nil, fmt.Errorf("could not get '%s' attribute: %s", itemsKey, err)
 	}
 
 	return &edgeKVGroupItemsAttrs{
@@ -471,6 +487,71 @@ func getAttributes(rd *schema.ResourceData) (*edgeKVGroupItemsAttrs, error) {
 	}, nil
 }
 
+// itemsConfigGetter allows getting values and the raw configuration of edgeKV_group_items
+type itemsConfigGetter interface {
+	Get(string) interface{}
+	GetRawConfig() cty.Value
+}
+
+// sensitiveItems reports whether the items of the group are stored in 'sensitive_items' instead of 'items'. This is the
+// case when 'sensitive' is set, or when 'sensitive_items' is used in the configuration. If the configuration is not
+// available, e.g. on refresh, the current state is used.
+func sensitiveItems(d itemsConfigGetter) bool {
+	if sensitive, ok := d.Get("sensitive").(bool); ok && sensitive {
+		return true
+	}
+	if config := d.GetRawConfig(); !config.IsNull() && config.IsKnown() {
+		return !config.GetAttr("sensitive_items").IsNull()
+	}
+	items, ok := d.Get("sensitive_items").(map[string]interface{})
+	return ok && len(items) > 0
+}
+
+// edgeKVGroupItemsCustomDiff loads the items of the group from 'items_file' and clears the items attribute which is
+// not used anymore, when the items are moved between 'items' and 'sensitive_items'. If the path of the file is not
+// known yet, the items are known only after apply
+func edgeKVGroupItemsCustomDiff(_ context.Context, diff *schema.ResourceDiff, _ interface{}) error {
+	itemsKey, unusedKey := "items", "sensitive_items"
+	if sensitiveItems(diff) {
+		itemsKey, unusedKey = unusedKey, itemsKey
+	}
+
+	if !diff.NewValueKnown("items_file") {
+		for _, key := range []string{"items", "sensitive_items"} {
+			if err := diff.SetNewComputed(key); err != nil {
+				return fmt.Errorf("could not set '%s' attribute as computed: %s", key, err)
+			}
+		}
+		return nil
+	}
+
+	if path, ok := diff.Get("items_file").(string); ok && path != "" {
+		items, err := loadItemsFile(path)
+		if err != nil {
+			return err
+		}
+		if err = diff.SetNew(itemsKey, items); err != nil {
+			return fmt.Errorf("could not set '%s' attribute: %s", itemsKey, err)
+		}
+	}
+
+	if unused, ok := diff.Get(unusedKey).(map[string]interface{}); ok && len(unused) > 0 {
+		if err := diff.SetNew(unusedKey, map[string]interface{}{}); err != nil {
+			return fmt.Errorf("could not set '%s' attribute: %s", unusedKey, err)
+		}
+	}
+	return nil
+}
+
+func sortedItemKeys[V any](items map[string]V) []string {
+	keys := make([]string, 0, len(items))
+	for key := range items {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
 // getEdgeKVItemPtr returns pointer to the edgeworkers.Item
 func getEdgeKVItemPtr(value string) *edgeworkers.Item {
 	itemVal := edgeworkers.Item(value)
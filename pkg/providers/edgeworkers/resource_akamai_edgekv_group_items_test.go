@@ -181,7 +181,114 @@ func TestCreateEdgeKVGroupItems(t *testing.T) {
 		"no items attribute - error": {
 			configPath: "testdata/TestResourceEdgeKVGroupItems/create/no_items.tf",
 			init:       func(m *edgeworkers.Mock, attrs edgeKVConfigurationForTests) {},
-			withError:  regexp.MustCompile("Error: Missing required argument"),
+			withError:  regexp.MustCompile("one of `items,items_file,sensitive_items` must be specified"),
+		},
+		"create edgeKV items from file": {
+			configPath: "testdata/TestResourceEdgeKVGroupItems/create/items_file.tf",
+			attrs: edgeKVConfigurationForTests{
+				namespaceID: "test_namespace",
+				network:     "staging",
+				groupID:     "1234",
+				items: map[string]string{
+					"key1": "value1",
+					"key2": `{"enabled":true,"limit":10}`,
+				},
+			},
+			init: func(m *edgeworkers.Mock, attrs edgeKVConfigurationForTests) {
+				// create
+				mockUpsertItem(m, attrs, "key1", "value1", 1)
+				mockUpsertItem(m, attrs, "key2", `{"enabled":true,"limit":10}`, 1)
+				// waitForEdgeKVGroupCreation
+				mockListGroupsWithinNamespace(m, attrs, []string{"1234"}, 1)
+				// waitForConsistentEdgeKVDatabase
+				mockGetItem(m, attrs, "key1", "value1", 1)
+				mockGetItem(m, attrs, "key2", `{"enabled":true,"limit":10}`, 1)
+				// read
+				mockListItems(m, attrs, edgeworkers.ListItemsResponse{"key1", "key2"}, 2)
+				mockGetItem(m, attrs, "key1", "value1", 2)
+				mockGetItem(m, attrs, "key2", `{"enabled":true,"limit":10}`, 2)
+				// delete
+				mockListItems(m, attrs, edgeworkers.ListItemsResponse{"key1", "key2"}, 1)
+				mockDeleteItem(m, attrs, "key1", "message1", 1)
+				mockDeleteItem(m, attrs, "key2", "message2", 1)
+				// waitForEdgeKVGroupDeletion
+				mockListGroupsWithinNamespace(m, attrs, []string{}, 1)
+			},
+		},
+		"create edgeKV items from file with path known after apply": {
+			configPath: "testdata/TestResourceEdgeKVGroupItems/create/items_file_unknown.tf",
+			attrs: edgeKVConfigurationForTests{
+				namespaceID: "test_namespace",
+				network:     "staging",
+				groupID:     "1234",
+				items: map[string]string{
+					"key1": "value1",
+					"key2": `{"enabled":true,"limit":10}`,
+				},
+			},
+			init: func(m *edgeworkers.Mock, attrs edgeKVConfigurationForTests) {
+				// create
+				mockUpsertItem(m, attrs, "key1", "value1", 1)
+				mockUpsertItem(m, attrs, "key2", `{"enabled":true,"limit":10}`, 1)
+				// waitForEdgeKVGroupCreation
+				mockListGroupsWithinNamespace(m, attrs, []string{"1234"}, 1)
+				// waitForConsistentEdgeKVDatabase
+				mockGetItem(m, attrs, "key1", "value1", 1)
+				mockGetItem(m, attrs, "key2", `{"enabled":true,"limit":10}`, 1)
+				// read
+				mockListItems(m, attrs, edgeworkers.ListItemsResponse{"key1", "key2"}, 2)
+				mockGetItem(m, attrs, "key1", "value1", 2)
+				mockGetItem(m, attrs, "key2", `{"enabled":true,"limit":10}`, 2)
+				// delete
+				mockListItems(m, attrs, edgeworkers.ListItemsResponse{"key1", "key2"}, 1)
+				mockDeleteItem(m, attrs, "key1", "message1", 1)
+				mockDeleteItem(m, attrs, "key2", "message2", 1)
+				// waitForEdgeKVGroupDeletion
+				mockListGroupsWithinNamespace(m, attrs, []string{}, 1)
+			},
+		},
+		"create sensitive edgeKV items from file": {
+			configPath: "testdata/TestResourceEdgeKVGroupItems/create/items_file_sensitive.tf",
+			attrs: edgeKVConfigurationForTests{
+				namespaceID: "test_namespace",
+				network:     "staging",
+				groupID:     "1234",
+				items: map[string]string{
+					"key1": "value1",
+					"key2": `{"enabled":true,"limit":10}`,
+				},
+				sensitive: true,
+			},
+			init: func(m *edgeworkers.Mock, attrs edgeKVConfigurationForTests) {
+				// create
+				mockUpsertItem(m, attrs, "key1", "value1", 1)
+				mockUpsertItem(m, attrs, "key2", `{"enabled":true,"limit":10}`, 1)
+				// waitForEdgeKVGroupCreation
+				mockListGroupsWithinNamespace(m, attrs, []string{"1234"}, 1)
+				// waitForConsistentEdgeKVDatabase
+				mockGetItem(m, attrs, "key1", "value1", 1)
+				mockGetItem(m, attrs, "key2", `{"enabled":true,"limit":10}`, 1)
+				// read
+				mockListItems(m, attrs, edgeworkers.ListItemsResponse{"key1", "key2"}, 2)
+				mockGetItem(m, attrs, "key1", "value1", 2)
+				mockGetItem(m, attrs, "key2", `{"enabled":true,"limit":10}`, 2)
+				// delete
+				mockListItems(m, attrs, edgeworkers.ListItemsResponse{"key1", "key2"}, 1)
+				mockDeleteItem(m, attrs, "key1", "message1", 1)
+				mockDeleteItem(m, attrs, "key2", "message2", 1)
+				// waitForEdgeKVGroupDeletion
+				mockListGroupsWithinNamespace(m, attrs, []string{}, 1)
+			},
+		},
+		"items file and items - error": {
+			configPath: "testdata/TestResourceEdgeKVGroupItems/create/items_file_and_items.tf",
+			init:       func(m *edgeworkers.Mock, attrs edgeKVConfigurationForTests) {},
+			withError:  regexp.MustCompile("only one of `items,items_file,sensitive_items` can be specified"),
+		},
+		"invalid items file - error": {
+			configPath: "testdata/TestResourceEdgeKVGroupItems/create/items_file_invalid.tf",
+			init:       func(m *edgeworkers.Mock, attrs edgeKVConfigurationForTests) {},
+			withError:  regexp.MustCompile("invalid content of items file"),
 		},
 	}
 
@@ -1017,6 +1124,7 @@ type edgeKVConfigurationForTests struct {
 	network     edgeworkers.ItemNetwork
 	groupID     string
 	items       map[string]string
+	sensitive   bool
 	timeouts    string
 }
 
@@ -1104,9 +1212,14 @@ func checkEdgeKVGroupItemsAttrs(data edgeKVConfigurationForTests) resource.TestC
 	checkFuncs = append(checkFuncs, resource.TestCheckResourceAttr("akamai_edgekv_group_items.test", "namespace_name", data.namespaceID))
 	checkFuncs = append(checkFuncs, resource.TestCheckResourceAttr("akamai_edgekv_group_items.test", "network", string(data.network)))
 	checkFuncs = append(checkFuncs, resource.TestCheckResourceAttr("akamai_edgekv_group_items.test", "group_name", data.groupID))
-	checkFuncs = append(checkFuncs, resource.TestCheckResourceAttr("akamai_edgekv_group_items.test", "items.%", strconv.Itoa(len(data.items))))
+	itemsKey := "items"
+	if data.sensitive {
+		itemsKey = "sensitive_items"
+		checkFuncs = append(checkFuncs, resource.TestCheckResourceAttr("akamai_edgekv_group_items.test", "items.%", "0"))
+	}
+	checkFuncs = append(checkFuncs, resource.TestCheckResourceAttr("akamai_edgekv_group_items.test", itemsKey+".%", strconv.Itoa(len(data.items))))
 	for key, val := range data.items {
-		checkFuncs = append(checkFuncs, resource.TestCheckResourceAttr("akamai_edgekv_group_items.test", fmt.Sprintf("items.%s", key), val))
+		checkFuncs = append(checkFuncs, resource.TestCheckResourceAttr("akamai_edgekv_group_items.test", fmt.Sprintf("%s.%s", itemsKey, key), val))
 	}
 	checkFuncs = append(checkFuncs, resource.TestCheckResourceAttr("akamai_edgekv_group_items.test", "id", fmt.Sprintf("%s:%s:%s", data.namespaceID, data.network, data.groupID)))
 	if data.timeouts != "" {
@@ -11,6 +11,7 @@ import (
 	"net/http"
 	"os"
 	"path/filepath"
+	"sort"
 	"strconv"
 	"time"
 
@@ -20,9 +21,11 @@ import (
 	"github.com/akamai/terraform-provider-akamai/v6/pkg/common/tf"
 	"github.com/akamai/terraform-provider-akamai/v6/pkg/common/timeouts"
 	"github.com/akamai/terraform-provider-akamai/v6/pkg/meta"
+	"github.com/apex/log"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"golang.org/x/sync/errgroup"
 )
 
@@ -76,6 +79,37 @@ func resourceEdgeWorker() *schema.Resource {
 				DefaultFunc: schema.EnvDefaultFunc("EW_DEFAULT_BUNDLE_URL", defaultBundleURL),
 				Description: "The path to the EdgeWorkers tgz code bundle",
 			},
+			"source_dir": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"local_bundle"},
+				Description:   "The path to a directory with the EdgeWorker code, packaged into a bundle instead of using local_bundle. Files are packaged in a stable order with fixed modification times and owners, so the bundle hash only changes when the files change",
+			},
+			"bundle_version": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				RequiredWith: []string{"source_dir"},
+				Description:  "The EdgeWorker version written to the bundle.json packaged from source_dir",
+			},
+			"bundle_description": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				RequiredWith: []string{"source_dir"},
+				Description:  "The description written to the bundle.json packaged from source_dir",
+			},
+			"source_files": {
+				Type:         schema.TypeMap,
+				Optional:     true,
+				RequiredWith: []string{"source_dir"},
+				Description:  "Additional files packaged with source_dir, by their path in the bundle, such as the edgekv_tokens_js of an akamai_edgekv_access_token resource written to edgekv_tokens.js. They replace the files of source_dir with the same path",
+				Elem:         &schema.Schema{Type: schema.TypeString},
+			},
+			"version_retention": {
+				Type:             schema.TypeInt,
+				Optional:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.IntAtLeast(1)),
+				Description:      "The number of the most recent versions to keep. Older versions are deleted when a new version is uploaded, unless they are active on any network. All versions are kept if not set",
+			},
 			"local_bundle_hash": {
 				Type:        schema.TypeString,
 				Computed:    true,
@@ -143,11 +177,11 @@ func resourceEdgeWorkerCreate(ctx context.Context, d *schema.ResourceData, m int
 		ResourceTierID: resourceTierID,
 	}
 
-	localBundlePath, err := tf.GetStringValue("local_bundle", d)
+	bytesArray, err := readBundle(d)
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	bytesArray, err := convertLocalBundleFileIntoBytes(localBundlePath)
+	diags, err := bundleLayoutDiagnostics(bytesArray)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -182,7 +216,7 @@ func resourceEdgeWorkerCreate(ctx context.Context, d *schema.ResourceData, m int
 		return diag.FromErr(err)
 	}
 
-	return resourceEdgeWorkerRead(ctx, d, m)
+	return append(diags, resourceEdgeWorkerRead(ctx, d, m)...)
 }
 
 func resourceEdgeWorkerRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
@@ -261,11 +295,7 @@ func resourceEdgeWorkerUpdate(ctx context.Context, d *schema.ResourceData, m int
 		return diag.Errorf("%s: %s", tf.ErrInvalidType, err.Error())
 	}
 
-	localBundlePath, err := tf.GetStringValue("local_bundle", d)
-	if err != nil {
-		return diag.FromErr(err)
-	}
-	bytesArray, err := convertLocalBundleFileIntoBytes(localBundlePath)
+	bytesArray, err := readBundle(d)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -277,7 +307,11 @@ func resourceEdgeWorkerUpdate(ctx context.Context, d *schema.ResourceData, m int
 	if err != nil {
 		return diag.FromErr(err)
 	}
+	var diags diag.Diagnostics
 	if bundleContentHash != hash {
+		if diags, err = bundleLayoutDiagnostics(bytesArray); err != nil {
+			return diag.FromErr(err)
+		}
 		validateBundleResponse, err := client.ValidateBundle(ctx, edgeworkers.ValidateBundleRequest{
 			Bundle: edgeworkers.Bundle{Reader: bytes.NewBuffer(bytesArray)},
 		})
@@ -302,6 +336,22 @@ func resourceEdgeWorkerUpdate(ctx context.Context, d *schema.ResourceData, m int
 			return diag.FromErr(err)
 		}
 	}
+
+	if bundleContentHash != hash || d.HasChange("version_retention") {
+		retention, err := tf.GetIntValue("version_retention", d)
+		if err != nil && !errors.Is(err, tf.ErrNotFound) {
+			return diag.FromErr(err)
+		}
+		if retention > 0 {
+			deleted, err := deleteOldEdgeWorkerVersions(ctx, client, edgeWorkerIDReq, retention)
+			if err != nil {
+				diags = append(diags, tf.DiagWarningf("cannot delete old versions of edgeworker %d: %s", edgeWorkerIDReq, err)...)
+			}
+			if len(deleted) > 0 {
+				logger.Debugf("Deleted versions %v of edgeworker %d", deleted, edgeWorkerIDReq)
+			}
+		}
+	}
 	groupID, err := tf.GetStringValue("group_id", d)
 	if err != nil {
 		return diag.FromErr(err)
@@ -328,9 +378,9 @@ func resourceEdgeWorkerUpdate(ctx context.Context, d *schema.ResourceData, m int
 		EdgeWorkerID: edgeWorkerIDReq,
 	})
 	if err != nil {
-		return diag.FromErr(err)
+		return append(diags, diag.FromErr(err)...)
 	}
-	return resourceEdgeWorkerRead(ctx, d, m)
+	return append(diags, resourceEdgeWorkerRead(ctx, d, m)...)
 }
 
 func resourceEdgeWorkerDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
@@ -434,6 +484,87 @@ func deactivateEdgeWorkerVersion(ctx context.Context, client edgeworkers.Edgewor
 	return nil
 }
 
+// deleteOldEdgeWorkerVersions deletes all but the given number of the most recent versions of the edgeworker.
+// Versions active on any network, and the versions activated before them which are needed for a rollback, are never
+// deleted. It returns the deleted versions.
+func deleteOldEdgeWorkerVersions(ctx context.Context, client edgeworkers.Edgeworkers, edgeWorkerID, retention int) ([]string, error) {
+	versions, err := client.ListEdgeWorkerVersions(ctx, edgeworkers.ListEdgeWorkerVersionsRequest{
+		EdgeWorkerID: edgeWorkerID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(versions.EdgeWorkerVersions) <= retention {
+		return nil, nil
+	}
+
+	sorted, err := sortEdgeWorkerVersionsByDate(versions.EdgeWorkerVersions)
+	if err != nil {
+		return nil, err
+	}
+
+	activations, err := client.ListActivations(ctx, edgeworkers.ListActivationsRequest{
+		EdgeWorkerID: edgeWorkerID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	retained := rollbackVersions(activations.Activations)
+
+	var deleted []string
+	for _, v := range sorted[retention:] {
+		if retained[v.Version] {
+			continue
+		}
+		err := client.DeleteEdgeWorkerVersion(ctx, edgeworkers.DeleteEdgeWorkerVersionRequest{
+			EdgeWorkerID: edgeWorkerID,
+			Version:      v.Version,
+		})
+		if err != nil {
+			return deleted, fmt.Errorf("cannot delete version '%s': %w", v.Version, err)
+		}
+		deleted = append(deleted, v.Version)
+	}
+	return deleted, nil
+}
+
+// sortEdgeWorkerVersionsByDate returns a copy of the versions sorted from the latest to the oldest
+// rollbackVersions returns the versions which are active or being activated on staging or production, and the
+// versions successfully activated on these networks before them, which are activated again on rollback
+func rollbackVersions(activations []edgeworkers.Activation) map[string]bool {
+	versions := make(map[string]bool)
+	for _, network := range validEdgeworkerActivationNetworks {
+		networkActivations := sortActivationsByDate(filterActivationsByNetwork(activations, network))
+		var current string
+		if len(networkActivations) > 0 && statusOngoingOrReady(networkActivations[0].Status) {
+			current = networkActivations[0].Version
+			versions[current] = true
+		}
+		if previous := lastSuccessfulActivation(networkActivations, network, current); previous != nil {
+			versions[previous.Version] = true
+		}
+	}
+	return versions
+}
+
+func sortEdgeWorkerVersionsByDate(versions []edgeworkers.EdgeWorkerVersion) ([]edgeworkers.EdgeWorkerVersion, error) {
+	createdTimes := make(map[string]time.Time, len(versions))
+	for _, v := range versions {
+		createdTime, err := time.Parse(time.RFC3339, v.CreatedTime)
+		if err != nil {
+			return nil, err
+		}
+		createdTimes[v.Version] = createdTime
+	}
+
+	sorted := make([]edgeworkers.EdgeWorkerVersion, len(versions))
+	copy(sorted, versions)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return createdTimes[sorted[i].Version].After(createdTimes[sorted[j].Version])
+	})
+	return sorted, nil
+}
+
 // since version of EdgeWorkerID bundle has type string and can be any unique value,
 // this function get the latest version of EdgeWorkerID bundle according to time creation
 func getLatestEdgeWorkerIDBundleVersion(versions *edgeworkers.ListEdgeWorkerVersionsResponse) (string, error) {
@@ -452,6 +583,72 @@ func getLatestEdgeWorkerIDBundleVersion(versions *edgeworkers.ListEdgeWorkerVers
 	return version, nil
 }
 
+// bundleLayoutDiagnostics returns the warnings about the layout of the bundle
+func bundleLayoutDiagnostics(bundle []byte) (diag.Diagnostics, error) {
+	warnings, err := bundleLayoutWarnings(bundle)
+	if err != nil {
+		return nil, err
+	}
+	var diags diag.Diagnostics
+	for _, warning := range warnings {
+		diags = append(diags, tf.DiagWarningf("%s, the EdgeWorker may fail to run", warning)...)
+	}
+	return diags, nil
+}
+
+// readBundle returns the bundle packaged from source_dir or, if it is not set, the content of local_bundle
+func readBundle(rd tf.ResourceDataFetcher) ([]byte, error) {
+	sourceDir, err := tf.GetStringValue("source_dir", rd)
+	if err != nil && !errors.Is(err, tf.ErrNotFound) {
+		return nil, err
+	}
+	if sourceDir != "" {
+		manifest, err := getBundleManifest(rd)
+		if err != nil {
+			return nil, err
+		}
+		sourceFiles, err := getSourceFiles(rd)
+		if err != nil {
+			return nil, err
+		}
+		return packSourceDir(sourceDir, *manifest, sourceFiles)
+	}
+
+	localBundlePath, err := tf.GetStringValue("local_bundle", rd)
+	if err != nil {
+		return nil, err
+	}
+	return convertLocalBundleFileIntoBytes(localBundlePath)
+}
+
+func getBundleManifest(rd tf.ResourceDataFetcher) (*bundleManifest, error) {
+	version, err := tf.GetStringValue("bundle_version", rd)
+	if err != nil && !errors.Is(err, tf.ErrNotFound) {
+		return nil, err
+	}
+	description, err := tf.GetStringValue("bundle_description", rd)
+	if err != nil && !errors.Is(err, tf.ErrNotFound) {
+		return nil, err
+	}
+	return &bundleManifest{Version: version, Description: description}, nil
+}
+
+func getSourceFiles(rd tf.ResourceDataFetcher) (map[string]string, error) {
+	values, err := tf.GetMapValue("source_files", rd)
+	if err != nil && !errors.Is(err, tf.ErrNotFound) {
+		return nil, err
+	}
+	files := make(map[string]string, len(values))
+	for name, value := range values {
+		content, ok := value.(string)
+		if !ok {
+			return nil, fmt.Errorf("could not cast value of type %T into string", value)
+		}
+		files[name] = content
+	}
+	return files, nil
+}
+
 func convertLocalBundleFileIntoBytes(localBundlePath string) ([]byte, error) {
 	var filePath string
 	if localBundlePath == defaultBundleURL {
@@ -528,7 +725,7 @@ func resourceEdgeWorkerImport(ctx context.Context, d *schema.ResourceData, m int
 	return []*schema.ResourceData{d}, nil
 }
 
-func bundleHashCustomDiff(_ context.Context, diff *schema.ResourceDiff, m interface{}) error {
+func bundleHashCustomDiff(ctx context.Context, diff *schema.ResourceDiff, m interface{}) error {
 	meta := meta.Must(m)
 	logger := meta.Log("EdgeWorkers", "bundleHashCustomDiff")
 
@@ -545,18 +742,72 @@ func bundleHashCustomDiff(_ context.Context, diff *schema.ResourceDiff, m interf
 	if err != nil && !errors.Is(err, tf.ErrNotFound) {
 		return fmt.Errorf("cannot get 'local_bundle_hash' value: %s", err)
 	}
-	if err != nil && errors.Is(err, tf.ErrNotFound) { // hash may be empty when resource was not created yet
+
+	// the bundle cannot be checked before its location is known
+	if !diff.NewValueKnown("source_dir") || !diff.NewValueKnown("local_bundle") || !diff.NewValueKnown("source_files") {
+		return allSetComputed("local_bundle_hash", "version", "warnings")
+	}
+
+	bundle, err := readDiffBundle(diff, logger)
+	if err != nil {
+		return err
+	}
+	hash, err := getSHAFromBundle(&edgeworkers.Bundle{Reader: bytes.NewReader(bundle)})
+	if err != nil {
+		return fmt.Errorf("error calculating bundle hash: %s", err)
+	}
+
+	// hash may be empty when resource was not created yet
+	if localBundleHash == "" || hash != localBundleHash {
+		resourceTierID, err := tf.GetIntValue("resource_tier_id", diff)
+		if err != nil && !errors.Is(err, tf.ErrNotFound) {
+			return fmt.Errorf("cannot get 'resource_tier_id' value: %s", err)
+		}
+		var edgeWorkerID int
+		if diff.Id() != "" && !diff.HasChange("resource_tier_id") {
+			if edgeWorkerID, err = strconv.Atoi(diff.Id()); err != nil {
+				return fmt.Errorf("%s: %s", tf.ErrInvalidType, err.Error())
+			}
+		}
+		tier, err := findResourceTier(ctx, inst.Client(meta), edgeWorkerID, resourceTierID)
+		if err != nil {
+			logger.Warnf("cannot get resource tier %d, the bundle size is not checked locally: %s", resourceTierID, err)
+		}
+		if err := lintBundle(bundle, getBundleLimits(tier)); err != nil {
+			return err
+		}
 		return allSetComputed("local_bundle_hash", "version", "warnings")
 	}
 
+	return nil
+}
+
+// readDiffBundle returns the bundle packaged from source_dir or, if it is not set, the content of local_bundle
+func readDiffBundle(diff *schema.ResourceDiff, logger log.Interface) ([]byte, error) {
+	sourceDir, err := tf.GetStringValue("source_dir", diff)
+	if err != nil && !errors.Is(err, tf.ErrNotFound) {
+		return nil, fmt.Errorf("cannot get 'source_dir' value: %s", err)
+	}
+	if sourceDir != "" {
+		manifest, err := getBundleManifest(diff)
+		if err != nil {
+			return nil, err
+		}
+		sourceFiles, err := getSourceFiles(diff)
+		if err != nil {
+			return nil, err
+		}
+		return packSourceDir(sourceDir, *manifest, sourceFiles)
+	}
+
 	localBundleFileName, err := tf.GetStringValue("local_bundle", diff)
 	if err != nil {
-		return fmt.Errorf("cannot get 'local_bundle' value: %s", err)
+		return nil, fmt.Errorf("cannot get 'local_bundle' value: %s", err)
 	}
 
 	f, err := openBundleFile(localBundleFileName)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	defer func() {
 		if err := f.Close(); err != nil {
@@ -564,16 +815,11 @@ func bundleHashCustomDiff(_ context.Context, diff *schema.ResourceDiff, m interf
 		}
 	}()
 
-	hash, err := getSHAFromBundle(&edgeworkers.Bundle{Reader: f})
+	bundle, err := io.ReadAll(f)
 	if err != nil {
-		return fmt.Errorf("error calculating bundle hash: %s", err)
-	}
-
-	if hash != localBundleHash {
-		return allSetComputed("local_bundle_hash", "version", "warnings")
+		return nil, fmt.Errorf("cannot read bundle file (%s): %s", localBundleFileName, err)
 	}
-
-	return nil
+	return bundle, nil
 }
 
 func openBundleFile(localBundleFileName string) (io.ReadCloser, error) {
@@ -2,6 +2,7 @@ package edgeworkers
 
 import (
 	"bytes"
+	"context"
 	"errors"
 	"fmt"
 	"io/fs"
@@ -9,6 +10,8 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"os"
+	"path/filepath"
+	"regexp"
 	"strconv"
 	"testing"
 	"time"
@@ -22,11 +25,13 @@ import (
 )
 
 var (
-	bundlePathForCreate = "testdata/TestResEdgeWorkersEdgeWorker/bundles/bundleForCreate.tgz"
-	bundleHashForCreate = "ba1ca447bdfebf06dee5be85eb17745b9f5dd6c718a3020409a5848f341d510f"
-	bundlePathForUpdate = "testdata/TestResEdgeWorkersEdgeWorker/bundles/bundleForUpdate.tgz"
-	bundleHashForUpdate = "ec177aef45a71354febdc58d0130af48c087a735e022fa53afa9b8f1e7afc245"
-	defaultBundleHash   = "ba1ca447bdfebf06dee5be85eb17745b9f5dd6c718a3020409a5848f341d510f"
+	bundlePathForCreate      = "testdata/TestResEdgeWorkersEdgeWorker/bundles/bundleForCreate.tgz"
+	bundleHashForCreate      = "ba1ca447bdfebf06dee5be85eb17745b9f5dd6c718a3020409a5848f341d510f"
+	bundlePathForUpdate      = "testdata/TestResEdgeWorkersEdgeWorker/bundles/bundleForUpdate.tgz"
+	bundleHashForUpdate      = "ec177aef45a71354febdc58d0130af48c087a735e022fa53afa9b8f1e7afc245"
+	bundlePathForLocalUpdate = "testdata/TestResEdgeWorkersEdgeWorker/bundles/bundleForLocalUpdate.tgz"
+	bundleHashForLocalUpdate = "d90041c5f60309750a212766d5d1f7896dbd1e1f87985e8deb9f3db70422c867"
+	defaultBundleHash        = "ba1ca447bdfebf06dee5be85eb17745b9f5dd6c718a3020409a5848f341d510f"
 )
 
 func TestResourceEdgeWorkersEdgeWorker(t *testing.T) {
@@ -376,6 +381,7 @@ func TestResourceEdgeWorkersEdgeWorker(t *testing.T) {
 	t.Run("create a new edgeworker lifecycle", func(t *testing.T) {
 		testDir := "testdata/TestResEdgeWorkersEdgeWorker/edgeworker_lifecycle"
 		client := new(edgeworkers.Mock)
+		expectResourceTierLookup(client)
 
 		timeForCreation := time.Now().Format(time.RFC3339)
 
@@ -408,6 +414,7 @@ func TestResourceEdgeWorkersEdgeWorker(t *testing.T) {
 	t.Run("create a new edgeworker lifecycle with timeout", func(t *testing.T) {
 		testDir := "testdata/TestResEdgeWorkersEdgeWorker/edgeworker_lifecycle"
 		client := new(edgeworkers.Mock)
+		expectResourceTierLookup(client)
 
 		timeForCreation := time.Now().Format(time.RFC3339)
 
@@ -438,6 +445,85 @@ func TestResourceEdgeWorkersEdgeWorker(t *testing.T) {
 		client.AssertExpectations(t)
 	})
 
+	t.Run("create a new edgeworker from source_dir", func(t *testing.T) {
+		testDir := "testdata/TestResEdgeWorkersEdgeWorker/edgeworker_lifecycle"
+		client := new(edgeworkers.Mock)
+		expectResourceTierLookup(client)
+
+		bundle, err := packSourceDir("testdata/TestResEdgeWorkersEdgeWorker/source_dir", bundleManifest{}, nil)
+		require.NoError(t, err)
+		packedBundlePath := filepath.Join(t.TempDir(), "bundle.tgz")
+		require.NoError(t, os.WriteFile(packedBundlePath, bundle, 0644))
+
+		timeForCreation := time.Now().Format(time.RFC3339)
+
+		edgeWorker, edgeWorkerVersion := expectCreateEdgeWorkerWithVersion(t, client, "example", packedBundlePath, timeForCreation, 12345, 54321, 123)
+		expectReadEdgeWorkerWithOneVersion(t, client, edgeWorker.Name, packedBundlePath, edgeWorkerVersion.Version, timeForCreation, int(edgeWorker.GroupID), edgeWorker.ResourceTierID, edgeWorkerVersion.EdgeWorkerID, 2)
+
+		expectDeleteEdgeWorkerWithOneVersion(t, client, edgeWorker.ResourceTierID, edgeWorkerVersion.EdgeWorkerID, timeForCreation)
+
+		useClient(client, func() {
+			resource.UnitTest(t, resource.TestCase{
+				ProtoV6ProviderFactories: testutils.NewProtoV6ProviderFactory(NewSubprovider()),
+				Steps: []resource.TestStep{
+					{
+						Config: testutils.LoadFixtureString(t, fmt.Sprintf("%s/edgeworker_source_dir.tf", testDir)),
+						Check: resource.ComposeAggregateTestCheckFunc(
+							checkAttributes(edgeWorkerAttributes{
+								name:            "example",
+								groupID:         "12345",
+								resourceTierID:  54321,
+								localBundle:     defaultBundleURL,
+								localBundleHash: bundleHashForCreate,
+								version:         "1.0",
+							}),
+							resource.TestCheckResourceAttr("akamai_edgeworker.edgeworker", "source_dir", "testdata/TestResEdgeWorkersEdgeWorker/source_dir"),
+						),
+					},
+				},
+			})
+		})
+		client.AssertExpectations(t)
+	})
+
+	t.Run("invalid local bundle fails on plan", func(t *testing.T) {
+		testDir := "testdata/TestResEdgeWorkersEdgeWorker/edgeworker_lifecycle"
+		client := new(edgeworkers.Mock)
+		expectResourceTierLookup(client)
+
+		useClient(client, func() {
+			resource.UnitTest(t, resource.TestCase{
+				ProtoV6ProviderFactories: testutils.NewProtoV6ProviderFactory(NewSubprovider()),
+				Steps: []resource.TestStep{
+					{
+						Config:      testutils.LoadFixtureString(t, fmt.Sprintf("%s/edgeworker_invalid_bundle.tf", testDir)),
+						ExpectError: regexp.MustCompile(`(?s)local bundle is not valid:.*bundle.json does not contain 'edgeworker-version'`),
+					},
+				},
+			})
+		})
+		client.AssertExpectations(t)
+	})
+
+	t.Run("source_dir conflicts with local_bundle", func(t *testing.T) {
+		testDir := "testdata/TestResEdgeWorkersEdgeWorker/edgeworker_lifecycle"
+		client := new(edgeworkers.Mock)
+		expectResourceTierLookup(client)
+
+		useClient(client, func() {
+			resource.UnitTest(t, resource.TestCase{
+				ProtoV6ProviderFactories: testutils.NewProtoV6ProviderFactory(NewSubprovider()),
+				Steps: []resource.TestStep{
+					{
+						Config:      testutils.LoadFixtureString(t, fmt.Sprintf("%s/edgeworker_source_dir_conflict.tf", testDir)),
+						ExpectError: regexp.MustCompile(`"source_dir": conflicts with local_bundle`),
+					},
+				},
+			})
+		})
+		client.AssertExpectations(t)
+	})
+
 	mockBundleServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		bundle, err := ioutil.ReadFile("./testdata/TestResEdgeWorkersEdgeWorker/bundles/defaultBundle.tgz")
 		require.NoError(t, err)
@@ -455,6 +541,7 @@ func TestResourceEdgeWorkersEdgeWorker(t *testing.T) {
 		}()
 		testDir := "testdata/TestResEdgeWorkersEdgeWorker/edgeworker_lifecycle"
 		client := new(edgeworkers.Mock)
+		expectResourceTierLookup(client)
 
 		timeForCreation := time.Now().Format(time.RFC3339)
 
@@ -487,6 +574,7 @@ func TestResourceEdgeWorkersEdgeWorker(t *testing.T) {
 	t.Run("update edgeworker local_bundle lifecycle", func(t *testing.T) {
 		testDir := "testdata/TestResEdgeWorkersEdgeWorker/edgeworker_lifecycle"
 		client := new(edgeworkers.Mock)
+		expectResourceTierLookup(client)
 
 		timeForCreation := time.Now().Format(time.RFC3339)
 		timeForUpdate := time.Now().Add(time.Hour * 24).Format(time.RFC3339)
@@ -494,8 +582,8 @@ func TestResourceEdgeWorkersEdgeWorker(t *testing.T) {
 		edgeWorker, edgeWorkerVersion := expectCreateEdgeWorkerWithVersion(t, client, "example", bundlePathForCreate, timeForCreation, 12345, 54321, 123)
 		expectReadEdgeWorkerWithOneVersion(t, client, edgeWorker.Name, bundlePathForCreate, edgeWorkerVersion.Version, timeForCreation, int(edgeWorker.GroupID), edgeWorker.ResourceTierID, edgeWorkerVersion.EdgeWorkerID, 3)
 
-		updatedEdgeWorker, updatedEdgeWorkerVersion := expectUpdateEdgeWorkerVersion(t, client, "example", bundlePathForUpdate, timeForUpdate, int(edgeWorker.GroupID), edgeWorker.ResourceTierID, edgeWorkerVersion.EdgeWorkerID)
-		expectReadEdgeWorkerWithTwoVersions(t, client, updatedEdgeWorker.Name, bundlePathForUpdate, updatedEdgeWorkerVersion.Version, timeForCreation, timeForUpdate, int(updatedEdgeWorker.GroupID), updatedEdgeWorker.ResourceTierID, updatedEdgeWorker.EdgeWorkerID, 2)
+		updatedEdgeWorker, updatedEdgeWorkerVersion := expectUpdateEdgeWorkerVersion(t, client, "example", bundlePathForLocalUpdate, timeForUpdate, int(edgeWorker.GroupID), edgeWorker.ResourceTierID, edgeWorkerVersion.EdgeWorkerID)
+		expectReadEdgeWorkerWithTwoVersions(t, client, updatedEdgeWorker.Name, bundlePathForLocalUpdate, updatedEdgeWorkerVersion.Version, timeForCreation, timeForUpdate, int(updatedEdgeWorker.GroupID), updatedEdgeWorker.ResourceTierID, updatedEdgeWorker.EdgeWorkerID, 2)
 
 		expectDeleteEdgeWorkerWithTwoVersions(t, client, edgeWorker.ResourceTierID, edgeWorkerVersion.EdgeWorkerID, timeForCreation, timeForUpdate)
 
@@ -520,8 +608,8 @@ func TestResourceEdgeWorkersEdgeWorker(t *testing.T) {
 							name:            "example",
 							groupID:         "12345",
 							resourceTierID:  54321,
-							localBundle:     bundlePathForUpdate,
-							localBundleHash: bundleHashForUpdate,
+							localBundle:     bundlePathForLocalUpdate,
+							localBundleHash: bundleHashForLocalUpdate,
 							version:         "2.0",
 						}),
 					},
@@ -534,6 +622,7 @@ func TestResourceEdgeWorkersEdgeWorker(t *testing.T) {
 	t.Run("update edgeworker local_bundle content lifecycle", func(t *testing.T) {
 		testDir := "testdata/TestResEdgeWorkersEdgeWorker/edgeworker_lifecycle"
 		client := new(edgeworkers.Mock)
+		expectResourceTierLookup(client)
 
 		tempBundlePath := "testdata/TestResEdgeWorkersEdgeWorker/bundles/_temp_bundle.tgz"
 
@@ -543,8 +632,8 @@ func TestResourceEdgeWorkersEdgeWorker(t *testing.T) {
 		edgeWorker, edgeWorkerVersion := expectCreateEdgeWorkerWithVersion(t, client, "example", bundlePathForCreate, timeForCreation, 12345, 54321, 123)
 		expectReadEdgeWorkerWithOneVersion(t, client, edgeWorker.Name, bundlePathForCreate, edgeWorkerVersion.Version, timeForCreation, int(edgeWorker.GroupID), edgeWorker.ResourceTierID, edgeWorkerVersion.EdgeWorkerID, 3)
 
-		updatedEdgeWorker, updatedEdgeWorkerVersion := expectUpdateEdgeWorkerVersion(t, client, "example", bundlePathForUpdate, timeForUpdate, int(edgeWorker.GroupID), edgeWorker.ResourceTierID, edgeWorkerVersion.EdgeWorkerID)
-		expectReadEdgeWorkerWithTwoVersions(t, client, updatedEdgeWorker.Name, bundlePathForUpdate, updatedEdgeWorkerVersion.Version, timeForCreation, timeForUpdate, int(updatedEdgeWorker.GroupID), updatedEdgeWorker.ResourceTierID, updatedEdgeWorker.EdgeWorkerID, 2)
+		updatedEdgeWorker, updatedEdgeWorkerVersion := expectUpdateEdgeWorkerVersion(t, client, "example", bundlePathForLocalUpdate, timeForUpdate, int(edgeWorker.GroupID), edgeWorker.ResourceTierID, edgeWorkerVersion.EdgeWorkerID)
+		expectReadEdgeWorkerWithTwoVersions(t, client, updatedEdgeWorker.Name, bundlePathForLocalUpdate, updatedEdgeWorkerVersion.Version, timeForCreation, timeForUpdate, int(updatedEdgeWorker.GroupID), updatedEdgeWorker.ResourceTierID, updatedEdgeWorker.EdgeWorkerID, 2)
 
 		expectDeleteEdgeWorkerWithTwoVersions(t, client, edgeWorker.ResourceTierID, edgeWorkerVersion.EdgeWorkerID, timeForCreation, timeForUpdate)
 
@@ -586,14 +675,14 @@ func TestResourceEdgeWorkersEdgeWorker(t *testing.T) {
 						}),
 					},
 					{
-						PreConfig: prepareTempBundleLink(t, bundlePathForUpdate, tempBundlePath),
+						PreConfig: prepareTempBundleLink(t, bundlePathForLocalUpdate, tempBundlePath),
 						Config:    testutils.LoadFixtureString(t, fmt.Sprintf("%s/edgeworker_temp_bundle.tf", testDir)),
 						Check: checkAttributes(edgeWorkerAttributes{
 							name:            "example",
 							groupID:         "12345",
 							resourceTierID:  54321,
 							localBundle:     tempBundlePath,
-							localBundleHash: bundleHashForUpdate,
+							localBundleHash: bundleHashForLocalUpdate,
 							version:         "2.0",
 						}),
 					},
@@ -606,6 +695,7 @@ func TestResourceEdgeWorkersEdgeWorker(t *testing.T) {
 	t.Run("update edgeworker group_id lifecycle", func(t *testing.T) {
 		testDir := "testdata/TestResEdgeWorkersEdgeWorker/edgeworker_lifecycle"
 		client := new(edgeworkers.Mock)
+		expectResourceTierLookup(client)
 
 		timeForCreation := time.Now().Format(time.RFC3339)
 		timeForUpdate := time.Now().Add(time.Hour * 24).Format(time.RFC3339)
@@ -653,6 +743,7 @@ func TestResourceEdgeWorkersEdgeWorker(t *testing.T) {
 	t.Run("edgeworker no update on group_id prefix change", func(t *testing.T) {
 		testDir := "testdata/TestResEdgeWorkersEdgeWorker/edgeworker_lifecycle"
 		client := new(edgeworkers.Mock)
+		expectResourceTierLookup(client)
 
 		timeForCreation := time.Now().Format(time.RFC3339)
 
@@ -696,6 +787,7 @@ func TestResourceEdgeWorkersEdgeWorker(t *testing.T) {
 	t.Run("update edgeworker name lifecycle", func(t *testing.T) {
 		testDir := "testdata/TestResEdgeWorkersEdgeWorker/edgeworker_lifecycle"
 		client := new(edgeworkers.Mock)
+		expectResourceTierLookup(client)
 
 		createdTime := time.Now().Format(time.RFC3339)
 		updatedTime := time.Now().Add(time.Hour * 24).Format(time.RFC3339)
@@ -743,6 +835,7 @@ func TestResourceEdgeWorkersEdgeWorker(t *testing.T) {
 	t.Run("delete activation upon edgeworker deletion - activations on staging and production", func(t *testing.T) {
 		testDir := "testdata/TestResEdgeWorkersEdgeWorker/edgeworker_lifecycle"
 		client := new(edgeworkers.Mock)
+		expectResourceTierLookup(client)
 
 		timeForCreation := time.Now().Format(time.RFC3339)
 
@@ -816,6 +909,7 @@ func TestResourceEdgeWorkersEdgeWorker(t *testing.T) {
 	t.Run("delete activation upon edgeworker deletion - only on one network - staging", func(t *testing.T) {
 		testDir := "testdata/TestResEdgeWorkersEdgeWorker/edgeworker_lifecycle"
 		client := new(edgeworkers.Mock)
+		expectResourceTierLookup(client)
 
 		timeForCreation := time.Now().Format(time.RFC3339)
 
@@ -880,6 +974,7 @@ func TestResourceEdgeWorkersEdgeWorker(t *testing.T) {
 	t.Run("import", func(t *testing.T) {
 		testDir := "testdata/TestResEdgeWorkersEdgeWorker/edgeworker_lifecycle"
 		client := new(edgeworkers.Mock)
+		expectResourceTierLookup(client)
 		createdTime := time.Now().Format(time.RFC3339)
 
 		edgeWorker, edgeWorkerVersion := expectCreateEdgeWorkerWithVersion(t, client, "example", bundlePathForCreate, createdTime, 12345, 54321, 123)
@@ -909,6 +1004,23 @@ func TestResourceEdgeWorkersEdgeWorker(t *testing.T) {
 		client.AssertExpectations(t)
 	})
 }
+
+// expectResourceTierLookup mocks the calls which find the resource tier to check the bundle size limits
+func expectResourceTierLookup(client *edgeworkers.Mock) {
+	tier := edgeworkers.ResourceTier{
+		ID:   54321,
+		Name: "Basic Compute",
+		EdgeWorkerLimits: []edgeworkers.EdgeWorkerLimit{
+			{LimitName: "Maximum compressed size of the code bundle", LimitValue: 1048576, LimitUnit: "BYTE"},
+			{LimitName: "Maximum uncompressed size of the code bundle", LimitValue: 5242880, LimitUnit: "BYTE"},
+		},
+	}
+	client.On("GetResourceTier", mock.Anything, mock.Anything).Return(&tier, nil).Maybe()
+	client.On("ListContracts", mock.Anything).Return(&edgeworkers.ListContractsResponse{ContractIDs: []string{"1-599K"}}, nil).Maybe()
+	client.On("ListResourceTiers", mock.Anything, edgeworkers.ListResourceTiersRequest{ContractID: "1-599K"}).
+		Return(&edgeworkers.ListResourceTiersResponse{ResourceTiers: []edgeworkers.ResourceTier{tier}}, nil).Maybe()
+}
+
 func TestGetLatestEdgeWorkerIDBundleVersion(t *testing.T) {
 	firstVersionTimeCreation := time.Now().Format(time.RFC3339)
 	secondVersionTimeCreation := time.Now().Add(time.Hour * 24).Format(time.RFC3339)
@@ -1123,3 +1235,83 @@ var (
 		}
 	}
 )
+
+func TestDeleteOldEdgeWorkerVersions(t *testing.T) {
+	edgeWorkerID := 123
+	versions := []edgeworkers.EdgeWorkerVersion{
+		{EdgeWorkerID: edgeWorkerID, Version: "3.0", CreatedTime: "2022-01-27T12:30:06Z"},
+		{EdgeWorkerID: edgeWorkerID, Version: "1.0", CreatedTime: "2022-01-25T12:30:06Z"},
+		{EdgeWorkerID: edgeWorkerID, Version: "5.0", CreatedTime: "2022-01-29T12:30:06Z"},
+		{EdgeWorkerID: edgeWorkerID, Version: "2.0", CreatedTime: "2022-01-26T12:30:06Z"},
+		{EdgeWorkerID: edgeWorkerID, Version: "4.0", CreatedTime: "2022-01-28T12:30:06Z"},
+	}
+	activations := []edgeworkers.Activation{
+		*createStubActivation(edgeWorkerID, 1, edgeworkers.ActivationNetworkProduction, "2.0", activationStatusComplete, "2022-01-26T13:30:06Z", ""),
+	}
+
+	expectActivations := func(client *edgeworkers.Mock) {
+		expectListActivations(client, edgeWorkerID, "", activations, nil).Once()
+	}
+
+	t.Run("old versions are deleted unless active", func(t *testing.T) {
+		client := &edgeworkers.Mock{}
+		expectListEdgeWorkerVersions(client, edgeWorkerID, versions, nil).Once()
+		expectActivations(client)
+		client.On("DeleteEdgeWorkerVersion", mock.Anything, edgeworkers.DeleteEdgeWorkerVersionRequest{EdgeWorkerID: edgeWorkerID, Version: "3.0"}).Return(nil).Once()
+		client.On("DeleteEdgeWorkerVersion", mock.Anything, edgeworkers.DeleteEdgeWorkerVersionRequest{EdgeWorkerID: edgeWorkerID, Version: "1.0"}).Return(nil).Once()
+
+		deleted, err := deleteOldEdgeWorkerVersions(context.Background(), client, edgeWorkerID, 2)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"3.0", "1.0"}, deleted)
+		client.AssertExpectations(t)
+	})
+
+	t.Run("versions needed for a rollback are not deleted", func(t *testing.T) {
+		client := &edgeworkers.Mock{}
+		expectListEdgeWorkerVersions(client, edgeWorkerID, versions, nil).Once()
+		expectListActivations(client, edgeWorkerID, "", []edgeworkers.Activation{
+			*createStubActivation(edgeWorkerID, 4, edgeworkers.ActivationNetworkStaging, "3.0", activationStatusComplete, "2022-01-28T13:30:06Z", ""),
+			*createStubActivation(edgeWorkerID, 3, edgeworkers.ActivationNetworkStaging, "2.0", "ERROR", "2022-01-27T13:30:06Z", ""),
+			*createStubActivation(edgeWorkerID, 2, edgeworkers.ActivationNetworkStaging, "1.0", activationStatusComplete, "2022-01-26T13:30:06Z", ""),
+		}, nil).Once()
+		client.On("DeleteEdgeWorkerVersion", mock.Anything, edgeworkers.DeleteEdgeWorkerVersionRequest{EdgeWorkerID: edgeWorkerID, Version: "2.0"}).Return(nil).Once()
+
+		deleted, err := deleteOldEdgeWorkerVersions(context.Background(), client, edgeWorkerID, 2)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"2.0"}, deleted)
+		client.AssertExpectations(t)
+	})
+
+	t.Run("nothing to delete", func(t *testing.T) {
+		client := &edgeworkers.Mock{}
+		expectListEdgeWorkerVersions(client, edgeWorkerID, versions, nil).Once()
+
+		deleted, err := deleteOldEdgeWorkerVersions(context.Background(), client, edgeWorkerID, 5)
+		require.NoError(t, err)
+		assert.Empty(t, deleted)
+		client.AssertExpectations(t)
+	})
+
+	t.Run("delete error", func(t *testing.T) {
+		client := &edgeworkers.Mock{}
+		expectListEdgeWorkerVersions(client, edgeWorkerID, versions, nil).Once()
+		expectActivations(client)
+		client.On("DeleteEdgeWorkerVersion", mock.Anything, edgeworkers.DeleteEdgeWorkerVersionRequest{EdgeWorkerID: edgeWorkerID, Version: "4.0"}).Return(nil).Once()
+		client.On("DeleteEdgeWorkerVersion", mock.Anything, edgeworkers.DeleteEdgeWorkerVersionRequest{EdgeWorkerID: edgeWorkerID, Version: "3.0"}).Return(errors.New("oops")).Once()
+
+		deleted, err := deleteOldEdgeWorkerVersions(context.Background(), client, edgeWorkerID, 1)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "cannot delete version '3.0': oops")
+		assert.Equal(t, []string{"4.0"}, deleted)
+		client.AssertExpectations(t)
+	})
+
+	t.Run("invalid created time", func(t *testing.T) {
+		client := &edgeworkers.Mock{}
+		expectListEdgeWorkerVersions(client, edgeWorkerID, append([]edgeworkers.EdgeWorkerVersion{{EdgeWorkerID: edgeWorkerID, Version: "6.0", CreatedTime: "now"}}, versions...), nil).Once()
+
+		_, err := deleteOldEdgeWorkerVersions(context.Background(), client, edgeWorkerID, 2)
+		assert.Error(t, err)
+		client.AssertExpectations(t)
+	})
+}
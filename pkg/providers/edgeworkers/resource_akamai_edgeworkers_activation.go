@@ -11,6 +11,7 @@ import (
 
 	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v9/pkg/edgeworkers"
 	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v9/pkg/session"
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/common/activation"
 	"github.com/akamai/terraform-provider-akamai/v6/pkg/common/collections"
 	"github.com/akamai/terraform-provider-akamai/v6/pkg/common/tf"
 	"github.com/akamai/terraform-provider-akamai/v6/pkg/common/timeouts"
@@ -52,9 +53,10 @@ func resourceEdgeworkersActivationSchema() map[string]*schema.Schema {
 			Description: "Id of the EdgeWorker to activate",
 		},
 		"version": {
-			Type:        schema.TypeString,
-			Required:    true,
-			Description: "The version of EdgeWorker to activate",
+			Type:             schema.TypeString,
+			Required:         true,
+			Description:      "The version of EdgeWorker to activate",
+			DiffSuppressFunc: suppressVersionOnRollback,
 		},
 		"network": {
 			Type:             schema.TypeString,
@@ -73,6 +75,19 @@ func resourceEdgeworkersActivationSchema() map[string]*schema.Schema {
 			Description:      "Assigns a log message to the activation request",
 			DiffSuppressFunc: suppressNoteFieldForEdgeWorkersActivation,
 		},
+		"rollback_on_failure": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+			Description: "Re-activates the last successfully activated version from the activation history of the network when the activation of the version fails",
+		},
+		"rollback": {
+			Type:     schema.TypeBool,
+			Optional: true,
+			Default:  false,
+			Description: "When changed to true on an existing activation, the version which was successfully activated on the network before the currently active one " +
+				"is activated again, regardless of 'version'. Changes of 'version' are ignored while it is set. It has no effect when the activation is created",
+		},
 		"timeouts": {
 			Type:        schema.TypeList,
 			Optional:    true,
@@ -304,6 +319,15 @@ func upsertActivation(ctx context.Context, rd *schema.ResourceData, m interface{
 		return diag.FromErr(err)
 	}
 
+	rollback, err := tf.GetBoolValue("rollback", rd)
+	if err != nil && !errors.Is(err, tf.ErrNotFound) {
+		return diag.FromErr(err)
+	}
+	// a rollback is only requested by changing 'rollback' of an existing activation, a new one activates 'version'
+	if rollback && rd.Id() != "" && rd.HasChange("rollback") {
+		return rollbackToPreviousVersion(ctx, rd, m, client, edgeworkerID, network)
+	}
+
 	versionsResp, err := client.ListEdgeWorkerVersions(ctx, edgeworkers.ListEdgeWorkerVersionsRequest{
 		EdgeWorkerID: edgeworkerID,
 	})
@@ -343,6 +367,13 @@ func upsertActivation(ctx context.Context, rd *schema.ResourceData, m interface{
 	}
 
 	if _, err := waitForEdgeworkerActivation(ctx, client, edgeworkerID, activation.ActivationID); err != nil {
+		rollbackOnFailure, rollbackErr := tf.GetBoolValue("rollback_on_failure", rd)
+		if rollbackErr != nil && !errors.Is(rollbackErr, tf.ErrNotFound) {
+			return diag.FromErr(rollbackErr)
+		}
+		if rollbackOnFailure && errors.Is(err, ErrEdgeworkerActivationFailure) {
+			return rollbackActivation(ctx, rd, m, client, edgeworkerID, network, version)
+		}
 		return diag.Errorf("%s: %s", ErrEdgeworkerActivation, err.Error())
 	}
 
@@ -350,6 +381,96 @@ func upsertActivation(ctx context.Context, rd *schema.ResourceData, m interface{
 	return resourceEdgeworkersActivationRead(ctx, rd, m)
 }
 
+// rollbackActivation re-activates the last successfully activated version on the network after the activation of
+// failedVersion failed. It always returns an error diagnostic, as the configured version is not active.
+func rollbackActivation(ctx context.Context, rd *schema.ResourceData, m interface{}, client edgeworkers.Edgeworkers, edgeworkerID int, network, failedVersion string) diag.Diagnostics {
+	activationsResp, err := client.ListActivations(ctx, edgeworkers.ListActivationsRequest{
+		EdgeWorkerID: edgeworkerID,
+	})
+	if err != nil {
+		return diag.Errorf("%s: activation of version '%s' failed, cannot read activation history for rollback: %s", ErrEdgeworkerActivation, failedVersion, err)
+	}
+
+	previous := lastSuccessfulActivation(activationsResp.Activations, network, failedVersion)
+	if previous == nil {
+		return diag.Errorf("%s: activation of version '%s' failed and no version was previously activated on network '%s' to roll back to", ErrEdgeworkerActivation, failedVersion, network)
+	}
+
+	rollback, err := client.ActivateVersion(ctx, edgeworkers.ActivateVersionRequest{
+		EdgeWorkerID: edgeworkerID,
+		ActivateVersion: edgeworkers.ActivateVersion{
+			Network: edgeworkers.ActivationNetwork(network),
+			Version: previous.Version,
+			Note:    fmt.Sprintf("Rollback after failed activation of version %s", failedVersion),
+		},
+	})
+	if err != nil {
+		return diag.Errorf("%s: activation of version '%s' failed, rollback to version '%s' failed: %s", ErrEdgeworkerActivation, failedVersion, previous.Version, err)
+	}
+	if _, err := waitForEdgeworkerActivation(ctx, client, edgeworkerID, rollback.ActivationID); err != nil {
+		return diag.Errorf("%s: activation of version '%s' failed, rollback to version '%s' failed: %s", ErrEdgeworkerActivation, failedVersion, previous.Version, err)
+	}
+
+	failure := diag.Errorf("%s: activation of version '%s' failed, version '%s' was activated again on network '%s'", ErrEdgeworkerActivation, failedVersion, previous.Version, network)
+	// a new resource is not stored, so that it is not tainted and deactivated on the next apply
+	if rd.Id() == "" {
+		return failure
+	}
+	return append(resourceEdgeworkersActivationRead(ctx, rd, m), failure...)
+}
+
+// rollbackToPreviousVersion re-activates the version which was successfully activated on the network before the
+// currently active version, as requested by the 'rollback' attribute
+func rollbackToPreviousVersion(ctx context.Context, rd *schema.ResourceData, m interface{}, client edgeworkers.Edgeworkers, edgeworkerID int, network string) diag.Diagnostics {
+	current, err := getCurrentActivation(ctx, client, edgeworkerID, network, true)
+	if err != nil {
+		if errors.Is(err, ErrEdgeworkerNoCurrentActivation) {
+			return diag.Errorf("%s: cannot roll back, no version is active on network '%s' for edgeworker with id=%d", ErrEdgeworkerActivation, network, edgeworkerID)
+		}
+		return diag.Errorf("%s: %s", ErrEdgeworkerActivation, err)
+	}
+
+	activationsResp, err := client.ListActivations(ctx, edgeworkers.ListActivationsRequest{
+		EdgeWorkerID: edgeworkerID,
+	})
+	if err != nil {
+		return diag.Errorf("%s: cannot read activation history for rollback: %s", ErrEdgeworkerActivation, err)
+	}
+	previous := lastSuccessfulActivation(activationsResp.Activations, network, current.Version)
+	if previous == nil {
+		return diag.Errorf("%s: cannot roll back, no version was activated on network '%s' before version '%s'", ErrEdgeworkerActivation, network, current.Version)
+	}
+
+	rollback, err := client.ActivateVersion(ctx, edgeworkers.ActivateVersionRequest{
+		EdgeWorkerID: edgeworkerID,
+		ActivateVersion: edgeworkers.ActivateVersion{
+			Network: edgeworkers.ActivationNetwork(network),
+			Version: previous.Version,
+			Note:    fmt.Sprintf("Rollback from version %s", current.Version),
+		},
+	})
+	if err != nil {
+		return diag.Errorf("%s: rollback to version '%s' failed: %s", ErrEdgeworkerActivation, previous.Version, err)
+	}
+	if _, err := waitForEdgeworkerActivation(ctx, client, edgeworkerID, rollback.ActivationID); err != nil {
+		return diag.Errorf("%s: rollback to version '%s' failed: %s", ErrEdgeworkerActivation, previous.Version, err)
+	}
+
+	rd.SetId(fmt.Sprintf("%d:%s", edgeworkerID, network))
+	return resourceEdgeworkersActivationRead(ctx, rd, m)
+}
+
+// lastSuccessfulActivation returns the latest completed activation of a version other than excludedVersion on the given
+// network, or nil if there is none
+func lastSuccessfulActivation(activations []edgeworkers.Activation, network, excludedVersion string) *edgeworkers.Activation {
+	for _, act := range sortActivationsByDate(filterActivationsByNetwork(activations, network)) {
+		if act.Status == activationStatusComplete && act.Version != excludedVersion {
+			return &act
+		}
+	}
+	return nil
+}
+
 func getCurrentActivation(ctx context.Context, client edgeworkers.Edgeworkers, edgeworkerID int, network string, waitForDeactivation bool) (*edgeworkers.Activation, error) {
 	activationsResp, err := client.ListActivations(ctx, edgeworkers.ListActivationsRequest{
 		EdgeWorkerID: edgeworkerID,
@@ -454,69 +575,60 @@ func versionExists(version string, versions []edgeworkers.EdgeWorkerVersion) boo
 	return false
 }
 
+// newActivationPoller returns the poller used while waiting for edgeworker activations and deactivations
+func newActivationPoller() *activation.Poller {
+	return activation.NewPoller(tf.MaxDuration(activationPollInterval, activationPollMinimum))
+}
+
 func waitForEdgeworkerActivation(ctx context.Context, client edgeworkers.Edgeworkers, edgeworkerID, activationID int) (*edgeworkers.Activation, error) {
-	activation, err := client.GetActivation(ctx, edgeworkers.GetActivationRequest{
-		EdgeWorkerID: edgeworkerID,
-		ActivationID: activationID,
-	})
-	if err != nil {
+	var act *edgeworkers.Activation
+	getActivation := func(ctx context.Context) (err error) {
+		act, err = client.GetActivation(ctx, edgeworkers.GetActivationRequest{
+			EdgeWorkerID: edgeworkerID,
+			ActivationID: activationID,
+		})
+		return err
+	}
+	poller := newActivationPoller()
+	if err := retryActivationRequest(ctx, poller, activationWaitError, getActivation); err != nil {
 		return nil, err
 	}
-	for activation != nil && activation.Status != activationStatusComplete {
-		if !statusOngoing(activation.Status) {
+	for act != nil && act.Status != activationStatusComplete {
+		if !statusOngoing(act.Status) {
 			return nil, ErrEdgeworkerActivationFailure
 		}
-		select {
-		case <-time.After(tf.MaxDuration(activationPollInterval, activationPollMinimum)):
-			activation, err = client.GetActivation(ctx, edgeworkers.GetActivationRequest{
-				EdgeWorkerID: edgeworkerID,
-				ActivationID: activationID,
-			})
-			if err != nil {
-				return nil, err
-			}
-		case <-ctx.Done():
-			if errors.Is(ctx.Err(), context.DeadlineExceeded) {
-				return nil, ErrEdgeworkerActivationTimeout
-			}
-			if errors.Is(ctx.Err(), context.Canceled) {
-				return nil, ErrEdgeworkerActivationCancelled
-			}
-			return nil, fmt.Errorf("%v: %w", ErrEdgeworkerActivationContextTerminated, ctx.Err())
+		if err := poller.Wait(ctx); err != nil {
+			return nil, activationWaitError(err)
+		}
+		if err := retryActivationRequest(ctx, poller, activationWaitError, getActivation); err != nil {
+			return nil, err
 		}
 	}
-	return activation, nil
+	return act, nil
 }
 
 func waitForEdgeworkerDeactivation(ctx context.Context, client edgeworkers.Edgeworkers, edgeworkerID, deactivationID int) (*edgeworkers.Deactivation, error) {
-	deactivation, err := client.GetDeactivation(ctx, edgeworkers.GetDeactivationRequest{
-		EdgeWorkerID:   edgeworkerID,
-		DeactivationID: deactivationID,
-	})
-	if err != nil {
+	var deactivation *edgeworkers.Deactivation
+	getDeactivation := func(ctx context.Context) (err error) {
+		deactivation, err = client.GetDeactivation(ctx, edgeworkers.GetDeactivationRequest{
+			EdgeWorkerID:   edgeworkerID,
+			DeactivationID: deactivationID,
+		})
+		return err
+	}
+	poller := newActivationPoller()
+	if err := retryActivationRequest(ctx, poller, deactivationWaitError, getDeactivation); err != nil {
 		return nil, err
 	}
 	for deactivation != nil && deactivation.Status != activationStatusComplete {
 		if !statusOngoing(deactivation.Status) {
 			return nil, ErrEdgeworkerDeactivationFailure
 		}
-		select {
-		case <-time.After(tf.MaxDuration(activationPollInterval, activationPollMinimum)):
-			deactivation, err = client.GetDeactivation(ctx, edgeworkers.GetDeactivationRequest{
-				EdgeWorkerID:   edgeworkerID,
-				DeactivationID: deactivationID,
-			})
-			if err != nil {
-				return nil, err
-			}
-		case <-ctx.Done():
-			if errors.Is(ctx.Err(), context.DeadlineExceeded) {
-				return nil, ErrEdgeworkerDeactivationTimeout
-			}
-			if errors.Is(ctx.Err(), context.Canceled) {
-				return nil, ErrEdgeworkerDeactivationCancelled
-			}
-			return nil, fmt.Errorf("%v: %w", ErrEdgeworkerDeactivationContextTerminated, ctx.Err())
+		if err := poller.Wait(ctx); err != nil {
+			return nil, deactivationWaitError(err)
+		}
+		if err := retryActivationRequest(ctx, poller, deactivationWaitError, getDeactivation); err != nil {
+			return nil, err
 		}
 	}
 	return deactivation, nil
@@ -611,6 +723,11 @@ func suppressNoteFieldForEdgeWorkersActivation(_, oldValue, newValue string, d *
 	return true
 }
 
+func suppressVersionOnRollback(_, _, _ string, d *schema.ResourceData) bool {
+	rollback, ok := d.Get("rollback").(bool)
+	return ok && rollback && d.Id() != ""
+}
+
 func statusOngoing(status string) bool {
 	return status == activationStatusInProgress || status == activationStatusPending || status == activationStatusPresubmit
 }
@@ -1,15 +1,24 @@
 package edgeworkers
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"regexp"
 	"testing"
 	"time"
 
 	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v9/pkg/edgeworkers"
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v9/pkg/session"
 	"github.com/akamai/terraform-provider-akamai/v6/pkg/common/testutils"
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/meta"
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
 func TestResourceEdgeworkersActivation(t *testing.T) {
@@ -1366,6 +1375,207 @@ func TestResourceEdgeworkersActivation(t *testing.T) {
 	}
 }
 
+func TestRollbackOnFailedActivation(t *testing.T) {
+	edgeworkerID := 1234
+	net := edgeworkers.ActivationNetworkStaging
+	rollbackNote := "Rollback after failed activation of version 2.0"
+	history := []edgeworkers.Activation{
+		*createStubActivation(edgeworkerID, 2, net, "1.0", activationStatusComplete, "2022-01-25T12:30:06Z", ""),
+		*createStubActivation(edgeworkerID, 1, net, "0.9", activationStatusComplete, "2022-01-24T12:30:06Z", ""),
+	}
+	failedActivation := *createStubActivation(edgeworkerID, 3, net, "2.0", "ERROR", "2022-01-26T12:30:06Z", "")
+	rollbackActivation := *createStubActivation(edgeworkerID, 4, net, "1.0", activationStatusComplete, "2022-01-26T13:30:06Z", rollbackNote)
+
+	activationPollMinimum = time.Millisecond
+	activationPollInterval = activationPollMinimum
+
+	m, err := meta.New(session.Must(session.New()), hclog.NewNullLogger(), "")
+	require.NoError(t, err)
+
+	newResourceData := func(t *testing.T, id string, rollbackOnFailure bool) *schema.ResourceData {
+		rd := schema.TestResourceDataRaw(t, resourceEdgeworkersActivationSchema(), map[string]interface{}{
+			"edgeworker_id":       edgeworkerID,
+			"version":             "2.0",
+			"network":             stagingNetwork,
+			"rollback_on_failure": rollbackOnFailure,
+		})
+		rd.SetId(id)
+		return rd
+	}
+
+	expectFailedActivation := func(client *edgeworkers.Mock, history []edgeworkers.Activation) {
+		expectListEdgeWorkerVersions(client, edgeworkerID, []edgeworkers.EdgeWorkerVersion{
+			*createStubEdgeworkerVersion(edgeworkerID, "2.0"),
+		}, nil).Once()
+		expectListActivations(client, edgeworkerID, "", history, nil).Once()
+		if len(history) > 0 {
+			expectListDeactivations(client, edgeworkerID, history[0].Version, []edgeworkers.Deactivation{}, nil).Once()
+		}
+		expectActivateVersion(client, edgeworkerID, 3, net, "2.0", "", nil).Once()
+		expectGetActivation(client, edgeworkerID, 3, net, "2.0", "ERROR", nil).Once()
+	}
+
+	t.Run("previous version is activated again", func(t *testing.T) {
+		client := &edgeworkers.Mock{}
+		expectFailedActivation(client, history)
+		expectListActivations(client, edgeworkerID, "", append([]edgeworkers.Activation{failedActivation}, history...), nil).Once()
+		expectActivateVersion(client, edgeworkerID, 4, net, "1.0", rollbackNote, nil).Once()
+		expectGetActivation(client, edgeworkerID, 4, net, "1.0", activationStatusComplete, nil).Once()
+
+		rd := newResourceData(t, "", true)
+		diags := upsertActivation(context.Background(), rd, m, client)
+		require.True(t, diags.HasError())
+		assert.Contains(t, diags[0].Summary, "activation of version '2.0' failed, version '1.0' was activated again on network 'STAGING'")
+		assert.Empty(t, rd.Id())
+		client.AssertExpectations(t)
+	})
+
+	t.Run("state of existing resource is updated to the previous version", func(t *testing.T) {
+		client := &edgeworkers.Mock{}
+		expectFailedActivation(client, history)
+		expectListActivations(client, edgeworkerID, "", append([]edgeworkers.Activation{failedActivation}, history...), nil).Once()
+		expectActivateVersion(client, edgeworkerID, 4, net, "1.0", rollbackNote, nil).Once()
+		expectGetActivation(client, edgeworkerID, 4, net, "1.0", activationStatusComplete, nil).Once()
+		expectFullRead(client, edgeworkerID, "1.0", append([]edgeworkers.Activation{rollbackActivation, failedActivation}, history...), []edgeworkers.Deactivation{}, 1)
+
+		rd := newResourceData(t, "1234:STAGING", true)
+		var diags diag.Diagnostics
+		useClient(client, func() {
+			diags = upsertActivation(context.Background(), rd, m, client)
+		})
+		require.True(t, diags.HasError())
+		assert.Equal(t, "1.0", rd.Get("version"))
+		assert.Equal(t, 4, rd.Get("activation_id"))
+		client.AssertExpectations(t)
+	})
+
+	t.Run("no previous version", func(t *testing.T) {
+		client := &edgeworkers.Mock{}
+		expectFailedActivation(client, nil)
+		expectListActivations(client, edgeworkerID, "", []edgeworkers.Activation{failedActivation}, nil).Once()
+
+		diags := upsertActivation(context.Background(), newResourceData(t, "", true), m, client)
+		require.True(t, diags.HasError())
+		assert.Contains(t, diags[0].Summary, "activation of version '2.0' failed and no version was previously activated on network 'STAGING' to roll back to")
+		client.AssertExpectations(t)
+	})
+
+	t.Run("rollback disabled", func(t *testing.T) {
+		client := &edgeworkers.Mock{}
+		expectFailedActivation(client, history)
+
+		diags := upsertActivation(context.Background(), newResourceData(t, "", false), m, client)
+		require.True(t, diags.HasError())
+		assert.Contains(t, diags[0].Summary, ErrEdgeworkerActivationFailure.Error())
+		client.AssertExpectations(t)
+	})
+}
+
+func TestRollbackToPreviousVersion(t *testing.T) {
+	edgeworkerID := 1234
+	net := edgeworkers.ActivationNetworkStaging
+	rollbackNote := "Rollback from version 2.0"
+	history := []edgeworkers.Activation{
+		*createStubActivation(edgeworkerID, 3, net, "2.0", activationStatusComplete, "2022-01-26T12:30:06Z", ""),
+		*createStubActivation(edgeworkerID, 2, net, "1.0", activationStatusComplete, "2022-01-25T12:30:06Z", ""),
+		*createStubActivation(edgeworkerID, 1, net, "0.9", activationStatusComplete, "2022-01-24T12:30:06Z", ""),
+	}
+	rollbackActivation := *createStubActivation(edgeworkerID, 4, net, "1.0", activationStatusComplete, "2022-01-27T12:30:06Z", rollbackNote)
+
+	activationPollMinimum = time.Millisecond
+	activationPollInterval = activationPollMinimum
+
+	m, err := meta.New(session.Must(session.New()), hclog.NewNullLogger(), "")
+	require.NoError(t, err)
+
+	newResourceData := func(t *testing.T, id string) *schema.ResourceData {
+		rd := schema.TestResourceDataRaw(t, resourceEdgeworkersActivationSchema(), map[string]interface{}{
+			"edgeworker_id": edgeworkerID,
+			"version":       "2.0",
+			"network":       stagingNetwork,
+			"rollback":      true,
+		})
+		rd.SetId(id)
+		return rd
+	}
+
+	t.Run("previous version is activated again", func(t *testing.T) {
+		client := &edgeworkers.Mock{}
+		expectListActivations(client, edgeworkerID, "", history, nil).Twice()
+		expectListDeactivations(client, edgeworkerID, "2.0", []edgeworkers.Deactivation{}, nil).Once()
+		expectActivateVersion(client, edgeworkerID, 4, net, "1.0", rollbackNote, nil).Once()
+		expectGetActivation(client, edgeworkerID, 4, net, "1.0", activationStatusComplete, nil).Once()
+		expectFullRead(client, edgeworkerID, "1.0", append([]edgeworkers.Activation{rollbackActivation}, history...), []edgeworkers.Deactivation{}, 1)
+
+		rd := newResourceData(t, "1234:STAGING")
+		var diags diag.Diagnostics
+		useClient(client, func() {
+			diags = upsertActivation(context.Background(), rd, m, client)
+		})
+		require.False(t, diags.HasError(), diags)
+		assert.Equal(t, "1.0", rd.Get("version"))
+		assert.Equal(t, 4, rd.Get("activation_id"))
+		client.AssertExpectations(t)
+	})
+
+	t.Run("no active version", func(t *testing.T) {
+		client := &edgeworkers.Mock{}
+		expectListActivations(client, edgeworkerID, "", []edgeworkers.Activation{}, nil).Once()
+
+		diags := upsertActivation(context.Background(), newResourceData(t, "1234:STAGING"), m, client)
+		require.True(t, diags.HasError())
+		assert.Contains(t, diags[0].Summary, "cannot roll back, no version is active on network 'STAGING'")
+		client.AssertExpectations(t)
+	})
+
+	t.Run("no previous version", func(t *testing.T) {
+		client := &edgeworkers.Mock{}
+		expectListActivations(client, edgeworkerID, "", history[:1], nil).Twice()
+		expectListDeactivations(client, edgeworkerID, "2.0", []edgeworkers.Deactivation{}, nil).Once()
+
+		diags := upsertActivation(context.Background(), newResourceData(t, "1234:STAGING"), m, client)
+		require.True(t, diags.HasError())
+		assert.Contains(t, diags[0].Summary, "cannot roll back, no version was activated on network 'STAGING' before version '2.0'")
+		client.AssertExpectations(t)
+	})
+
+	t.Run("rollback is ignored when the activation is created", func(t *testing.T) {
+		client := &edgeworkers.Mock{}
+		expectListEdgeWorkerVersions(client, edgeworkerID, nil, errors.New("oops")).Once()
+
+		diags := upsertActivation(context.Background(), newResourceData(t, ""), m, client)
+		require.True(t, diags.HasError())
+		assert.Contains(t, diags[0].Summary, "oops")
+		client.AssertExpectations(t)
+	})
+
+	t.Run("version change is ignored while rollback is set", func(t *testing.T) {
+		assert.True(t, suppressVersionOnRollback("version", "1.0", "2.0", newResourceData(t, "1234:STAGING")))
+		assert.False(t, suppressVersionOnRollback("version", "", "2.0", newResourceData(t, "")))
+	})
+}
+
+func TestLastSuccessfulActivation(t *testing.T) {
+	edgeworkerID := 1234
+	activations := []edgeworkers.Activation{
+		*createStubActivation(edgeworkerID, 1, edgeworkers.ActivationNetworkStaging, "1.0", activationStatusComplete, "2022-01-22T12:30:06Z", ""),
+		*createStubActivation(edgeworkerID, 5, edgeworkers.ActivationNetworkStaging, "3.0", "ERROR", "2022-01-26T12:30:06Z", ""),
+		*createStubActivation(edgeworkerID, 4, edgeworkers.ActivationNetworkProduction, "2.0", activationStatusComplete, "2022-01-25T12:30:06Z", ""),
+		*createStubActivation(edgeworkerID, 3, edgeworkers.ActivationNetworkStaging, "2.0", activationStatusComplete, "2022-01-24T12:30:06Z", ""),
+		*createStubActivation(edgeworkerID, 2, edgeworkers.ActivationNetworkStaging, "1.0", activationStatusComplete, "2022-01-23T12:30:06Z", ""),
+	}
+
+	act := lastSuccessfulActivation(activations, stagingNetwork, "3.0")
+	require.NotNil(t, act)
+	assert.Equal(t, 3, act.ActivationID)
+
+	act = lastSuccessfulActivation(activations, stagingNetwork, "2.0")
+	require.NotNil(t, act)
+	assert.Equal(t, 2, act.ActivationID)
+
+	assert.Nil(t, lastSuccessfulActivation(activations, productionNetwork, "2.0"))
+}
+
 func expectActivateVersion(m *edgeworkers.Mock, edgeworkerID, activationID int, net edgeworkers.ActivationNetwork, version, note string, e error) *mock.Call {
 	req := edgeworkers.ActivateVersionRequest{
 		EdgeWorkerID: edgeworkerID,
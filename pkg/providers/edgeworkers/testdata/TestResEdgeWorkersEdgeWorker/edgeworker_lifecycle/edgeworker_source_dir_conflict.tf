@@ -0,0 +1,11 @@
+provider "akamai" {
+  edgerc = "../../common/testutils/edgerc"
+}
+
+resource "akamai_edgeworker" "edgeworker" {
+  name             = "example"
+  group_id         = "grp_12345"
+  resource_tier_id = 54321
+  local_bundle     = "testdata/TestResEdgeWorkersEdgeWorker/bundles/bundleForCreate.tgz"
+  source_dir       = "testdata/TestResEdgeWorkersEdgeWorker/source_dir"
+}
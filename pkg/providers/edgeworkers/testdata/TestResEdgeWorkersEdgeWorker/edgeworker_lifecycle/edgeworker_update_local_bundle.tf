@@ -6,5 +6,5 @@ resource "akamai_edgeworker" "edgeworker" {
   name             = "example"
   group_id         = "12345"
   resource_tier_id = 54321
-  local_bundle     = "testdata/TestResEdgeWorkersEdgeWorker/bundles/bundleForUpdate.tgz"
+  local_bundle     = "testdata/TestResEdgeWorkersEdgeWorker/bundles/bundleForLocalUpdate.tgz"
 }
\ No newline at end of file
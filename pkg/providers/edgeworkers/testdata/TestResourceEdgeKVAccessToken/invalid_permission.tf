@@ -0,0 +1,12 @@
+provider "akamai" {
+  edgerc = "../../common/testutils/edgerc"
+}
+
+resource "akamai_edgekv_access_token" "test" {
+  name                = "test_token"
+  allow_on_production = true
+  namespace_permissions {
+    namespace_name = "marketing"
+    permissions    = ["x"]
+  }
+}
@@ -0,0 +1,11 @@
+provider "akamai" {
+  edgerc = "../../common/testutils/edgerc"
+}
+
+resource "akamai_edgekv_access_token" "test" {
+  name = "test_token"
+  namespace_permissions {
+    namespace_name = "marketing"
+    permissions    = ["r"]
+  }
+}
@@ -0,0 +1,18 @@
+provider "akamai" {
+  edgerc = "../../common/testutils/edgerc"
+}
+
+resource "akamai_edgekv_access_token" "test" {
+  name             = "test_token"
+  allow_on_staging = true
+  namespace_permissions {
+    namespace_name = "marketing"
+    permissions    = ["r", "w"]
+  }
+  namespace_permissions {
+    namespace_name = "default"
+    permissions    = ["r"]
+  }
+  restrict_to_edgeworker_ids = ["1234"]
+  rotate_before_expiry       = "720h"
+}
@@ -0,0 +1,14 @@
+provider "akamai" {
+  edgerc = "../../common/testutils/edgerc"
+}
+
+resource "terraform_data" "items_file" {
+  input = "testdata/TestResourceEdgeKVGroupItems/items/items.json"
+}
+
+resource "akamai_edgekv_group_items" "test" {
+  namespace_name = "test_namespace"
+  network        = "staging"
+  group_name     = "1234"
+  items_file     = terraform_data.items_file.output
+}
@@ -1,7 +1,9 @@
 package gtm
 
 import (
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/common/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 type resourceInstance struct {
@@ -16,3 +18,40 @@ type link struct {
 	Rel  types.String `tfsdk:"rel"`
 	Href types.String `tfsdk:"href"`
 }
+
+// resourceTimeouts returns the timeouts of GTM resources, matching the keys of the block returned by timeoutsSchema
+func resourceTimeouts() *schema.ResourceTimeout {
+	return &schema.ResourceTimeout{
+		Default: &timeouts.SDKDefaultTimeout,
+		Create:  &timeouts.SDKDefaultTimeout,
+		Update:  &timeouts.SDKDefaultTimeout,
+		Delete:  &timeouts.SDKDefaultTimeout,
+	}
+}
+
+// timeoutsSchema returns the `timeouts` block shared by GTM resources. The timeouts
+// cover the whole operation, including waiting for the domain propagation.
+func timeoutsSchema() *schema.Schema {
+	durationSchema := func(description string) *schema.Schema {
+		return &schema.Schema{
+			Type:             schema.TypeString,
+			Optional:         true,
+			ValidateDiagFunc: timeouts.ValidateDurationFormat,
+			Description:      description,
+		}
+	}
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Optional:    true,
+		MaxItems:    1,
+		Description: "Enables to set timeouts for processing, including waiting for the domain propagation",
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"default": durationSchema("Timeout used for operations without a dedicated timeout"),
+				"create":  durationSchema("Timeout for the create operation"),
+				"update":  durationSchema("Timeout for the update operation"),
+				"delete":  durationSchema("Timeout for the delete operation"),
+			},
+		},
+	}
+}
@@ -0,0 +1,486 @@
+package gtm
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v9/pkg/gtm"
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/common/tf"
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/meta"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"golang.org/x/exp/slices"
+)
+
+type (
+	// livenessCheck is a local equivalent of a GTM liveness test executed against a single target
+	livenessCheck struct {
+		target         string
+		protocol       string
+		testObject     string
+		port           int
+		timeout        time.Duration
+		method         string
+		body           string
+		headers        http.Header
+		error3xx       bool
+		error4xx       bool
+		error5xx       bool
+		requestString  string
+		responseString string
+		tlsConfig      *tls.Config
+	}
+
+	// livenessCheckResult holds the outcome of a livenessCheck
+	livenessCheckResult struct {
+		success    bool
+		statusCode int
+		err        error
+		duration   time.Duration
+	}
+)
+
+var (
+	// localLivenessCheckProtocols lists protocols which can be checked by akamai_gtm_liveness_test_check
+	localLivenessCheckProtocols = []string{"HTTP", "HTTPS", "TCP", "TCPS"}
+
+	// maxLivenessCheckResponseSize limits the part of the response searched for the response string
+	maxLivenessCheckResponseSize int64 = 64 * 1024
+)
+
+func dataSourceGTMLivenessTestCheck() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataGTMLivenessTestCheckRead,
+		Description: "Validates a liveness test definition and executes an equivalent HTTP or TCP check " +
+			"from the machine running Terraform against the given target.",
+		Schema: map[string]*schema.Schema{
+			"target": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Host name or IP address of the server to test.",
+			},
+			"test_object_protocol": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: fmt.Sprintf("Protocol of the test. Supported values: %v.", localLivenessCheckProtocols),
+			},
+			"test_object": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Path requested by HTTP and HTTPS tests.",
+			},
+			"test_object_port": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Default:     80,
+				Description: "Port of the server to test.",
+			},
+			"test_interval": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Default:     60,
+				Description: "Interval of the liveness test in seconds. It is only used to validate 'test_timeout'.",
+			},
+			"test_timeout": {
+				Type:        schema.TypeFloat,
+				Required:    true,
+				Description: "Time in seconds after which the test fails.",
+			},
+			"http_method": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "HTTP method of the request. Defaults to GET.",
+			},
+			"http_request_body": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Body of the HTTP request.",
+			},
+			"http_header": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "HTTP headers sent with the request. The 'Host' header is also used as the TLS server name.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"value": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+					},
+				},
+			},
+			"http_error3xx": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: "Treats a 3xx HTTP response as a failure.",
+			},
+			"http_error4xx": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: "Treats a 4xx HTTP response as a failure.",
+			},
+			"http_error5xx": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: "Treats a 5xx HTTP response as a failure.",
+			},
+			"request_string": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "String sent to the server by TCP and TCPS tests.",
+			},
+			"response_string": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "String the response has to contain for the test to succeed.",
+			},
+			"peer_certificate_verification": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Validates the certificate of the server.",
+			},
+			"ssl_client_certificate": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "PEM encoded client certificate presented to the server.",
+			},
+			"ssl_client_private_key": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Sensitive:   true,
+				Description: "PEM encoded private key of the client certificate.",
+			},
+			"alternate_ca_certificates": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "PEM encoded CA certificates trusted in addition to the system ones.",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"success": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Indicates whether the check succeeded.",
+			},
+			"status_code": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "HTTP status code of the response. It is 0 for TCP and TCPS checks.",
+			},
+			"error": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Reason of the failure, if the check did not succeed.",
+			},
+			"duration": {
+				Type:        schema.TypeFloat,
+				Computed:    true,
+				Description: "Duration of the check in seconds.",
+			},
+		},
+	}
+}
+
+func dataGTMLivenessTestCheckRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	meta := meta.Must(m)
+	logger := meta.Log("Akamai GTM", "dataGTMLivenessTestCheckRead")
+
+	item := make(map[string]interface{})
+	for key := range dataSourceGTMLivenessTestCheck().Schema {
+		item[key] = d.Get(key)
+	}
+	if err := validateLivenessTest(item, func(string) bool { return true }); err != nil {
+		return diag.FromErr(err)
+	}
+
+	check, err := livenessCheckFromResourceData(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	logger.Debugf("Executing %s liveness check of [%s]", check.protocol, check.target)
+	result := check.run(ctx)
+
+	errMsg := ""
+	if result.err != nil {
+		errMsg = result.err.Error()
+	}
+	attrs := map[string]interface{}{
+		"success":     result.success,
+		"status_code": result.statusCode,
+		"error":       errMsg,
+		"duration":    result.duration.Seconds(),
+	}
+	for key, value := range attrs {
+		if err := d.Set(key, value); err != nil {
+			return diag.Errorf("%s: %s", tf.ErrValueSet, err.Error())
+		}
+	}
+	d.SetId(net.JoinHostPort(check.target, strconv.Itoa(check.port)))
+	return nil
+}
+
+// livenessCheckFromResourceData reads the liveness check definition from the data source configuration
+func livenessCheckFromResourceData(d *schema.ResourceData) (*livenessCheck, error) {
+	protocol := d.Get("test_object_protocol").(string)
+	if !slices.Contains(localLivenessCheckProtocols, protocol) {
+		return nil, fmt.Errorf("protocol '%s' cannot be checked locally, supported protocols: %v", protocol, localLivenessCheckProtocols)
+	}
+
+	check := &livenessCheck{
+		target:         d.Get("target").(string),
+		protocol:       protocol,
+		testObject:     d.Get("test_object").(string),
+		port:           d.Get("test_object_port").(int),
+		timeout:        time.Duration(d.Get("test_timeout").(float64) * float64(time.Second)),
+		method:         d.Get("http_method").(string),
+		body:           d.Get("http_request_body").(string),
+		headers:        http.Header{},
+		error3xx:       d.Get("http_error3xx").(bool),
+		error4xx:       d.Get("http_error4xx").(bool),
+		error5xx:       d.Get("http_error5xx").(bool),
+		requestString:  d.Get("request_string").(string),
+		responseString: d.Get("response_string").(string),
+	}
+	for _, headerRaw := range d.Get("http_header").([]interface{}) {
+		header, ok := headerRaw.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		check.headers.Add(header["name"].(string), header["value"].(string))
+	}
+
+	if protocol == "HTTPS" || protocol == "TCPS" {
+		tlsConfig, err := livenessCheckTLSConfig(d, check)
+		if err != nil {
+			return nil, err
+		}
+		check.tlsConfig = tlsConfig
+	}
+	return check, nil
+}
+
+// livenessCheckTLSConfig builds the TLS configuration from the certificate attributes
+func livenessCheckTLSConfig(d *schema.ResourceData, check *livenessCheck) (*tls.Config, error) {
+	caCertificates := make([]string, 0)
+	for _, caRaw := range d.Get("alternate_ca_certificates").([]interface{}) {
+		caCertificates = append(caCertificates, caRaw.(string))
+	}
+	return newLivenessCheckTLSConfig(check, d.Get("peer_certificate_verification").(bool),
+		d.Get("ssl_client_certificate").(string), d.Get("ssl_client_private_key").(string), caCertificates)
+}
+
+// newLivenessCheckTLSConfig builds the TLS configuration of the check from the certificates of a liveness test
+func newLivenessCheckTLSConfig(check *livenessCheck, verify bool, certificate, privateKey string, caCertificates []string) (*tls.Config, error) {
+	tlsConfig := &tls.Config{
+		ServerName:         check.target,
+		InsecureSkipVerify: !verify, // #nosec G402 -- explicitly requested by the user
+	}
+	if host := check.headers.Get("Host"); host != "" {
+		tlsConfig.ServerName = host
+	}
+
+	if certificate != "" {
+		keyPair, err := tls.X509KeyPair([]byte(certificate), []byte(privateKey))
+		if err != nil {
+			return nil, err
+		}
+		tlsConfig.Certificates = []tls.Certificate{keyPair}
+	}
+
+	if len(caCertificates) > 0 {
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		for i, caRaw := range caCertificates {
+			ca, err := parseCertificate(caRaw)
+			if err != nil {
+				return nil, fmt.Errorf("'alternate_ca_certificates' element %d is not a valid certificate: %s", i, err)
+			}
+			pool.AddCert(ca)
+		}
+		tlsConfig.RootCAs = pool
+	}
+	return tlsConfig, nil
+}
+
+// livenessCheckFromLivenessTest creates a check executing the liveness test of a GTM property against the given target
+func livenessCheckFromLivenessTest(lt gtm.LivenessTest, target string) (*livenessCheck, error) {
+	if !slices.Contains(localLivenessCheckProtocols, lt.TestObjectProtocol) {
+		return nil, fmt.Errorf("protocol '%s' cannot be checked locally, supported protocols: %v", lt.TestObjectProtocol, localLivenessCheckProtocols)
+	}
+
+	check := &livenessCheck{
+		target:         target,
+		protocol:       lt.TestObjectProtocol,
+		testObject:     lt.TestObject,
+		port:           lt.TestObjectPort,
+		timeout:        time.Duration(float64(lt.TestTimeout) * float64(time.Second)),
+		headers:        http.Header{},
+		error3xx:       lt.HTTPError3xx,
+		error4xx:       lt.HTTPError4xx,
+		error5xx:       lt.HTTPError5xx,
+		requestString:  lt.RequestString,
+		responseString: lt.ResponseString,
+	}
+	if lt.HTTPMethod != nil {
+		check.method = *lt.HTTPMethod
+	}
+	if lt.HTTPRequestBody != nil {
+		check.body = *lt.HTTPRequestBody
+	}
+	for _, header := range lt.HTTPHeaders {
+		check.headers.Add(header.Name, header.Value)
+	}
+
+	if check.protocol == "HTTPS" || check.protocol == "TCPS" {
+		tlsConfig, err := newLivenessCheckTLSConfig(check, lt.PeerCertificateVerification,
+			lt.SSLClientCertificate, lt.SSLClientPrivateKey, lt.AlternateCACertificates)
+		if err != nil {
+			return nil, err
+		}
+		check.tlsConfig = tlsConfig
+	}
+	return check, nil
+}
+
+// run executes the check and measures its duration
+func (c *livenessCheck) run(ctx context.Context) livenessCheckResult {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	start := time.Now()
+	var result livenessCheckResult
+	switch c.protocol {
+	case "HTTP", "HTTPS":
+		result = c.runHTTP(ctx)
+	default:
+		result = c.runTCP(ctx)
+	}
+	result.duration = time.Since(start)
+	result.success = result.err == nil
+	return result
+}
+
+// runHTTP requests the test object and checks the status code and the response body
+func (c *livenessCheck) runHTTP(ctx context.Context) livenessCheckResult {
+	scheme := strings.ToLower(c.protocol)
+	url := fmt.Sprintf("%s://%s%s", scheme, net.JoinHostPort(c.target, strconv.Itoa(c.port)), c.testObject)
+	method := c.method
+	if method == "" {
+		method = http.MethodGet
+	}
+	req, err := http.NewRequestWithContext(ctx, method, url, strings.NewReader(c.body))
+	if err != nil {
+		return livenessCheckResult{err: err}
+	}
+	req.Header = c.headers.Clone()
+	if host := c.headers.Get("Host"); host != "" {
+		req.Host = host
+	}
+
+	// each check has its own TLS configuration, so the transport is not reused and its connection is closed afterwards
+	transport := &http.Transport{TLSClientConfig: c.tlsConfig}
+	defer transport.CloseIdleConnections()
+	client := &http.Client{
+		Transport: transport,
+		// GTM does not follow redirects, the 3xx response is the result of the test
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return livenessCheckResult{err: err}
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	result := livenessCheckResult{statusCode: resp.StatusCode}
+	switch {
+	case resp.StatusCode >= 300 && resp.StatusCode < 400 && c.error3xx,
+		resp.StatusCode >= 400 && resp.StatusCode < 500 && c.error4xx,
+		resp.StatusCode >= 500 && c.error5xx:
+		result.err = fmt.Errorf("server responded with status %s", resp.Status)
+		return result
+	}
+	if c.responseString != "" {
+		body, err := io.ReadAll(io.LimitReader(resp.Body, maxLivenessCheckResponseSize))
+		if err != nil {
+			result.err = fmt.Errorf("reading response: %w", err)
+			return result
+		}
+		if !bytes.Contains(body, []byte(c.responseString)) {
+			result.err = fmt.Errorf("response does not contain the response string '%s'", c.responseString)
+		}
+	}
+	return result
+}
+
+// runTCP connects to the target, sends the request string and waits for the response string
+func (c *livenessCheck) runTCP(ctx context.Context) livenessCheckResult {
+	address := net.JoinHostPort(c.target, strconv.Itoa(c.port))
+	var conn net.Conn
+	var err error
+	if c.tlsConfig != nil {
+		dialer := &tls.Dialer{Config: c.tlsConfig}
+		conn, err = dialer.DialContext(ctx, "tcp", address)
+	} else {
+		var dialer net.Dialer
+		conn, err = dialer.DialContext(ctx, "tcp", address)
+	}
+	if err != nil {
+		return livenessCheckResult{err: err}
+	}
+	defer func() {
+		_ = conn.Close()
+	}()
+	if deadline, ok := ctx.Deadline(); ok {
+		if err := conn.SetDeadline(deadline); err != nil {
+			return livenessCheckResult{err: err}
+		}
+	}
+
+	if c.requestString != "" {
+		if _, err := io.WriteString(conn, c.requestString); err != nil {
+			return livenessCheckResult{err: fmt.Errorf("sending request string: %w", err)}
+		}
+	}
+	if c.responseString == "" {
+		return livenessCheckResult{}
+	}
+
+	var received []byte
+	buf := make([]byte, 4096)
+	for int64(len(received)) < maxLivenessCheckResponseSize {
+		n, err := conn.Read(buf)
+		received = append(received, buf[:n]...)
+		if bytes.Contains(received, []byte(c.responseString)) {
+			return livenessCheckResult{}
+		}
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return livenessCheckResult{err: fmt.Errorf("waiting for response string '%s': %w", c.responseString, err)}
+		}
+	}
+	return livenessCheckResult{err: fmt.Errorf("response does not contain the response string '%s'", c.responseString)}
+}
@@ -0,0 +1,196 @@
+package gtm
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v9/pkg/session"
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/meta"
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDataGTMLivenessTestCheck(t *testing.T) {
+	m, err := meta.New(session.Must(session.New()), hclog.NewNullLogger(), "")
+	require.NoError(t, err)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/health":
+			assert.Equal(t, "www.example.com", r.Host)
+			_, _ = io.WriteString(w, "status: OK")
+		case "/moved":
+			http.Redirect(w, r, "/health", http.StatusFound)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+	host, port, err := net.SplitHostPort(server.Listener.Addr().String())
+	require.NoError(t, err)
+	serverPort, err := strconv.Atoi(port)
+	require.NoError(t, err)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer func() {
+		_ = listener.Close()
+	}()
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			buf := make([]byte, 4)
+			_, _ = io.ReadFull(conn, buf)
+			if string(buf) == "PING" {
+				_, _ = io.WriteString(conn, "+PONG\r\n")
+			}
+			_ = conn.Close()
+		}
+	}()
+	tcpPort := listener.Addr().(*net.TCPAddr).Port
+
+	tests := map[string]struct {
+		config         map[string]interface{}
+		expectedStatus int
+		expectedErr    string
+		expectedDiag   string
+	}{
+		"HTTP check succeeds": {
+			config: map[string]interface{}{
+				"test_object":     "/health",
+				"response_string": "OK",
+				"http_header":     []interface{}{map[string]interface{}{"name": "Host", "value": "www.example.com"}},
+			},
+			expectedStatus: http.StatusOK,
+		},
+		"HTTP check fails on 4xx": {
+			config: map[string]interface{}{
+				"test_object":   "/missing",
+				"http_error4xx": true,
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedErr:    "server responded with status 404 Not Found",
+		},
+		"HTTP check does not follow redirects": {
+			config: map[string]interface{}{
+				"test_object":   "/moved",
+				"http_error3xx": true,
+			},
+			expectedStatus: http.StatusFound,
+			expectedErr:    "server responded with status 302 Found",
+		},
+		"HTTP check fails on missing response string": {
+			config: map[string]interface{}{
+				"test_object":     "/health",
+				"response_string": "healthy",
+				"http_header":     []interface{}{map[string]interface{}{"name": "Host", "value": "www.example.com"}},
+			},
+			expectedStatus: http.StatusOK,
+			expectedErr:    "response does not contain the response string 'healthy'",
+		},
+		"TCP check succeeds": {
+			config: map[string]interface{}{
+				"test_object_protocol": "TCP",
+				"test_object_port":     tcpPort,
+				"request_string":       "PING",
+				"response_string":      "PONG",
+			},
+		},
+		"TCP check fails on unexpected response": {
+			config: map[string]interface{}{
+				"test_object_protocol": "TCP",
+				"test_object_port":     tcpPort,
+				"request_string":       "HELO",
+				"response_string":      "PONG",
+			},
+			expectedErr: "response does not contain the response string 'PONG'",
+		},
+		"invalid liveness test": {
+			config: map[string]interface{}{
+				"test_object":  "/health",
+				"test_timeout": 60,
+			},
+			expectedDiag: "attribute 'test_timeout' (60) must be less than 'test_interval' (60)",
+		},
+		"protocol not supported locally": {
+			config: map[string]interface{}{
+				"test_object_protocol": "SMTP",
+			},
+			expectedDiag: "protocol 'SMTP' cannot be checked locally",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			config := map[string]interface{}{
+				"target":               host,
+				"test_object_protocol": "HTTP",
+				"test_object_port":     serverPort,
+				"test_timeout":         5,
+			}
+			for key, value := range test.config {
+				config[key] = value
+			}
+			d := schema.TestResourceDataRaw(t, dataSourceGTMLivenessTestCheck().Schema, config)
+
+			diags := dataGTMLivenessTestCheckRead(context.Background(), d, m)
+			if test.expectedDiag != "" {
+				require.True(t, diags.HasError())
+				assert.Contains(t, diags[0].Summary, test.expectedDiag)
+				return
+			}
+			require.False(t, diags.HasError(), diags)
+			assert.Equal(t, test.expectedErr == "", d.Get("success"))
+			assert.Equal(t, test.expectedStatus, d.Get("status_code"))
+			assert.Contains(t, d.Get("error"), test.expectedErr)
+			assert.Equal(t, net.JoinHostPort(host, strconv.Itoa(d.Get("test_object_port").(int))), d.Id())
+		})
+	}
+}
+
+func TestLivenessCheckClosesConnections(t *testing.T) {
+	var opened, closed atomic.Int32
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = io.WriteString(w, "status: OK")
+	}))
+	server.Config.ConnState = func(_ net.Conn, state http.ConnState) {
+		switch state {
+		case http.StateNew:
+			opened.Add(1)
+		case http.StateClosed:
+			closed.Add(1)
+		}
+	}
+	server.Start()
+	defer server.Close()
+	host, port, err := net.SplitHostPort(server.Listener.Addr().String())
+	require.NoError(t, err)
+	serverPort, err := strconv.Atoi(port)
+	require.NoError(t, err)
+
+	check := &livenessCheck{
+		target:     host,
+		protocol:   "HTTP",
+		testObject: "/",
+		port:       serverPort,
+		timeout:    5 * time.Second,
+	}
+	for i := 0; i < 3; i++ {
+		require.NoError(t, check.run(context.Background()).err)
+	}
+
+	assert.Equal(t, int32(3), opened.Load())
+	assert.Eventually(t, func() bool { return closed.Load() == 3 }, 5*time.Second, 10*time.Millisecond)
+}
@@ -0,0 +1,248 @@
+package gtm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v9/pkg/gtm"
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/common/tf"
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/meta"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"golang.org/x/exp/slices"
+)
+
+type (
+	// domainChange modifies the domain object as part of the batched domain update
+	domainChange func(*gtm.Domain)
+
+	// domainBatcher accumulates changes of GTM sub-resources made within one apply
+	// and submits them per domain as a single domain update. As every resource operation waits
+	// for the outcome of its change, a batch holds at most as many changes as Terraform runs
+	// concurrently, which is set with the `-parallelism` flag
+	domainBatcher struct {
+		mu      sync.Mutex
+		pending map[string]*domainBatch
+	}
+
+	// domainBatch holds the changes accumulated for a single domain
+	domainBatch struct {
+		changes []*batchedChange
+		timer   *time.Timer
+		done    chan struct{}
+	}
+
+	// batchedChange is a change submitted to a batch together with the outcome of the batched update
+	batchedChange struct {
+		change   domainChange
+		wait     bool
+		deadline time.Time
+		err      error
+	}
+)
+
+var (
+	batcher = &domainBatcher{pending: make(map[string]*domainBatch)}
+
+	// batchWindow is the time the batch waits for further changes after the last submitted one
+	batchWindow = 3 * time.Second
+
+	// ErrBatchedUpdate is returned when the batched domain update could not be submitted
+	ErrBatchedUpdate = errors.New("batched domain update failed")
+)
+
+// batchUpdateSchema returns the `batch_update` attribute shared by GTM domain sub-resources
+func batchUpdateSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeBool,
+		Optional: true,
+		Default:  false,
+		Description: "If set, the change is accumulated with changes of other GTM resources of the same domain " +
+			"that also use batching and submitted with them as a single domain update, waiting for the propagation once. " +
+			"A batch holds at most as many changes as Terraform applies concurrently",
+	}
+}
+
+// submitBatchedChange adds the change to the batched update of the domain and blocks until the batch
+// is submitted and, if `wait_on_complete` is set for any of the batched resources, propagated.
+func submitBatchedChange(ctx context.Context, d *schema.ResourceData, domain, operation string, m interface{}, change domainChange) diag.Diagnostics {
+	meta := meta.Must(m)
+	logger := meta.Log("Akamai GTM", "submitBatchedChange")
+
+	waitOnComplete, err := tf.GetBoolValue("wait_on_complete", d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	logger.Infof("%s added to the batched update of domain [%s]", operation, domain)
+	return propagationDiagnostics(batcher.submit(ctx, domain, waitOnComplete, change, m), domain, operation, logger)
+}
+
+// submit adds the change to the pending batch of the domain, starting a new batch if needed,
+// and waits for the batch to be flushed. If the operation ends before the batch is flushed, the change
+// is withdrawn from the batch. Once the flush started, the outcome of the change is always awaited.
+func (b *domainBatcher) submit(ctx context.Context, domain string, wait bool, change domainChange, m interface{}) error {
+	b.mu.Lock()
+	batch, ok := b.pending[domain]
+	if !ok {
+		batch = &domainBatch{done: make(chan struct{})}
+		b.pending[domain] = batch
+		// the batch outlives the operation which started it, so only its values are kept
+		flushCtx := context.WithoutCancel(ctx)
+		batch.timer = time.AfterFunc(batchWindow, func() {
+			b.flush(flushCtx, domain, batch, m)
+		})
+	} else {
+		batch.timer.Reset(batchWindow)
+	}
+	bc := &batchedChange{change: change, wait: wait}
+	if deadline, ok := ctx.Deadline(); ok {
+		bc.deadline = deadline
+	}
+	batch.changes = append(batch.changes, bc)
+	b.mu.Unlock()
+
+	select {
+	case <-batch.done:
+		return bc.err
+	case <-ctx.Done():
+	}
+
+	b.mu.Lock()
+	if b.pending[domain] != batch {
+		// the batch is already being submitted with the change
+		b.mu.Unlock()
+		<-batch.done
+		return bc.err
+	}
+	batch.changes = slices.DeleteFunc(batch.changes, func(c *batchedChange) bool { return c == bc })
+	if len(batch.changes) == 0 {
+		batch.timer.Stop()
+		delete(b.pending, domain)
+	}
+	b.mu.Unlock()
+	return fmt.Errorf("%w: change was withdrawn while waiting for the batch to be submitted: %s", ErrBatchedUpdate, ctx.Err())
+}
+
+// flush submits all changes accumulated in the batch as one domain update
+func (b *domainBatcher) flush(ctx context.Context, domain string, batch *domainBatch, m interface{}) {
+	b.mu.Lock()
+	if b.pending[domain] != batch {
+		// timer was reset after it had already fired or all changes were withdrawn, batch is flushed at most once
+		b.mu.Unlock()
+		return
+	}
+	delete(b.pending, domain)
+	b.mu.Unlock()
+	defer close(batch.done)
+
+	var wait bool
+	var deadline time.Time
+	for _, bc := range batch.changes {
+		wait = wait || bc.wait
+		if bc.deadline.After(deadline) {
+			deadline = bc.deadline
+		}
+	}
+	if !deadline.IsZero() {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithDeadline(ctx, deadline)
+		defer cancel()
+	}
+	err := submitDomainChanges(ctx, domain, batch.changes, wait, m)
+	for _, bc := range batch.changes {
+		bc.err = err
+	}
+}
+
+// submitDomainChanges applies the changes to the current domain and submits it as a single update
+func submitDomainChanges(ctx context.Context, domain string, changes []*batchedChange, wait bool, m interface{}) error {
+	meta := meta.Must(m)
+	logger := meta.Log("Akamai GTM", "submitDomainChanges")
+
+	logger.Debugf("Submitting %d batched changes of domain [%s]", len(changes), domain)
+	existDom, err := Client(meta).GetDomain(ctx, gtm.GetDomainRequest{
+		DomainName: domain,
+	})
+	if err != nil {
+		return fmt.Errorf("%w: GetDomain error: %s", ErrBatchedUpdate, err)
+	}
+	newDom := createDomainStruct(existDom)
+	for _, bc := range changes {
+		bc.change(newDom)
+	}
+
+	uStat, err := Client(meta).UpdateDomain(ctx, gtm.UpdateDomainRequest{
+		Domain: newDom,
+	})
+	if err != nil {
+		return fmt.Errorf("%w: UpdateDomain error: %s", ErrBatchedUpdate, err)
+	}
+	logger.Debugf("Batched update status: %v", uStat)
+	if uStat.Status.PropagationStatus == "DENIED" {
+		return fmt.Errorf("%w: %s", ErrBatchedUpdate, uStat.Status.Message)
+	}
+
+	if !wait {
+		return nil
+	}
+	return waitForCompletion(ctx, domain, m)
+}
+
+// upsertChange returns a domainChange which replaces the object with the same key
+// in the domain list selected by list, or appends the object if it is not present
+func upsertChange[T any](list func(*gtm.Domain) *[]T, key func(T) string, obj T) domainChange {
+	return func(dom *gtm.Domain) {
+		items := list(dom)
+		for i := range *items {
+			if key((*items)[i]) == key(obj) {
+				(*items)[i] = obj
+				return
+			}
+		}
+		*items = append(*items, obj)
+	}
+}
+
+// removeChange returns a domainChange which removes the object with the given key
+// from the domain list selected by list
+func removeChange[T any](list func(*gtm.Domain) *[]T, key func(T) string, objKey string) domainChange {
+	return func(dom *gtm.Domain) {
+		items := list(dom)
+		kept := make([]T, 0, len(*items))
+		for _, item := range *items {
+			if key(item) != objKey {
+				kept = append(kept, item)
+			}
+		}
+		*items = kept
+	}
+}
+
+func domainProperties(dom *gtm.Domain) *[]gtm.Property { return &dom.Properties }
+
+func domainDatacenters(dom *gtm.Domain) *[]gtm.Datacenter { return &dom.Datacenters }
+
+func domainResources(dom *gtm.Domain) *[]gtm.Resource { return &dom.Resources }
+
+func domainASMaps(dom *gtm.Domain) *[]gtm.ASMap { return &dom.ASMaps }
+
+func domainGeoMaps(dom *gtm.Domain) *[]gtm.GeoMap { return &dom.GeographicMaps }
+
+func domainCIDRMaps(dom *gtm.Domain) *[]gtm.CIDRMap { return &dom.CIDRMaps }
+
+func propertyKey(p gtm.Property) string { return p.Name }
+
+func datacenterKey(dc gtm.Datacenter) string { return strconv.Itoa(dc.DatacenterID) }
+
+func resourceKey(r gtm.Resource) string { return r.Name }
+
+func asMapKey(as gtm.ASMap) string { return as.Name }
+
+func geoMapKey(geo gtm.GeoMap) string { return geo.Name }
+
+func cidrMapKey(cidr gtm.CIDRMap) string { return cidr.Name }
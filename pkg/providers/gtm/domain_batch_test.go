@@ -0,0 +1,218 @@
+package gtm
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v9/pkg/gtm"
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v9/pkg/session"
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/meta"
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDomainChanges(t *testing.T) {
+	tests := map[string]struct {
+		change   domainChange
+		expected []gtm.Property
+	}{
+		"upsert replaces existing property": {
+			change: upsertChange(domainProperties, propertyKey, gtm.Property{Name: "prop1", Type: "failover"}),
+			expected: []gtm.Property{
+				{Name: "prop1", Type: "failover"},
+				{Name: "prop2", Type: "weighted-round-robin"},
+			},
+		},
+		"upsert appends new property": {
+			change: upsertChange(domainProperties, propertyKey, gtm.Property{Name: "prop3", Type: "failover"}),
+			expected: []gtm.Property{
+				{Name: "prop1", Type: "weighted-round-robin"},
+				{Name: "prop2", Type: "weighted-round-robin"},
+				{Name: "prop3", Type: "failover"},
+			},
+		},
+		"remove deletes property": {
+			change: removeChange(domainProperties, propertyKey, "prop1"),
+			expected: []gtm.Property{
+				{Name: "prop2", Type: "weighted-round-robin"},
+			},
+		},
+		"remove of missing property is a no-op": {
+			change: removeChange(domainProperties, propertyKey, "prop3"),
+			expected: []gtm.Property{
+				{Name: "prop1", Type: "weighted-round-robin"},
+				{Name: "prop2", Type: "weighted-round-robin"},
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			dom := &gtm.Domain{
+				Properties: []gtm.Property{
+					{Name: "prop1", Type: "weighted-round-robin"},
+					{Name: "prop2", Type: "weighted-round-robin"},
+				},
+			}
+			test.change(dom)
+			assert.Equal(t, test.expected, dom.Properties)
+		})
+	}
+}
+
+func TestDomainBatcher(t *testing.T) {
+	m, err := meta.New(session.Must(session.New()), hclog.NewNullLogger(), "")
+	require.NoError(t, err)
+
+	t.Run("concurrent changes are submitted as one domain update", func(t *testing.T) {
+		client := &gtm.Mock{}
+		client.On("GetDomain", mock.Anything, gtm.GetDomainRequest{DomainName: gtmTestDomain}).
+			Return(&gtm.GetDomainResponse{
+				Name:        gtmTestDomain,
+				Properties:  []gtm.Property{{Name: "prop1"}},
+				Datacenters: []gtm.Datacenter{{DatacenterID: 3131}, {DatacenterID: 3132}},
+			}, nil).Once()
+		client.On("UpdateDomain", mock.Anything, gtm.UpdateDomainRequest{
+			Domain: &gtm.Domain{
+				Name:        gtmTestDomain,
+				Properties:  []gtm.Property{{Name: "prop1", Type: "failover"}, {Name: "prop2"}},
+				Datacenters: []gtm.Datacenter{{DatacenterID: 3132}},
+			},
+		}).Return(&updateDomainResponseStatus, nil).Once()
+		client.On("GetDomainStatus", mock.Anything, gtm.GetDomainStatusRequest{DomainName: gtmTestDomain}).
+			Return(getDomainStatusResponseStatus, nil).Once()
+
+		useClient(client, func() {
+			useBatchWindow(50*time.Millisecond, func() {
+				changes := []domainChange{
+					upsertChange(domainProperties, propertyKey, gtm.Property{Name: "prop1", Type: "failover"}),
+					upsertChange(domainProperties, propertyKey, gtm.Property{Name: "prop2"}),
+					removeChange(domainDatacenters, datacenterKey, "3131"),
+				}
+				var wg sync.WaitGroup
+				errs := make([]error, len(changes))
+				for i, change := range changes {
+					wg.Add(1)
+					go func(i int, change domainChange) {
+						defer wg.Done()
+						errs[i] = batcher.submit(context.Background(), gtmTestDomain, i == 0, change, m)
+					}(i, change)
+				}
+				wg.Wait()
+				for _, err := range errs {
+					assert.NoError(t, err)
+				}
+			})
+		})
+
+		client.AssertExpectations(t)
+	})
+
+	t.Run("failed domain update is reported to all batched changes", func(t *testing.T) {
+		client := &gtm.Mock{}
+		client.On("GetDomain", mock.Anything, gtm.GetDomainRequest{DomainName: gtmTestDomain}).
+			Return(&gtm.GetDomainResponse{Name: gtmTestDomain}, nil).Once()
+		client.On("UpdateDomain", mock.Anything, mock.AnythingOfType("gtm.UpdateDomainRequest")).
+			Return(nil, errors.New("oops")).Once()
+
+		useClient(client, func() {
+			useBatchWindow(50*time.Millisecond, func() {
+				var wg sync.WaitGroup
+				errs := make([]error, 2)
+				for i := range errs {
+					wg.Add(1)
+					go func(i int) {
+						defer wg.Done()
+						errs[i] = batcher.submit(context.Background(), gtmTestDomain, false, removeChange(domainProperties, propertyKey, "prop1"), m)
+					}(i)
+				}
+				wg.Wait()
+				for _, err := range errs {
+					assert.ErrorIs(t, err, ErrBatchedUpdate)
+					assert.ErrorContains(t, err, "oops")
+				}
+			})
+		})
+
+		client.AssertExpectations(t)
+	})
+
+	t.Run("cancelled operation withdraws its change from the batch", func(t *testing.T) {
+		client := &gtm.Mock{}
+		client.On("GetDomain", mock.Anything, gtm.GetDomainRequest{DomainName: gtmTestDomain}).
+			Return(&gtm.GetDomainResponse{Name: gtmTestDomain, Properties: []gtm.Property{{Name: "prop1"}}}, nil).Once()
+		client.On("UpdateDomain", mock.Anything, gtm.UpdateDomainRequest{
+			Domain: &gtm.Domain{
+				Name:       gtmTestDomain,
+				Properties: []gtm.Property{{Name: "prop1"}, {Name: "prop2"}},
+			},
+		}).Return(&updateDomainResponseStatus, nil).Once()
+
+		useClient(client, func() {
+			useBatchWindow(50*time.Millisecond, func() {
+				ctx, cancel := context.WithCancel(context.Background())
+				var wg sync.WaitGroup
+				var kept error
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					kept = batcher.submit(context.Background(), gtmTestDomain, false, upsertChange(domainProperties, propertyKey, gtm.Property{Name: "prop2"}), m)
+				}()
+				time.AfterFunc(10*time.Millisecond, cancel)
+				err := batcher.submit(ctx, gtmTestDomain, false, removeChange(domainProperties, propertyKey, "prop1"), m)
+				assert.ErrorIs(t, err, ErrBatchedUpdate)
+				assert.ErrorContains(t, err, "context canceled")
+				wg.Wait()
+				assert.NoError(t, kept)
+			})
+		})
+
+		client.AssertExpectations(t)
+	})
+
+	t.Run("batch without changes is not submitted", func(t *testing.T) {
+		client := &gtm.Mock{}
+
+		useClient(client, func() {
+			useBatchWindow(10*time.Millisecond, func() {
+				ctx, cancel := context.WithCancel(context.Background())
+				cancel()
+				err := batcher.submit(ctx, gtmTestDomain, false, removeChange(domainProperties, propertyKey, "prop1"), m)
+				assert.ErrorIs(t, err, ErrBatchedUpdate)
+				batcher.mu.Lock()
+				assert.Empty(t, batcher.pending)
+				batcher.mu.Unlock()
+				// let the stopped window pass, nothing is submitted
+				time.Sleep(20 * time.Millisecond)
+			})
+		})
+
+		client.AssertExpectations(t)
+	})
+
+	t.Run("cancelled operation waits for the outcome of the batch being submitted", func(t *testing.T) {
+		client := &gtm.Mock{}
+		client.On("GetDomain", mock.Anything, gtm.GetDomainRequest{DomainName: gtmTestDomain}).
+			Return(&gtm.GetDomainResponse{Name: gtmTestDomain, Properties: []gtm.Property{{Name: "prop1"}}}, nil).
+			After(50 * time.Millisecond).Once()
+		client.On("UpdateDomain", mock.Anything, mock.AnythingOfType("gtm.UpdateDomainRequest")).
+			Return(nil, errors.New("oops")).Once()
+
+		useClient(client, func() {
+			useBatchWindow(10*time.Millisecond, func() {
+				ctx, cancel := context.WithTimeout(context.Background(), 30*time.Millisecond)
+				defer cancel()
+				err := batcher.submit(ctx, gtmTestDomain, false, removeChange(domainProperties, propertyKey, "prop1"), m)
+				assert.ErrorIs(t, err, ErrBatchedUpdate)
+				assert.ErrorContains(t, err, "oops")
+			})
+		})
+
+		client.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,161 @@
+package gtm
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"golang.org/x/exp/slices"
+)
+
+var (
+	// livenessTestProtocols lists test object protocols supported by GTM liveness tests
+	livenessTestProtocols = []string{"DNS", "FTP", "HTTP", "HTTPS", "HTTP3", "POP", "POPS", "SMTP", "SMTPS", "SNMP", "SNMPV3", "SIP", "SIPS", "TCP", "TCPS"}
+
+	// secureLivenessTestProtocols lists test object protocols using TLS
+	secureLivenessTestProtocols = []string{"HTTPS", "HTTP3", "POPS", "SMTPS", "SIPS", "TCPS"}
+
+	// httpLivenessTestProtocols lists test object protocols for which test_object is a URL path
+	httpLivenessTestProtocols = []string{"HTTP", "HTTPS", "HTTP3"}
+
+	livenessTestHTTPMethods = []string{"GET", "HEAD", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"}
+
+	// minLivenessTestInterval is the shortest test interval, in seconds, accepted by GTM
+	minLivenessTestInterval = 10
+
+	// httpHeaderNameRegexp matches a header field name, which is a token as defined by RFC 9110
+	httpHeaderNameRegexp = regexp.MustCompile("^[!#$%&'*+.^_`|~0-9A-Za-z-]+$")
+)
+
+// validateLivenessTest validates a single liveness test given in the `liveness_test` schema format.
+// Attributes for which known returns false are not known yet at plan time and are not validated.
+func validateLivenessTest(item map[string]interface{}, known func(key string) bool) error {
+	protocol, _ := item["test_object_protocol"].(string)
+	testObject, _ := item["test_object"].(string)
+
+	if known("test_object_protocol") && !slices.Contains(livenessTestProtocols, protocol) {
+		return fmt.Errorf("attribute 'test_object_protocol' must be one of %v, got: '%s'", livenessTestProtocols, protocol)
+	}
+	if slices.Contains([]string{"HTTP", "HTTPS", "FTP"}, protocol) && testObject == "" && known("test_object") {
+		return fmt.Errorf("attribute 'test_object' is required when 'test_object_protocol' is set to 'HTTP', 'HTTPS' or 'FTP'")
+	}
+	if testObject != "" {
+		if slices.Contains(httpLivenessTestProtocols, protocol) && !strings.HasPrefix(testObject, "/") {
+			return fmt.Errorf("attribute 'test_object' must be a path starting with '/' when 'test_object_protocol' is set to '%s', got: '%s'", protocol, testObject)
+		}
+		if strings.ContainsAny(testObject, " \t\r\n") {
+			return fmt.Errorf("attribute 'test_object' must not contain whitespace characters, got: '%s'", testObject)
+		}
+	}
+	if protocol == "DNS" && known("resource_type") {
+		if resourceType, _ := item["resource_type"].(string); resourceType == "" {
+			return fmt.Errorf("attribute 'resource_type' is required when 'test_object_protocol' is set to 'DNS'")
+		}
+	}
+
+	testInterval, _ := item["test_interval"].(int)
+	testTimeout, _ := item["test_timeout"].(float64)
+	if known("test_interval") && testInterval < minLivenessTestInterval {
+		return fmt.Errorf("attribute 'test_interval' must be at least %d seconds, got: %d", minLivenessTestInterval, testInterval)
+	}
+	if known("test_timeout") && testTimeout <= 0 {
+		return fmt.Errorf("attribute 'test_timeout' must be greater than 0, got: %v", testTimeout)
+	}
+	if known("test_interval") && known("test_timeout") && testTimeout >= float64(testInterval) {
+		return fmt.Errorf("attribute 'test_timeout' (%v) must be less than 'test_interval' (%d)", testTimeout, testInterval)
+	}
+	if port, ok := item["test_object_port"].(int); ok && known("test_object_port") && (port < 1 || port > 65535) {
+		return fmt.Errorf("attribute 'test_object_port' must be between 1 and 65535, got: %d", port)
+	}
+
+	if method, _ := item["http_method"].(string); method != "" && !slices.Contains(livenessTestHTTPMethods, method) {
+		return fmt.Errorf("attribute 'http_method' must be one of %v, got: '%s'", livenessTestHTTPMethods, method)
+	}
+	if err := validateLivenessTestHeaders(item["http_header"]); err != nil {
+		return err
+	}
+
+	username, _ := item["test_object_username"].(string)
+	password, _ := item["test_object_password"].(string)
+	if password != "" && username == "" && known("test_object_username") {
+		return fmt.Errorf("attribute 'test_object_username' is required when 'test_object_password' is set")
+	}
+
+	return validateLivenessTestCertificates(item, protocol, known)
+}
+
+// validateLivenessTestHeaders checks that the http_header blocks are valid HTTP header fields
+func validateLivenessTestHeaders(headersRaw interface{}) error {
+	headers, _ := headersRaw.([]interface{})
+	var hosts int
+	for _, headerRaw := range headers {
+		header, ok := headerRaw.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		name, _ := header["name"].(string)
+		value, _ := header["value"].(string)
+		if !httpHeaderNameRegexp.MatchString(name) {
+			return fmt.Errorf("'http_header' name must be a valid HTTP header field name, got: '%s'", name)
+		}
+		if strings.ContainsAny(value, "\r\n\x00") {
+			return fmt.Errorf("'http_header' value of '%s' must not contain CR, LF or NUL characters", name)
+		}
+		if strings.EqualFold(name, "Host") {
+			hosts++
+		}
+	}
+	if hosts > 1 {
+		return fmt.Errorf("'http_header' 'Host' can be defined only once, as it is also used as the TLS server name")
+	}
+	return nil
+}
+
+// validateLivenessTestCertificates checks the client certificate and the alternate CA certificates
+func validateLivenessTestCertificates(item map[string]interface{}, protocol string, known func(key string) bool) error {
+	certificate, _ := item["ssl_client_certificate"].(string)
+	privateKey, _ := item["ssl_client_private_key"].(string)
+	if !known("ssl_client_certificate") || !known("ssl_client_private_key") {
+		return nil
+	}
+	if (certificate == "") != (privateKey == "") {
+		return fmt.Errorf("attributes 'ssl_client_certificate' and 'ssl_client_private_key' have to be set together")
+	}
+	if certificate != "" {
+		if !slices.Contains(secureLivenessTestProtocols, protocol) {
+			return fmt.Errorf("client certificate can be used only when 'test_object_protocol' is one of %v, got: '%s'", secureLivenessTestProtocols, protocol)
+		}
+		if _, err := tls.X509KeyPair([]byte(certificate), []byte(privateKey)); err != nil {
+			return fmt.Errorf("attributes 'ssl_client_certificate' and 'ssl_client_private_key' are not a valid key pair: %s", err)
+		}
+	}
+
+	// alternate CA certificates are used only to verify the peer certificate
+	if verify, ok := item["peer_certificate_verification"].(bool); !ok || !verify {
+		return nil
+	}
+	caCertificates, _ := item["alternate_ca_certificates"].([]interface{})
+	for i, caRaw := range caCertificates {
+		ca, _ := caRaw.(string)
+		if ca == "" {
+			continue
+		}
+		if _, err := parseCertificate(ca); err != nil {
+			return fmt.Errorf("'alternate_ca_certificates' element %d is not a valid certificate: %s", i, err)
+		}
+	}
+	return nil
+}
+
+// parseCertificate parses a single PEM encoded certificate
+func parseCertificate(certificate string) (*x509.Certificate, error) {
+	block, _ := pem.Decode([]byte(certificate))
+	if block == nil || block.Type != "CERTIFICATE" {
+		return nil, errors.New("no PEM encoded certificate found")
+	}
+	return x509.ParseCertificate(block.Bytes)
+}
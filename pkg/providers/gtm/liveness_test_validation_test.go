@@ -0,0 +1,154 @@
+package gtm
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateLivenessTest(t *testing.T) {
+	valid := func() map[string]interface{} {
+		return map[string]interface{}{
+			"name":                          "lt1",
+			"test_object_protocol":          "HTTP",
+			"test_object":                   "/health",
+			"test_interval":                 30,
+			"test_timeout":                  float64(10),
+			"test_object_port":              80,
+			"peer_certificate_verification": true,
+			"http_header": []interface{}{
+				map[string]interface{}{"name": "Host", "value": "www.example.com"},
+			},
+		}
+	}
+	known := func(string) bool { return true }
+
+	tests := map[string]struct {
+		modify      func(map[string]interface{})
+		known       func(string) bool
+		expectedErr string
+	}{
+		"valid HTTP test": {
+			modify: func(map[string]interface{}) {},
+		},
+		"unsupported protocol": {
+			modify:      func(lt map[string]interface{}) { lt["test_object_protocol"] = "GOPHER" },
+			expectedErr: "attribute 'test_object_protocol' must be one of",
+		},
+		"missing test object": {
+			modify:      func(lt map[string]interface{}) { lt["test_object"] = "" },
+			expectedErr: "attribute 'test_object' is required when 'test_object_protocol' is set to 'HTTP', 'HTTPS' or 'FTP'",
+		},
+		"unknown test object is not validated": {
+			modify: func(lt map[string]interface{}) { lt["test_object"] = "" },
+			known:  func(key string) bool { return key != "test_object" },
+		},
+		"relative HTTP test object": {
+			modify:      func(lt map[string]interface{}) { lt["test_object"] = "health" },
+			expectedErr: "attribute 'test_object' must be a path starting with '/'",
+		},
+		"test object with whitespace": {
+			modify:      func(lt map[string]interface{}) { lt["test_object"] = "/health check" },
+			expectedErr: "attribute 'test_object' must not contain whitespace characters",
+		},
+		"DNS test without resource type": {
+			modify: func(lt map[string]interface{}) {
+				lt["test_object_protocol"] = "DNS"
+				lt["test_object"] = "www.example.com"
+				lt["resource_type"] = ""
+			},
+			expectedErr: "attribute 'resource_type' is required when 'test_object_protocol' is set to 'DNS'",
+		},
+		"test interval too short": {
+			modify:      func(lt map[string]interface{}) { lt["test_interval"] = 5 },
+			expectedErr: "attribute 'test_interval' must be at least 10 seconds",
+		},
+		"test timeout not positive": {
+			modify:      func(lt map[string]interface{}) { lt["test_timeout"] = float64(0) },
+			expectedErr: "attribute 'test_timeout' must be greater than 0",
+		},
+		"test timeout not less than interval": {
+			modify:      func(lt map[string]interface{}) { lt["test_timeout"] = float64(30) },
+			expectedErr: "attribute 'test_timeout' (30) must be less than 'test_interval' (30)",
+		},
+		"invalid port": {
+			modify:      func(lt map[string]interface{}) { lt["test_object_port"] = 70000 },
+			expectedErr: "attribute 'test_object_port' must be between 1 and 65535",
+		},
+		"invalid HTTP method": {
+			modify:      func(lt map[string]interface{}) { lt["http_method"] = "get" },
+			expectedErr: "attribute 'http_method' must be one of",
+		},
+		"invalid header name": {
+			modify: func(lt map[string]interface{}) {
+				lt["http_header"] = []interface{}{map[string]interface{}{"name": "X Custom", "value": "a"}}
+			},
+			expectedErr: "'http_header' name must be a valid HTTP header field name, got: 'X Custom'",
+		},
+		"header value with line break": {
+			modify: func(lt map[string]interface{}) {
+				lt["http_header"] = []interface{}{map[string]interface{}{"name": "X-Custom", "value": "a\r\nInjected: b"}}
+			},
+			expectedErr: "'http_header' value of 'X-Custom' must not contain CR, LF or NUL characters",
+		},
+		"duplicated host header": {
+			modify: func(lt map[string]interface{}) {
+				lt["http_header"] = []interface{}{
+					map[string]interface{}{"name": "Host", "value": "a.example.com"},
+					map[string]interface{}{"name": "host", "value": "b.example.com"},
+				}
+			},
+			expectedErr: "'http_header' 'Host' can be defined only once",
+		},
+		"password without username": {
+			modify:      func(lt map[string]interface{}) { lt["test_object_password"] = "secret" },
+			expectedErr: "attribute 'test_object_username' is required when 'test_object_password' is set",
+		},
+		"client certificate without private key": {
+			modify:      func(lt map[string]interface{}) { lt["ssl_client_certificate"] = "cert" },
+			expectedErr: "attributes 'ssl_client_certificate' and 'ssl_client_private_key' have to be set together",
+		},
+		"client certificate with plain protocol": {
+			modify: func(lt map[string]interface{}) {
+				lt["ssl_client_certificate"] = "cert"
+				lt["ssl_client_private_key"] = "key"
+			},
+			expectedErr: "client certificate can be used only when 'test_object_protocol' is one of",
+		},
+		"invalid client key pair": {
+			modify: func(lt map[string]interface{}) {
+				lt["test_object_protocol"] = "HTTPS"
+				lt["ssl_client_certificate"] = "cert"
+				lt["ssl_client_private_key"] = "key"
+			},
+			expectedErr: "attributes 'ssl_client_certificate' and 'ssl_client_private_key' are not a valid key pair",
+		},
+		"invalid alternate CA certificate": {
+			modify:      func(lt map[string]interface{}) { lt["alternate_ca_certificates"] = []interface{}{"test1"} },
+			expectedErr: "'alternate_ca_certificates' element 0 is not a valid certificate",
+		},
+		"alternate CA certificate is not validated without peer verification": {
+			modify: func(lt map[string]interface{}) {
+				lt["alternate_ca_certificates"] = []interface{}{"test1"}
+				lt["peer_certificate_verification"] = false
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			lt := valid()
+			test.modify(lt)
+			isKnown := known
+			if test.known != nil {
+				isKnown = test.known
+			}
+			err := validateLivenessTest(lt, isKnown)
+			if test.expectedErr != "" {
+				assert.ErrorContains(t, err, test.expectedErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,407 @@
+package gtm
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/csv"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/netip"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/common/tf"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+type (
+	// mapAssignment is a datacenter assignment of a geo, AS or CIDR map in a provider independent form
+	mapAssignment struct {
+		datacenterID int
+		nickname     string
+		values       []string
+	}
+
+	// mapAssignmentsKind describes the values assigned to datacenters by one of the map types
+	mapAssignmentsKind struct {
+		// valuesKey is the name of the assignment attribute, CSV column and JSON field holding the values
+		valuesKey string
+		// normalize validates a single value and returns its canonical form
+		normalize func(string) (string, error)
+		// validate checks the values across all assignments
+		validate func([]mapAssignment) error
+	}
+)
+
+var (
+	geoAssignmentsKind = mapAssignmentsKind{
+		valuesKey: "countries",
+		normalize: normalizeCountryCode,
+		validate:  validateUniqueValues("country"),
+	}
+
+	asAssignmentsKind = mapAssignmentsKind{
+		valuesKey: "as_numbers",
+		normalize: normalizeASNumber,
+		validate:  validateUniqueValues("AS number"),
+	}
+
+	cidrAssignmentsKind = mapAssignmentsKind{
+		valuesKey: "blocks",
+		normalize: normalizeCIDRBlock,
+		validate:  validateCIDRBlocksOverlap,
+	}
+
+	// ErrAssignmentsFile is returned when the assignments file cannot be loaded
+	ErrAssignmentsFile = errors.New("invalid assignments file")
+
+	// isoCountryCodes holds ISO 3166-1 alpha-2 country codes
+	isoCountryCodes = map[string]struct{}{}
+)
+
+func init() {
+	for _, code := range strings.Fields(`
+		AD AE AF AG AI AL AM AO AQ AR AS AT AU AW AX AZ BA BB BD BE BF BG BH BI BJ BL BM BN BO BQ BR BS BT BV BW BY BZ
+		CA CC CD CF CG CH CI CK CL CM CN CO CR CU CV CW CX CY CZ DE DJ DK DM DO DZ EC EE EG EH ER ES ET FI FJ FK FM FO FR
+		GA GB GD GE GF GG GH GI GL GM GN GP GQ GR GS GT GU GW GY HK HM HN HR HT HU ID IE IL IM IN IO IQ IR IS IT JE JM JO
+		JP KE KG KH KI KM KN KP KR KW KY KZ LA LB LC LI LK LR LS LT LU LV LY MA MC MD ME MF MG MH MK ML MM MN MO MP MQ MR
+		MS MT MU MV MW MX MY MZ NA NC NE NF NG NI NL NO NP NR NU NZ OM PA PE PF PG PH PK PL PM PN PR PS PT PW PY QA RE RO
+		RS RU RW SA SB SC SD SE SG SH SI SJ SK SL SM SN SO SR SS ST SV SX SY SZ TC TD TF TG TH TJ TK TL TM TN TO TR TT TV
+		TW TZ UA UG UM US UY UZ VA VC VE VG VI VN VU WF WS YE YT ZA ZM ZW`) {
+		isoCountryCodes[code] = struct{}{}
+	}
+}
+
+// assignmentsFileSchema returns the `assignments_file` attribute shared by GTM map resources
+func assignmentsFileSchema(kind mapAssignmentsKind) *schema.Schema {
+	return &schema.Schema{
+		Type:          schema.TypeString,
+		Optional:      true,
+		ConflictsWith: []string{"assignment"},
+		Description: fmt.Sprintf("Path to a CSV or JSON file with the assignments, used instead of the `assignment` blocks. "+
+			"CSV files need a header with the `datacenter_id`, `nickname` and `%[1]s` columns, rows of the same datacenter are merged. "+
+			"JSON files hold a list of objects with the `datacenter_id`, `nickname` and `%[1]s` fields", kind.valuesKey),
+	}
+}
+
+// assignmentsHashSchema returns the `assignments_hash` attribute, which tracks the assignments loaded from the file
+func assignmentsHashSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeString,
+		Computed:    true,
+		Description: "Hash of the normalized assignments, used to detect changes when `assignments_file` is set",
+	}
+}
+
+// customDiffAssignmentsFile loads and validates the assignments file at plan time and marks
+// the assignments as changed when they differ from the ones in the map
+func customDiffAssignmentsFile(kind mapAssignmentsKind) schema.CustomizeDiffFunc {
+	return func(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+		if !d.NewValueKnown("assignments_file") {
+			return d.SetNewComputed("assignments_hash")
+		}
+		path, ok := d.Get("assignments_file").(string)
+		if !ok || path == "" {
+			return nil
+		}
+		assignments, err := loadMapAssignments(path, kind)
+		if err != nil {
+			return err
+		}
+		if hash := hashMapAssignments(assignments); hash != d.Get("assignments_hash").(string) {
+			return d.SetNew("assignments_hash", hash)
+		}
+		return nil
+	}
+}
+
+// assignmentsFromFile returns the assignments loaded from `assignments_file`,
+// or false if the resource does not use an assignments file
+func assignmentsFromFile(d *schema.ResourceData, kind mapAssignmentsKind) ([]mapAssignment, bool, error) {
+	path, err := tf.GetStringValue("assignments_file", d)
+	if errors.Is(err, tf.ErrNotFound) || path == "" {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	assignments, err := loadMapAssignments(path, kind)
+	if err != nil {
+		return nil, false, err
+	}
+	return assignments, true, nil
+}
+
+// setAssignmentsHash stores the hash of the map assignments instead of the `assignment` blocks
+func setAssignmentsHash(d *schema.ResourceData, assignments []mapAssignment, kind mapAssignmentsKind) error {
+	normalized := make([]mapAssignment, 0, len(assignments))
+	for _, a := range assignments {
+		values := make([]string, 0, len(a.values))
+		for _, v := range a.values {
+			// values which the file loader would reject are kept as they are, so they are reported as a difference
+			if n, err := kind.normalize(v); err == nil {
+				v = n
+			}
+			values = append(values, v)
+		}
+		normalized = append(normalized, mapAssignment{datacenterID: a.datacenterID, nickname: a.nickname, values: values})
+	}
+	if err := d.Set("assignment", []interface{}{}); err != nil {
+		return err
+	}
+	return d.Set("assignments_hash", hashMapAssignments(normalized))
+}
+
+// loadMapAssignments reads the assignments from a CSV or JSON file, normalizes and validates them
+func loadMapAssignments(path string, kind mapAssignmentsKind) ([]mapAssignment, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrAssignmentsFile, err)
+	}
+
+	var rows []mapAssignment
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".csv":
+		rows, err = parseCSVAssignments(content, kind.valuesKey)
+	case ".json":
+		rows, err = parseJSONAssignments(content, kind.valuesKey)
+	default:
+		err = fmt.Errorf("unsupported file extension '%s', expected '.csv' or '.json'", filepath.Ext(path))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w '%s': %s", ErrAssignmentsFile, path, err)
+	}
+
+	assignments, err := mergeMapAssignments(rows, kind)
+	if err != nil {
+		return nil, fmt.Errorf("%w '%s': %s", ErrAssignmentsFile, path, err)
+	}
+	if err = kind.validate(assignments); err != nil {
+		return nil, fmt.Errorf("%w '%s': %s", ErrAssignmentsFile, path, err)
+	}
+	return assignments, nil
+}
+
+// parseCSVAssignments parses CSV rows. A values cell can hold several values separated by spaces, ';' or '|'
+func parseCSVAssignments(content []byte, valuesKey string) ([]mapAssignment, error) {
+	reader := csv.NewReader(bytes.NewReader(content))
+	reader.TrimLeadingSpace = true
+	reader.Comment = '#'
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("reading header: %s", err)
+	}
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))] = i
+	}
+	for _, name := range []string{"datacenter_id", "nickname", valuesKey} {
+		if _, ok := columns[name]; !ok {
+			return nil, fmt.Errorf("missing column '%s' in header", name)
+		}
+	}
+
+	var rows []mapAssignment
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		line, _ := reader.FieldPos(0)
+		datacenterID, err := strconv.Atoi(strings.TrimSpace(record[columns["datacenter_id"]]))
+		if err != nil {
+			return nil, fmt.Errorf("line %d: invalid datacenter_id: %s", line, err)
+		}
+		rows = append(rows, mapAssignment{
+			datacenterID: datacenterID,
+			nickname:     strings.TrimSpace(record[columns["nickname"]]),
+			values: strings.FieldsFunc(record[columns[valuesKey]], func(r rune) bool {
+				return r == ' ' || r == ';' || r == '|' || r == '\t' || r == '\n' || r == '\r'
+			}),
+		})
+	}
+	return rows, nil
+}
+
+// parseJSONAssignments parses a JSON list of assignment objects
+func parseJSONAssignments(content []byte, valuesKey string) ([]mapAssignment, error) {
+	var items []map[string]json.RawMessage
+	if err := json.Unmarshal(content, &items); err != nil {
+		return nil, err
+	}
+
+	rows := make([]mapAssignment, 0, len(items))
+	for i, item := range items {
+		var row mapAssignment
+		if err := json.Unmarshal(item["datacenter_id"], &row.datacenterID); err != nil {
+			return nil, fmt.Errorf("item %d: invalid datacenter_id: %s", i, err)
+		}
+		if raw, ok := item["nickname"]; ok {
+			if err := json.Unmarshal(raw, &row.nickname); err != nil {
+				return nil, fmt.Errorf("item %d: invalid nickname: %s", i, err)
+			}
+		}
+		decoder := json.NewDecoder(bytes.NewReader(item[valuesKey]))
+		decoder.UseNumber()
+		var rawValues []interface{}
+		if err := decoder.Decode(&rawValues); err != nil {
+			return nil, fmt.Errorf("item %d: invalid %s: %s", i, valuesKey, err)
+		}
+		for _, v := range rawValues {
+			switch value := v.(type) {
+			case string:
+				row.values = append(row.values, value)
+			case json.Number:
+				row.values = append(row.values, value.String())
+			default:
+				return nil, fmt.Errorf("item %d: invalid %s value of type %T", i, valuesKey, v)
+			}
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+// mergeMapAssignments normalizes the values and merges rows of the same datacenter into one assignment
+func mergeMapAssignments(rows []mapAssignment, kind mapAssignmentsKind) ([]mapAssignment, error) {
+	byDatacenter := make(map[int]*mapAssignment)
+	seen := make(map[int]map[string]struct{})
+	var order []int
+	for _, row := range rows {
+		a, ok := byDatacenter[row.datacenterID]
+		if !ok {
+			a = &mapAssignment{datacenterID: row.datacenterID, nickname: row.nickname}
+			byDatacenter[row.datacenterID] = a
+			seen[row.datacenterID] = make(map[string]struct{})
+			order = append(order, row.datacenterID)
+		}
+		if row.nickname != "" && a.nickname != "" && row.nickname != a.nickname {
+			return nil, fmt.Errorf("datacenter %d has conflicting nicknames '%s' and '%s'", row.datacenterID, a.nickname, row.nickname)
+		}
+		if a.nickname == "" {
+			a.nickname = row.nickname
+		}
+		for _, v := range row.values {
+			normalized, err := kind.normalize(strings.TrimSpace(v))
+			if err != nil {
+				return nil, fmt.Errorf("datacenter %d: %s", row.datacenterID, err)
+			}
+			if _, ok := seen[row.datacenterID][normalized]; ok {
+				continue
+			}
+			seen[row.datacenterID][normalized] = struct{}{}
+			a.values = append(a.values, normalized)
+		}
+	}
+
+	assignments := make([]mapAssignment, 0, len(order))
+	for _, datacenterID := range order {
+		a := byDatacenter[datacenterID]
+		if a.nickname == "" {
+			return nil, fmt.Errorf("datacenter %d has no nickname", datacenterID)
+		}
+		assignments = append(assignments, *a)
+	}
+	return assignments, nil
+}
+
+// hashMapAssignments returns a hash of the assignments which does not depend on their order
+func hashMapAssignments(assignments []mapAssignment) string {
+	sorted := make([]mapAssignment, len(assignments))
+	copy(sorted, assignments)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].datacenterID < sorted[j].datacenterID
+	})
+
+	h := sha256.New()
+	for _, a := range sorted {
+		values := make([]string, len(a.values))
+		copy(values, a.values)
+		sort.Strings(values)
+		_, _ = fmt.Fprintf(h, "%d\x00%s\x00%s\n", a.datacenterID, a.nickname, strings.Join(values, "\x00"))
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func normalizeCountryCode(value string) (string, error) {
+	code := strings.ToUpper(value)
+	if _, ok := isoCountryCodes[code]; !ok {
+		return "", fmt.Errorf("unknown country code '%s'", value)
+	}
+	return code, nil
+}
+
+func normalizeASNumber(value string) (string, error) {
+	asNumber, err := strconv.ParseUint(strings.TrimPrefix(strings.ToUpper(value), "AS"), 10, 32)
+	if err != nil || asNumber == 0 {
+		return "", fmt.Errorf("invalid AS number '%s'", value)
+	}
+	return strconv.FormatUint(asNumber, 10), nil
+}
+
+func normalizeCIDRBlock(value string) (string, error) {
+	prefix, err := netip.ParsePrefix(value)
+	if err != nil {
+		return "", fmt.Errorf("invalid CIDR block '%s'", value)
+	}
+	return prefix.Masked().String(), nil
+}
+
+// validateUniqueValues returns a validation func which rejects values assigned to more than one datacenter
+func validateUniqueValues(name string) func([]mapAssignment) error {
+	return func(assignments []mapAssignment) error {
+		owners := make(map[string]int)
+		for _, a := range assignments {
+			for _, v := range a.values {
+				if owner, ok := owners[v]; ok {
+					return fmt.Errorf("%s %s is assigned to datacenters %d and %d", name, v, owner, a.datacenterID)
+				}
+				owners[v] = a.datacenterID
+			}
+		}
+		return nil
+	}
+}
+
+// validateCIDRBlocksOverlap rejects CIDR blocks which overlap with other blocks of any assignment
+func validateCIDRBlocksOverlap(assignments []mapAssignment) error {
+	type block struct {
+		prefix       netip.Prefix
+		datacenterID int
+	}
+	var blocks []block
+	for _, a := range assignments {
+		for _, v := range a.values {
+			blocks = append(blocks, block{prefix: netip.MustParsePrefix(v), datacenterID: a.datacenterID})
+		}
+	}
+	sort.Slice(blocks, func(i, j int) bool {
+		if c := blocks[i].prefix.Addr().Compare(blocks[j].prefix.Addr()); c != 0 {
+			return c < 0
+		}
+		return blocks[i].prefix.Bits() < blocks[j].prefix.Bits()
+	})
+
+	// blocks either nest or are disjoint, so after sorting it is enough to check
+	// each block against the last block not contained in another one
+	var outer *block
+	for i := range blocks {
+		current := &blocks[i]
+		if outer != nil && outer.prefix.Contains(current.prefix.Addr()) {
+			return fmt.Errorf("CIDR block %s of datacenter %d overlaps with block %s of datacenter %d",
+				current.prefix, current.datacenterID, outer.prefix, outer.datacenterID)
+		}
+		outer = current
+	}
+	return nil
+}
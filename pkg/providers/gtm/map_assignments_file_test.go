@@ -0,0 +1,136 @@
+package gtm
+
+import (
+	"testing"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v9/pkg/gtm"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadMapAssignments(t *testing.T) {
+	tests := map[string]struct {
+		file        string
+		kind        mapAssignmentsKind
+		expected    []mapAssignment
+		expectedErr string
+	}{
+		"CSV rows of one datacenter are merged and blocks normalized": {
+			file: "cidr_blocks.csv",
+			kind: cidrAssignmentsKind,
+			expected: []mapAssignment{
+				{datacenterID: 3131, nickname: "dc1", values: []string{"10.0.0.0/24", "10.0.1.0/24", "10.0.2.0/24"}},
+				{datacenterID: 3132, nickname: "dc2", values: []string{"192.168.1.0/24", "2001:db8::/48"}},
+			},
+		},
+		"overlapping CIDR blocks": {
+			file:        "cidr_blocks_overlap.csv",
+			kind:        cidrAssignmentsKind,
+			expectedErr: "CIDR block 10.0.5.0/24 of datacenter 3132 overlaps with block 10.0.0.0/16 of datacenter 3131",
+		},
+		"invalid CIDR block": {
+			file:        "cidr_blocks_invalid.csv",
+			kind:        cidrAssignmentsKind,
+			expectedErr: "datacenter 3131: invalid CIDR block '10.0.0.0/33'",
+		},
+		"conflicting nicknames": {
+			file:        "nickname_conflict.csv",
+			kind:        cidrAssignmentsKind,
+			expectedErr: "datacenter 3131 has conflicting nicknames 'dc1' and 'dc2'",
+		},
+		"missing CSV column": {
+			file:        "missing_column.csv",
+			kind:        cidrAssignmentsKind,
+			expectedErr: "missing column 'nickname' in header",
+		},
+		"JSON AS numbers": {
+			file: "as_numbers.json",
+			kind: asAssignmentsKind,
+			expected: []mapAssignment{
+				{datacenterID: 3131, nickname: "dc1", values: []string{"12222", "16702", "17334"}},
+				{datacenterID: 3132, nickname: "dc2", values: []string{"1111"}},
+			},
+		},
+		"duplicate AS numbers": {
+			file:        "as_numbers_duplicate.json",
+			kind:        asAssignmentsKind,
+			expectedErr: "AS number 16702 is assigned to datacenters 3131 and 3132",
+		},
+		"JSON countries": {
+			file: "countries.json",
+			kind: geoAssignmentsKind,
+			expected: []mapAssignment{
+				{datacenterID: 3131, nickname: "dc1", values: []string{"GB", "PL"}},
+				{datacenterID: 3132, nickname: "dc2", values: []string{"US"}},
+			},
+		},
+		"unknown country code": {
+			file:        "countries_unknown.csv",
+			kind:        geoAssignmentsKind,
+			expectedErr: "unknown country code 'XX'",
+		},
+		"unsupported file type": {
+			file:        "countries.yaml",
+			kind:        geoAssignmentsKind,
+			expectedErr: "unsupported file extension '.yaml'",
+		},
+		"missing file": {
+			file:        "missing.csv",
+			kind:        geoAssignmentsKind,
+			expectedErr: "invalid assignments file",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assignments, err := loadMapAssignments("testdata/TestMapAssignmentsFile/"+test.file, test.kind)
+			if test.expectedErr != "" {
+				assert.ErrorIs(t, err, ErrAssignmentsFile)
+				assert.ErrorContains(t, err, test.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, assignments)
+		})
+	}
+}
+
+func TestHashMapAssignments(t *testing.T) {
+	hash := hashMapAssignments([]mapAssignment{
+		{datacenterID: 3131, nickname: "dc1", values: []string{"GB", "PL"}},
+		{datacenterID: 3132, nickname: "dc2", values: []string{"US"}},
+	})
+	assert.Equal(t, hash, hashMapAssignments([]mapAssignment{
+		{datacenterID: 3132, nickname: "dc2", values: []string{"US"}},
+		{datacenterID: 3131, nickname: "dc1", values: []string{"PL", "GB"}},
+	}), "order of assignments and values is ignored")
+	assert.NotEqual(t, hash, hashMapAssignments([]mapAssignment{
+		{datacenterID: 3131, nickname: "dc1", values: []string{"GB"}},
+		{datacenterID: 3132, nickname: "dc2", values: []string{"US", "PL"}},
+	}))
+}
+
+func TestCIDRAssignmentsFromFile(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, resourceGTMv1CIDRMap().Schema, map[string]interface{}{
+		"domain":           gtmTestDomain,
+		"name":             "tfexample_cidrmap_1",
+		"assignments_file": "testdata/TestMapAssignmentsFile/cidr_blocks.csv",
+	})
+	cidr := &gtm.CIDRMap{}
+	require.NoError(t, populateCIDRAssignmentsFromFile(d, cidr))
+	assert.Equal(t, []gtm.CIDRAssignment{
+		{DatacenterBase: gtm.DatacenterBase{DatacenterID: 3131, Nickname: "dc1"}, Blocks: []string{"10.0.0.0/24", "10.0.1.0/24", "10.0.2.0/24"}},
+		{DatacenterBase: gtm.DatacenterBase{DatacenterID: 3132, Nickname: "dc2"}, Blocks: []string{"192.168.1.0/24", "2001:db8::/48"}},
+	}, cidr.Assignments)
+
+	// the state keeps the hash of the assignments instead of the blocks
+	require.NoError(t, setAssignmentsHash(d, []mapAssignment{
+		{datacenterID: 3132, nickname: "dc2", values: []string{"2001:db8::/48", "192.168.1.7/24"}},
+		{datacenterID: 3131, nickname: "dc1", values: []string{"10.0.0.0/24", "10.0.1.0/24", "10.0.2.0/24"}},
+	}, cidrAssignmentsKind))
+	assert.Empty(t, d.Get("assignment"))
+	assignments, err := loadMapAssignments("testdata/TestMapAssignmentsFile/cidr_blocks.csv", cidrAssignmentsKind)
+	require.NoError(t, err)
+	assert.Equal(t, hashMapAssignments(assignments), d.Get("assignments_hash"))
+}
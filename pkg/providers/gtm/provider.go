@@ -55,21 +55,23 @@ func (p *Subprovider) FrameworkDataSources() []func() datasource.DataSource {
 // SDKResources returns the gtm resources implemented using terraform-plugin-sdk
 func (p *Subprovider) SDKResources() map[string]*schema.Resource {
 	return map[string]*schema.Resource{
-		"akamai_gtm_domain":     resourceGTMv1Domain(),
-		"akamai_gtm_property":   resourceGTMv1Property(),
-		"akamai_gtm_datacenter": resourceGTMv1Datacenter(),
-		"akamai_gtm_resource":   resourceGTMv1Resource(),
-		"akamai_gtm_asmap":      resourceGTMv1ASMap(),
-		"akamai_gtm_geomap":     resourceGTMv1GeoMap(),
-		"akamai_gtm_cidrmap":    resourceGTMv1CIDRMap(),
+		"akamai_gtm_domain":        resourceGTMv1Domain(),
+		"akamai_gtm_property":      resourceGTMv1Property(),
+		"akamai_gtm_datacenter":    resourceGTMv1Datacenter(),
+		"akamai_gtm_resource":      resourceGTMv1Resource(),
+		"akamai_gtm_asmap":         resourceGTMv1ASMap(),
+		"akamai_gtm_geomap":        resourceGTMv1GeoMap(),
+		"akamai_gtm_cidrmap":       resourceGTMv1CIDRMap(),
+		"akamai_gtm_traffic_shift": resourceGTMv1TrafficShift(),
 	}
 }
 
 // SDKDataSources returns the gtm data sources implemented using terraform-plugin-sdk
 func (p *Subprovider) SDKDataSources() map[string]*schema.Resource {
 	return map[string]*schema.Resource{
-		"akamai_gtm_datacenter":         dataSourceGTMDatacenter(),
-		"akamai_gtm_datacenters":        dataSourceGTMDatacenters(),
-		"akamai_gtm_default_datacenter": dataSourceGTMDefaultDatacenter(),
+		"akamai_gtm_datacenter":          dataSourceGTMDatacenter(),
+		"akamai_gtm_datacenters":         dataSourceGTMDatacenters(),
+		"akamai_gtm_default_datacenter":  dataSourceGTMDefaultDatacenter(),
+		"akamai_gtm_liveness_test_check": dataSourceGTMLivenessTestCheck(),
 	}
 }
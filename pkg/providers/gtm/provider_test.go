@@ -3,9 +3,14 @@ package gtm
 import (
 	"sync"
 	"testing"
+	"time"
 
 	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v9/pkg/gtm"
 	"github.com/akamai/terraform-provider-akamai/v6/pkg/common/testutils"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestMain(m *testing.M) {
@@ -28,3 +33,44 @@ func useClient(gtmClient gtm.GTM, f func()) {
 
 	f()
 }
+
+// usePropagationPollInterval overrides the interval between domain status checks for the duration of f
+func usePropagationPollInterval(interval time.Duration, f func()) {
+	orig := propagationPollInterval
+	propagationPollInterval = interval
+
+	defer func() {
+		propagationPollInterval = orig
+	}()
+
+	f()
+}
+
+// useBatchWindow overrides the time batched domain updates wait for further changes for the duration of f
+func useBatchWindow(window time.Duration, f func()) {
+	orig := batchWindow
+	batchWindow = window
+
+	defer func() {
+		batchWindow = orig
+	}()
+
+	f()
+}
+
+func TestResourceTimeouts(t *testing.T) {
+	config := terraform.NewResourceConfigRaw(map[string]interface{}{
+		"timeouts": []interface{}{
+			map[string]interface{}{"default": "10m", "create": "15m", "update": "20m", "delete": "25m"},
+		},
+	})
+	for name, res := range NewSubprovider().SDKResources() {
+		t.Run(name, func(t *testing.T) {
+			timeouts := &schema.ResourceTimeout{}
+			require.NoError(t, timeouts.ConfigDecode(res, config))
+			assert.Equal(t, 15*time.Minute, *timeouts.Create)
+			assert.Equal(t, 20*time.Minute, *timeouts.Update)
+			assert.Equal(t, 25*time.Minute, *timeouts.Delete)
+		})
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"net/http"
 	"sort"
+	"strconv"
 
 	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v9/pkg/gtm"
 	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v9/pkg/session"
@@ -21,9 +22,11 @@ func resourceGTMv1ASMap() *schema.Resource {
 		ReadContext:   resourceGTMv1ASMapRead,
 		UpdateContext: resourceGTMv1ASMapUpdate,
 		DeleteContext: resourceGTMv1ASMapDelete,
+		CustomizeDiff: customDiffAssignmentsFile(asAssignmentsKind),
 		Importer: &schema.ResourceImporter{
 			StateContext: resourceGTMv1ASMapImport,
 		},
+		Timeouts: resourceTimeouts(),
 		Schema: map[string]*schema.Schema{
 			"domain": {
 				Type:     schema.TypeString,
@@ -34,6 +37,8 @@ func resourceGTMv1ASMap() *schema.Resource {
 				Optional: true,
 				Default:  true,
 			},
+			"timeouts":     timeoutsSchema(),
+			"batch_update": batchUpdateSchema(),
 			"name": {
 				Type:     schema.TypeString,
 				Required: true,
@@ -78,6 +83,8 @@ func resourceGTMv1ASMap() *schema.Resource {
 					},
 				},
 			},
+			"assignments_file": assignmentsFileSchema(asAssignmentsKind),
+			"assignments_hash": assignmentsHashSchema(),
 		},
 	}
 }
@@ -172,7 +179,29 @@ func resourceGTMv1ASMapCreate(ctx context.Context, d *schema.ResourceData, m int
 			Detail:   err.Error(),
 		})
 	}
+	if err = populateASAssignmentsFromFile(d, newAS); err != nil {
+		logger.Errorf("asMap Create failed: %s", err.Error())
+		return append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "asMap Create failed",
+			Detail:   err.Error(),
+		})
+	}
 	logger.Debugf("Proposed New asMap: [%v]", newAS)
+
+	batchUpdate, err := tf.GetBoolValue("batch_update", d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if batchUpdate {
+		diags = append(diags, submitBatchedChange(ctx, d, domain, "asMap Create", m, upsertChange(domainASMaps, asMapKey, *newAS))...)
+		if diags.HasError() {
+			return diags
+		}
+		d.SetId(fmt.Sprintf("%s:%s", domain, newAS.Name))
+		return append(diags, resourceGTMv1ASMapRead(ctx, d, m)...)
+	}
+
 	cStatus, err := Client(meta).CreateASMap(ctx, gtm.CreateASMapRequest{
 		ASMap:      newAS,
 		DomainName: domain,
@@ -191,33 +220,16 @@ func resourceGTMv1ASMapCreate(ctx context.Context, d *schema.ResourceData, m int
 			Summary:  cStatus.Status.Message,
 		})
 	}
-	waitOnComplete, err := tf.GetBoolValue("wait_on_complete", d)
-	if err != nil {
-		return diag.FromErr(err)
-	}
-	if waitOnComplete {
-		done, err := waitForCompletion(ctx, domain, m)
-		if done {
-			logger.Infof("asMap Create completed")
-		} else {
-			if err == nil {
-				logger.Infof("asMap Create pending")
-			} else {
-				logger.Errorf("asMap Create failed [%s]", err.Error())
-				return append(diags, diag.Diagnostic{
-					Severity: diag.Error,
-					Summary:  "asMap Create failed",
-					Detail:   err.Error(),
-				})
-			}
-		}
+	diags = append(diags, waitForPropagation(ctx, d, domain, "asMap Create", m)...)
+	if diags.HasError() {
+		return diags
 	}
 
 	// Give terraform the ID. Format domain:asMap
 	asMapID := fmt.Sprintf("%s:%s", domain, cStatus.Resource.Name)
 	logger.Debugf("Generated asMap Id: %s", asMapID)
 	d.SetId(asMapID)
-	return resourceGTMv1ASMapRead(ctx, d, m)
+	return append(diags, resourceGTMv1ASMapRead(ctx, d, m)...)
 
 }
 
@@ -264,6 +276,10 @@ func resourceGTMv1ASMapUpdate(ctx context.Context, d *schema.ResourceData, m int
 	)
 
 	logger.Debugf("UPDATE asMap: %s", d.Id())
+	if !d.HasChangeExcept("timeouts") {
+		logger.Debug("Only timeouts were updated, skipping")
+		return nil
+	}
 	var diags diag.Diagnostics
 	// pull domain and asMap out of id
 	domain, asMap, err := parseResourceStringID(d.Id())
@@ -287,7 +303,28 @@ func resourceGTMv1ASMapUpdate(ctx context.Context, d *schema.ResourceData, m int
 	logger.Debugf("asMap BEFORE: %v", existAs)
 	newAs := createASMapStruct(existAs)
 	populateASMapObject(d, newAs, m)
+	if err = populateASAssignmentsFromFile(d, newAs); err != nil {
+		logger.Errorf("asMap Update failed: %s", err.Error())
+		return append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "asMap Update failed",
+			Detail:   err.Error(),
+		})
+	}
 	logger.Debugf("asMap PROPOSED: %v", existAs)
+
+	batchUpdate, err := tf.GetBoolValue("batch_update", d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if batchUpdate {
+		diags = append(diags, submitBatchedChange(ctx, d, domain, "asMap Update", m, upsertChange(domainASMaps, asMapKey, *newAs))...)
+		if diags.HasError() {
+			return diags
+		}
+		return append(diags, resourceGTMv1ASMapRead(ctx, d, m)...)
+	}
+
 	uStat, err := Client(meta).UpdateASMap(ctx, gtm.UpdateASMapRequest{
 		ASMap:      newAs,
 		DomainName: domain,
@@ -308,29 +345,12 @@ func resourceGTMv1ASMapUpdate(ctx context.Context, d *schema.ResourceData, m int
 		})
 	}
 
-	waitOnComplete, err := tf.GetBoolValue("wait_on_complete", d)
-	if err != nil {
-		return diag.FromErr(err)
-	}
-	if waitOnComplete {
-		done, err := waitForCompletion(ctx, domain, m)
-		if done {
-			logger.Infof("ASmap Update completed")
-		} else {
-			if err == nil {
-				logger.Infof("ASmap Update pending")
-			} else {
-				logger.Errorf("ASmap Update failed [%s]", err.Error())
-				return append(diags, diag.Diagnostic{
-					Severity: diag.Error,
-					Summary:  "asMap Update failed",
-					Detail:   err.Error(),
-				})
-			}
-		}
+	diags = append(diags, waitForPropagation(ctx, d, domain, "asMap Update", m)...)
+	if diags.HasError() {
+		return diags
 	}
 
-	return resourceGTMv1ASMapRead(ctx, d, m)
+	return append(diags, resourceGTMv1ASMapRead(ctx, d, m)...)
 }
 
 func resourceGTMv1ASMapImport(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
@@ -361,6 +381,9 @@ func resourceGTMv1ASMapImport(ctx context.Context, d *schema.ResourceData, m int
 	if err := d.Set("wait_on_complete", true); err != nil {
 		return nil, err
 	}
+	if err := d.Set("batch_update", false); err != nil {
+		return nil, err
+	}
 	populateTerraformASMapState(d, as, m)
 
 	// use same Id as passed in
@@ -399,6 +422,20 @@ func resourceGTMv1ASMapDelete(ctx context.Context, d *schema.ResourceData, m int
 	}
 	newAs := createASMapStruct(existAs)
 	logger.Debugf("Deleting ASmap: %v", newAs)
+
+	batchUpdate, err := tf.GetBoolValue("batch_update", d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if batchUpdate {
+		diags = append(diags, submitBatchedChange(ctx, d, domain, "asMap Delete", m, removeChange(domainASMaps, asMapKey, asMap))...)
+		if diags.HasError() {
+			return diags
+		}
+		d.SetId("")
+		return diags
+	}
+
 	uStat, err := Client(meta).DeleteASMap(ctx, gtm.DeleteASMapRequest{
 		ASMapName:  asMap,
 		DomainName: domain,
@@ -419,30 +456,13 @@ func resourceGTMv1ASMapDelete(ctx context.Context, d *schema.ResourceData, m int
 		})
 	}
 
-	waitOnComplete, err := tf.GetBoolValue("wait_on_complete", d)
-	if err != nil {
-		return diag.FromErr(err)
-	}
-	if waitOnComplete {
-		done, err := waitForCompletion(ctx, domain, m)
-		if done {
-			logger.Infof("asMap Delete completed")
-		} else {
-			if err == nil {
-				logger.Infof("asMap Delete pending")
-			} else {
-				logger.Errorf("asMap Delete failed [%s]", err.Error())
-				return append(diags, diag.Diagnostic{
-					Severity: diag.Error,
-					Summary:  "asMap Delete failed",
-					Detail:   err.Error(),
-				})
-			}
-		}
+	diags = append(diags, waitForPropagation(ctx, d, domain, "asMap Delete", m)...)
+	if diags.HasError() {
+		return diags
 	}
 
 	d.SetId("")
-	return nil
+	return diags
 }
 
 // Create and populate a new asMap object from asMap data
@@ -517,11 +537,51 @@ func populateASAssignmentsObject(d *schema.ResourceData, as *gtm.ASMap, m interf
 	}
 }
 
+// populateASAssignmentsFromFile replaces the asMap assignments with the ones loaded from `assignments_file`, if set
+func populateASAssignmentsFromFile(d *schema.ResourceData, as *gtm.ASMap) error {
+	assignments, ok, err := assignmentsFromFile(d, asAssignmentsKind)
+	if err != nil || !ok {
+		return err
+	}
+	as.Assignments = make([]gtm.ASAssignment, 0, len(assignments))
+	for _, a := range assignments {
+		asNumbers := make([]int64, 0, len(a.values))
+		for _, v := range a.values {
+			// values are normalized by the loader
+			asNumber, err := strconv.ParseInt(v, 10, 64)
+			if err != nil {
+				return err
+			}
+			asNumbers = append(asNumbers, asNumber)
+		}
+		as.Assignments = append(as.Assignments, gtm.ASAssignment{
+			DatacenterBase: gtm.DatacenterBase{DatacenterID: a.datacenterID, Nickname: a.nickname},
+			ASNumbers:      asNumbers,
+		})
+	}
+	return nil
+}
+
 // create and populate Terraform asMap assignments schema
 func populateTerraformASAssignmentsState(d *schema.ResourceData, asm *gtm.GetASMapResponse, m interface{}) {
 	meta := meta.Must(m)
 	logger := meta.Log("Akamai GTM", "populateTerraformASAssignmentsState")
 
+	if path, _ := d.Get("assignments_file").(string); path != "" {
+		assignments := make([]mapAssignment, 0, len(asm.Assignments))
+		for _, a := range asm.Assignments {
+			asNumbers := make([]string, 0, len(a.ASNumbers))
+			for _, asNumber := range a.ASNumbers {
+				asNumbers = append(asNumbers, strconv.FormatInt(asNumber, 10))
+			}
+			assignments = append(assignments, mapAssignment{datacenterID: a.DatacenterID, nickname: a.Nickname, values: asNumbers})
+		}
+		if err := setAssignmentsHash(d, assignments, asAssignmentsKind); err != nil {
+			logger.Errorf("populateTerraformASAssignmentsState failed: %s", err.Error())
+		}
+		return
+	}
+
 	var asStateList []map[string]interface{}
 	for _, as := range asm.Assignments {
 		asNew := map[string]interface{}{
@@ -19,9 +19,11 @@ func resourceGTMv1CIDRMap() *schema.Resource {
 		ReadContext:   resourceGTMv1CIDRMapRead,
 		UpdateContext: resourceGTMv1CIDRMapUpdate,
 		DeleteContext: resourceGTMv1CIDRMapDelete,
+		CustomizeDiff: customDiffAssignmentsFile(cidrAssignmentsKind),
 		Importer: &schema.ResourceImporter{
 			State: resourceGTMv1CIDRMapImport,
 		},
+		Timeouts: resourceTimeouts(),
 		Schema: map[string]*schema.Schema{
 			"domain": {
 				Type:     schema.TypeString,
@@ -32,6 +34,8 @@ func resourceGTMv1CIDRMap() *schema.Resource {
 				Optional: true,
 				Default:  true,
 			},
+			"timeouts":     timeoutsSchema(),
+			"batch_update": batchUpdateSchema(),
 			"name": {
 				Type:     schema.TypeString,
 				Required: true,
@@ -75,6 +79,8 @@ func resourceGTMv1CIDRMap() *schema.Resource {
 					},
 				},
 			},
+			"assignments_file": assignmentsFileSchema(cidrAssignmentsKind),
+			"assignments_hash": assignmentsHashSchema(),
 		},
 	}
 }
@@ -115,7 +121,29 @@ func resourceGTMv1CIDRMapCreate(ctx context.Context, d *schema.ResourceData, m i
 	}
 
 	newCidr := populateNewCIDRMapObject(meta, d, m)
+	if err = populateCIDRAssignmentsFromFile(d, newCidr); err != nil {
+		logger.Errorf("cidrMap Create failed: %s", err.Error())
+		return append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "cidrMap Create failed",
+			Detail:   err.Error(),
+		})
+	}
 	logger.Debugf("Proposed New CidrMap: [%v]", newCidr)
+
+	batchUpdate, err := tf.GetBoolValue("batch_update", d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if batchUpdate {
+		diags = append(diags, submitBatchedChange(ctx, d, domain, "cidrMap Create", m, upsertChange(domainCIDRMaps, cidrMapKey, *newCidr))...)
+		if diags.HasError() {
+			return diags
+		}
+		d.SetId(fmt.Sprintf("%s:%s", domain, newCidr.Name))
+		return append(diags, resourceGTMv1CIDRMapRead(ctx, d, m)...)
+	}
+
 	cStatus, err := Client(meta).CreateCIDRMap(ctx, gtm.CreateCIDRMapRequest{
 		CIDR:       newCidr,
 		DomainName: domain,
@@ -135,33 +163,16 @@ func resourceGTMv1CIDRMapCreate(ctx context.Context, d *schema.ResourceData, m i
 			Summary:  cStatus.Status.Message,
 		})
 	}
-	waitOnComplete, err := tf.GetBoolValue("wait_on_complete", d)
-	if err != nil {
-		return diag.FromErr(err)
-	}
-	if waitOnComplete {
-		done, err := waitForCompletion(ctx, domain, m)
-		if done {
-			logger.Infof("cidrMap Create completed")
-		} else {
-			if err == nil {
-				logger.Infof("cidrMap Create pending")
-			} else {
-				logger.Errorf("cidrMap Create failed [%s]", err.Error())
-				return append(diags, diag.Diagnostic{
-					Severity: diag.Error,
-					Summary:  "cidrMap Create failed",
-					Detail:   err.Error(),
-				})
-			}
-		}
+	diags = append(diags, waitForPropagation(ctx, d, domain, "cidrMap Create", m)...)
+	if diags.HasError() {
+		return diags
 	}
 
 	// Give terraform the ID. Format domain:cidrMap
 	cidrMapID := fmt.Sprintf("%s:%s", domain, cStatus.Resource.Name)
 	logger.Debugf("Generated cidrMap resource Id: %s", cidrMapID)
 	d.SetId(cidrMapID)
-	return resourceGTMv1CIDRMapRead(ctx, d, m)
+	return append(diags, resourceGTMv1CIDRMapRead(ctx, d, m)...)
 
 }
 
@@ -209,6 +220,10 @@ func resourceGTMv1CIDRMapUpdate(ctx context.Context, d *schema.ResourceData, m i
 	)
 
 	logger.Debugf("Updating cidrMap: %s", d.Id())
+	if !d.HasChangeExcept("timeouts") {
+		logger.Debug("Only timeouts were updated, skipping")
+		return nil
+	}
 	var diags diag.Diagnostics
 	// pull domain and cidrMap out of id
 	domain, cidrMap, err := parseResourceStringID(d.Id())
@@ -232,7 +247,28 @@ func resourceGTMv1CIDRMapUpdate(ctx context.Context, d *schema.ResourceData, m i
 	newCidr := createCIDRMapStruct(existCidr)
 	logger.Debugf("Updating cidrMap BEFORE: %v", newCidr)
 	populateCIDRMapObject(d, newCidr, m)
+	if err = populateCIDRAssignmentsFromFile(d, newCidr); err != nil {
+		logger.Errorf("cidrMap Update failed: %s", err.Error())
+		return append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "cidrMap Update failed",
+			Detail:   err.Error(),
+		})
+	}
 	logger.Debugf("Updating cidrMap PROPOSED: %v", existCidr)
+
+	batchUpdate, err := tf.GetBoolValue("batch_update", d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if batchUpdate {
+		diags = append(diags, submitBatchedChange(ctx, d, domain, "cidrMap Update", m, upsertChange(domainCIDRMaps, cidrMapKey, *newCidr))...)
+		if diags.HasError() {
+			return diags
+		}
+		return append(diags, resourceGTMv1CIDRMapRead(ctx, d, m)...)
+	}
+
 	uStat, err := Client(meta).UpdateCIDRMap(ctx, gtm.UpdateCIDRMapRequest{
 		CIDR:       newCidr,
 		DomainName: domain,
@@ -252,29 +288,12 @@ func resourceGTMv1CIDRMapUpdate(ctx context.Context, d *schema.ResourceData, m i
 			Summary:  uStat.Status.Message,
 		})
 	}
-	waitOnComplete, err := tf.GetBoolValue("wait_on_complete", d)
-	if err != nil {
-		return diag.FromErr(err)
-	}
-	if waitOnComplete {
-		done, err := waitForCompletion(ctx, domain, m)
-		if done {
-			logger.Infof("cidrMap Update completed")
-		} else {
-			if err == nil {
-				logger.Infof("cidrMap Update pending")
-			} else {
-				logger.Errorf("cidrMap Update failed [%s]", err.Error())
-				return append(diags, diag.Diagnostic{
-					Severity: diag.Error,
-					Summary:  "cidrMap Update failed",
-					Detail:   err.Error(),
-				})
-			}
-		}
+	diags = append(diags, waitForPropagation(ctx, d, domain, "cidrMap Update", m)...)
+	if diags.HasError() {
+		return diags
 	}
 
-	return resourceGTMv1CIDRMapRead(ctx, d, m)
+	return append(diags, resourceGTMv1CIDRMapRead(ctx, d, m)...)
 }
 
 func resourceGTMv1CIDRMapImport(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
@@ -306,6 +325,9 @@ func resourceGTMv1CIDRMapImport(d *schema.ResourceData, m interface{}) ([]*schem
 	if err := d.Set("wait_on_complete", true); err != nil {
 		logger.Errorf("resourceGTMCidrMapImport failed: %s", err.Error())
 	}
+	if err := d.Set("batch_update", false); err != nil {
+		logger.Errorf("resourceGTMCidrMapImport failed: %s", err.Error())
+	}
 	populateTerraformCIDRMapState(d, cidr, m)
 
 	// use same Id as passed in
@@ -344,6 +366,20 @@ func resourceGTMv1CIDRMapDelete(ctx context.Context, d *schema.ResourceData, m i
 	}
 	newCidr := createCIDRMapStruct(existCidr)
 	logger.Debugf("Deleting cidrMap: %v", newCidr)
+
+	batchUpdate, err := tf.GetBoolValue("batch_update", d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if batchUpdate {
+		diags = append(diags, submitBatchedChange(ctx, d, domain, "cidrMap Delete", m, removeChange(domainCIDRMaps, cidrMapKey, cidrMap))...)
+		if diags.HasError() {
+			return diags
+		}
+		d.SetId("")
+		return diags
+	}
+
 	uStat, err := Client(meta).DeleteCIDRMap(ctx, gtm.DeleteCIDRMapRequest{
 		MapName:    cidrMap,
 		DomainName: domain,
@@ -363,31 +399,14 @@ func resourceGTMv1CIDRMapDelete(ctx context.Context, d *schema.ResourceData, m i
 			Summary:  uStat.Status.Message,
 		})
 	}
-	waitOnComplete, err := tf.GetBoolValue("wait_on_complete", d)
-	if err != nil {
-		return diag.FromErr(err)
-	}
-	if waitOnComplete {
-		done, err := waitForCompletion(ctx, domain, m)
-		if done {
-			logger.Infof("CidrMap Delete completed")
-		} else {
-			if err == nil {
-				logger.Infof("cidrMap Delete pending")
-			} else {
-				logger.Errorf("cidrMap Delete failed [%s]", err.Error())
-				return append(diags, diag.Diagnostic{
-					Severity: diag.Error,
-					Summary:  "cidrMap Delete failed",
-					Detail:   err.Error(),
-				})
-			}
-		}
+	diags = append(diags, waitForPropagation(ctx, d, domain, "cidrMap Delete", m)...)
+	if diags.HasError() {
+		return diags
 	}
 
 	// if successful ....
 	d.SetId("")
-	return nil
+	return diags
 }
 
 // Create and populate a new cidrMap object from cidrMap data
@@ -461,11 +480,38 @@ func populateCIDRAssignmentsObject(d *schema.ResourceData, cidr *gtm.CIDRMap, m
 	}
 }
 
+// populateCIDRAssignmentsFromFile replaces the cidrMap assignments with the ones loaded from `assignments_file`, if set
+func populateCIDRAssignmentsFromFile(d *schema.ResourceData, cidr *gtm.CIDRMap) error {
+	assignments, ok, err := assignmentsFromFile(d, cidrAssignmentsKind)
+	if err != nil || !ok {
+		return err
+	}
+	cidr.Assignments = make([]gtm.CIDRAssignment, 0, len(assignments))
+	for _, a := range assignments {
+		cidr.Assignments = append(cidr.Assignments, gtm.CIDRAssignment{
+			DatacenterBase: gtm.DatacenterBase{DatacenterID: a.datacenterID, Nickname: a.nickname},
+			Blocks:         a.values,
+		})
+	}
+	return nil
+}
+
 // create and populate Terraform cidrMap assignments schema
 func populateTerraformCIDRAssignmentsState(d *schema.ResourceData, cidr *gtm.GetCIDRMapResponse, m interface{}) {
 	meta := meta.Must(m)
 	logger := meta.Log("Akamai GTM", "populateTerraformCidrAssignmentsState")
 
+	if path, _ := d.Get("assignments_file").(string); path != "" {
+		assignments := make([]mapAssignment, 0, len(cidr.Assignments))
+		for _, a := range cidr.Assignments {
+			assignments = append(assignments, mapAssignment{datacenterID: a.DatacenterID, nickname: a.Nickname, values: a.Blocks})
+		}
+		if err := setAssignmentsHash(d, assignments, cidrAssignmentsKind); err != nil {
+			logger.Errorf("populateTerraformCidrAssignmentsState failed: %s", err.Error())
+		}
+		return
+	}
+
 	objectInventory := make(map[int]gtm.CIDRAssignment, len(cidr.Assignments))
 	if len(cidr.Assignments) > 0 {
 		for _, aObj := range cidr.Assignments {
@@ -25,6 +25,7 @@ func resourceGTMv1Datacenter() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			State: resourceGTMv1DatacenterImport,
 		},
+		Timeouts: resourceTimeouts(),
 		Schema: map[string]*schema.Schema{
 			"domain": {
 				Type:     schema.TypeString,
@@ -35,6 +36,8 @@ func resourceGTMv1Datacenter() *schema.Resource {
 				Optional: true,
 				Default:  true,
 			},
+			"timeouts":     timeoutsSchema(),
+			"batch_update": batchUpdateSchema(),
 			"nickname": {
 				Type:     schema.TypeString,
 				Optional: true,
@@ -187,6 +190,7 @@ func resourceGTMv1DatacenterCreate(ctx context.Context, d *schema.ResourceData,
 		return diag.FromErr(err)
 	}
 	logger.Debugf("Proposed New Datacenter: [%v]", newDC)
+	// datacenters are always created individually, even with `batch_update` set, as their ID is assigned by GTM
 	cStatus, err := Client(meta).CreateDatacenter(ctx, gtm.CreateDatacenterRequest{
 		DomainName: domain,
 		Datacenter: newDC,
@@ -207,34 +211,16 @@ func resourceGTMv1DatacenterCreate(ctx context.Context, d *schema.ResourceData,
 			Summary:  cStatus.Status.Message,
 		})
 	}
-	waitOnComplete, err := tf.GetBoolValue("wait_on_complete", d)
-	if err != nil {
-		return diag.FromErr(err)
-	}
-
-	if waitOnComplete {
-		done, err := waitForCompletion(ctx, domain, m)
-		if done {
-			logger.Infof("Datacenter Create completed")
-		} else {
-			if err == nil {
-				logger.Infof("Datacenter Create pending")
-			} else {
-				logger.Errorf("Datacenter Create failed [%s]", err.Error())
-				return append(diags, diag.Diagnostic{
-					Severity: diag.Error,
-					Summary:  "Datacenter Create failed",
-					Detail:   err.Error(),
-				})
-			}
-		}
+	diags = append(diags, waitForPropagation(ctx, d, domain, "Datacenter Create", m)...)
+	if diags.HasError() {
+		return diags
 	}
 
 	// Give terraform the ID. Format domain::dcid
 	datacenterID := fmt.Sprintf("%s:%d", domain, cStatus.Resource.DatacenterID)
 	logger.Debugf("Generated DC resource ID: %s", datacenterID)
 	d.SetId(datacenterID)
-	return resourceGTMv1DatacenterRead(ctx, d, m)
+	return append(diags, resourceGTMv1DatacenterRead(ctx, d, m)...)
 
 }
 
@@ -289,6 +275,10 @@ func resourceGTMv1DatacenterUpdate(ctx context.Context, d *schema.ResourceData,
 	)
 
 	logger.Debugf("Updating Datacenter: %s", d.Id())
+	if !d.HasChangeExcept("timeouts") {
+		logger.Debug("Only timeouts were updated, skipping")
+		return nil
+	}
 	var diags diag.Diagnostics
 	// pull domain and dcid out of resource id
 	domain, dcID, err := parseDatacenterResourceID(d.Id())
@@ -318,6 +308,19 @@ func resourceGTMv1DatacenterUpdate(ctx context.Context, d *schema.ResourceData,
 		return diag.FromErr(err)
 	}
 	logger.Debugf("Updating Datacenter PROPOSED: %v", existDC)
+
+	batchUpdate, err := tf.GetBoolValue("batch_update", d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if batchUpdate {
+		diags = append(diags, submitBatchedChange(ctx, d, domain, "Datacenter Update", m, upsertChange(domainDatacenters, datacenterKey, *existDC))...)
+		if diags.HasError() {
+			return diags
+		}
+		return append(diags, resourceGTMv1DatacenterRead(ctx, d, m)...)
+	}
+
 	uStat, err := Client(meta).UpdateDatacenter(ctx, gtm.UpdateDatacenterRequest{
 		Datacenter: existDC,
 		DomainName: domain,
@@ -336,26 +339,12 @@ func resourceGTMv1DatacenterUpdate(ctx context.Context, d *schema.ResourceData,
 
 	}
 
-	waitOnComplete, err := tf.GetBoolValue("wait_on_complete", d)
-	if err != nil {
-		return diag.FromErr(err)
-	}
-
-	if waitOnComplete {
-		done, err := waitForCompletion(ctx, domain, m)
-		if done {
-			logger.Infof("Datacenter Update completed")
-		} else {
-			if err == nil {
-				logger.Infof("Datacenter Update pending")
-			} else {
-				logger.Errorf("Datacenter Update failed [%s]", err.Error())
-				return diag.FromErr(fmt.Errorf("Datacenter Update failed [%s]", err.Error()))
-			}
-		}
+	diags = append(diags, waitForPropagation(ctx, d, domain, "Datacenter Update", m)...)
+	if diags.HasError() {
+		return diags
 	}
 
-	return resourceGTMv1DatacenterRead(ctx, d, m)
+	return append(diags, resourceGTMv1DatacenterRead(ctx, d, m)...)
 }
 
 func resourceGTMv1DatacenterImport(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
@@ -389,6 +378,9 @@ func resourceGTMv1DatacenterImport(d *schema.ResourceData, m interface{}) ([]*sc
 	if err := d.Set("wait_on_complete", true); err != nil {
 		return nil, err
 	}
+	if err := d.Set("batch_update", false); err != nil {
+		return nil, err
+	}
 	logger.Debugf("Import %v", dc)
 	return []*schema.ResourceData{d}, err
 
@@ -429,6 +421,20 @@ func resourceGTMv1DatacenterDelete(ctx context.Context, d *schema.ResourceData,
 		})
 	}
 	logger.Debugf("Deleting Datacenter: %v", existDC)
+
+	batchUpdate, err := tf.GetBoolValue("batch_update", d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if batchUpdate {
+		diags = append(diags, submitBatchedChange(ctx, d, domain, "Datacenter Delete", m, removeChange(domainDatacenters, datacenterKey, strconv.Itoa(dcID)))...)
+		if diags.HasError() {
+			return diags
+		}
+		d.SetId("")
+		return diags
+	}
+
 	uStat, err := Client(meta).DeleteDatacenter(ctx, gtm.DeleteDatacenterRequest{
 		DatacenterID: dcID,
 		DomainName:   domain,
@@ -450,32 +456,14 @@ func resourceGTMv1DatacenterDelete(ctx context.Context, d *schema.ResourceData,
 		})
 	}
 
-	waitOnComplete, err := tf.GetBoolValue("wait_on_complete", d)
-	if err != nil {
-		return diag.FromErr(err)
-	}
-
-	if waitOnComplete {
-		done, err := waitForCompletion(ctx, domain, m)
-		if done {
-			logger.Infof("Datacenter Delete completed")
-		} else {
-			if err == nil {
-				logger.Infof("Datacenter Delete pending")
-			} else {
-				logger.Errorf("Datacenter Delete failed [%s]", err.Error())
-				return append(diags, diag.Diagnostic{
-					Severity: diag.Error,
-					Summary:  "Datacenter Delete error",
-					Detail:   err.Error(),
-				})
-			}
-		}
+	diags = append(diags, waitForPropagation(ctx, d, domain, "Datacenter Delete", m)...)
+	if diags.HasError() {
+		return diags
 	}
 
 	// if successful ....
 	d.SetId("")
-	return nil
+	return diags
 }
 
 // Create and populate a new datacenter object from resource data
@@ -13,6 +13,7 @@ import (
 	"github.com/akamai/terraform-provider-akamai/v6/pkg/common/ptr"
 	"github.com/akamai/terraform-provider-akamai/v6/pkg/common/tf"
 	"github.com/akamai/terraform-provider-akamai/v6/pkg/meta"
+	"github.com/apex/log"
 	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -21,6 +22,16 @@ import (
 // HashiAcc is Hack for Hashicorp Acceptance Tests
 var HashiAcc = false
 
+var (
+	// ErrPropagationTimeout is returned when domain changes did not propagate before the operation timeout
+	ErrPropagationTimeout = errors.New("domain propagation did not complete before the timeout")
+
+	// propagationPollInterval is the interval between subsequent domain status checks
+	propagationPollInterval = 5 * time.Second
+	// propagationReadReserve is the part of the operation timeout left for reading the resource once waiting is over
+	propagationReadReserve = 30 * time.Second
+)
+
 func resourceGTMv1Domain() *schema.Resource {
 	return &schema.Resource{
 		CreateContext: resourceGTMv1DomainCreate,
@@ -30,6 +41,7 @@ func resourceGTMv1Domain() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: resourceGTMv1DomainImport,
 		},
+		Timeouts: resourceTimeouts(),
 		Schema: map[string]*schema.Schema{
 			"contract": {
 				Type:             schema.TypeString,
@@ -48,6 +60,7 @@ func resourceGTMv1Domain() *schema.Resource {
 				Optional: true,
 				Default:  true,
 			},
+			"timeouts": timeoutsSchema(),
 			"name": {
 				Type:     schema.TypeString,
 				Required: true,
@@ -290,32 +303,14 @@ func resourceGTMv1DomainCreate(ctx context.Context, d *schema.ResourceData, m in
 			})
 		}
 
-		waitOnComplete, err := tf.GetBoolValue("wait_on_complete", d)
-		if err != nil {
-			return diag.FromErr(err)
-		}
-
-		if waitOnComplete {
-			done, err := waitForCompletion(ctx, dname, m)
-			if done {
-				logger.Infof("Domain Create completed")
-			} else {
-				if err == nil {
-					logger.Infof("Domain Create pending")
-				} else {
-					logger.Errorf("Domain Create failed [%s]", err.Error())
-					return append(diags, diag.Diagnostic{
-						Severity: diag.Error,
-						Summary:  "Domain Create failed",
-						Detail:   err.Error(),
-					})
-				}
-			}
+		diags = append(diags, waitForPropagation(ctx, d, dname, "Domain Create", m)...)
+		if diags.HasError() {
+			return diags
 		}
 	}
 	// Give terraform the ID
 	d.SetId(dname)
-	return resourceGTMv1DomainRead(ctx, d, m)
+	return append(diags, resourceGTMv1DomainRead(ctx, d, m)...)
 
 }
 
@@ -360,6 +355,10 @@ func resourceGTMv1DomainUpdate(ctx context.Context, d *schema.ResourceData, m in
 	)
 
 	logger.Debugf("Updating Domain: %s", d.Id())
+	if !d.HasChangeExcept("timeouts") {
+		logger.Debug("Only timeouts were updated, skipping")
+		return nil
+	}
 	var diags diag.Diagnostics
 	// Get existing domain
 	existDom, err := Client(meta).GetDomain(ctx, gtm.GetDomainRequest{
@@ -412,31 +411,12 @@ func resourceGTMv1DomainUpdate(ctx context.Context, d *schema.ResourceData, m in
 		})
 	}
 
-	waitOnComplete, err := tf.GetBoolValue("wait_on_complete", d)
-	if err != nil {
-		return diag.FromErr(err)
+	diags = append(diags, waitForPropagation(ctx, d, d.Id(), "Domain Update", m)...)
+	if diags.HasError() {
+		return diags
 	}
 
-	if waitOnComplete {
-		done, err := waitForCompletion(ctx, d.Id(), m)
-		if done {
-			logger.Infof("Domain Update completed")
-		} else {
-			if err == nil {
-				logger.Infof("Domain Update pending")
-			} else {
-				logger.Errorf("Domain Update failed [%s]", err.Error())
-				return append(diags, diag.Diagnostic{
-					Severity: diag.Error,
-					Summary:  "domain Update failed",
-					Detail:   err.Error(),
-				})
-			}
-		}
-
-	}
-
-	return resourceGTMv1DomainRead(ctx, d, m)
+	return append(diags, resourceGTMv1DomainRead(ctx, d, m)...)
 
 }
 
@@ -507,31 +487,13 @@ func resourceGTMv1DomainDelete(ctx context.Context, d *schema.ResourceData, m in
 			})
 		}
 
-		waitOnComplete, err := tf.GetBoolValue("wait_on_complete", d)
-		if err != nil {
-			return diag.FromErr(err)
-		}
-
-		if waitOnComplete {
-			done, err := waitForCompletion(ctx, d.Id(), m)
-			if done {
-				logger.Infof("Domain Delete completed")
-			} else {
-				if err == nil {
-					logger.Infof("Domain Delete pending")
-				} else {
-					logger.Errorf("Domain Delete failed [%s]", err.Error())
-					return append(diags, diag.Diagnostic{
-						Severity: diag.Error,
-						Summary:  "Domain Delete failed",
-						Detail:   err.Error(),
-					})
-				}
-			}
+		diags = append(diags, waitForPropagation(ctx, d, d.Id(), "Domain Delete", m)...)
+		if diags.HasError() {
+			return diags
 		}
 	}
 	d.SetId("")
-	return nil
+	return diags
 
 }
 
@@ -869,46 +831,103 @@ func createDomainStruct(domain *gtm.GetDomainResponse) *gtm.Domain {
 	return nil
 }
 
-// Util function to wait for change deployment. return true if complete. false if not - error or nil (timeout)
-func waitForCompletion(ctx context.Context, domain string, m interface{}) (bool, error) {
+// waitForPropagation waits for the domain changes to propagate if `wait_on_complete` is set.
+// Denied or failed propagation results in an error diagnostic. Propagation not finished within
+// the resource timeout results in a warning, as the change was accepted and is still being deployed.
+func waitForPropagation(ctx context.Context, d *schema.ResourceData, domain, operation string, m interface{}) diag.Diagnostics {
+	meta := meta.Must(m)
+	logger := meta.Log("Akamai GTM", "waitForPropagation")
+
+	waitOnComplete, err := tf.GetBoolValue("wait_on_complete", d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if !waitOnComplete {
+		logger.Infof("%s submitted, not waiting for propagation", operation)
+		return nil
+	}
+
+	return propagationDiagnostics(waitForCompletion(ctx, domain, m), domain, operation, logger)
+}
+
+// propagationDiagnostics converts the result of waiting for the domain propagation into diagnostics
+func propagationDiagnostics(err error, domain, operation string, logger log.Interface) diag.Diagnostics {
+	switch {
+	case err == nil:
+		logger.Infof("%s completed", operation)
+		return nil
+	case errors.Is(err, ErrPropagationTimeout):
+		logger.Warnf("%s pending", operation)
+		return diag.Diagnostics{{
+			Severity: diag.Warning,
+			Summary:  fmt.Sprintf("%s pending", operation),
+			Detail: fmt.Sprintf("changes to domain %s were accepted, but did not propagate within the resource timeout. "+
+				"Check the domain status or increase the timeout using the `timeouts` block", domain),
+		}}
+	default:
+		logger.Errorf("%s failed [%s]", operation, err.Error())
+		return diag.Diagnostics{{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("%s failed", operation),
+			Detail:   err.Error(),
+		}}
+	}
+}
+
+// waitForCompletion polls the domain status until the change deployment is complete.
+// It returns ErrPropagationTimeout if the deployment is still pending when the context deadline,
+// reduced by propagationReadReserve, is reached.
+func waitForCompletion(ctx context.Context, domain string, m interface{}) error {
 	meta := meta.Must(m)
 	logger := meta.Log("Akamai GTMv1", "waitForCompletion")
 
-	var defaultInterval = 5 * time.Second
-	var defaultTimeout = 300 * time.Second
-	var sleepInterval = defaultInterval // seconds. TODO:Should be configurable by user ...
-	var sleepTimeout = defaultTimeout   // seconds. TODO: Should be configurable by user ...
+	waitCtx := ctx
+	if deadline, ok := ctx.Deadline(); ok {
+		var cancel context.CancelFunc
+		waitCtx, cancel = context.WithDeadline(ctx, deadline.Add(-propagationReadReserve))
+		defer cancel()
+	}
 	if HashiAcc {
 		// Override for ACC tests
-		sleepTimeout = sleepInterval
+		var cancel context.CancelFunc
+		waitCtx, cancel = context.WithTimeout(waitCtx, propagationPollInterval)
+		defer cancel()
+	}
+	logger.Debugf("WAIT: Poll Interval [%v]", propagationPollInterval)
+	if deadline, ok := waitCtx.Deadline(); ok {
+		logger.Debugf("WAIT: Deadline [%v]", deadline)
 	}
-	logger.Debugf("WAIT: Sleep Interval [%v]", sleepInterval/time.Second)
-	logger.Debugf("WAIT: Sleep Timeout [%v]", sleepTimeout/time.Second)
 	for {
-		propStat, err := Client(meta).GetDomainStatus(ctx, gtm.GetDomainStatusRequest{
+		propStat, err := Client(meta).GetDomainStatus(waitCtx, gtm.GetDomainStatusRequest{
 			DomainName: domain,
 		})
 		if err != nil {
-			return false, fmt.Errorf("GetDomainStatus error: %s", err.Error())
+			if errors.Is(waitCtx.Err(), context.DeadlineExceeded) {
+				logger.Debugf("WAIT: Return TIMED OUT")
+				return ErrPropagationTimeout
+			}
+			return fmt.Errorf("GetDomainStatus error: %s", err.Error())
 		}
 		logger.Debugf("WAIT: propStat.PropagationStatus [%v]", propStat.PropagationStatus)
 		switch propStat.PropagationStatus {
 		case "COMPLETE":
 			logger.Debugf("WAIT: Return COMPLETE")
-			return true, nil
+			return nil
 		case "DENIED":
 			logger.Debugf("WAIT: Return DENIED")
-			return false, fmt.Errorf(propStat.Message)
+			return errors.New(propStat.Message)
 		case "PENDING":
-			if sleepTimeout <= 0 {
-				logger.Debugf("WAIT: Return TIMED OUT")
-				return false, nil
+			select {
+			case <-time.After(propagationPollInterval):
+			case <-waitCtx.Done():
+				if errors.Is(waitCtx.Err(), context.DeadlineExceeded) {
+					logger.Debugf("WAIT: Return TIMED OUT")
+					return ErrPropagationTimeout
+				}
+				return fmt.Errorf("waiting for propagation terminated: %w", waitCtx.Err())
 			}
-			time.Sleep(sleepInterval)
-			sleepTimeout -= sleepInterval
-			logger.Debugf("WAIT: Sleep Time Remaining [%v]", sleepTimeout/time.Second)
 		default:
-			return false, fmt.Errorf("unknown propagationStatus while waiting for change completion") // don't know how/why we would have broken out.
+			return fmt.Errorf("unknown propagationStatus while waiting for change completion") // don't know how/why we would have broken out.
 		}
 	}
 }
@@ -1,15 +1,23 @@
 package gtm
 
 import (
+	"context"
 	"net/http"
 	"regexp"
 	"testing"
+	"time"
 
 	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v9/pkg/gtm"
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v9/pkg/session"
 	"github.com/akamai/terraform-provider-akamai/v6/pkg/common/ptr"
 	"github.com/akamai/terraform-provider-akamai/v6/pkg/common/testutils"
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/meta"
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
 func TestResGTMDomain(t *testing.T) {
@@ -149,6 +157,97 @@ func TestResGTMDomain(t *testing.T) {
 		client.AssertExpectations(t)
 	})
 
+	t.Run("create domain with timeouts waits for pending propagation", func(t *testing.T) {
+		client := &gtm.Mock{}
+
+		getCall := client.On("GetDomain",
+			mock.Anything, // ctx is irrelevant for this test
+			mock.AnythingOfType("gtm.GetDomainRequest"),
+		).Return(nil, &gtm.Error{
+			StatusCode: http.StatusNotFound,
+		})
+
+		dr := testGetDomain
+		client.On("CreateDomain",
+			mock.Anything, // ctx is irrelevant for this test
+			mock.AnythingOfType("gtm.CreateDomainRequest"),
+		).Return(&gtm.CreateDomainResponse{
+			Resource: testDomain,
+			Status:   testGetDomain.Status,
+		}, nil).Run(func(args mock.Arguments) {
+			getCall.ReturnArguments = mock.Arguments{&dr, nil}
+		})
+
+		client.On("GetDomainStatus",
+			mock.Anything, // ctx is irrelevant for this test
+			mock.AnythingOfType("gtm.GetDomainStatusRequest"),
+		).Return(&gtm.GetDomainStatusResponse{PropagationStatus: "PENDING"}, nil).Times(2)
+
+		client.On("GetDomainStatus",
+			mock.Anything, // ctx is irrelevant for this test
+			mock.AnythingOfType("gtm.GetDomainStatusRequest"),
+		).Return(getDomainStatusResponseStatus, nil)
+
+		client.On("DeleteDomain",
+			mock.Anything, // ctx is irrelevant for this test
+			mock.AnythingOfType("gtm.DeleteDomainRequest"),
+		).Return(&deleteDomainResponseStatus, nil)
+
+		useClient(client, func() {
+			usePropagationPollInterval(time.Millisecond, func() {
+				resource.UnitTest(t, resource.TestCase{
+					ProtoV6ProviderFactories: testutils.NewProtoV6ProviderFactory(NewSubprovider()),
+					Steps: []resource.TestStep{
+						{
+							Config: testutils.LoadFixtureString(t, "testdata/TestResGtmDomain/create_basic_with_timeouts.tf"),
+							Check: resource.ComposeTestCheckFunc(
+								resource.TestCheckResourceAttr(resourceName, "name", gtmTestDomain),
+								resource.TestCheckResourceAttr(resourceName, "timeouts.0.default", "10m"),
+								resource.TestCheckResourceAttr(resourceName, "timeouts.0.create", "15m"),
+							),
+						},
+					},
+				})
+			})
+		})
+
+		client.AssertExpectations(t)
+	})
+
+	t.Run("create domain - propagation denied", func(t *testing.T) {
+		client := &gtm.Mock{}
+
+		client.On("CreateDomain",
+			mock.Anything, // ctx is irrelevant for this test
+			mock.AnythingOfType("gtm.CreateDomainRequest"),
+		).Return(&gtm.CreateDomainResponse{
+			Resource: testDomain,
+			Status:   testGetDomain.Status,
+		}, nil)
+
+		client.On("GetDomainStatus",
+			mock.Anything, // ctx is irrelevant for this test
+			mock.AnythingOfType("gtm.GetDomainStatusRequest"),
+		).Return(&gtm.GetDomainStatusResponse{
+			PropagationStatus: "DENIED",
+			Message:           "configuration rejected by GTM",
+		}, nil).Once()
+
+		useClient(client, func() {
+			resource.UnitTest(t, resource.TestCase{
+				ProtoV6ProviderFactories: testutils.NewProtoV6ProviderFactory(NewSubprovider()),
+				Steps: []resource.TestStep{
+					{
+						Config:      testutils.LoadFixtureString(t, "testdata/TestResGtmDomain/create_basic.tf"),
+						ExpectError: regexp.MustCompile("configuration rejected by GTM"),
+					},
+				},
+			})
+		})
+
+		client.AssertExpectations(t)
+	})
+
 	t.Run("create, update domain name - error", func(t *testing.T) {
 		client := &gtm.Mock{}
 
@@ -317,6 +416,52 @@ func TestResGTMDomain(t *testing.T) {
 	})
 }
 
+func TestWaitForCompletion(t *testing.T) {
+	m, err := meta.New(session.Must(session.New()), hclog.NewNullLogger(), "")
+	require.NoError(t, err)
+	getDomainStatusRequest := gtm.GetDomainStatusRequest{DomainName: gtmTestDomain}
+	pending := &gtm.GetDomainStatusResponse{PropagationStatus: "PENDING"}
+
+	t.Run("propagation pending past the deadline results in the pending warning", func(t *testing.T) {
+		client := &gtm.Mock{}
+		client.On("GetDomainStatus", mock.Anything, getDomainStatusRequest).Return(pending, nil)
+
+		// the wait ends propagationReadReserve before the deadline, so the read after the wait fits into the timeout
+		ctx, cancel := context.WithTimeout(context.Background(), propagationReadReserve+50*time.Millisecond)
+		defer cancel()
+		useClient(client, func() {
+			usePropagationPollInterval(10*time.Millisecond, func() {
+				err := waitForCompletion(ctx, gtmTestDomain, m)
+				assert.ErrorIs(t, err, ErrPropagationTimeout)
+
+				diags := propagationDiagnostics(err, gtmTestDomain, "Domain Update", m.Log("Akamai GTM", "TestWaitForCompletion"))
+				require.Len(t, diags, 1)
+				assert.Equal(t, diag.Warning, diags[0].Severity)
+				assert.Equal(t, "Domain Update pending", diags[0].Summary)
+			})
+		})
+		client.AssertExpectations(t)
+	})
+
+	t.Run("status request interrupted by the deadline results in the pending warning", func(t *testing.T) {
+		client := &gtm.Mock{}
+		client.On("GetDomainStatus", mock.Anything, getDomainStatusRequest).Return(pending, nil).Once()
+		client.On("GetDomainStatus", mock.Anything, getDomainStatusRequest).
+			Run(func(args mock.Arguments) {
+				<-args.Get(0).(context.Context).Done()
+			}).Return(nil, context.DeadlineExceeded).Once()
+
+		ctx, cancel := context.WithTimeout(context.Background(), propagationReadReserve+50*time.Millisecond)
+		defer cancel()
+		useClient(client, func() {
+			usePropagationPollInterval(10*time.Millisecond, func() {
+				assert.ErrorIs(t, waitForCompletion(ctx, gtmTestDomain, m), ErrPropagationTimeout)
+			})
+		})
+		client.AssertExpectations(t)
+	})
+}
+
 func TestGTMDomainOrder(t *testing.T) {
 	tests := map[string]struct {
 		client        *gtm.Mock
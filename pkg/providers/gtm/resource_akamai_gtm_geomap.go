@@ -20,9 +20,11 @@ func resourceGTMv1GeoMap() *schema.Resource {
 		ReadContext:   resourceGTMv1GeoMapRead,
 		UpdateContext: resourceGTMv1GeoMapUpdate,
 		DeleteContext: resourceGTMv1GeoMapDelete,
+		CustomizeDiff: customDiffAssignmentsFile(geoAssignmentsKind),
 		Importer: &schema.ResourceImporter{
 			State: resourceGTMv1GeoMapImport,
 		},
+		Timeouts: resourceTimeouts(),
 		Schema: map[string]*schema.Schema{
 			"domain": {
 				Type:     schema.TypeString,
@@ -33,6 +35,8 @@ func resourceGTMv1GeoMap() *schema.Resource {
 				Optional: true,
 				Default:  true,
 			},
+			"timeouts":     timeoutsSchema(),
+			"batch_update": batchUpdateSchema(),
 			"name": {
 				Type:     schema.TypeString,
 				Required: true,
@@ -77,6 +81,8 @@ func resourceGTMv1GeoMap() *schema.Resource {
 					},
 				},
 			},
+			"assignments_file": assignmentsFileSchema(geoAssignmentsKind),
+			"assignments_hash": assignmentsHashSchema(),
 		},
 	}
 }
@@ -126,7 +132,29 @@ func resourceGTMv1GeoMapCreate(ctx context.Context, d *schema.ResourceData, m in
 			Detail:   err.Error(),
 		})
 	}
+	if err = populateGeoAssignmentsFromFile(d, newGeo); err != nil {
+		logger.Errorf("geoMap Create failed: %s", err.Error())
+		return append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "geoMap Create failed",
+			Detail:   err.Error(),
+		})
+	}
 	logger.Debugf("Proposed New geoMap: [%v]", newGeo)
+
+	batchUpdate, err := tf.GetBoolValue("batch_update", d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if batchUpdate {
+		diags = append(diags, submitBatchedChange(ctx, d, domain, "geoMap Create", m, upsertChange(domainGeoMaps, geoMapKey, *newGeo))...)
+		if diags.HasError() {
+			return diags
+		}
+		d.SetId(fmt.Sprintf("%s:%s", domain, newGeo.Name))
+		return append(diags, resourceGTMv1GeoMapRead(ctx, d, m)...)
+	}
+
 	cStatus, err := Client(meta).CreateGeoMap(ctx, gtm.CreateGeoMapRequest{
 		GeoMap:     newGeo,
 		DomainName: domain,
@@ -147,35 +175,16 @@ func resourceGTMv1GeoMapCreate(ctx context.Context, d *schema.ResourceData, m in
 		})
 	}
 
-	waitOnComplete, err := tf.GetBoolValue("wait_on_complete", d)
-	if err != nil {
-		return diag.FromErr(err)
-	}
-
-	if waitOnComplete {
-		done, err := waitForCompletion(ctx, domain, m)
-		if done {
-			logger.Infof("geoMap Create completed")
-		} else {
-			if err == nil {
-				logger.Infof("geoMap Create pending")
-			} else {
-				logger.Errorf("geoMap Create failed [%s]", err.Error())
-				return append(diags, diag.Diagnostic{
-					Severity: diag.Error,
-					Summary:  "geoMap Create failed",
-					Detail:   err.Error(),
-				})
-			}
-		}
-
+	diags = append(diags, waitForPropagation(ctx, d, domain, "geoMap Create", m)...)
+	if diags.HasError() {
+		return diags
 	}
 
 	// Give terraform the ID. Format domain:geoMap
 	geoMapID := fmt.Sprintf("%s:%s", domain, cStatus.Resource.Name)
 	logger.Debugf("Generated geoMap resource ID: %s", geoMapID)
 	d.SetId(geoMapID)
-	return resourceGTMv1GeoMapRead(ctx, d, m)
+	return append(diags, resourceGTMv1GeoMapRead(ctx, d, m)...)
 }
 
 func resourceGTMv1GeoMapRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
@@ -228,6 +237,10 @@ func resourceGTMv1GeoMapUpdate(ctx context.Context, d *schema.ResourceData, m in
 	)
 
 	logger.Debugf("Updating geoMap: %s", d.Id())
+	if !d.HasChangeExcept("timeouts") {
+		logger.Debug("Only timeouts were updated, skipping")
+		return nil
+	}
 	var diags diag.Diagnostics
 	// pull domain and geoMap out of id
 	domain, geoMap, err := parseResourceStringID(d.Id())
@@ -251,7 +264,28 @@ func resourceGTMv1GeoMapUpdate(ctx context.Context, d *schema.ResourceData, m in
 	newGeo := createGeoMapStruct(existGeo)
 	logger.Debugf("Updating geoMap BEFORE: %v", newGeo)
 	populateGeoMapObject(d, newGeo, m)
+	if err = populateGeoAssignmentsFromFile(d, newGeo); err != nil {
+		logger.Errorf("geoMap Update failed: %s", err.Error())
+		return append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "geoMap Update failed",
+			Detail:   err.Error(),
+		})
+	}
 	logger.Debugf("Updating geoMap PROPOSED: %v", existGeo)
+
+	batchUpdate, err := tf.GetBoolValue("batch_update", d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if batchUpdate {
+		diags = append(diags, submitBatchedChange(ctx, d, domain, "geoMap Update", m, upsertChange(domainGeoMaps, geoMapKey, *newGeo))...)
+		if diags.HasError() {
+			return diags
+		}
+		return append(diags, resourceGTMv1GeoMapRead(ctx, d, m)...)
+	}
+
 	uStat, err := Client(meta).UpdateGeoMap(ctx, gtm.UpdateGeoMapRequest{
 		GeoMap:     newGeo,
 		DomainName: domain,
@@ -273,31 +307,12 @@ func resourceGTMv1GeoMapUpdate(ctx context.Context, d *schema.ResourceData, m in
 		})
 	}
 
-	waitOnComplete, err := tf.GetBoolValue("wait_on_complete", d)
-	if err != nil {
-		return diag.FromErr(err)
+	diags = append(diags, waitForPropagation(ctx, d, domain, "geoMap Update", m)...)
+	if diags.HasError() {
+		return diags
 	}
 
-	if waitOnComplete {
-		done, err := waitForCompletion(ctx, domain, m)
-		if done {
-			logger.Infof("geoMap Update completed")
-		} else {
-			if err == nil {
-				logger.Infof("geoMap Update pending")
-			} else {
-				logger.Errorf("geoMap Update failed [%s]", err.Error())
-				return append(diags, diag.Diagnostic{
-					Severity: diag.Error,
-					Summary:  "geoMap Update failed",
-					Detail:   err.Error(),
-				})
-			}
-		}
-
-	}
-
-	return resourceGTMv1GeoMapRead(ctx, d, m)
+	return append(diags, resourceGTMv1GeoMapRead(ctx, d, m)...)
 }
 
 func resourceGTMv1GeoMapImport(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
@@ -329,6 +344,9 @@ func resourceGTMv1GeoMapImport(d *schema.ResourceData, m interface{}) ([]*schema
 	if err := d.Set("wait_on_complete", true); err != nil {
 		return nil, err
 	}
+	if err := d.Set("batch_update", false); err != nil {
+		return nil, err
+	}
 	if err = populateTerraformGeoMapState(d, geo, m); err != nil {
 		return nil, err
 	}
@@ -373,6 +391,20 @@ func resourceGTMv1GeoMapDelete(ctx context.Context, d *schema.ResourceData, m in
 	}
 	newGeo := createGeoMapStruct(existGeo)
 	logger.Debugf("Deleting geoMap: %v", newGeo)
+
+	batchUpdate, err := tf.GetBoolValue("batch_update", d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if batchUpdate {
+		diags = append(diags, submitBatchedChange(ctx, d, domain, "geoMap Delete", m, removeChange(domainGeoMaps, geoMapKey, geoMap))...)
+		if diags.HasError() {
+			return diags
+		}
+		d.SetId("")
+		return diags
+	}
+
 	uStat, err := Client(meta).DeleteGeoMap(ctx, gtm.DeleteGeoMapRequest{
 		MapName:    geoMap,
 		DomainName: domain,
@@ -394,31 +426,13 @@ func resourceGTMv1GeoMapDelete(ctx context.Context, d *schema.ResourceData, m in
 		})
 	}
 
-	waitOnComplete, err := tf.GetBoolValue("wait_on_complete", d)
-	if err != nil {
-		return diag.FromErr(err)
-	}
-
-	if waitOnComplete {
-		done, err := waitForCompletion(ctx, domain, m)
-		if done {
-			logger.Infof("geoMap Delete completed")
-		} else {
-			if err == nil {
-				logger.Infof("geoMap Delete pending")
-			} else {
-				logger.Errorf("geoMap Delete failed [%s]", err.Error())
-				return append(diags, diag.Diagnostic{
-					Severity: diag.Error,
-					Summary:  "geoMap Delete failed",
-					Detail:   err.Error(),
-				})
-			}
-		}
+	diags = append(diags, waitForPropagation(ctx, d, domain, "geoMap Delete", m)...)
+	if diags.HasError() {
+		return diags
 	}
 
 	d.SetId("")
-	return nil
+	return diags
 }
 
 // Create and populate a new geoMap object from geoMap data
@@ -496,11 +510,35 @@ func populateGeoAssignmentsObject(d *schema.ResourceData, geo *gtm.GeoMap, m int
 	}
 }
 
+// populateGeoAssignmentsFromFile replaces the geoMap assignments with the ones loaded from `assignments_file`, if set
+func populateGeoAssignmentsFromFile(d *schema.ResourceData, geo *gtm.GeoMap) error {
+	assignments, ok, err := assignmentsFromFile(d, geoAssignmentsKind)
+	if err != nil || !ok {
+		return err
+	}
+	geo.Assignments = make([]gtm.GeoAssignment, 0, len(assignments))
+	for _, a := range assignments {
+		geo.Assignments = append(geo.Assignments, gtm.GeoAssignment{
+			DatacenterBase: gtm.DatacenterBase{DatacenterID: a.datacenterID, Nickname: a.nickname},
+			Countries:      a.values,
+		})
+	}
+	return nil
+}
+
 // create and populate Terraform geoMap assignments schema
 func populateTerraformGeoAssignmentsState(d *schema.ResourceData, geo *gtm.GetGeoMapResponse, m interface{}) error {
 	meta := meta.Must(m)
 	logger := meta.Log("Akamai GTM", "populateTerraformGeoAssignmentsState")
 
+	if path, _ := d.Get("assignments_file").(string); path != "" {
+		assignments := make([]mapAssignment, 0, len(geo.Assignments))
+		for _, a := range geo.Assignments {
+			assignments = append(assignments, mapAssignment{datacenterID: a.DatacenterID, nickname: a.Nickname, values: a.Countries})
+		}
+		return setAssignmentsHash(d, assignments, geoAssignmentsKind)
+	}
+
 	objectInventory := make(map[int]gtm.GeoAssignment, len(geo.Assignments))
 	if len(geo.Assignments) > 0 {
 		for _, aObj := range geo.Assignments {
@@ -32,6 +32,7 @@ func resourceGTMv1Property() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			State: resourceGTMv1PropertyImport,
 		},
+		Timeouts: resourceTimeouts(),
 		Schema: map[string]*schema.Schema{
 			"domain": {
 				Type:     schema.TypeString,
@@ -42,6 +43,8 @@ func resourceGTMv1Property() *schema.Resource {
 				Optional: true,
 				Default:  true,
 			},
+			"timeouts":     timeoutsSchema(),
+			"batch_update": batchUpdateSchema(),
 			"name": {
 				Type:     schema.TypeString,
 				Required: true,
@@ -415,12 +418,18 @@ func validateTrafficTargets(d *schema.ResourceDiff) error {
 	if !ok {
 		return fmt.Errorf("could not cast the value of type %T to string", propertyType)
 	}
+	trafficTargetsRaw := d.Get("traffic_target")
+	trafficTargets, ok := trafficTargetsRaw.([]interface{})
+	if !ok {
+		return fmt.Errorf("could not cast the value of type %T to []interface{}", trafficTargets)
+	}
+	return validatePropertyTrafficTargets(propertyType, trafficTargets)
+}
+
+// validatePropertyTrafficTargets validates the traffic targets, given in the `traffic_target` schema format,
+// against the requirements of the property type
+func validatePropertyTrafficTargets(propertyType string, trafficTargets []interface{}) error {
 	if propertyType == "ranked-failover" {
-		trafficTargetsRaw := d.Get("traffic_target")
-		trafficTargets, ok := trafficTargetsRaw.([]interface{})
-		if !ok {
-			return fmt.Errorf("could not cast the value of type %T to []interface{}", trafficTargets)
-		}
 		if len(trafficTargets) == 0 {
 			return fmt.Errorf("at least one 'traffic_target' has to be defined and enabled")
 		}
@@ -451,24 +460,16 @@ func customDiffGTMProperty(_ context.Context, d *schema.ResourceDiff, m interfac
 		return fmt.Errorf("could not cast the value of type %T to []interface{}", livenessTest)
 	}
 
-	requiredWith := []string{"HTTP", "HTTPS", "FTP"}
-
-	for _, itemRaw := range livenessTest {
+	for i, itemRaw := range livenessTest {
 		item, ok := itemRaw.(map[string]interface{})
 		if !ok {
 			return fmt.Errorf("could not cast the value of type %T to map[string]interface{}", item)
 		}
-		testObjectProtocol, ok := item["test_object_protocol"].(string)
-		if !ok {
-			return fmt.Errorf("could not cast the value of type %T to string", testObjectProtocol)
+		known := func(key string) bool {
+			return d.NewValueKnown(fmt.Sprintf("liveness_test.%d.%s", i, key))
 		}
-		testObject, ok := item["test_object"].(string)
-		if !ok {
-			return fmt.Errorf("could not cast the value of type %T to string", testObject)
-		}
-
-		if slices.Contains(requiredWith, testObjectProtocol) && testObject == "" {
-			return fmt.Errorf("attribute 'test_object' is required when 'test_object_protocol' is set to 'HTTP', 'HTTPS' or 'FTP'")
+		if err := validateLivenessTest(item, known); err != nil {
+			return fmt.Errorf("%w (liveness_test '%s')", err, item["name"])
 		}
 	}
 
@@ -541,6 +542,20 @@ func resourceGTMv1PropertyCreate(ctx context.Context, d *schema.ResourceData, m
 		return diag.FromErr(err)
 	}
 	logger.Debugf("Proposed New Property: [%v]", newProp)
+
+	batchUpdate, err := tf.GetBoolValue("batch_update", d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if batchUpdate {
+		diags := submitBatchedChange(ctx, d, domain, "Property Create", m, upsertChange(domainProperties, propertyKey, *newProp))
+		if diags.HasError() {
+			return diags
+		}
+		d.SetId(fmt.Sprintf("%s:%s", domain, newProp.Name))
+		return append(diags, resourceGTMv1PropertyRead(ctx, d, m)...)
+	}
+
 	cStatus, err := createPropertyWithRetry(ctx, meta, logger, gtm.CreatePropertyRequest{
 		Property:   newProp,
 		DomainName: domain,
@@ -556,30 +571,16 @@ func resourceGTMv1PropertyCreate(ctx context.Context, d *schema.ResourceData, m
 		return diag.FromErr(fmt.Errorf(cStatus.Status.Message))
 	}
 
-	waitOnComplete, err := tf.GetBoolValue("wait_on_complete", d)
-	if err != nil {
-		return diag.FromErr(err)
-	}
-
-	if waitOnComplete {
-		done, err := waitForCompletion(ctx, domain, m)
-		if done {
-			logger.Infof("Property Create completed")
-		} else {
-			if err == nil {
-				logger.Infof("Property Create pending")
-			} else {
-				logger.Errorf("Property Create failed [%s]", err.Error())
-				return diag.Errorf("property Create failed [%s]", err.Error())
-			}
-		}
+	diags := waitForPropagation(ctx, d, domain, "Property Create", m)
+	if diags.HasError() {
+		return diags
 	}
 
 	// Give terraform the ID. Format domain::property
 	propertyResourceID := fmt.Sprintf("%s:%s", domain, cStatus.Resource.Name)
 	logger.Debugf("Generated Property resource ID: %s", propertyResourceID)
 	d.SetId(propertyResourceID)
-	return resourceGTMv1PropertyRead(ctx, d, m)
+	return append(diags, resourceGTMv1PropertyRead(ctx, d, m)...)
 
 }
 
@@ -669,6 +670,10 @@ func resourceGTMv1PropertyUpdate(ctx context.Context, d *schema.ResourceData, m
 	)
 
 	logger.Debugf("Updating Property: %s", d.Id())
+	if !d.HasChangeExcept("timeouts") {
+		logger.Debug("Only timeouts were updated, skipping")
+		return nil
+	}
 	// pull domain and property out of resource id
 	domain, property, err := parseResourceStringID(d.Id())
 	if err != nil {
@@ -690,6 +695,19 @@ func resourceGTMv1PropertyUpdate(ctx context.Context, d *schema.ResourceData, m
 		return diag.FromErr(err)
 	}
 	logger.Debugf("Updating Property PROPOSED: %v", existProp)
+
+	batchUpdate, err := tf.GetBoolValue("batch_update", d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if batchUpdate {
+		diags := submitBatchedChange(ctx, d, domain, "Property Update", m, upsertChange(domainProperties, propertyKey, *newProp))
+		if diags.HasError() {
+			return diags
+		}
+		return append(diags, resourceGTMv1PropertyRead(ctx, d, m)...)
+	}
+
 	uStat, err := Client(meta).UpdateProperty(ctx, gtm.UpdatePropertyRequest{
 		Property:   newProp,
 		DomainName: domain,
@@ -704,26 +722,12 @@ func resourceGTMv1PropertyUpdate(ctx context.Context, d *schema.ResourceData, m
 		return diag.FromErr(fmt.Errorf(uStat.Status.Message))
 	}
 
-	waitOnComplete, err := tf.GetBoolValue("wait_on_complete", d)
-	if err != nil {
-		return diag.FromErr(err)
+	diags := waitForPropagation(ctx, d, domain, "Property Update", m)
+	if diags.HasError() {
+		return diags
 	}
 
-	if waitOnComplete {
-		done, err := waitForCompletion(ctx, domain, m)
-		if done {
-			logger.Infof("Property Update completed")
-		} else {
-			if err == nil {
-				logger.Infof("Property Update pending")
-			} else {
-				logger.Errorf("Property Update failed [%s]", err.Error())
-				return diag.Errorf("property Update failed [%s]", err.Error())
-			}
-		}
-	}
-
-	return resourceGTMv1PropertyRead(ctx, d, m)
+	return append(diags, resourceGTMv1PropertyRead(ctx, d, m)...)
 }
 
 // Import GTM Property.
@@ -755,6 +759,9 @@ func resourceGTMv1PropertyImport(d *schema.ResourceData, m interface{}) ([]*sche
 	if err := d.Set("wait_on_complete", true); err != nil {
 		return nil, err
 	}
+	if err := d.Set("batch_update", false); err != nil {
+		return nil, err
+	}
 	populateTerraformPropertyState(d, prop, m)
 
 	// use same Id as passed in
@@ -788,6 +795,20 @@ func resourceGTMv1PropertyDelete(ctx context.Context, d *schema.ResourceData, m
 	}
 	newProp := createPropertyStruct(existProp)
 	logger.Debugf("Deleting Property: %v", newProp)
+
+	batchUpdate, err := tf.GetBoolValue("batch_update", d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if batchUpdate {
+		diags := submitBatchedChange(ctx, d, domain, "Property Delete", m, removeChange(domainProperties, propertyKey, property))
+		if diags.HasError() {
+			return diags
+		}
+		d.SetId("")
+		return diags
+	}
+
 	uStat, err := Client(meta).DeleteProperty(ctx, gtm.DeletePropertyRequest{
 		PropertyName: property,
 		DomainName:   domain,
@@ -802,28 +823,14 @@ func resourceGTMv1PropertyDelete(ctx context.Context, d *schema.ResourceData, m
 		return diag.FromErr(fmt.Errorf(uStat.Status.Message))
 	}
 
-	waitOnComplete, err := tf.GetBoolValue("wait_on_complete", d)
-	if err != nil {
-		return diag.FromErr(err)
-	}
-
-	if waitOnComplete {
-		done, err := waitForCompletion(ctx, domain, m)
-		if done {
-			logger.Infof("Property Delete completed")
-		} else {
-			if err == nil {
-				logger.Infof("Property Delete pending")
-			} else {
-				logger.Errorf("Property Delete failed [%s]", err.Error())
-				return diag.Errorf("property Delete failed [%s]", err.Error())
-			}
-		}
+	diags := waitForPropagation(ctx, d, domain, "Property Delete", m)
+	if diags.HasError() {
+		return diags
 	}
 
 	// if successful ....
 	d.SetId("")
-	return nil
+	return diags
 }
 
 // nolint:gocyclo
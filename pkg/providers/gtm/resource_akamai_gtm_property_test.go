@@ -434,6 +434,15 @@ func TestResGTMProperty(t *testing.T) {
 				},
 			},
 		},
+		"create property with test_timeout not less than test_interval - validation error": {
+			property: getBasicProperty(),
+			steps: []resource.TestStep{
+				{
+					Config:      testutils.LoadFixtureString(t, "testdata/TestResGtmProperty/liveness_test/timeout_not_less_than_interval.tf"),
+					ExpectError: regexp.MustCompile(`Error: attribute 'test_timeout' \(40\) must be less than 'test_interval' \(40\)`),
+				},
+			},
+		},
 	}
 
 	for name, test := range tests {
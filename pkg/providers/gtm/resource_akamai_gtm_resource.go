@@ -24,6 +24,7 @@ func resourceGTMv1Resource() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			State: resourceGTMv1ResourceImport,
 		},
+		Timeouts: resourceTimeouts(),
 		Schema: map[string]*schema.Schema{
 			"domain": {
 				Type:     schema.TypeString,
@@ -34,6 +35,8 @@ func resourceGTMv1Resource() *schema.Resource {
 				Optional: true,
 				Default:  true,
 			},
+			"timeouts":     timeoutsSchema(),
+			"batch_update": batchUpdateSchema(),
 			"name": {
 				Type:     schema.TypeString,
 				Required: true,
@@ -142,6 +145,20 @@ func resourceGTMv1ResourceCreate(ctx context.Context, d *schema.ResourceData, m
 		return diag.FromErr(err)
 	}
 	logger.Debugf("Proposed New Resource: [%v]", newRsrc)
+
+	batchUpdate, err := tf.GetBoolValue("batch_update", d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if batchUpdate {
+		diags = append(diags, submitBatchedChange(ctx, d, domain, "Resource Create", m, upsertChange(domainResources, resourceKey, *newRsrc))...)
+		if diags.HasError() {
+			return diags
+		}
+		d.SetId(fmt.Sprintf("%s:%s", domain, newRsrc.Name))
+		return append(diags, resourceGTMv1ResourceRead(ctx, d, m)...)
+	}
+
 	cStatus, err := Client(meta).CreateResource(ctx, gtm.CreateResourceRequest{
 		Resource:   newRsrc,
 		DomainName: domain,
@@ -163,34 +180,16 @@ func resourceGTMv1ResourceCreate(ctx context.Context, d *schema.ResourceData, m
 		})
 	}
 
-	waitOnComplete, err := tf.GetBoolValue("wait_on_complete", d)
-	if err != nil {
-		return diag.FromErr(err)
-	}
-
-	if waitOnComplete {
-		done, err := waitForCompletion(ctx, domain, m)
-		if done {
-			logger.Infof("Resource Create completed")
-		} else {
-			if err == nil {
-				logger.Infof("Resource Create pending")
-			} else {
-				logger.Errorf("Resource Create failed [%s]", err.Error())
-				return append(diags, diag.Diagnostic{
-					Severity: diag.Error,
-					Summary:  "Resource Create failed",
-					Detail:   err.Error(),
-				})
-			}
-		}
+	diags = append(diags, waitForPropagation(ctx, d, domain, "Resource Create", m)...)
+	if diags.HasError() {
+		return diags
 	}
 
 	// Give terraform the ID. Format domain:resource
 	resourceID := fmt.Sprintf("%s:%s", domain, cStatus.Resource.Name)
 	logger.Debugf("Generated Resource. Resource ID: %s", resourceID)
 	d.SetId(resourceID)
-	return resourceGTMv1ResourceRead(ctx, d, m)
+	return append(diags, resourceGTMv1ResourceRead(ctx, d, m)...)
 
 }
 
@@ -246,6 +245,10 @@ func resourceGTMv1ResourceUpdate(ctx context.Context, d *schema.ResourceData, m
 	)
 
 	logger.Infof("Updating Resource %s", d.Id())
+	if !d.HasChangeExcept("timeouts") {
+		logger.Debug("Only timeouts were updated, skipping")
+		return nil
+	}
 	var diags diag.Diagnostics
 	// pull domain and resource out of id
 	domain, resource, err := parseResourceStringID(d.Id())
@@ -272,6 +275,19 @@ func resourceGTMv1ResourceUpdate(ctx context.Context, d *schema.ResourceData, m
 		return diag.FromErr(err)
 	}
 	logger.Debugf("Updating Resource PROPOSED: %v", existRsrc)
+
+	batchUpdate, err := tf.GetBoolValue("batch_update", d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if batchUpdate {
+		diags = append(diags, submitBatchedChange(ctx, d, domain, "Resource Update", m, upsertChange(domainResources, resourceKey, *newRsrc))...)
+		if diags.HasError() {
+			return diags
+		}
+		return append(diags, resourceGTMv1ResourceRead(ctx, d, m)...)
+	}
+
 	uStat, err := Client(meta).UpdateResource(ctx, gtm.UpdateResourceRequest{
 		Resource:   newRsrc,
 		DomainName: domain,
@@ -293,30 +309,12 @@ func resourceGTMv1ResourceUpdate(ctx context.Context, d *schema.ResourceData, m
 		})
 	}
 
-	waitOnComplete, err := tf.GetBoolValue("wait_on_complete", d)
-	if err != nil {
-		return diag.FromErr(err)
+	diags = append(diags, waitForPropagation(ctx, d, domain, "Resource Update", m)...)
+	if diags.HasError() {
+		return diags
 	}
 
-	if waitOnComplete {
-		done, err := waitForCompletion(ctx, domain, m)
-		if done {
-			logger.Infof("Resource update completed")
-		} else {
-			if err == nil {
-				logger.Infof("Resource update pending")
-			} else {
-				logger.Errorf("Resource update failed [%s]", err.Error())
-				return append(diags, diag.Diagnostic{
-					Severity: diag.Error,
-					Summary:  "Resource Update failed",
-					Detail:   err.Error(),
-				})
-			}
-		}
-	}
-
-	return resourceGTMv1ResourceRead(ctx, d, m)
+	return append(diags, resourceGTMv1ResourceRead(ctx, d, m)...)
 }
 
 // Import GTM Resource.
@@ -351,6 +349,9 @@ func resourceGTMv1ResourceImport(d *schema.ResourceData, m interface{}) ([]*sche
 	if err != nil {
 		return nil, err
 	}
+	if err := d.Set("batch_update", false); err != nil {
+		return nil, err
+	}
 	if err = populateTerraformResourceState(d, rsrc, m); err != nil {
 		return nil, err
 	}
@@ -396,6 +397,20 @@ func resourceGTMv1ResourceDelete(ctx context.Context, d *schema.ResourceData, m
 	}
 	newRsrc := createResourceStruct(existRsrc)
 	logger.Debugf("Deleting Resource: %v", newRsrc)
+
+	batchUpdate, err := tf.GetBoolValue("batch_update", d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if batchUpdate {
+		diags = append(diags, submitBatchedChange(ctx, d, domain, "Resource Delete", m, removeChange(domainResources, resourceKey, resource))...)
+		if diags.HasError() {
+			return diags
+		}
+		d.SetId("")
+		return diags
+	}
+
 	uStat, err := Client(meta).DeleteResource(ctx, gtm.DeleteResourceRequest{
 		ResourceName: resource,
 		DomainName:   domain,
@@ -417,32 +432,14 @@ func resourceGTMv1ResourceDelete(ctx context.Context, d *schema.ResourceData, m
 		})
 	}
 
-	waitOnComplete, err := tf.GetBoolValue("wait_on_complete", d)
-	if err != nil {
-		return diag.FromErr(err)
-	}
-
-	if waitOnComplete {
-		done, err := waitForCompletion(ctx, domain, m)
-		if done {
-			logger.Infof("Resource Delete completed")
-		} else {
-			if err == nil {
-				logger.Infof("Resource Delete pending")
-			} else {
-				logger.Errorf("Resource Delete failed [%s]", err.Error())
-				return append(diags, diag.Diagnostic{
-					Severity: diag.Error,
-					Summary:  "Resource Delete failed",
-					Detail:   err.Error(),
-				})
-			}
-		}
+	diags = append(diags, waitForPropagation(ctx, d, domain, "Resource Delete", m)...)
+	if diags.HasError() {
+		return diags
 	}
 
 	// if successful ....
 	d.SetId("")
-	return nil
+	return diags
 }
 
 // Create and populate a new resource object from resource data
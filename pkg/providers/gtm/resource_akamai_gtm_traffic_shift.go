@@ -0,0 +1,560 @@
+package gtm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v9/pkg/gtm"
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v9/pkg/session"
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/common/tf"
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/common/timeouts"
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/meta"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"golang.org/x/exp/slices"
+)
+
+type (
+	// trafficShift describes a gradual change of traffic target weights of a GTM property
+	trafficShift struct {
+		domain          string
+		property        string
+		weights         map[int]float64
+		steps           int
+		dwell           time.Duration
+		rollback        bool
+		checkLiveness   bool
+		maxFailedChecks int
+	}
+)
+
+var (
+	// ErrTrafficShiftAborted is returned when the health check between the traffic shift steps fails
+	ErrTrafficShiftAborted = errors.New("traffic shift aborted")
+
+	// shiftRollbackTimeout limits restoring of the initial weights when the shift is aborted
+	shiftRollbackTimeout = 10 * time.Minute
+
+	// weightedPropertyTypes lists property types which distribute the traffic by the traffic target weights
+	weightedPropertyTypes = []string{"weighted-round-robin", "weighted-hashed", "weighted-round-robin-load-feedback"}
+)
+
+func resourceGTMv1TrafficShift() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceGTMv1TrafficShiftCreate,
+		ReadContext:   resourceGTMv1TrafficShiftRead,
+		UpdateContext: resourceGTMv1TrafficShiftUpdate,
+		DeleteContext: resourceGTMv1TrafficShiftDelete,
+		CustomizeDiff: customDiffGTMTrafficShift,
+		Timeouts:      resourceTimeouts(),
+		Description: "Gradually shifts traffic of a weighted GTM property to the given traffic target weights. " +
+			"The timeouts have to cover all steps including the dwell time between them.",
+		Schema: map[string]*schema.Schema{
+			"domain": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Name of the GTM domain",
+			},
+			"property": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Name of the weighted GTM property whose traffic is shifted",
+			},
+			"traffic_target": {
+				Type:        schema.TypeList,
+				Required:    true,
+				MinItems:    1,
+				Description: "Weights the traffic targets of the property reach after the last step. Traffic targets not listed keep their weights",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"datacenter_id": {
+							Type:        schema.TypeInt,
+							Required:    true,
+							Description: "Identifier of the datacenter of the traffic target",
+						},
+						"weight": {
+							Type:         schema.TypeFloat,
+							Required:     true,
+							ValidateFunc: validation.FloatAtLeast(0),
+							Description:  "Weight of the traffic target after the shift",
+						},
+					},
+				},
+			},
+			"steps": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      5,
+				ValidateFunc: validation.IntBetween(1, 100),
+				Description:  "Number of steps in which the weights are moved to the desired values",
+			},
+			"dwell_time": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Default:          "5m",
+				ValidateDiagFunc: timeouts.ValidateDurationFormat,
+				Description:      "Time to wait after each propagated step before the health of the domain is checked and the next step is applied",
+			},
+			"check_liveness": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+				Description: "If set, the enabled HTTP, HTTPS, TCP and TCPS liveness tests of the property are executed from the machine running Terraform " +
+					"against the servers of every traffic target receiving traffic after each step. Liveness tests of other protocols are skipped",
+			},
+			"max_failed_liveness_checks": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      0,
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "Number of failed liveness checks tolerated per datacenter before the shift is aborted",
+			},
+			"rollback_on_abort": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "If set, the weights present before the shift are restored when the shift is aborted",
+			},
+			"timeouts": timeoutsSchema(),
+		},
+	}
+}
+
+// customDiffGTMTrafficShift validates the desired traffic target weights
+func customDiffGTMTrafficShift(_ context.Context, d *schema.ResourceDiff, m interface{}) error {
+	meta := meta.Must(m)
+	logger := meta.Log("Akamai GTM", "customDiffGTMTrafficShift")
+	logger.Debug("customDiffGTMTrafficShift")
+
+	trafficTargets, ok := d.Get("traffic_target").([]interface{})
+	if !ok {
+		return fmt.Errorf("could not cast the value of type %T to []interface{}", d.Get("traffic_target"))
+	}
+	datacenters := make(map[int]struct{}, len(trafficTargets))
+	for _, ttRaw := range trafficTargets {
+		tt, ok := ttRaw.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("could not cast the value of type %T to map[string]interface{}", ttRaw)
+		}
+		datacenterID, ok := tt["datacenter_id"].(int)
+		if !ok {
+			return fmt.Errorf("could not cast the value of type %T to int", tt["datacenter_id"])
+		}
+		if _, ok := datacenters[datacenterID]; ok {
+			return fmt.Errorf("'traffic_target' with datacenter_id %d is defined more than once", datacenterID)
+		}
+		datacenters[datacenterID] = struct{}{}
+	}
+	return nil
+}
+
+// Create GTM Traffic Shift
+func resourceGTMv1TrafficShiftCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	meta := meta.Must(m)
+	logger := meta.Log("Akamai GTM", "resourceGTMv1TrafficShiftCreate")
+	// create a context with logging for api calls
+	ctx = session.ContextWithOptions(
+		ctx,
+		session.WithContextLog(logger),
+	)
+
+	shift, err := trafficShiftFromResourceData(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	logger.Infof("Shifting traffic of property [%s] in domain [%s]", shift.property, shift.domain)
+	if err = shift.run(ctx, m); err != nil {
+		logger.Errorf("Traffic Shift Create failed: %s", err.Error())
+		return diag.Errorf("traffic shift Create failed: %s", err.Error())
+	}
+
+	d.SetId(fmt.Sprintf("%s:%s", shift.domain, shift.property))
+	return resourceGTMv1TrafficShiftRead(ctx, d, m)
+}
+
+// Read GTM Traffic Shift reflects the current weights of the shifted traffic targets
+func resourceGTMv1TrafficShiftRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	meta := meta.Must(m)
+	logger := meta.Log("Akamai GTM", "resourceGTMv1TrafficShiftRead")
+	// create a context with logging for api calls
+	ctx = session.ContextWithOptions(
+		ctx,
+		session.WithContextLog(logger),
+	)
+
+	logger.Debugf("Reading Traffic Shift: %s", d.Id())
+	domain, property, err := parseResourceStringID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	prop, err := Client(meta).GetProperty(ctx, gtm.GetPropertyRequest{
+		PropertyName: property,
+		DomainName:   domain,
+	})
+	if errors.Is(err, gtm.ErrNotFound) {
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		logger.Errorf("Traffic Shift Read failed: GetProperty error: %s", err.Error())
+		return diag.Errorf("traffic shift Read failed: GetProperty error: %s", err.Error())
+	}
+
+	current := trafficTargetWeights(prop.TrafficTargets)
+	ttStateList, err := tf.GetInterfaceArrayValue("traffic_target", d)
+	if err != nil && !errors.Is(err, tf.ErrNotFound) {
+		return diag.FromErr(err)
+	}
+	kept := make([]interface{}, 0, len(ttStateList))
+	for _, ttRaw := range ttStateList {
+		tt := ttRaw.(map[string]interface{})
+		weight, ok := current[tt["datacenter_id"].(int)]
+		if !ok {
+			logger.Warnf("Traffic Shift TrafficTarget %d NOT FOUND in returned GTM Property", tt["datacenter_id"])
+			continue
+		}
+		tt["weight"] = weight
+		kept = append(kept, tt)
+	}
+	if err := d.Set("traffic_target", kept); err != nil {
+		return diag.FromErr(err)
+	}
+	return nil
+}
+
+// Update GTM Traffic Shift starts a new shift from the current weights to the desired ones
+func resourceGTMv1TrafficShiftUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	meta := meta.Must(m)
+	logger := meta.Log("Akamai GTM", "resourceGTMv1TrafficShiftUpdate")
+	// create a context with logging for api calls
+	ctx = session.ContextWithOptions(
+		ctx,
+		session.WithContextLog(logger),
+	)
+
+	logger.Debugf("Updating Traffic Shift: %s", d.Id())
+	if !d.HasChange("traffic_target") {
+		logger.Debug("Traffic target weights were not updated, skipping")
+		return nil
+	}
+
+	shift, err := trafficShiftFromResourceData(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if err = shift.run(ctx, m); err != nil {
+		// keep the previous weights in the state, so the shift is planned again
+		d.Partial(true)
+		logger.Errorf("Traffic Shift Update failed: %s", err.Error())
+		return diag.Errorf("traffic shift Update failed: %s", err.Error())
+	}
+
+	return resourceGTMv1TrafficShiftRead(ctx, d, m)
+}
+
+// Delete GTM Traffic Shift only removes the resource from the state, the property keeps its weights
+func resourceGTMv1TrafficShiftDelete(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	meta := meta.Must(m)
+	logger := meta.Log("Akamai GTM", "resourceGTMv1TrafficShiftDelete")
+
+	logger.Debugf("Deleting Traffic Shift: %s, property weights are left unchanged", d.Id())
+	d.SetId("")
+	return nil
+}
+
+// trafficShiftFromResourceData reads the traffic shift configuration from the resource data
+func trafficShiftFromResourceData(d *schema.ResourceData) (*trafficShift, error) {
+	domain, err := tf.GetStringValue("domain", d)
+	if err != nil {
+		return nil, err
+	}
+	property, err := tf.GetStringValue("property", d)
+	if err != nil {
+		return nil, err
+	}
+	steps, err := tf.GetIntValue("steps", d)
+	if err != nil {
+		return nil, err
+	}
+	dwellTime, err := tf.GetStringValue("dwell_time", d)
+	if err != nil {
+		return nil, err
+	}
+	dwell, err := time.ParseDuration(dwellTime)
+	if err != nil {
+		return nil, err
+	}
+	rollback, err := tf.GetBoolValue("rollback_on_abort", d)
+	if err != nil {
+		return nil, err
+	}
+	checkLiveness, err := tf.GetBoolValue("check_liveness", d)
+	if err != nil {
+		return nil, err
+	}
+	maxFailedChecks, err := tf.GetIntValue("max_failed_liveness_checks", d)
+	if err != nil && !errors.Is(err, tf.ErrNotFound) {
+		return nil, err
+	}
+	trafficTargets, err := tf.GetInterfaceArrayValue("traffic_target", d)
+	if err != nil {
+		return nil, err
+	}
+	weights := make(map[int]float64, len(trafficTargets))
+	for _, ttRaw := range trafficTargets {
+		tt := ttRaw.(map[string]interface{})
+		weights[tt["datacenter_id"].(int)] = tt["weight"].(float64)
+	}
+
+	return &trafficShift{
+		domain:          domain,
+		property:        property,
+		weights:         weights,
+		steps:           steps,
+		dwell:           dwell,
+		rollback:        rollback,
+		checkLiveness:   checkLiveness,
+		maxFailedChecks: maxFailedChecks,
+	}, nil
+}
+
+// run moves the traffic target weights of the property to the desired values in steps. Each step is
+// propagated and followed by the dwell time, after which the domain health is checked. If the check fails,
+// the shift is aborted and, if requested, the initial weights are restored.
+func (s *trafficShift) run(ctx context.Context, m interface{}) error {
+	meta := meta.Must(m)
+	logger := meta.Log("Akamai GTM", "trafficShift")
+
+	prop, err := Client(meta).GetProperty(ctx, gtm.GetPropertyRequest{
+		PropertyName: s.property,
+		DomainName:   s.domain,
+	})
+	if err != nil {
+		return fmt.Errorf("GetProperty error: %s", err.Error())
+	}
+	if !slices.Contains(weightedPropertyTypes, prop.Type) {
+		return fmt.Errorf("property [%s] of type '%s' does not distribute traffic by weights, supported types: %v",
+			s.property, prop.Type, weightedPropertyTypes)
+	}
+	initial := trafficTargetWeights(prop.TrafficTargets)
+	for datacenterID := range s.weights {
+		if _, ok := initial[datacenterID]; !ok {
+			return fmt.Errorf("property [%s] has no traffic target with datacenter_id %d", s.property, datacenterID)
+		}
+	}
+
+	for step := 1; step <= s.steps; step++ {
+		stepProp := createPropertyStruct(prop)
+		stepProp.TrafficTargets = s.stepTrafficTargets(prop.TrafficTargets, initial, step)
+		logger.Infof("Traffic Shift step %d/%d of property [%s]", step, s.steps, s.property)
+		if err := s.apply(ctx, stepProp, m); err != nil {
+			return s.abort(ctx, prop, fmt.Errorf("step %d/%d: %w", step, s.steps, err), m)
+		}
+		if step == s.steps {
+			break
+		}
+
+		logger.Debugf("Traffic Shift dwelling for %v", s.dwell)
+		select {
+		case <-time.After(s.dwell):
+		case <-ctx.Done():
+			return s.abort(ctx, prop, fmt.Errorf("step %d/%d: dwell time interrupted: %w", step, s.steps, ctx.Err()), m)
+		}
+		if err := s.checkHealth(ctx, m); err != nil {
+			return s.abort(ctx, prop, fmt.Errorf("%w after step %d/%d: %s", ErrTrafficShiftAborted, step, s.steps, err), m)
+		}
+	}
+	return nil
+}
+
+// stepTrafficTargets returns the traffic targets with weights interpolated between the initial
+// and the desired ones for the given step
+func (s *trafficShift) stepTrafficTargets(trafficTargets []gtm.TrafficTarget, initial map[int]float64, step int) []gtm.TrafficTarget {
+	result := make([]gtm.TrafficTarget, len(trafficTargets))
+	copy(result, trafficTargets)
+	for i, tt := range result {
+		target, ok := s.weights[tt.DatacenterID]
+		if !ok {
+			continue
+		}
+		if step == s.steps {
+			result[i].Weight = target
+			continue
+		}
+		start := initial[tt.DatacenterID]
+		result[i].Weight = start + (target-start)*float64(step)/float64(s.steps)
+	}
+	return result
+}
+
+// apply validates and submits the property and waits for its propagation
+func (s *trafficShift) apply(ctx context.Context, prop *gtm.Property, m interface{}) error {
+	meta := meta.Must(m)
+	logger := meta.Log("Akamai GTM", "trafficShift")
+
+	if err := validatePropertyTrafficTargets(prop.Type, trafficTargetsToList(prop.TrafficTargets)); err != nil {
+		return err
+	}
+	if !hasActiveTrafficTarget(prop.TrafficTargets) {
+		return fmt.Errorf("at least one enabled 'traffic_target' has to have a positive weight")
+	}
+
+	uStat, err := Client(meta).UpdateProperty(ctx, gtm.UpdatePropertyRequest{
+		Property:   prop,
+		DomainName: s.domain,
+	})
+	if err != nil {
+		return fmt.Errorf("UpdateProperty error: %s", err.Error())
+	}
+	logger.Debugf("Traffic Shift update status: %v", uStat)
+	if uStat.Status.PropagationStatus == "DENIED" {
+		return errors.New(uStat.Status.Message)
+	}
+	return waitForCompletion(ctx, s.domain, m)
+}
+
+// checkHealth verifies that the domain still passes validation, that every traffic target of the property
+// receiving traffic is enabled and, if requested, that its servers pass the liveness tests of the property
+func (s *trafficShift) checkHealth(ctx context.Context, m interface{}) error {
+	meta := meta.Must(m)
+
+	status, err := Client(meta).GetDomainStatus(ctx, gtm.GetDomainStatusRequest{
+		DomainName: s.domain,
+	})
+	if err != nil {
+		return fmt.Errorf("GetDomainStatus error: %s", err.Error())
+	}
+	if status.PropagationStatus == "DENIED" || !status.PassingValidation {
+		return fmt.Errorf("domain [%s] is not passing validation: %s", s.domain, status.Message)
+	}
+
+	prop, err := Client(meta).GetProperty(ctx, gtm.GetPropertyRequest{
+		PropertyName: s.property,
+		DomainName:   s.domain,
+	})
+	if err != nil {
+		return fmt.Errorf("GetProperty error: %s", err.Error())
+	}
+	for _, tt := range prop.TrafficTargets {
+		if tt.Weight > 0 && !tt.Enabled {
+			return fmt.Errorf("traffic target with datacenter_id %d receives traffic but is disabled", tt.DatacenterID)
+		}
+	}
+	if len(prop.LivenessTests) > 0 && !slices.ContainsFunc(prop.LivenessTests, func(lt gtm.LivenessTest) bool {
+		return !lt.Disabled
+	}) {
+		return fmt.Errorf("all liveness tests of property [%s] are disabled", s.property)
+	}
+	if !s.checkLiveness {
+		return nil
+	}
+	return s.checkTrafficTargetsLiveness(ctx, prop, m)
+}
+
+// checkTrafficTargetsLiveness executes the enabled liveness tests of the property against the servers of every
+// traffic target receiving traffic. It fails when more checks of a datacenter fail than tolerated.
+func (s *trafficShift) checkTrafficTargetsLiveness(ctx context.Context, prop *gtm.GetPropertyResponse, m interface{}) error {
+	meta := meta.Must(m)
+	logger := meta.Log("Akamai GTM", "trafficShift")
+
+	var checks []*gtm.LivenessTest
+	for i, lt := range prop.LivenessTests {
+		if lt.Disabled {
+			continue
+		}
+		if !slices.Contains(localLivenessCheckProtocols, lt.TestObjectProtocol) {
+			logger.Debugf("Traffic Shift skipping liveness test [%s]: protocol '%s' cannot be checked locally", lt.Name, lt.TestObjectProtocol)
+			continue
+		}
+		checks = append(checks, &prop.LivenessTests[i])
+	}
+
+	for _, tt := range prop.TrafficTargets {
+		if tt.Weight <= 0 {
+			continue
+		}
+		targets := tt.Servers
+		if len(targets) == 0 && tt.HandoutCName != "" {
+			targets = []string{tt.HandoutCName}
+		}
+		var failures []string
+		for _, lt := range checks {
+			for _, target := range targets {
+				check, err := livenessCheckFromLivenessTest(*lt, target)
+				if err != nil {
+					return fmt.Errorf("liveness test [%s]: %s", lt.Name, err)
+				}
+				result := check.run(ctx)
+				logger.Debugf("Traffic Shift liveness test [%s] of [%s] in datacenter %d: success=%t",
+					lt.Name, target, tt.DatacenterID, result.success)
+				if !result.success {
+					failures = append(failures, fmt.Sprintf("liveness test [%s] of [%s]: %s", lt.Name, target, result.err))
+				}
+			}
+		}
+		if len(failures) > s.maxFailedChecks {
+			return fmt.Errorf("%d liveness checks of datacenter %d failed: %s",
+				len(failures), tt.DatacenterID, strings.Join(failures, "; "))
+		}
+	}
+	return nil
+}
+
+// abort restores the initial property, if requested, and returns the cause of the abort
+func (s *trafficShift) abort(ctx context.Context, initial *gtm.GetPropertyResponse, cause error, m interface{}) error {
+	meta := meta.Must(m)
+	logger := meta.Log("Akamai GTM", "trafficShift")
+
+	if !s.rollback {
+		return cause
+	}
+	logger.Warnf("Traffic Shift of property [%s] aborted, restoring initial weights: %s", s.property, cause)
+	// the operation context may already be done, the rollback still has to be attempted
+	rollbackCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), shiftRollbackTimeout)
+	defer cancel()
+	if err := s.apply(rollbackCtx, createPropertyStruct(initial), m); err != nil {
+		return fmt.Errorf("%w; restoring initial weights failed: %s", cause, err)
+	}
+	return fmt.Errorf("%w; initial weights were restored", cause)
+}
+
+// trafficTargetWeights returns the traffic target weights by datacenter
+func trafficTargetWeights(trafficTargets []gtm.TrafficTarget) map[int]float64 {
+	weights := make(map[int]float64, len(trafficTargets))
+	for _, tt := range trafficTargets {
+		weights[tt.DatacenterID] = tt.Weight
+	}
+	return weights
+}
+
+// trafficTargetsToList converts the traffic targets to the `traffic_target` schema format
+func trafficTargetsToList(trafficTargets []gtm.TrafficTarget) []interface{} {
+	list := make([]interface{}, 0, len(trafficTargets))
+	for _, tt := range trafficTargets {
+		ttMap := map[string]interface{}{
+			"datacenter_id": tt.DatacenterID,
+			"enabled":       tt.Enabled,
+			"weight":        tt.Weight,
+			"handout_cname": tt.HandoutCName,
+			"servers":       tt.Servers,
+			"precedence":    0,
+		}
+		if tt.Precedence != nil {
+			ttMap["precedence"] = *tt.Precedence
+		}
+		list = append(list, ttMap)
+	}
+	return list
+}
+
+func hasActiveTrafficTarget(trafficTargets []gtm.TrafficTarget) bool {
+	return slices.ContainsFunc(trafficTargets, func(tt gtm.TrafficTarget) bool {
+		return tt.Enabled && tt.Weight > 0
+	})
+}
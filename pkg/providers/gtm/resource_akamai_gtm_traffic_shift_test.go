@@ -0,0 +1,273 @@
+package gtm
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v9/pkg/gtm"
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v9/pkg/session"
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/meta"
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTrafficShiftStepTrafficTargets(t *testing.T) {
+	shift := &trafficShift{weights: map[int]float64{3131: 0, 3132: 100}, steps: 4}
+	trafficTargets := []gtm.TrafficTarget{
+		{DatacenterID: 3131, Enabled: true, Weight: 100},
+		{DatacenterID: 3132, Enabled: true, Weight: 0},
+		{DatacenterID: 3133, Enabled: true, Weight: 10},
+	}
+	initial := trafficTargetWeights(trafficTargets)
+
+	tests := map[int][]float64{
+		1: {75, 25, 10},
+		2: {50, 50, 10},
+		4: {0, 100, 10},
+	}
+	for step, expected := range tests {
+		result := shift.stepTrafficTargets(trafficTargets, initial, step)
+		for i, tt := range result {
+			assert.Equal(t, expected[i], tt.Weight, "step %d, datacenter %d", step, tt.DatacenterID)
+		}
+	}
+	// initial traffic targets are left unchanged
+	assert.Equal(t, float64(100), trafficTargets[0].Weight)
+}
+
+func TestTrafficShiftRun(t *testing.T) {
+	m, err := meta.New(session.Must(session.New()), hclog.NewNullLogger(), "")
+	require.NoError(t, err)
+
+	propertyWithWeights := func(weights ...float64) *gtm.GetPropertyResponse {
+		prop := &gtm.GetPropertyResponse{Name: "tfexample_prop_1", Type: "weighted-round-robin"}
+		for i, weight := range weights {
+			prop.TrafficTargets = append(prop.TrafficTargets, gtm.TrafficTarget{DatacenterID: 3131 + i, Enabled: true, Weight: weight})
+		}
+		return prop
+	}
+	expectUpdate := func(client *gtm.Mock, weights ...float64) {
+		client.On("UpdateProperty", mock.Anything, mock.MatchedBy(func(req gtm.UpdatePropertyRequest) bool {
+			if len(req.Property.TrafficTargets) != len(weights) {
+				return false
+			}
+			for i, tt := range req.Property.TrafficTargets {
+				if tt.Weight != weights[i] {
+					return false
+				}
+			}
+			return req.DomainName == gtmTestDomain
+		})).Return(&gtm.UpdatePropertyResponse{Status: &gtm.ResponseStatus{PropagationStatus: "PENDING"}}, nil).Once()
+	}
+	getPropertyRequest := gtm.GetPropertyRequest{PropertyName: "tfexample_prop_1", DomainName: gtmTestDomain}
+	getDomainStatusRequest := gtm.GetDomainStatusRequest{DomainName: gtmTestDomain}
+
+	t.Run("weights are shifted in steps", func(t *testing.T) {
+		client := &gtm.Mock{}
+		client.On("GetProperty", mock.Anything, getPropertyRequest).Return(propertyWithWeights(100, 0), nil).Once()
+		expectUpdate(client, 50, 50)
+		expectUpdate(client, 0, 100)
+		client.On("GetDomainStatus", mock.Anything, getDomainStatusRequest).Return(getDomainStatusResponseStatus, nil).Times(3)
+		client.On("GetProperty", mock.Anything, getPropertyRequest).Return(propertyWithWeights(50, 50), nil).Once()
+
+		shift := &trafficShift{
+			domain:   gtmTestDomain,
+			property: "tfexample_prop_1",
+			weights:  map[int]float64{3131: 0, 3132: 100},
+			steps:    2,
+			dwell:    time.Millisecond,
+		}
+		useClient(client, func() {
+			assert.NoError(t, shift.run(context.Background(), m))
+		})
+		client.AssertExpectations(t)
+	})
+
+	t.Run("failed health check aborts the shift and restores initial weights", func(t *testing.T) {
+		client := &gtm.Mock{}
+		client.On("GetProperty", mock.Anything, getPropertyRequest).Return(propertyWithWeights(100, 0), nil).Once()
+		expectUpdate(client, 50, 50)
+		client.On("GetDomainStatus", mock.Anything, getDomainStatusRequest).Return(getDomainStatusResponseStatus, nil).Once()
+		client.On("GetDomainStatus", mock.Anything, getDomainStatusRequest).Return(&gtm.GetDomainStatusResponse{
+			PropagationStatus: "COMPLETE",
+			PassingValidation: false,
+			Message:           "datacenter 3132 has no live servers",
+		}, nil).Once()
+		expectUpdate(client, 100, 0)
+		client.On("GetDomainStatus", mock.Anything, getDomainStatusRequest).Return(getDomainStatusResponseStatus, nil).Once()
+
+		shift := &trafficShift{
+			domain:   gtmTestDomain,
+			property: "tfexample_prop_1",
+			weights:  map[int]float64{3131: 0, 3132: 100},
+			steps:    2,
+			dwell:    time.Millisecond,
+			rollback: true,
+		}
+		useClient(client, func() {
+			err := shift.run(context.Background(), m)
+			assert.ErrorIs(t, err, ErrTrafficShiftAborted)
+			assert.ErrorContains(t, err, "datacenter 3132 has no live servers")
+			assert.ErrorContains(t, err, "initial weights were restored")
+		})
+		client.AssertExpectations(t)
+	})
+
+	withLivenessTest := func(prop *gtm.GetPropertyResponse, server *httptest.Server) *gtm.GetPropertyResponse {
+		serverURL, err := url.Parse(server.URL)
+		require.NoError(t, err)
+		port, err := strconv.Atoi(serverURL.Port())
+		require.NoError(t, err)
+		for i := range prop.TrafficTargets {
+			prop.TrafficTargets[i].Servers = []string{serverURL.Hostname()}
+		}
+		prop.LivenessTests = []gtm.LivenessTest{
+			{Name: "health", TestObjectProtocol: "HTTP", TestObject: "/health", TestObjectPort: port, TestTimeout: 1, HTTPError5xx: true},
+			{Name: "dns", TestObjectProtocol: "DNS", TestObjectPort: 53, TestTimeout: 1},
+		}
+		return prop
+	}
+	unhealthyServer := func(t *testing.T) *httptest.Server {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/health", r.URL.Path)
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}))
+		t.Cleanup(server.Close)
+		return server
+	}
+
+	t.Run("failed liveness check aborts the shift in the middle and rollback_on_abort restores initial weights", func(t *testing.T) {
+		server := unhealthyServer(t)
+		client := &gtm.Mock{}
+		client.On("GetProperty", mock.Anything, getPropertyRequest).Return(propertyWithWeights(100, 0), nil).Once()
+		expectUpdate(client, 75, 25)
+		client.On("GetDomainStatus", mock.Anything, getDomainStatusRequest).Return(getDomainStatusResponseStatus, nil).Twice()
+		client.On("GetProperty", mock.Anything, getPropertyRequest).Return(withLivenessTest(propertyWithWeights(75, 25), server), nil).Once()
+		expectUpdate(client, 100, 0)
+		client.On("GetDomainStatus", mock.Anything, getDomainStatusRequest).Return(getDomainStatusResponseStatus, nil).Once()
+
+		shift := &trafficShift{
+			domain:        gtmTestDomain,
+			property:      "tfexample_prop_1",
+			weights:       map[int]float64{3131: 0, 3132: 100},
+			steps:         4,
+			dwell:         time.Millisecond,
+			rollback:      true,
+			checkLiveness: true,
+		}
+		useClient(client, func() {
+			err := shift.run(context.Background(), m)
+			assert.ErrorIs(t, err, ErrTrafficShiftAborted)
+			assert.ErrorContains(t, err, "after step 1/4")
+			assert.ErrorContains(t, err, "1 liveness checks of datacenter 3131 failed: liveness test [health]")
+			assert.ErrorContains(t, err, "503 Service Unavailable")
+			assert.ErrorContains(t, err, "initial weights were restored")
+		})
+		client.AssertExpectations(t)
+	})
+
+	t.Run("failed liveness check aborts the shift without rollback", func(t *testing.T) {
+		server := unhealthyServer(t)
+		client := &gtm.Mock{}
+		client.On("GetProperty", mock.Anything, getPropertyRequest).Return(propertyWithWeights(100, 0), nil).Once()
+		expectUpdate(client, 50, 50)
+		client.On("GetDomainStatus", mock.Anything, getDomainStatusRequest).Return(getDomainStatusResponseStatus, nil).Twice()
+		client.On("GetProperty", mock.Anything, getPropertyRequest).Return(withLivenessTest(propertyWithWeights(50, 50), server), nil).Once()
+
+		shift := &trafficShift{
+			domain:        gtmTestDomain,
+			property:      "tfexample_prop_1",
+			weights:       map[int]float64{3131: 0, 3132: 100},
+			steps:         2,
+			dwell:         time.Millisecond,
+			checkLiveness: true,
+		}
+		useClient(client, func() {
+			err := shift.run(context.Background(), m)
+			assert.ErrorIs(t, err, ErrTrafficShiftAborted)
+			assert.NotContains(t, err.Error(), "initial weights were restored")
+		})
+		client.AssertExpectations(t)
+	})
+
+	t.Run("tolerated failed liveness checks do not abort the shift", func(t *testing.T) {
+		server := unhealthyServer(t)
+		client := &gtm.Mock{}
+		client.On("GetProperty", mock.Anything, getPropertyRequest).Return(propertyWithWeights(100, 0), nil).Once()
+		expectUpdate(client, 50, 50)
+		expectUpdate(client, 0, 100)
+		client.On("GetDomainStatus", mock.Anything, getDomainStatusRequest).Return(getDomainStatusResponseStatus, nil).Times(3)
+		client.On("GetProperty", mock.Anything, getPropertyRequest).Return(withLivenessTest(propertyWithWeights(50, 50), server), nil).Once()
+
+		shift := &trafficShift{
+			domain:          gtmTestDomain,
+			property:        "tfexample_prop_1",
+			weights:         map[int]float64{3131: 0, 3132: 100},
+			steps:           2,
+			dwell:           time.Millisecond,
+			checkLiveness:   true,
+			maxFailedChecks: 1,
+		}
+		useClient(client, func() {
+			assert.NoError(t, shift.run(context.Background(), m))
+		})
+		client.AssertExpectations(t)
+	})
+
+	t.Run("property without weighted traffic distribution is rejected", func(t *testing.T) {
+		client := &gtm.Mock{}
+		prop := propertyWithWeights(100, 0)
+		prop.Type = "failover"
+		client.On("GetProperty", mock.Anything, getPropertyRequest).Return(prop, nil).Once()
+
+		shift := &trafficShift{
+			domain:   gtmTestDomain,
+			property: "tfexample_prop_1",
+			weights:  map[int]float64{3131: 0},
+			steps:    2,
+		}
+		useClient(client, func() {
+			assert.ErrorContains(t, shift.run(context.Background(), m), "does not distribute traffic by weights")
+		})
+		client.AssertExpectations(t)
+	})
+
+	t.Run("unknown datacenter is rejected", func(t *testing.T) {
+		client := &gtm.Mock{}
+		client.On("GetProperty", mock.Anything, getPropertyRequest).Return(propertyWithWeights(100, 0), nil).Once()
+
+		shift := &trafficShift{
+			domain:   gtmTestDomain,
+			property: "tfexample_prop_1",
+			weights:  map[int]float64{4000: 100},
+			steps:    2,
+		}
+		useClient(client, func() {
+			assert.ErrorContains(t, shift.run(context.Background(), m), "has no traffic target with datacenter_id 4000")
+		})
+		client.AssertExpectations(t)
+	})
+
+	t.Run("step without active traffic target is not submitted", func(t *testing.T) {
+		client := &gtm.Mock{}
+		client.On("GetProperty", mock.Anything, getPropertyRequest).Return(propertyWithWeights(100, 0), nil).Once()
+
+		shift := &trafficShift{
+			domain:   gtmTestDomain,
+			property: "tfexample_prop_1",
+			weights:  map[int]float64{3131: 0},
+			steps:    1,
+		}
+		useClient(client, func() {
+			assert.ErrorContains(t, shift.run(context.Background(), m), "has to have a positive weight")
+		})
+		client.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,120 @@
+package networklists
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v9/pkg/networklists"
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v9/pkg/session"
+)
+
+type (
+	// networkListElements submits the changes of single elements of a network list, which are not supported
+	// by the network lists API client
+	networkListElements interface {
+		// AppendElements adds the elements to the network list.
+		//
+		// See: https://techdocs.akamai.com/network-lists/reference/post-network-list-append
+		AppendElements(ctx context.Context, uniqueID string, elements []string) error
+
+		// RemoveElement removes the element from the network list.
+		//
+		// See: https://techdocs.akamai.com/network-lists/reference/delete-network-list-elements
+		RemoveElement(ctx context.Context, uniqueID, element string) error
+	}
+
+	elementsClient struct {
+		session.Session
+	}
+
+	appendElementsRequest struct {
+		List []string `json:"list"`
+	}
+)
+
+// maxRemovedElements is the number of removed elements above which the whole list is submitted at once,
+// as every element is removed with a separate request
+const maxRemovedElements = 100
+
+func (e *elementsClient) AppendElements(ctx context.Context, uniqueID string, list []string) error {
+	e.Log(ctx).Debug("AppendElements")
+
+	postURL := fmt.Sprintf("/network-list/v2/network-lists/%s/append", uniqueID)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, postURL, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create append elements request: %s", err.Error())
+	}
+	resp, err := e.Exec(req, nil, appendElementsRequest{List: list})
+	if err != nil {
+		return fmt.Errorf("append elements request failed: %s", err.Error())
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
+		return elementsError(resp)
+	}
+	return nil
+}
+
+func (e *elementsClient) RemoveElement(ctx context.Context, uniqueID, element string) error {
+	e.Log(ctx).Debug("RemoveElement")
+
+	deleteURL := fmt.Sprintf("/network-list/v2/network-lists/%s/elements?element=%s", uniqueID, url.QueryEscape(element))
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, deleteURL, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create remove element request: %s", err.Error())
+	}
+	resp, err := e.Exec(req, nil)
+	if err != nil {
+		return fmt.Errorf("remove element request failed: %s", err.Error())
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNoContent {
+		return elementsError(resp)
+	}
+	return nil
+}
+
+// elementsError parses the error of the network lists API in the same way as the API client
+func elementsError(resp *http.Response) error {
+	e := networklists.Error{StatusCode: resp.StatusCode}
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		e.Title = "Failed to read error body"
+		e.Detail = err.Error()
+		return &e
+	}
+	if err := json.Unmarshal(body, &e); err != nil {
+		e.Title = "Failed to unmarshal error body. Network Lists API failed. Check details for more information."
+		e.Detail = string(body)
+	}
+	e.StatusCode = resp.StatusCode
+	return &e
+}
+
+// elementChanges returns the elements of the target list which are missing in the current list, and the elements
+// of the current list which are missing in the target list. Elements are compared regardless of their case.
+func elementChanges(current, target []string) (added, removed []string) {
+	currentSet := make(map[string]struct{}, len(current))
+	for _, element := range current {
+		currentSet[strings.ToLower(element)] = struct{}{}
+	}
+	targetSet := make(map[string]struct{}, len(target))
+	for _, element := range target {
+		targetSet[strings.ToLower(element)] = struct{}{}
+		if _, ok := currentSet[strings.ToLower(element)]; !ok {
+			added = append(added, element)
+			currentSet[strings.ToLower(element)] = struct{}{}
+		}
+	}
+	for _, element := range current {
+		if _, ok := targetSet[strings.ToLower(element)]; !ok {
+			removed = append(removed, element)
+		}
+	}
+	return added, removed
+}
@@ -0,0 +1,123 @@
+package networklists
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v9/pkg/edgegrid"
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v9/pkg/networklists"
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v9/pkg/session"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type mockElements struct {
+	mock.Mock
+}
+
+func (m *mockElements) AppendElements(ctx context.Context, uniqueID string, elements []string) error {
+	return m.Called(ctx, uniqueID, elements).Error(0)
+}
+
+func (m *mockElements) RemoveElement(ctx context.Context, uniqueID, element string) error {
+	return m.Called(ctx, uniqueID, element).Error(0)
+}
+
+// useElements swaps out the elements client on the global instance for the duration of the given func
+func useElements(elements networkListElements, f func()) {
+	orig := inst.elements
+	inst.elements = elements
+	defer func() {
+		inst.elements = orig
+	}()
+
+	f()
+}
+
+func TestElementChanges(t *testing.T) {
+	tests := map[string]struct {
+		current, target []string
+		added, removed  []string
+	}{
+		"no changes": {
+			current: []string{"10.1.8.23", "10.3.5.67"},
+			target:  []string{"10.3.5.67", "10.1.8.23"},
+		},
+		"added and removed elements": {
+			current: []string{"10.1.8.23", "10.3.5.67"},
+			target:  []string{"10.3.5.67", "10.5.0.0/16"},
+			added:   []string{"10.5.0.0/16"},
+			removed: []string{"10.1.8.23"},
+		},
+		"elements are compared regardless of their case": {
+			current: []string{"US", "CA"},
+			target:  []string{"us", "mx", "mx"},
+			added:   []string{"mx"},
+			removed: []string{"CA"},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			added, removed := elementChanges(test.current, test.target)
+			assert.Equal(t, test.added, added)
+			assert.Equal(t, test.removed, removed)
+		})
+	}
+}
+
+func TestElementsClient(t *testing.T) {
+	newClient := func(t *testing.T, handler http.HandlerFunc) networkListElements {
+		server := httptest.NewTLSServer(handler)
+		t.Cleanup(server.Close)
+		serverURL, err := url.Parse(server.URL)
+		require.NoError(t, err)
+		s, err := session.New(session.WithClient(server.Client()), session.WithSigner(&edgegrid.Config{Host: serverURL.Host}))
+		require.NoError(t, err)
+		return &elementsClient{Session: s}
+	}
+
+	t.Run("append elements", func(t *testing.T) {
+		client := newClient(t, func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, http.MethodPost, r.Method)
+			assert.Equal(t, "/network-list/v2/network-lists/2275_VOYAGERCALLCENTERWHITELI/append", r.URL.Path)
+			body, err := io.ReadAll(r.Body)
+			require.NoError(t, err)
+			assert.JSONEq(t, `{"list":["10.5.0.0/16","10.6.1.1"]}`, string(body))
+			w.WriteHeader(http.StatusOK)
+			_, err = w.Write([]byte(`{}`))
+			assert.NoError(t, err)
+		})
+		err := client.AppendElements(context.Background(), "2275_VOYAGERCALLCENTERWHITELI", []string{"10.5.0.0/16", "10.6.1.1"})
+		assert.NoError(t, err)
+	})
+
+	t.Run("remove element", func(t *testing.T) {
+		client := newClient(t, func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, http.MethodDelete, r.Method)
+			assert.Equal(t, "/network-list/v2/network-lists/2275_VOYAGERCALLCENTERWHITELI/elements", r.URL.Path)
+			assert.Equal(t, "10.5.0.0/16", r.URL.Query().Get("element"))
+			w.WriteHeader(http.StatusOK)
+		})
+		err := client.RemoveElement(context.Background(), "2275_VOYAGERCALLCENTERWHITELI", "10.5.0.0/16")
+		assert.NoError(t, err)
+	})
+
+	t.Run("API error", func(t *testing.T) {
+		client := newClient(t, func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+			_, err := w.Write([]byte(`{"title":"Not Found","detail":"Element not found"}`))
+			assert.NoError(t, err)
+		})
+		err := client.RemoveElement(context.Background(), "2275_VOYAGERCALLCENTERWHITELI", "10.5.0.0/16")
+		var apiErr *networklists.Error
+		require.ErrorAs(t, err, &apiErr)
+		assert.Equal(t, http.StatusNotFound, apiErr.StatusCode)
+		assert.Equal(t, "Element not found", apiErr.Detail)
+	})
+}
@@ -0,0 +1,144 @@
+package networklists
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/meta"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// normalizeNetworkListEntries plans the entries of the 'list' attribute. Entries are loaded from 'list_file' if it
+// is set. Entries of IP network lists are normalized and deduplicated, and entries covered by a broader CIDR block
+// of the same list are dropped, so that the plan only shows the entries which are actually added or removed.
+func normalizeNetworkListEntries(_ context.Context, d *schema.ResourceDiff, m interface{}) error {
+	meta := meta.Must(m)
+	logger := meta.Log("NETWORKLIST", "normalizeNetworkListEntries")
+
+	var entries []string
+	switch {
+	case !d.NewValueKnown("list_file") || !d.NewValueKnown("list") || !d.NewValueKnown("type") || !d.NewValueKnown("mode"):
+		if d.Get("list_file").(string) != "" || !d.NewValueKnown("list_file") {
+			return d.SetNewComputed("list")
+		}
+		return nil
+	case d.Get("list_file").(string) != "":
+		fileEntries, err := readNetworkListFile(d.Get("list_file").(string))
+		if err != nil {
+			return err
+		}
+		entries = fileEntries
+	case d.GetRawConfig().GetAttr("list").IsNull():
+		// 'list' is optional and computed, so removing it from the configuration has to clear the list explicitly
+		entries = []string{}
+	default:
+		entries = setToStrings(d.Get("list").(*schema.Set))
+	}
+
+	if d.Get("type").(string) == IP {
+		normalized, err := normalizeIPEntries(entries, d.Get("mode").(string) != Remove)
+		if err != nil {
+			return err
+		}
+		entries = normalized
+	}
+
+	current := setToStrings(d.Get("list").(*schema.Set))
+	sort.Strings(current)
+	sort.Strings(entries)
+	if strings.Join(current, ",") == strings.Join(entries, ",") {
+		return nil
+	}
+	logger.Debugf("planning %d network list entries", len(entries))
+	return d.SetNew("list", entries)
+}
+
+// readNetworkListFile reads network list entries from a file. Entries are separated by new lines, commas or
+// whitespace. Empty lines and lines starting with '#' are ignored.
+func readNetworkListFile(path string) ([]string, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading network list file: %w", err)
+	}
+
+	entries := make([]string, 0)
+	for _, line := range strings.Split(string(content), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		for _, entry := range strings.FieldsFunc(line, func(r rune) bool { return r == ',' || r == ' ' || r == '\t' }) {
+			entries = append(entries, entry)
+		}
+	}
+	return entries, nil
+}
+
+// normalizeIPEntries returns the sorted, canonical form of IP addresses and CIDR blocks without duplicates.
+// Host bits of CIDR blocks are cleared. If dropCovered is set, entries covered by a broader CIDR block of the
+// same list are dropped as well.
+func normalizeIPEntries(entries []string, dropCovered bool) ([]string, error) {
+	prefixes := make(map[netip.Prefix]bool, len(entries))
+	for _, entry := range entries {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		var prefix netip.Prefix
+		if strings.Contains(entry, "/") {
+			p, err := netip.ParsePrefix(entry)
+			if err != nil {
+				return nil, fmt.Errorf("invalid CIDR block %q in network list", entry)
+			}
+			prefix = p.Masked()
+		} else {
+			addr, err := netip.ParseAddr(entry)
+			if err != nil {
+				return nil, fmt.Errorf("invalid IP address %q in network list", entry)
+			}
+			prefix = netip.PrefixFrom(addr, addr.BitLen())
+		}
+		// single addresses are kept without a prefix length, unless given as CIDR blocks
+		prefixes[prefix] = prefixes[prefix] || strings.Contains(entry, "/")
+	}
+
+	normalized := make([]string, 0, len(prefixes))
+	for prefix, isBlock := range prefixes {
+		if dropCovered && isCoveredByBroaderPrefix(prefix, prefixes) {
+			continue
+		}
+		if prefix.IsSingleIP() && !isBlock {
+			normalized = append(normalized, prefix.Addr().String())
+			continue
+		}
+		normalized = append(normalized, prefix.String())
+	}
+	sort.Strings(normalized)
+	return normalized, nil
+}
+
+// isCoveredByBroaderPrefix checks whether any shorter prefix in prefixes contains the given prefix
+func isCoveredByBroaderPrefix(prefix netip.Prefix, prefixes map[netip.Prefix]bool) bool {
+	for bits := prefix.Bits() - 1; bits >= 0; bits-- {
+		broader, err := prefix.Addr().Prefix(bits)
+		if err != nil {
+			return false
+		}
+		if _, ok := prefixes[broader]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+func setToStrings(set *schema.Set) []string {
+	values := make([]string, 0, set.Len())
+	for _, value := range set.List() {
+		values = append(values, value.(string))
+	}
+	return values
+}
@@ -0,0 +1,293 @@
+package networklists
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// feed formats supported by the 'source' blocks of akamai_networklist_feed_sync
+const (
+	feedFormatText = "text"
+	feedFormatCSV  = "csv"
+	feedFormatJSON = "json"
+)
+
+var (
+	// feedHTTPClient is used to download feeds from URLs
+	feedHTTPClient = &http.Client{Timeout: time.Minute}
+
+	jsonPathTokenRegexp = regexp.MustCompile(`^(?:\.([A-Za-z0-9_\-]+)|\.?\[(\d*)\])`)
+	geoEntryRegexp      = regexp.MustCompile(`^[A-Z]{2}(:[A-Z0-9]{1,3})?$`)
+)
+
+// feedSource is a single feed of network list entries
+type feedSource struct {
+	path      string
+	url       string
+	format    string
+	csvColumn string
+	jsonPath  string
+}
+
+// load reads and parses the entries of the feed
+func (s feedSource) load(ctx context.Context) ([]string, error) {
+	content, err := s.read(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var entries []string
+	switch s.format {
+	case feedFormatCSV:
+		entries, err = parseCSVFeed(content, s.csvColumn)
+	case feedFormatJSON:
+		entries, err = parseJSONFeed(content, s.jsonPath)
+	default:
+		entries = parseTextFeed(content)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("parsing feed %s: %w", s.name(), err)
+	}
+	return entries, nil
+}
+
+func (s feedSource) name() string {
+	if s.path != "" {
+		return s.path
+	}
+	return s.url
+}
+
+func (s feedSource) read(ctx context.Context) ([]byte, error) {
+	if s.path != "" {
+		content, err := os.ReadFile(s.path)
+		if err != nil {
+			return nil, fmt.Errorf("reading feed file: %w", err)
+		}
+		return content, nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("creating feed request: %w", err)
+	}
+	resp, err := feedHTTPClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("downloading feed %s: %w", s.url, err)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("downloading feed %s: unexpected status %s", s.url, resp.Status)
+	}
+	content, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("downloading feed %s: %w", s.url, err)
+	}
+	return content, nil
+}
+
+// parseTextFeed returns the first field of every line. Empty lines and comments starting with '#' or ';' are ignored.
+func parseTextFeed(content []byte) []string {
+	entries := make([]string, 0)
+	for _, line := range strings.Split(string(content), "\n") {
+		if i := strings.IndexAny(line, "#;"); i >= 0 {
+			line = line[:i]
+		}
+		if fields := strings.Fields(line); len(fields) > 0 {
+			entries = append(entries, fields[0])
+		}
+	}
+	return entries
+}
+
+// parseCSVFeed returns the values of a CSV column. The column is given by its zero-based index or, if the first
+// row is a header, by its name. The first column is used if no column is given.
+func parseCSVFeed(content []byte, column string) ([]string, error) {
+	reader := csv.NewReader(bytes.NewReader(content))
+	reader.Comment = '#'
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(records) == 0 {
+		return []string{}, nil
+	}
+
+	index := 0
+	if column != "" {
+		if index, err = strconv.Atoi(column); err != nil {
+			index = -1
+			for i, name := range records[0] {
+				if strings.EqualFold(strings.TrimSpace(name), column) {
+					index = i
+				}
+			}
+			if index < 0 {
+				return nil, fmt.Errorf("column %q not found in CSV header", column)
+			}
+			records = records[1:]
+		}
+	}
+
+	entries := make([]string, 0, len(records))
+	for i, record := range records {
+		if index >= len(record) {
+			return nil, fmt.Errorf("row %d has no column %d", i+1, index)
+		}
+		if value := strings.TrimSpace(record[index]); value != "" {
+			entries = append(entries, value)
+		}
+	}
+	return entries, nil
+}
+
+// parseJSONFeed returns the strings selected by a jq-like path, such as '.data[].ip' or '.[]'.
+// An empty path selects the top-level array.
+func parseJSONFeed(content []byte, path string) ([]string, error) {
+	var document interface{}
+	if err := json.Unmarshal(content, &document); err != nil {
+		return nil, err
+	}
+	if path == "" || path == "." {
+		path = ".[]"
+	}
+
+	values := []interface{}{document}
+	for rest := path; rest != ""; {
+		match := jsonPathTokenRegexp.FindStringSubmatch(rest)
+		if match == nil {
+			return nil, fmt.Errorf("invalid JSON path %q", path)
+		}
+		rest = rest[len(match[0]):]
+
+		next := make([]interface{}, 0, len(values))
+		for _, value := range values {
+			switch {
+			case match[1] != "":
+				if object, ok := value.(map[string]interface{}); ok {
+					if field, ok := object[match[1]]; ok {
+						next = append(next, field)
+					}
+				}
+			case match[2] != "":
+				i, _ := strconv.Atoi(match[2])
+				if array, ok := value.([]interface{}); ok && i < len(array) {
+					next = append(next, array[i])
+				}
+			default:
+				if array, ok := value.([]interface{}); ok {
+					next = append(next, array...)
+				}
+			}
+		}
+		values = next
+	}
+
+	entries := make([]string, 0, len(values))
+	for _, value := range values {
+		entry, ok := value.(string)
+		if !ok {
+			return nil, fmt.Errorf("JSON path %q selects a value which is not a string: %v", path, value)
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+// loadFeeds loads the entries of all feeds and merges them into the sorted, deduplicated entries of a network
+// list of the given type
+func loadFeeds(ctx context.Context, sources []feedSource, listType string) ([]string, error) {
+	merged := make([]string, 0)
+	for _, source := range sources {
+		entries, err := source.load(ctx)
+		if err != nil {
+			return nil, err
+		}
+		merged = append(merged, entries...)
+	}
+	return normalizeFeedEntries(merged, listType)
+}
+
+// normalizeFeedEntries normalizes IP addresses and CIDR blocks as in akamai_networklist_network_list, and
+// upper-cases GEO codes
+func normalizeFeedEntries(entries []string, listType string) ([]string, error) {
+	if listType == IP {
+		return normalizeIPEntries(entries, true)
+	}
+
+	unique := make(map[string]struct{}, len(entries))
+	for _, entry := range entries {
+		entry = strings.ToUpper(strings.TrimSpace(entry))
+		if entry == "" {
+			continue
+		}
+		if !geoEntryRegexp.MatchString(entry) {
+			return nil, fmt.Errorf("invalid GEO code %q in feed", entry)
+		}
+		unique[entry] = struct{}{}
+	}
+	normalized := make([]string, 0, len(unique))
+	for entry := range unique {
+		normalized = append(normalized, entry)
+	}
+	sort.Strings(normalized)
+	return normalized, nil
+}
+
+// networkListChanges counts the entries added to and removed from a network list, ignoring case
+func networkListChanges(current, desired []string) (added, removed int) {
+	currentSet := make(map[string]struct{}, len(current))
+	for _, entry := range current {
+		currentSet[strings.ToLower(entry)] = struct{}{}
+	}
+	desiredSet := make(map[string]struct{}, len(desired))
+	for _, entry := range desired {
+		desiredSet[strings.ToLower(entry)] = struct{}{}
+	}
+	for entry := range desiredSet {
+		if _, ok := currentSet[entry]; !ok {
+			added++
+		}
+	}
+	for entry := range currentSet {
+		if _, ok := desiredSet[entry]; !ok {
+			removed++
+		}
+	}
+	return added, removed
+}
+
+var errChangeBudgetExceeded = errors.New("change budget exceeded")
+
+// checkFeedGuards enforces the maximum number of entries and the change budget, given in percent of the current
+// entries. The change budget does not apply to an empty list.
+func checkFeedGuards(current, desired []string, maxEntries int, changeBudget float64) error {
+	if maxEntries > 0 && len(desired) > maxEntries {
+		return fmt.Errorf("feeds contain %d entries, which is more than 'max_entries' (%d)", len(desired), maxEntries)
+	}
+	if changeBudget <= 0 || len(current) == 0 {
+		return nil
+	}
+	added, removed := networkListChanges(current, desired)
+	changed := float64(added+removed) * 100 / float64(len(current))
+	if changed > changeBudget {
+		return fmt.Errorf("%w: %d entries would be added and %d removed, which is %.1f%% of the %d current entries and more than 'change_budget_percent' (%.1f%%)",
+			errChangeBudgetExceeded, added, removed, changed, len(current), changeBudget)
+	}
+	return nil
+}
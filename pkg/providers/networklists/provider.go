@@ -16,7 +16,8 @@ import (
 type (
 	// Subprovider gathers networklists resources and data sources
 	Subprovider struct {
-		client networklists.NetworkList
+		client   networklists.NetworkList
+		elements networkListElements
 	}
 
 	option func(p *Subprovider)
@@ -57,6 +58,14 @@ func (p *Subprovider) Client(meta meta.Meta) networklists.NetworkList {
 	return networklists.Client(meta.Session())
 }
 
+// Elements returns the client submitting changes of single elements of network lists
+func (p *Subprovider) Elements(meta meta.Meta) networkListElements {
+	if p.elements != nil {
+		return p.elements
+	}
+	return &elementsClient{Session: meta.Session()}
+}
+
 // SDKResources returns the networklists resources implemented using terraform-plugin-sdk
 func (p *Subprovider) SDKResources() map[string]*schema.Resource {
 	return map[string]*schema.Resource{
@@ -64,6 +73,7 @@ func (p *Subprovider) SDKResources() map[string]*schema.Resource {
 		"akamai_networklist_description":  resourceNetworkListDescription(),
 		"akamai_networklist_subscription": resourceNetworkListSubscription(),
 		"akamai_networklist_network_list": resourceNetworkList(),
+		"akamai_networklist_feed_sync":    resourceNetworkListFeedSync(),
 	}
 }
 
@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v9/pkg/networklists"
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/common/activation"
 	"github.com/akamai/terraform-provider-akamai/v6/pkg/common/tf"
 	"github.com/akamai/terraform-provider-akamai/v6/pkg/meta"
 	"github.com/hashicorp/go-hclog"
@@ -68,6 +69,9 @@ func resourceActivations() *schema.Resource {
 				Description: `This network list's current activation status in the environment specified by the "network" attribute`,
 			},
 		},
+		Timeouts: &schema.ResourceTimeout{
+			Default: &ActivationResourceTimeout,
+		},
 	}
 }
 
@@ -82,6 +86,9 @@ var (
 
 	// CreateActivationRetry poll wait time code waits between retries for activation creation
 	CreateActivationRetry = 10 * time.Second
+
+	// ActivationResourceTimeout is the default timeout for the resource operations
+	ActivationResourceTimeout = activation.DefaultTimeout
 )
 
 func resourceActivationsCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
@@ -128,7 +135,7 @@ func resourceActivationsCreate(ctx context.Context, d *schema.ResourceData, m in
 	}
 
 	if err = pollActivation(ctx, client, lookupResponse.ActivationStatus, lookupResponse.ActivationID); err != nil {
-		return diag.FromErr(err)
+		return activation.Diagnostics("waiting for network list activation", err)
 	}
 
 	return resourceActivationsRead(ctx, d, m)
@@ -150,30 +157,19 @@ func createActivation(ctx context.Context, client networklists.NetworkList, para
 		errMsg = "create deactivation failed"
 	}
 
-	createActivationRetry := CreateActivationRetry
-
-	for {
+	var create *networklists.CreateActivationsResponse
+	err := activation.Retry(ctx, activation.NewBackoff(CreateActivationRetry, activation.DefaultCreateRetryMaxInterval), isActivationErrorRetryable, func(ctx context.Context) error {
 		log.Debug("creating activation")
-		create, err := client.CreateActivations(ctx, params)
-
-		if err == nil {
-			return create, nil
-		}
-		log.Debug("%s: retrying: %w", errMsg, err)
-
-		if !isCreateActivationErrorRetryable(err) {
-			return nil, diag.Errorf("%s: %s", errMsg, err)
-		}
-
-		select {
-		case <-time.After(createActivationRetry):
-			createActivationRetry = capDuration(createActivationRetry*2, 5*time.Minute)
-			continue
-
-		case <-ctx.Done():
-			return nil, diag.Errorf("activation context terminated: %s", ctx.Err())
+		var err error
+		if create, err = client.CreateActivations(ctx, params); err != nil {
+			log.Debug(fmt.Sprintf("%s: %s", errMsg, err))
 		}
+		return err
+	})
+	if err != nil {
+		return nil, activation.Diagnostics(errMsg, err)
 	}
+	return create, nil
 }
 
 func resourceActivationsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
@@ -246,7 +242,7 @@ func resourceActivationsUpdate(ctx context.Context, d *schema.ResourceData, m in
 	}
 
 	if err = pollActivation(ctx, client, lookupResponse.ActivationStatus, lookupResponse.ActivationID); err != nil {
-		return diag.FromErr(err)
+		return activation.Diagnostics("waiting for network list activation", err)
 	}
 	return resourceActivationsRead(ctx, d, m)
 }
@@ -279,54 +275,19 @@ func suppressFieldsForNetworkListActivation(_, oldValue, newValue string, d *sch
 }
 
 func pollActivation(ctx context.Context, client networklists.NetworkList, activationStatus string, activationID int) error {
-	retriesMax := 5
-	retries5xx := 0
-
-	for activationStatus != string(networklists.StatusActive) {
-		select {
-		case <-time.After(tf.MaxDuration(ActivationPollInterval, ActivationPollMinimum)):
+	if activationStatus == string(networklists.StatusActive) {
+		return nil
+	}
+	return activation.Poll(ctx, activation.NewPoller(tf.MaxDuration(ActivationPollInterval, ActivationPollMinimum)), activation.DefaultMaxRetries, isActivationErrorRetryable,
+		func(ctx context.Context) (bool, error) {
 			act, err := client.GetActivation(ctx, networklists.GetActivationRequest{ActivationID: activationID})
-
 			if err != nil {
-				var target = &networklists.Error{}
-				if !errors.As(err, &target) {
-					return fmt.Errorf("error has unexpected type: %T", err)
-				}
-				if isCreateActivationErrorRetryable(target) {
-					retries5xx = retries5xx + 1
-					if retries5xx > retriesMax {
-						return fmt.Errorf("reached max number of 5xx retries: %d", retries5xx)
-					}
-					continue
-				}
-				return err
+				return false, err
 			}
-			retries5xx = 0
-			activationStatus = act.ActivationStatus
-
-		case <-ctx.Done():
-			return fmt.Errorf("activation context terminated: %s", ctx.Err())
-		}
-	}
-	return nil
-}
-
-func isCreateActivationErrorRetryable(err error) bool {
-	var responseErr = &networklists.Error{}
-	if !errors.As(err, &responseErr) {
-		return false
-	}
-	if responseErr.StatusCode < 500 &&
-		responseErr.StatusCode != 422 &&
-		responseErr.StatusCode != 409 {
-		return false
-	}
-	return true
+			return act.ActivationStatus == string(networklists.StatusActive), nil
+		})
 }
 
-func capDuration(t time.Duration, tMax time.Duration) time.Duration {
-	if t > tMax {
-		return tMax
-	}
-	return t
+func isActivationErrorRetryable(err error) bool {
+	return activation.IsRetryable(err, func(e *networklists.Error) int { return e.StatusCode }, activation.IsRetryableStatusCode)
 }
@@ -2,7 +2,11 @@ package networklists
 
 import (
 	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
 	"testing"
+	"time"
 
 	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v9/pkg/networklists"
 	"github.com/akamai/terraform-provider-akamai/v6/pkg/common/testutils"
@@ -275,44 +279,96 @@ func TestAccAkamaiActivations_res_basic(t *testing.T) {
 		client.AssertExpectations(t)
 	})
 
-	t.Run("Retry create activation on 500x error", func(t *testing.T) {
-		client := &networklists.Mock{}
+	for _, statusCode := range []int{http.StatusInternalServerError, http.StatusTooManyRequests} {
+		t.Run(fmt.Sprintf("Retry create activation on %d error", statusCode), func(t *testing.T) {
+			CreateActivationRetry = time.Microsecond
+			client := &networklists.Mock{}
+
+			cu := networklists.RemoveActivationsResponse{}
+			err := json.Unmarshal(testutils.LoadFixtureBytes(t, "testdata/TestResActivations/ActivationsDelete.json"), &cu)
+			require.NoError(t, err)
+
+			ga := networklists.GetActivationsResponse{}
+			err = json.Unmarshal(testutils.LoadFixtureBytes(t, "testdata/TestResActivations/Activations.json"), &ga)
+			require.NoError(t, err)
+
+			cr := networklists.CreateActivationsResponse{}
+			err = json.Unmarshal(testutils.LoadFixtureBytes(t, "testdata/TestResActivations/Activations.json"), &cr)
+			require.NoError(t, err)
+
+			ar := networklists.GetActivationResponse{}
+			err = json.Unmarshal(testutils.LoadFixtureBytes(t, "testdata/TestResActivations/Activations.json"), &ar)
+			require.NoError(t, err)
+
+			client.On("CreateActivations",
+				mock.Anything, // ctx is irrelevant for this test
+				networklists.CreateActivationsRequest{UniqueID: "86093_AGEOLIST", Action: "ACTIVATE", Network: "STAGING", Comments: "Test Notes", NotificationRecipients: []string{"user@example.com"}},
+			).Return(nil, &networklists.Error{StatusCode: statusCode}).Once()
+
+			client.On("CreateActivations",
+				mock.Anything, // ctx is irrelevant for this test
+				networklists.CreateActivationsRequest{UniqueID: "86093_AGEOLIST", Action: "ACTIVATE", Network: "STAGING", Comments: "Test Notes", NotificationRecipients: []string{"user@example.com"}},
+			).Return(&cr, nil)
+
+			client.On("GetActivation",
+				mock.Anything,
+				networklists.GetActivationRequest{ActivationID: 547694},
+			).Return(&ar, nil)
+
+			client.On("CreateActivations",
+				mock.Anything,
+				networklists.CreateActivationsRequest{UniqueID: "86093_AGEOLIST", Action: "ACTIVATE", Network: "PRODUCTION", Comments: "Test Notes Updated", NotificationRecipients: []string{"user@example.com"}},
+			).Return(&cr, nil)
+
+			useClient(client, func() {
+				resource.Test(t, resource.TestCase{
+					IsUnitTest:               true,
+					ProtoV6ProviderFactories: testutils.NewProtoV6ProviderFactory(NewSubprovider()),
+					Steps: []resource.TestStep{
+						{
+							Config: testutils.LoadFixtureString(t, "testdata/TestResActivations/match_by_id.tf"),
+							Check: resource.ComposeAggregateTestCheckFunc(
+								resource.TestCheckResourceAttr("akamai_networklist_activations.test", "network_list_id", "86093_AGEOLIST"),
+								resource.TestCheckResourceAttr("akamai_networklist_activations.test", "network", "STAGING"),
+								resource.TestCheckResourceAttr("akamai_networklist_activations.test", "notes", "Test Notes"),
+								resource.TestCheckResourceAttr("akamai_networklist_activations.test", "notification_emails.0", "user@example.com"),
+								resource.TestCheckResourceAttr("akamai_networklist_activations.test", "sync_point", "0"),
+							),
+						},
+						{
+							Config: testutils.LoadFixtureString(t, "testdata/TestResActivations/update_by_id.tf"),
+							Check: resource.ComposeAggregateTestCheckFunc(
+								resource.TestCheckResourceAttr("akamai_networklist_activations.test", "network_list_id", "86093_AGEOLIST"),
+								resource.TestCheckResourceAttr("akamai_networklist_activations.test", "network", "PRODUCTION"),
+								resource.TestCheckResourceAttr("akamai_networklist_activations.test", "notes", "Test Notes Updated"),
+								resource.TestCheckResourceAttr("akamai_networklist_activations.test", "notification_emails.0", "user@example.com"),
+								resource.TestCheckResourceAttr("akamai_networklist_activations.test", "sync_point", "1"),
+							),
+						},
+					},
+				})
+			})
 
-		cu := networklists.RemoveActivationsResponse{}
-		err := json.Unmarshal(testutils.LoadFixtureBytes(t, "testdata/TestResActivations/ActivationsDelete.json"), &cu)
-		require.NoError(t, err)
+			client.AssertExpectations(t)
+		})
+	}
 
-		ga := networklists.GetActivationsResponse{}
-		err = json.Unmarshal(testutils.LoadFixtureBytes(t, "testdata/TestResActivations/Activations.json"), &ga)
-		require.NoError(t, err)
+	t.Run("create activation exceeds timeout of timeouts block", func(t *testing.T) {
+		client := &networklists.Mock{}
 
 		cr := networklists.CreateActivationsResponse{}
-		err = json.Unmarshal(testutils.LoadFixtureBytes(t, "testdata/TestResActivations/Activations.json"), &cr)
+		err := json.Unmarshal(testutils.LoadFixtureBytes(t, "testdata/TestResActivations/Activations.json"), &cr)
 		require.NoError(t, err)
 
-		ar := networklists.GetActivationResponse{}
-		err = json.Unmarshal(testutils.LoadFixtureBytes(t, "testdata/TestResActivations/Activations.json"), &ar)
-		require.NoError(t, err)
-
-		client.On("CreateActivations",
-			mock.Anything, // ctx is irrelevant for this test
-			networklists.CreateActivationsRequest{UniqueID: "86093_AGEOLIST", Action: "ACTIVATE", Network: "STAGING", Comments: "Test Notes", NotificationRecipients: []string{"user@example.com"}},
-		).Return(nil, &networklists.Error{StatusCode: 500}).Once()
-
 		client.On("CreateActivations",
-			mock.Anything, // ctx is irrelevant for this test
+			mock.Anything,
 			networklists.CreateActivationsRequest{UniqueID: "86093_AGEOLIST", Action: "ACTIVATE", Network: "STAGING", Comments: "Test Notes", NotificationRecipients: []string{"user@example.com"}},
-		).Return(&cr, nil)
+		).Return(&cr, nil).Once()
 
 		client.On("GetActivation",
 			mock.Anything,
 			networklists.GetActivationRequest{ActivationID: 547694},
-		).Return(&ar, nil)
-
-		client.On("CreateActivations",
-			mock.Anything,
-			networklists.CreateActivationsRequest{UniqueID: "86093_AGEOLIST", Action: "ACTIVATE", Network: "PRODUCTION", Comments: "Test Notes Updated", NotificationRecipients: []string{"user@example.com"}},
-		).Return(&cr, nil)
+		).Return(&networklists.GetActivationResponse{ActivationID: 547694, ActivationStatus: "PENDING_ACTIVATION"}, nil)
 
 		useClient(client, func() {
 			resource.Test(t, resource.TestCase{
@@ -320,24 +376,8 @@ func TestAccAkamaiActivations_res_basic(t *testing.T) {
 				ProtoV6ProviderFactories: testutils.NewProtoV6ProviderFactory(NewSubprovider()),
 				Steps: []resource.TestStep{
 					{
-						Config: testutils.LoadFixtureString(t, "testdata/TestResActivations/match_by_id.tf"),
-						Check: resource.ComposeAggregateTestCheckFunc(
-							resource.TestCheckResourceAttr("akamai_networklist_activations.test", "network_list_id", "86093_AGEOLIST"),
-							resource.TestCheckResourceAttr("akamai_networklist_activations.test", "network", "STAGING"),
-							resource.TestCheckResourceAttr("akamai_networklist_activations.test", "notes", "Test Notes"),
-							resource.TestCheckResourceAttr("akamai_networklist_activations.test", "notification_emails.0", "user@example.com"),
-							resource.TestCheckResourceAttr("akamai_networklist_activations.test", "sync_point", "0"),
-						),
-					},
-					{
-						Config: testutils.LoadFixtureString(t, "testdata/TestResActivations/update_by_id.tf"),
-						Check: resource.ComposeAggregateTestCheckFunc(
-							resource.TestCheckResourceAttr("akamai_networklist_activations.test", "network_list_id", "86093_AGEOLIST"),
-							resource.TestCheckResourceAttr("akamai_networklist_activations.test", "network", "PRODUCTION"),
-							resource.TestCheckResourceAttr("akamai_networklist_activations.test", "notes", "Test Notes Updated"),
-							resource.TestCheckResourceAttr("akamai_networklist_activations.test", "notification_emails.0", "user@example.com"),
-							resource.TestCheckResourceAttr("akamai_networklist_activations.test", "sync_point", "1"),
-						),
+						Config:      testutils.LoadFixtureString(t, "testdata/TestResActivations/activation_timeout.tf"),
+						ExpectError: regexp.MustCompile(`waiting for network list activation: activation context terminated: context\s+deadline exceeded`),
 					},
 				},
 			})
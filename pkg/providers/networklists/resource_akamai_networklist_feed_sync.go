@@ -0,0 +1,401 @@
+package networklists
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v9/pkg/networklists"
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/common/activation"
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/common/tf"
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/meta"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// network_lists v2
+//
+// https://techdocs.akamai.com/network-lists/reference/api
+func resourceNetworkListFeedSync() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceNetworkListFeedSyncCreate,
+		ReadContext:   resourceNetworkListFeedSyncRead,
+		UpdateContext: resourceNetworkListFeedSyncUpdate,
+		DeleteContext: resourceNetworkListFeedSyncDelete,
+		CustomizeDiff: customdiff.All(
+			planFeedEntries,
+		),
+		Timeouts: &schema.ResourceTimeout{
+			Default: &ActivationResourceTimeout,
+		},
+		Schema: map[string]*schema.Schema{
+			"network_list_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Unique identifier of the network list which is synchronized with the feeds",
+			},
+			"source": {
+				Type:        schema.TypeList,
+				Required:    true,
+				MinItems:    1,
+				Description: "Feeds of IP addresses, CIDR blocks or GEO codes which are merged into the network list",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"path": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "Path of a local file containing the feed. Exactly one of 'path' and 'url' must be set",
+						},
+						"url": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "URL from which the feed is downloaded. Exactly one of 'path' and 'url' must be set",
+						},
+						"format": {
+							Type:             schema.TypeString,
+							Optional:         true,
+							Default:          feedFormatText,
+							ValidateDiagFunc: tf.ValidateStringInSlice([]string{feedFormatText, feedFormatCSV, feedFormatJSON}),
+							Description:      "Format of the feed: text (one entry per line), csv or json",
+						},
+						"csv_column": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "Zero-based index or header name of the CSV column containing the entries. Defaults to the first column",
+						},
+						"json_path": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "jq-like path selecting the entries of a JSON feed, such as '.data[].ip'. Defaults to the elements of the top-level array",
+						},
+					},
+				},
+			},
+			"max_entries": {
+				Type:             schema.TypeInt,
+				Optional:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.IntAtLeast(1)),
+				Description:      "Maximum number of entries the merged feeds may contain",
+			},
+			"change_budget_percent": {
+				Type:             schema.TypeFloat,
+				Optional:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.FloatBetween(0, 100)),
+				Description:      "Maximum share of the current entries, in percent, which may be added or removed at once. Not enforced if unset or if the network list is empty",
+			},
+			"activation": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Description: "Activates the network list whenever the feeds change its entries, or when the previous activation did not complete",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"network": {
+							Type:             schema.TypeString,
+							Optional:         true,
+							Default:          "STAGING",
+							ValidateDiagFunc: tf.ValidateStringInSlice([]string{"STAGING", "PRODUCTION"}),
+							Description:      "The Akamai network on which the list is activated: STAGING or PRODUCTION",
+						},
+						"notes": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Default:     "Network list feed sync",
+							Description: "Descriptive text to accompany the activation",
+						},
+						"notification_emails": {
+							Type:        schema.TypeSet,
+							Required:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+							Description: "List of email addresses of Control Center users who receive an email when activation of this list is complete",
+						},
+					},
+				},
+			},
+			"type": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The type of the network list: IP or GEO",
+			},
+			"entries": {
+				Type:        schema.TypeSet,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "The normalized entries of the network list",
+			},
+			"entry_count": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The number of entries of the network list",
+			},
+			"sync_point": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Identifies the current version of the network list",
+			},
+			"activation_status": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Status of the last activation triggered by this resource",
+			},
+		},
+	}
+}
+
+// planFeedEntries loads the feeds at plan time, so that the plan shows the entries which are added or removed,
+// and enforces the 'max_entries' and 'change_budget_percent' guards.
+func planFeedEntries(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	meta := meta.Must(m)
+	logger := meta.Log("NETWORKLIST", "planFeedEntries")
+
+	if !d.NewValueKnown("source") || !d.NewValueKnown("network_list_id") {
+		return setFeedEntriesComputed(d)
+	}
+	sources, err := getFeedSources(d.Get("source").([]interface{}))
+	if err != nil {
+		return err
+	}
+
+	listType := d.Get("type").(string)
+	if listType == "" {
+		networkList, err := inst.Client(meta).GetNetworkList(ctx, networklists.GetNetworkListRequest{UniqueID: d.Get("network_list_id").(string)})
+		if err != nil {
+			return fmt.Errorf("reading network list: %w", err)
+		}
+		listType = networkList.Type
+	}
+
+	entries, err := loadFeeds(ctx, sources, listType)
+	if err != nil {
+		return err
+	}
+	current := setToStrings(d.Get("entries").(*schema.Set))
+	if err := checkFeedGuards(current, entries, d.Get("max_entries").(int), d.Get("change_budget_percent").(float64)); err != nil {
+		return err
+	}
+
+	if added, removed := networkListChanges(current, entries); added+removed == 0 && d.Id() != "" {
+		if activationPending(d) {
+			logger.Debug("planning activation of the network list, as the last one did not complete")
+			return d.SetNewComputed("activation_status")
+		}
+		return nil
+	}
+	logger.Debugf("planning %d network list entries from %d feeds", len(entries), len(sources))
+	if err := d.SetNew("entries", entries); err != nil {
+		return err
+	}
+	if err := d.SetNew("entry_count", len(entries)); err != nil {
+		return err
+	}
+	if len(d.Get("activation").([]interface{})) > 0 {
+		if err := d.SetNewComputed("activation_status"); err != nil {
+			return err
+		}
+	}
+	return d.SetNewComputed("sync_point")
+}
+
+// activationPending reports whether the 'activation' block is set and the last activation of the synchronized entries
+// did not complete, for example because it failed after the network list was updated.
+func activationPending(d *schema.ResourceDiff) bool {
+	if len(d.Get("activation").([]interface{})) == 0 {
+		return false
+	}
+	status, _ := d.GetChange("activation_status")
+	return status.(string) != string(networklists.StatusActive)
+}
+
+func setFeedEntriesComputed(d *schema.ResourceDiff) error {
+	for _, key := range []string{"entries", "entry_count", "sync_point"} {
+		if err := d.SetNewComputed(key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func getFeedSources(blocks []interface{}) ([]feedSource, error) {
+	sources := make([]feedSource, 0, len(blocks))
+	for i, block := range blocks {
+		attrs, ok := block.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("%w: 'source' block %d", tf.ErrInvalidType, i)
+		}
+		source := feedSource{
+			path:      attrs["path"].(string),
+			url:       attrs["url"].(string),
+			format:    attrs["format"].(string),
+			csvColumn: attrs["csv_column"].(string),
+			jsonPath:  attrs["json_path"].(string),
+		}
+		if (source.path == "") == (source.url == "") {
+			return nil, fmt.Errorf("exactly one of 'path' and 'url' must be set in 'source' block %d", i)
+		}
+		sources = append(sources, source)
+	}
+	return sources, nil
+}
+
+func resourceNetworkListFeedSyncCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	meta := meta.Must(m)
+	logger := meta.Log("NETWORKLIST", "resourceNetworkListFeedSyncCreate")
+	logger.Debug("Creating network list feed sync")
+
+	networkListID, err := tf.GetStringValue("network_list_id", d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(networkListID)
+
+	if diags := syncNetworkListFeeds(ctx, d, m); diags.HasError() {
+		d.SetId("")
+		return diags
+	}
+	return resourceNetworkListFeedSyncRead(ctx, d, m)
+}
+
+func resourceNetworkListFeedSyncRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	meta := meta.Must(m)
+	client := inst.Client(meta)
+	logger := meta.Log("NETWORKLIST", "resourceNetworkListFeedSyncRead")
+	logger.Debug("Reading network list feed sync")
+
+	networkList, err := client.GetNetworkList(ctx, networklists.GetNetworkListRequest{UniqueID: d.Id()})
+	if err != nil {
+		logger.Errorf("calling 'getNetworkList': %s", err.Error())
+		return diag.FromErr(err)
+	}
+
+	fields := map[string]interface{}{
+		"network_list_id": networkList.UniqueID,
+		"type":            networkList.Type,
+		"entries":         networkList.List,
+		"entry_count":     len(networkList.List),
+		"sync_point":      networkList.SyncPoint,
+	}
+	if err := tf.SetAttrs(d, fields); err != nil {
+		return diag.Errorf("%s: %s", tf.ErrValueSet, err.Error())
+	}
+	return nil
+}
+
+func resourceNetworkListFeedSyncUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	meta := meta.Must(m)
+	logger := meta.Log("NETWORKLIST", "resourceNetworkListFeedSyncUpdate")
+	logger.Debug("Updating network list feed sync")
+
+	if diags := syncNetworkListFeeds(ctx, d, m); diags.HasError() {
+		return diags
+	}
+	return resourceNetworkListFeedSyncRead(ctx, d, m)
+}
+
+func resourceNetworkListFeedSyncDelete(_ context.Context, _ *schema.ResourceData, m interface{}) diag.Diagnostics {
+	meta := meta.Must(m)
+	logger := meta.Log("NETWORKLIST", "resourceNetworkListFeedSyncDelete")
+	logger.Debug("removing network list feed sync from local state")
+	return diag.Diagnostics{
+		diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  "removing network list feed sync resource (will be removed from local state only)",
+		},
+	}
+}
+
+// syncNetworkListFeeds replaces the entries of the network list with the planned feed entries and activates the
+// list if an 'activation' block is set. The guards are checked again against the remote list, which may have
+// changed since the plan.
+func syncNetworkListFeeds(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	meta := meta.Must(m)
+	client := inst.Client(meta)
+	logger := meta.Log("NETWORKLIST", "syncNetworkListFeeds")
+
+	networkList, err := client.GetNetworkList(ctx, networklists.GetNetworkListRequest{UniqueID: d.Id()})
+	if err != nil {
+		logger.Errorf("calling 'getNetworkList': %s", err.Error())
+		return diag.FromErr(err)
+	}
+
+	entries := setToStrings(d.Get("entries").(*schema.Set))
+	sort.Strings(entries)
+	if err := checkFeedGuards(networkList.List, entries, d.Get("max_entries").(int), d.Get("change_budget_percent").(float64)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	activationBlock, err := tf.GetListValue("activation", d)
+	if err != nil && !errors.Is(err, tf.ErrNotFound) {
+		return diag.FromErr(err)
+	}
+	// the previous activation status is kept in the state when the activation did not complete
+	previousStatus, _ := d.GetChange("activation_status")
+	activate := len(activationBlock) > 0 && previousStatus.(string) != string(networklists.StatusActive)
+
+	added, removed := networkListChanges(networkList.List, entries)
+	if added+removed == 0 && !activate {
+		logger.Debugf("network list %s is in sync with the feeds", d.Id())
+		return nil
+	}
+
+	if added+removed > 0 {
+		logger.Debugf("updating network list %s: adding %d and removing %d entries", d.Id(), added, removed)
+		_, err = client.UpdateNetworkList(ctx, networklists.UpdateNetworkListRequest{
+			Name:        networkList.Name,
+			Type:        networkList.Type,
+			Description: networkList.Description,
+			ContractID:  networkList.ContractID,
+			GroupID:     networkList.GroupID,
+			SyncPoint:   networkList.SyncPoint,
+			List:        entries,
+			UniqueID:    d.Id(),
+		})
+		if err != nil {
+			logger.Errorf("calling 'updateNetworkList': %s", err.Error())
+			return diag.FromErr(err)
+		}
+	}
+
+	if len(activationBlock) == 0 {
+		return nil
+	}
+	attrs, ok := activationBlock[0].(map[string]interface{})
+	if !ok {
+		return diag.Errorf("%s: 'activation' block", tf.ErrInvalidType)
+	}
+	// until the activation completes, the status does not report an active list, so that the next plan activates
+	// the updated entries again
+	if err := d.Set("activation_status", ""); err != nil {
+		return diag.Errorf("%s: %s", tf.ErrValueSet, err.Error())
+	}
+
+	createResponse, diags := createActivation(ctx, client, networklists.CreateActivationsRequest{
+		UniqueID:               d.Id(),
+		Network:                attrs["network"].(string),
+		Comments:               strings.TrimSpace(attrs["notes"].(string)),
+		Action:                 string(networklists.ActivationTypeActivate),
+		NotificationRecipients: tf.SetToStringSlice(attrs["notification_emails"].(*schema.Set)),
+	})
+	if diags != nil {
+		return diags
+	}
+	if err := d.Set("activation_status", createResponse.ActivationStatus); err != nil {
+		return diag.Errorf("%s: %s", tf.ErrValueSet, err.Error())
+	}
+
+	lookupResponse, err := lookupActivation(ctx, client, networklists.GetActivationRequest{ActivationID: createResponse.ActivationID})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if err = pollActivation(ctx, client, lookupResponse.ActivationStatus, lookupResponse.ActivationID); err != nil {
+		return activation.Diagnostics("waiting for network list activation", err)
+	}
+	if err := d.Set("activation_status", string(networklists.StatusActive)); err != nil {
+		return diag.Errorf("%s: %s", tf.ErrValueSet, err.Error())
+	}
+	return nil
+}
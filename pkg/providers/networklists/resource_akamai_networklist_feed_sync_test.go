@@ -0,0 +1,310 @@
+package networklists
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v9/pkg/networklists"
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/common/testutils"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccAkamaiNetworkListFeedSync_res_basic(t *testing.T) {
+	client := &networklists.Mock{}
+
+	getResponse := networklists.GetNetworkListResponse{}
+	err := json.Unmarshal(testutils.LoadFixtureBytes(t, "testdata/TestResNetworkListFeedSync/NetworkList.json"), &getResponse)
+	require.NoError(t, err)
+
+	getResponseAfterUpdate := networklists.GetNetworkListResponse{}
+	err = json.Unmarshal(testutils.LoadFixtureBytes(t, "testdata/TestResNetworkListFeedSync/NetworkListUpdated.json"), &getResponseAfterUpdate)
+	require.NoError(t, err)
+
+	updateResponse := networklists.UpdateNetworkListResponse{}
+	err = json.Unmarshal(testutils.LoadFixtureBytes(t, "testdata/TestResNetworkListFeedSync/NetworkListUpdated.json"), &updateResponse)
+	require.NoError(t, err)
+
+	client.On("GetNetworkList",
+		mock.Anything,
+		networklists.GetNetworkListRequest{UniqueID: "2275_THREATFEED"},
+	).Return(&getResponse, nil).Twice()
+
+	client.On("UpdateNetworkList",
+		mock.Anything,
+		networklists.UpdateNetworkListRequest{
+			Name:        "Threat feed",
+			Type:        "IP",
+			Description: "Addresses synchronized from threat feeds",
+			ContractID:  "C-1FRYVV3",
+			GroupID:     64867,
+			SyncPoint:   3,
+			List:        []string{"10.1.8.0/24", "10.3.5.67", "192.168.10.1"},
+			UniqueID:    "2275_THREATFEED",
+		},
+	).Return(&updateResponse, nil)
+
+	client.On("GetNetworkList",
+		mock.Anything,
+		networklists.GetNetworkListRequest{UniqueID: "2275_THREATFEED"},
+	).Return(&getResponseAfterUpdate, nil)
+
+	useClient(client, func() {
+		resource.Test(t, resource.TestCase{
+			IsUnitTest:               true,
+			ProtoV6ProviderFactories: testutils.NewProtoV6ProviderFactory(NewSubprovider()),
+			Steps: []resource.TestStep{
+				{
+					Config: testutils.LoadFixtureString(t, "testdata/TestResNetworkListFeedSync/match_by_id.tf"),
+					Check: resource.ComposeAggregateTestCheckFunc(
+						resource.TestCheckResourceAttr("akamai_networklist_feed_sync.test", "id", "2275_THREATFEED"),
+						resource.TestCheckResourceAttr("akamai_networklist_feed_sync.test", "type", "IP"),
+						resource.TestCheckResourceAttr("akamai_networklist_feed_sync.test", "entry_count", "3"),
+						resource.TestCheckResourceAttr("akamai_networklist_feed_sync.test", "sync_point", "4"),
+						resource.TestCheckTypeSetElemAttr("akamai_networklist_feed_sync.test", "entries.*", "10.1.8.0/24"),
+						resource.TestCheckTypeSetElemAttr("akamai_networklist_feed_sync.test", "entries.*", "192.168.10.1"),
+					),
+				},
+			},
+		})
+	})
+
+	client.AssertExpectations(t)
+}
+
+func TestAccAkamaiNetworkListFeedSync_res_failedActivation(t *testing.T) {
+	client := &networklists.Mock{}
+
+	networkList := networklists.GetNetworkListResponse{}
+	err := json.Unmarshal(testutils.LoadFixtureBytes(t, "testdata/TestResNetworkListFeedSync/NetworkList.json"), &networkList)
+	require.NoError(t, err)
+
+	updateNetworkList := func(syncPoint int, entries []string) *mock.Call {
+		return client.On("UpdateNetworkList",
+			mock.Anything,
+			networklists.UpdateNetworkListRequest{
+				Name:        "Threat feed",
+				Type:        "IP",
+				Description: "Addresses synchronized from threat feeds",
+				ContractID:  "C-1FRYVV3",
+				GroupID:     64867,
+				SyncPoint:   syncPoint,
+				List:        entries,
+				UniqueID:    "2275_THREATFEED",
+			},
+		).Run(func(mock.Arguments) {
+			networkList.SyncPoint = syncPoint + 1
+			networkList.List = entries
+		}).Return(&networklists.UpdateNetworkListResponse{}, nil).Once()
+	}
+	activationRequest := networklists.CreateActivationsRequest{
+		UniqueID:               "2275_THREATFEED",
+		Action:                 "ACTIVATE",
+		Network:                "STAGING",
+		Comments:               "Network list feed sync",
+		NotificationRecipients: []string{"user@example.com"},
+	}
+
+	client.On("GetNetworkList",
+		mock.Anything,
+		networklists.GetNetworkListRequest{UniqueID: "2275_THREATFEED"},
+	).Return(&networkList, nil)
+	updateNetworkList(3, []string{"10.1.8.0/24", "10.3.5.67", "192.168.10.1"})
+	updateNetworkList(4, []string{"10.1.8.0/24", "10.3.5.67", "10.5.0.1", "192.168.10.1"})
+	client.On("CreateActivations", mock.Anything, activationRequest).Return(nil, fmt.Errorf("activation failed")).Once()
+	client.On("CreateActivations", mock.Anything, activationRequest).Return(&networklists.CreateActivationsResponse{
+		ActivationID:     12,
+		ActivationStatus: string(networklists.StatusPending),
+	}, nil).Once()
+	client.On("GetActivation", mock.Anything, networklists.GetActivationRequest{ActivationID: 12}).Return(&networklists.GetActivationResponse{
+		ActivationID:     12,
+		ActivationStatus: string(networklists.StatusActive),
+	}, nil)
+
+	useClient(client, func() {
+		resource.Test(t, resource.TestCase{
+			IsUnitTest:               true,
+			ProtoV6ProviderFactories: testutils.NewProtoV6ProviderFactory(NewSubprovider()),
+			Steps: []resource.TestStep{
+				{
+					Config: testutils.LoadFixtureString(t, "testdata/TestResNetworkListFeedSync/match_by_id.tf"),
+					Check:  resource.TestCheckResourceAttr("akamai_networklist_feed_sync.test", "entry_count", "3"),
+				},
+				{
+					Config:      testutils.LoadFixtureString(t, "testdata/TestResNetworkListFeedSync/activation.tf"),
+					ExpectError: regexp.MustCompile("activation failed"),
+				},
+				{
+					Config:             testutils.LoadFixtureString(t, "testdata/TestResNetworkListFeedSync/activation.tf"),
+					PlanOnly:           true,
+					ExpectNonEmptyPlan: true,
+				},
+				{
+					Config: testutils.LoadFixtureString(t, "testdata/TestResNetworkListFeedSync/activation.tf"),
+					Check: resource.ComposeAggregateTestCheckFunc(
+						resource.TestCheckResourceAttr("akamai_networklist_feed_sync.test", "entry_count", "4"),
+						resource.TestCheckResourceAttr("akamai_networklist_feed_sync.test", "sync_point", "5"),
+						resource.TestCheckResourceAttr("akamai_networklist_feed_sync.test", "activation_status", string(networklists.StatusActive)),
+					),
+				},
+			},
+		})
+	})
+
+	client.AssertExpectations(t)
+}
+
+func TestParseFeeds(t *testing.T) {
+	tests := map[string]struct {
+		source    feedSource
+		expected  []string
+		withError string
+	}{
+		"text feed with comments": {
+			source:   feedSource{path: "testdata/TestResNetworkListFeedSync/feed.txt", format: feedFormatText},
+			expected: []string{"10.1.8.0/24", "10.1.8.23", "10.3.5.67"},
+		},
+		"csv feed by header name": {
+			source:   feedSource{path: "testdata/TestResNetworkListFeedSync/feed.csv", format: feedFormatCSV, csvColumn: "address"},
+			expected: []string{"192.168.10.1", "10.3.5.67"},
+		},
+		"csv feed by index": {
+			source:   feedSource{path: "testdata/TestResNetworkListFeedSync/feed.csv", format: feedFormatCSV, csvColumn: "1"},
+			expected: []string{"first_seen", "2024-01-01", "2024-01-02"},
+		},
+		"csv feed with unknown column": {
+			source:    feedSource{path: "testdata/TestResNetworkListFeedSync/feed.csv", format: feedFormatCSV, csvColumn: "ip"},
+			withError: `column "ip" not found in CSV header`,
+		},
+		"json feed": {
+			source:   feedSource{path: "testdata/TestResNetworkListFeedSync/feed.json", format: feedFormatJSON, jsonPath: ".data[].ip"},
+			expected: []string{"192.168.10.1", "10.1.8.23"},
+		},
+		"json feed with index": {
+			source:   feedSource{path: "testdata/TestResNetworkListFeedSync/feed.json", format: feedFormatJSON, jsonPath: ".data[1].ip"},
+			expected: []string{"10.1.8.23"},
+		},
+		"json path selecting numbers": {
+			source:    feedSource{path: "testdata/TestResNetworkListFeedSync/feed.json", format: feedFormatJSON, jsonPath: ".data[].score"},
+			withError: "selects a value which is not a string",
+		},
+		"invalid json path": {
+			source:    feedSource{path: "testdata/TestResNetworkListFeedSync/feed.json", format: feedFormatJSON, jsonPath: "data.ip"},
+			withError: `invalid JSON path "data.ip"`,
+		},
+		"missing file": {
+			source:    feedSource{path: "testdata/TestResNetworkListFeedSync/missing.txt", format: feedFormatText},
+			withError: "reading feed file",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			entries, err := test.source.load(context.Background())
+			if test.withError != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), test.withError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, entries)
+		})
+	}
+}
+
+func TestLoadFeeds(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/feed.json":
+			_, _ = w.Write(testutils.LoadFixtureBytes(t, "testdata/TestResNetworkListFeedSync/feed.json"))
+		case "/geo.txt":
+			_, _ = w.Write([]byte("us\nUS:ca\nde # Germany\n"))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	t.Run("files and URLs are merged and normalized", func(t *testing.T) {
+		entries, err := loadFeeds(context.Background(), []feedSource{
+			{path: "testdata/TestResNetworkListFeedSync/feed.txt", format: feedFormatText},
+			{url: server.URL + "/feed.json", format: feedFormatJSON, jsonPath: ".data[].ip"},
+		}, IP)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"10.1.8.0/24", "10.3.5.67", "192.168.10.1"}, entries)
+	})
+
+	t.Run("GEO codes are upper-cased", func(t *testing.T) {
+		entries, err := loadFeeds(context.Background(), []feedSource{{url: server.URL + "/geo.txt", format: feedFormatText}}, "GEO")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"DE", "US", "US:CA"}, entries)
+	})
+
+	t.Run("invalid entries are rejected", func(t *testing.T) {
+		_, err := loadFeeds(context.Background(), []feedSource{{url: server.URL + "/feed.json", format: feedFormatJSON, jsonPath: ".data[].ip"}}, "GEO")
+		assert.ErrorContains(t, err, `invalid GEO code "192.168.10.1"`)
+	})
+
+	t.Run("unavailable URL", func(t *testing.T) {
+		_, err := loadFeeds(context.Background(), []feedSource{{url: server.URL + "/missing.txt", format: feedFormatText}}, IP)
+		assert.ErrorContains(t, err, "unexpected status 404 Not Found")
+	})
+}
+
+func TestCheckFeedGuards(t *testing.T) {
+	current := []string{"10.0.0.1", "10.0.0.2", "10.0.0.3", "10.0.0.4"}
+
+	tests := map[string]struct {
+		current      []string
+		desired      []string
+		maxEntries   int
+		changeBudget float64
+		withError    string
+	}{
+		"within budget": {
+			current:      current,
+			desired:      []string{"10.0.0.1", "10.0.0.2", "10.0.0.3", "10.0.0.5"},
+			changeBudget: 50,
+		},
+		"budget exceeded": {
+			current:      current,
+			desired:      []string{"10.0.0.1", "10.0.0.6", "10.0.0.7"},
+			changeBudget: 50,
+			withError:    "2 entries would be added and 3 removed, which is 125.0% of the 4 current entries",
+		},
+		"no budget": {
+			current: current,
+			desired: []string{"10.0.0.9"},
+		},
+		"empty list ignores budget": {
+			current:      []string{},
+			desired:      current,
+			changeBudget: 10,
+		},
+		"too many entries": {
+			current:    []string{},
+			desired:    current,
+			maxEntries: 3,
+			withError:  "feeds contain 4 entries, which is more than 'max_entries' (3)",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := checkFeedGuards(test.current, test.desired, test.maxEntries, test.changeBudget)
+			if test.withError != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), test.withError)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
@@ -27,6 +27,7 @@ func resourceNetworkList() *schema.Resource {
 		DeleteContext: resourceNetworkListDelete,
 		CustomizeDiff: customdiff.All(
 			verifyContractGroupUnchanged,
+			normalizeNetworkListEntries,
 			markSyncPointComputedIfListModified,
 		),
 		Importer: &schema.ResourceImporter{
@@ -54,10 +55,18 @@ func resourceNetworkList() *schema.Resource {
 				Description: "A description of the network list",
 			},
 			"list": {
-				Type:        schema.TypeSet,
-				Elem:        &schema.Schema{Type: schema.TypeString},
-				Optional:    true,
-				Description: "A list of IP addresses or locations to be included in the list, added to an existing list, or removed from an existing list",
+				Type:          schema.TypeSet,
+				Elem:          &schema.Schema{Type: schema.TypeString},
+				Optional:      true,
+				Computed:      true,
+				ConflictsWith: []string{"list_file"},
+				Description:   "A list of IP addresses or locations to be included in the list, added to an existing list, or removed from an existing list",
+			},
+			"list_file": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"list"},
+				Description:   "Path to a file with the entries of the list, separated by new lines, commas or whitespace. Lines starting with '#' are ignored",
 			},
 			"mode": {
 				Type:        schema.TypeString,
@@ -290,10 +299,30 @@ func resourceNetworkListUpdate(ctx context.Context, d *schema.ResourceData, m in
 
 	updateNetworkList.SyncPoint = syncPoint
 
-	_, err = client.UpdateNetworkList(ctx, updateNetworkList)
-	if err != nil {
-		logger.Errorf("calling 'updateNetworkList': %s", err.Error())
-		return diag.FromErr(err)
+	// only the changed elements are submitted, unless the list itself changes or too many elements are removed
+	added, removed := elementChanges(networkLists.List, finallist)
+	if d.HasChanges("name", "description", "contract_id", "group_id") || len(removed) > maxRemovedElements {
+		_, err = client.UpdateNetworkList(ctx, updateNetworkList)
+		if err != nil {
+			logger.Errorf("calling 'updateNetworkList': %s", err.Error())
+			return diag.FromErr(err)
+		}
+	} else {
+		elements := inst.Elements(meta)
+		if len(added) > 0 {
+			logger.Debugf("appending %d elements to network list %s", len(added), d.Id())
+			if err := elements.AppendElements(ctx, d.Id(), added); err != nil {
+				logger.Errorf("calling 'appendElements': %s", err.Error())
+				return diag.FromErr(err)
+			}
+		}
+		for _, element := range removed {
+			logger.Debugf("removing element %s from network list %s", element, d.Id())
+			if err := elements.RemoveElement(ctx, d.Id(), element); err != nil {
+				logger.Errorf("calling 'removeElement': %s", err.Error())
+				return diag.FromErr(err)
+			}
+		}
 	}
 
 	if err := d.Set("contract_id", attrs.contractID); err != nil {
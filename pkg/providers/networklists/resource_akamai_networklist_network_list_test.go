@@ -8,8 +8,10 @@ import (
 	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v9/pkg/networklists"
 	"github.com/akamai/terraform-provider-akamai/v6/pkg/common/testutils"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
+	"golang.org/x/exp/slices"
 )
 
 func TestAccAkamaiNetworkList_res_basic(t *testing.T) {
@@ -175,3 +177,214 @@ func TestAccAkamaiNetworkListConfigChanged(t *testing.T) {
 	})
 
 }
+
+func TestAccAkamaiNetworkList_res_listFile(t *testing.T) {
+	client := &networklists.Mock{}
+
+	createResponse := networklists.CreateNetworkListResponse{}
+	err := json.Unmarshal(testutils.LoadFixtureBytes(t, "testdata/TestResNetworkList/NetworkList.json"), &createResponse)
+	require.NoError(t, err)
+
+	crl := networklists.GetNetworkListsResponse{}
+	err = json.Unmarshal(testutils.LoadFixtureBytes(t, "testdata/TestResNetworkList/NetworkLists.json"), &crl)
+	require.NoError(t, err)
+
+	getResponse := networklists.GetNetworkListResponse{}
+	err = json.Unmarshal(testutils.LoadFixtureBytes(t, "testdata/TestResNetworkList/NetworkList.json"), &getResponse)
+	require.NoError(t, err)
+
+	cd := networklists.RemoveNetworkListResponse{}
+	err = json.Unmarshal(testutils.LoadFixtureBytes(t, "testdata/TestResNetworkList/empty.json"), &cd)
+	require.NoError(t, err)
+
+	client.On("CreateNetworkList",
+		mock.Anything,
+		mock.MatchedBy(func(req networklists.CreateNetworkListRequest) bool {
+			return req.Name == "Voyager Call Center Whitelist" && assert.ElementsMatch(t, []string{"10.1.8.23", "10.3.5.67"}, req.List)
+		}),
+	).Return(&createResponse, nil)
+
+	client.On("GetNetworkLists",
+		mock.Anything,
+		networklists.GetNetworkListsRequest{Name: "Voyager Call Center Whitelist", Type: "IP"},
+	).Return(&crl, nil)
+
+	client.On("GetNetworkList",
+		mock.Anything,
+		networklists.GetNetworkListRequest{UniqueID: "2275_VOYAGERCALLCENTERWHITELI"},
+	).Return(&getResponse, nil)
+
+	client.On("RemoveNetworkList",
+		mock.Anything,
+		networklists.RemoveNetworkListRequest{UniqueID: "2275_VOYAGERCALLCENTERWHITELI"},
+	).Return(&cd, nil)
+
+	t.Run("entries loaded from list_file", func(t *testing.T) {
+		useClient(client, func() {
+			resource.Test(t, resource.TestCase{
+				IsUnitTest:               true,
+				ProtoV6ProviderFactories: testutils.NewProtoV6ProviderFactory(NewSubprovider()),
+				Steps: []resource.TestStep{
+					{
+						Config: testutils.LoadFixtureString(t, "testdata/TestResNetworkList/list_file.tf"),
+						Check: resource.ComposeAggregateTestCheckFunc(
+							resource.TestCheckResourceAttr("akamai_networklist_network_list.test", "list.#", "2"),
+							resource.TestCheckTypeSetElemAttr("akamai_networklist_network_list.test", "list.*", "10.1.8.23"),
+							resource.TestCheckTypeSetElemAttr("akamai_networklist_network_list.test", "list.*", "10.3.5.67"),
+						),
+					},
+				},
+			})
+		})
+
+		client.AssertExpectations(t)
+	})
+}
+
+func TestAccAkamaiNetworkList_res_elements(t *testing.T) {
+	client := &networklists.Mock{}
+	elements := &mockElements{}
+
+	createResponse := networklists.CreateNetworkListResponse{}
+	err := json.Unmarshal(testutils.LoadFixtureBytes(t, "testdata/TestResNetworkList/NetworkList.json"), &createResponse)
+	require.NoError(t, err)
+
+	crl := networklists.GetNetworkListsResponse{}
+	err = json.Unmarshal(testutils.LoadFixtureBytes(t, "testdata/TestResNetworkList/NetworkLists.json"), &crl)
+	require.NoError(t, err)
+
+	getResponse := networklists.GetNetworkListResponse{}
+	err = json.Unmarshal(testutils.LoadFixtureBytes(t, "testdata/TestResNetworkList/NetworkList.json"), &getResponse)
+	require.NoError(t, err)
+
+	cd := networklists.RemoveNetworkListResponse{}
+	err = json.Unmarshal(testutils.LoadFixtureBytes(t, "testdata/TestResNetworkList/empty.json"), &cd)
+	require.NoError(t, err)
+
+	client.On("CreateNetworkList",
+		mock.Anything,
+		mock.MatchedBy(func(req networklists.CreateNetworkListRequest) bool {
+			return req.Name == "Voyager Call Center Whitelist" && assert.ElementsMatch(t, []string{"10.1.8.23", "10.3.5.67"}, req.List)
+		}),
+	).Return(&createResponse, nil)
+
+	client.On("GetNetworkLists",
+		mock.Anything,
+		networklists.GetNetworkListsRequest{Name: "Voyager Call Center Whitelist", Type: "IP"},
+	).Return(&crl, nil)
+
+	client.On("GetNetworkList",
+		mock.Anything,
+		networklists.GetNetworkListRequest{UniqueID: "2275_VOYAGERCALLCENTERWHITELI"},
+	).Return(&getResponse, nil)
+
+	elements.On("AppendElements",
+		mock.Anything,
+		"2275_VOYAGERCALLCENTERWHITELI",
+		[]string{"10.5.0.0/16"},
+	).Run(func(mock.Arguments) {
+		getResponse.List = append(getResponse.List, "10.5.0.0/16")
+	}).Return(nil).Once()
+
+	elements.On("RemoveElement",
+		mock.Anything,
+		"2275_VOYAGERCALLCENTERWHITELI",
+		"10.1.8.23",
+	).Run(func(mock.Arguments) {
+		getResponse.List = slices.DeleteFunc(getResponse.List, func(element string) bool { return element == "10.1.8.23" })
+	}).Return(nil).Once()
+
+	client.On("RemoveNetworkList",
+		mock.Anything,
+		networklists.RemoveNetworkListRequest{UniqueID: "2275_VOYAGERCALLCENTERWHITELI"},
+	).Return(&cd, nil)
+
+	t.Run("changed entries are submitted as elements", func(t *testing.T) {
+		useClient(client, func() {
+			useElements(elements, func() {
+				resource.Test(t, resource.TestCase{
+					IsUnitTest:               true,
+					ProtoV6ProviderFactories: testutils.NewProtoV6ProviderFactory(NewSubprovider()),
+					Steps: []resource.TestStep{
+						{
+							Config: testutils.LoadFixtureString(t, "testdata/TestResNetworkList/match_by_id.tf"),
+						},
+						{
+							Config: testutils.LoadFixtureString(t, "testdata/TestResNetworkList/update_list.tf"),
+							Check: resource.ComposeAggregateTestCheckFunc(
+								resource.TestCheckResourceAttr("akamai_networklist_network_list.test", "list.#", "2"),
+								resource.TestCheckTypeSetElemAttr("akamai_networklist_network_list.test", "list.*", "10.3.5.67"),
+								resource.TestCheckTypeSetElemAttr("akamai_networklist_network_list.test", "list.*", "10.5.0.0/16"),
+							),
+						},
+					},
+				})
+			})
+		})
+
+		client.AssertExpectations(t)
+		client.AssertNotCalled(t, "UpdateNetworkList", mock.Anything, mock.Anything)
+		elements.AssertExpectations(t)
+	})
+}
+
+func TestNormalizeIPEntries(t *testing.T) {
+	tests := map[string]struct {
+		entries     []string
+		dropCovered bool
+		expected    []string
+		withError   string
+	}{
+		"host bits are cleared and addresses are canonical": {
+			entries:  []string{"10.1.2.3/24", "2001:DB8:0:0::1", " 192.168.0.1 "},
+			expected: []string{"10.1.2.0/24", "192.168.0.1", "2001:db8::1"},
+		},
+		"duplicates are removed": {
+			entries:  []string{"10.1.2.0/24", "10.1.2.7/24", "10.0.0.1", "10.0.0.1"},
+			expected: []string{"10.0.0.1", "10.1.2.0/24"},
+		},
+		"covered entries are dropped": {
+			entries:     []string{"10.0.0.0/8", "10.1.2.0/24", "10.1.2.3", "11.0.0.1", "2001:db8::/32", "2001:db8:1::/48"},
+			dropCovered: true,
+			expected:    []string{"10.0.0.0/8", "11.0.0.1", "2001:db8::/32"},
+		},
+		"covered entries are kept when not dropping": {
+			entries:  []string{"10.0.0.0/8", "10.1.2.3"},
+			expected: []string{"10.0.0.0/8", "10.1.2.3"},
+		},
+		"single address given as CIDR block keeps its prefix length": {
+			entries:  []string{"10.0.0.1/32"},
+			expected: []string{"10.0.0.1/32"},
+		},
+		"invalid address": {
+			entries:   []string{"10.0.0.256"},
+			withError: `invalid IP address "10.0.0.256"`,
+		},
+		"invalid CIDR block": {
+			entries:   []string{"10.0.0.0/33"},
+			withError: `invalid CIDR block "10.0.0.0/33"`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			normalized, err := normalizeIPEntries(test.entries, test.dropCovered)
+			if test.withError != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), test.withError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, normalized)
+		})
+	}
+}
+
+func TestReadNetworkListFile(t *testing.T) {
+	entries, err := readNetworkListFile("testdata/TestResNetworkList/entries.txt")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"10.1.8.23", "10.3.5.67", "10.1.8.23"}, entries)
+
+	_, err = readNetworkListFile("testdata/TestResNetworkList/missing.txt")
+	assert.ErrorContains(t, err, "reading network list file")
+}
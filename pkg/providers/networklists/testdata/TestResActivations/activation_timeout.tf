@@ -0,0 +1,15 @@
+provider "akamai" {
+  edgerc = "../../common/testutils/edgerc"
+}
+
+resource "akamai_networklist_activations" "test" {
+  network_list_id     = "86093_AGEOLIST"
+  network             = "STAGING"
+  notes               = "Test Notes"
+  notification_emails = ["user@example.com"]
+  sync_point          = 0
+
+  timeouts {
+    default = "1s"
+  }
+}
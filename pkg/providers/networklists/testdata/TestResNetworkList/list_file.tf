@@ -0,0 +1,11 @@
+provider "akamai" {
+  edgerc = "../../common/testutils/edgerc"
+}
+
+resource "akamai_networklist_network_list" "test" {
+  name        = "Voyager Call Center Whitelist"
+  type        = "IP"
+  description = "Notes about this network list"
+  list_file   = "testdata/TestResNetworkList/entries.txt"
+  mode        = "REPLACE"
+}
@@ -0,0 +1,11 @@
+provider "akamai" {
+  edgerc = "../../common/testutils/edgerc"
+}
+
+resource "akamai_networklist_network_list" "test" {
+  name        = "Voyager Call Center Whitelist"
+  type        = "IP"
+  description = "Notes about this network list"
+  list        = ["10.3.5.67", "10.5.0.0/16"]
+  mode        = "REPLACE"
+}
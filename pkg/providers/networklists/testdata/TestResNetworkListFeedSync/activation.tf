@@ -0,0 +1,26 @@
+provider "akamai" {
+  edgerc = "../../common/testutils/edgerc"
+}
+
+resource "akamai_networklist_feed_sync" "test" {
+  network_list_id = "2275_THREATFEED"
+  max_entries     = 10
+
+  source {
+    path = "testdata/TestResNetworkListFeedSync/feed.txt"
+  }
+
+  source {
+    path       = "testdata/TestResNetworkListFeedSync/feed.csv"
+    format     = "csv"
+    csv_column = "address"
+  }
+
+  source {
+    path = "testdata/TestResNetworkListFeedSync/feed_extra.txt"
+  }
+
+  activation {
+    notification_emails = ["user@example.com"]
+  }
+}
@@ -5,13 +5,13 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
-	"io"
 	"sort"
 	"strings"
 	"time"
 
 	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v9/pkg/papi"
 	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v9/pkg/session"
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/common/activation"
 	"github.com/akamai/terraform-provider-akamai/v6/pkg/common/date"
 	"github.com/akamai/terraform-provider-akamai/v6/pkg/common/str"
 	"github.com/akamai/terraform-provider-akamai/v6/pkg/common/tf"
@@ -401,6 +401,7 @@ func resourcePropertyActivationDelete(ctx context.Context, d *schema.ResourceDat
 	}
 
 	// deactivations also use status Active for when they are fully processed
+	poller := newActivationPoller()
 	for activation.Status != papi.ActivationStatusActive {
 		if activation.Status == papi.ActivationStatusAborted {
 			return diag.FromErr(fmt.Errorf("deactivation request aborted"))
@@ -408,23 +409,20 @@ func resourcePropertyActivationDelete(ctx context.Context, d *schema.ResourceDat
 		if activation.Status == papi.ActivationStatusFailed {
 			return diag.FromErr(fmt.Errorf("deactivation request failed in downstream system"))
 		}
-		select {
-		case <-time.After(tf.MaxDuration(ActivationPollInterval, ActivationPollMinimum)):
-			act, err := client.GetActivation(ctx, papi.GetActivationRequest{
-				ActivationID: activation.ActivationID,
-				PropertyID:   propertyID,
-			})
-			if err != nil {
-				return diag.FromErr(err)
-			}
-			activation = act.Activation
-
-			if err = setErrorsAndWarnings(d, flattenErrorArray(act.Errors), flattenErrorArray(act.Warnings)); err != nil {
-				return diag.FromErr(err)
-			}
+		if err := poller.Wait(ctx); err != nil {
+			return diag.FromErr(err)
+		}
+		act, err := client.GetActivation(ctx, papi.GetActivationRequest{
+			ActivationID: activation.ActivationID,
+			PropertyID:   propertyID,
+		})
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		activation = act.Activation
 
-		case <-ctx.Done():
-			return diag.FromErr(fmt.Errorf("activation context terminated: %w", ctx.Err()))
+		if err = setErrorsAndWarnings(d, flattenErrorArray(act.Errors), flattenErrorArray(act.Warnings)); err != nil {
+			return diag.FromErr(err)
 		}
 	}
 
@@ -908,52 +906,41 @@ func networkAlias(d *schema.ResourceData) (papi.ActivationNetwork, error) {
 	return papi.ActivationNetwork(alias), nil
 }
 
-func pollActivation(ctx context.Context, client papi.PAPI, activation *papi.Activation, propertyID string) (*papi.Activation, diag.Diagnostics) {
-
-	retriesMax := 5
-	retries5xx := 0
+// newActivationPoller returns the poller used while waiting for property activations and deactivations
+func newActivationPoller() *activation.Poller {
+	return activation.NewPoller(tf.MaxDuration(ActivationPollInterval, ActivationPollMinimum))
+}
 
-	for activation.Status != papi.ActivationStatusActive {
-		if activation.Status == papi.ActivationStatusAborted {
-			return nil, diag.FromErr(fmt.Errorf("activation request aborted"))
+func pollActivation(ctx context.Context, client papi.PAPI, act *papi.Activation, propertyID string) (*papi.Activation, diag.Diagnostics) {
+	checkStatus := func(act *papi.Activation) (bool, error) {
+		switch act.Status {
+		case papi.ActivationStatusAborted:
+			return false, fmt.Errorf("activation request aborted")
+		case papi.ActivationStatusFailed:
+			return false, fmt.Errorf("activation request failed in downstream system")
 		}
-		if activation.Status == papi.ActivationStatusFailed {
-			return nil, diag.FromErr(fmt.Errorf("activation request failed in downstream system"))
-		}
-		select {
-		case <-time.After(tf.MaxDuration(ActivationPollInterval, ActivationPollMinimum)):
-			act, err := client.GetActivation(ctx, papi.GetActivationRequest{
-				ActivationID: activation.ActivationID,
-				PropertyID:   propertyID,
-			})
-			if err != nil {
-				var target = &papi.Error{}
-				if !errors.As(err, &target) {
-					return nil, diag.Errorf("error has unexpected type: %T", err)
-				}
-				if target.StatusCode >= 500 {
-					retries5xx = retries5xx + 1
-					if retries5xx > retriesMax {
-						return nil, diag.Errorf("reached max number of 5xx retries: %d", retries5xx)
-					}
-					continue
+		return act.Status == papi.ActivationStatusActive, nil
+	}
+
+	done, err := checkStatus(act)
+	if err == nil && !done {
+		err = activation.Poll(ctx, newActivationPoller(), activation.DefaultMaxRetries, isActivationErrorRetryable,
+			func(ctx context.Context) (bool, error) {
+				res, err := client.GetActivation(ctx, papi.GetActivationRequest{
+					ActivationID: act.ActivationID,
+					PropertyID:   propertyID,
+				})
+				if err != nil {
+					return false, err
 				}
-
-				return nil, diag.FromErr(err)
-			}
-			retries5xx = 0
-			activation = act.Activation
-
-		case <-ctx.Done():
-			if errors.Is(ctx.Err(), context.DeadlineExceeded) {
-				return nil, diag.Diagnostics{DiagWarnActivationTimeout}
-			} else if errors.Is(ctx.Err(), context.Canceled) {
-				return nil, diag.Diagnostics{DiagWarnActivationCanceled}
-			}
-			return nil, diag.FromErr(fmt.Errorf("activation context terminated: %w", ctx.Err()))
-		}
+				act = res.Activation
+				return checkStatus(act)
+			})
 	}
-	return activation, nil
+	if err != nil {
+		return nil, activationDiagnostics(err)
+	}
+	return act, nil
 }
 
 func suppressNoteFieldForPropertyActivation(_, oldValue, newValue string, d *schema.ResourceData) bool {
@@ -974,67 +961,55 @@ func createActivation(ctx context.Context, client papi.PAPI, request papi.Create
 		errMsg = "create deactivation failed"
 	}
 
-	createActivationRetry := CreateActivationRetry
-
-	for {
+	var activationID string
+	err := activation.Retry(ctx, activation.NewBackoff(CreateActivationRetry, activation.DefaultCreateRetryMaxInterval), isActivationErrorRetryable, func(ctx context.Context) error {
 		log.Debug("creating activation")
 		create, err := client.CreateActivation(ctx, request)
 		if err == nil {
-			return create.ActivationID, nil
+			activationID = create.ActivationID
+			return nil
 		}
-		log.Debug("%s: retrying: %w", errMsg, err)
+		log.Debug(fmt.Sprintf("%s: retrying: %s", errMsg, err))
 
-		if !isCreateActivationErrorRetryable(err) {
-			return "", diag.Errorf("%s: %s", errMsg, err)
+		if !isActivationErrorRetryable(err) {
+			return err
 		}
-
 		if actID, ok := isActivationPendingOrActive(ctx, client, expectedActivation{
 			PropertyID: request.PropertyID,
 			Version:    request.Activation.PropertyVersion,
 			Network:    request.Activation.Network,
 			Type:       request.Activation.ActivationType,
 		}); ok {
-			return actID, nil
-		}
-
-		select {
-		case <-time.After(createActivationRetry):
-			createActivationRetry = capDuration(createActivationRetry*2, 5*time.Minute)
-			continue
-
-		case <-ctx.Done():
-			if errors.Is(ctx.Err(), context.DeadlineExceeded) {
-				return "", diag.Diagnostics{DiagWarnActivationTimeout}
-			} else if errors.Is(ctx.Err(), context.Canceled) {
-				return "", diag.Diagnostics{DiagWarnActivationCanceled}
-			}
-			return "", diag.FromErr(fmt.Errorf("activation context terminated: %w", ctx.Err()))
+			activationID = actID
+			return nil
 		}
+		return err
+	})
+	if errors.Is(err, activation.ErrContextTerminated) {
+		return "", activationDiagnostics(err)
 	}
-}
-
-func capDuration(t time.Duration, tMax time.Duration) time.Duration {
-	if t > tMax {
-		return tMax
+	if err != nil {
+		return "", diag.Errorf("%s: %s", errMsg, err)
 	}
-	return t
+	return activationID, nil
 }
 
-func isCreateActivationErrorRetryable(err error) bool {
-	if errors.Is(err, io.EOF) {
-		return true
-	}
+func isActivationErrorRetryable(err error) bool {
+	return activation.IsRetryable(err, func(e *papi.Error) int { return e.StatusCode }, activation.IsRetryableStatusCode)
+}
 
-	var responseErr = &papi.Error{}
-	if !errors.As(err, &responseErr) {
-		return false
-	}
-	if responseErr.StatusCode < 500 &&
-		responseErr.StatusCode != 422 &&
-		responseErr.StatusCode != 409 {
-		return false
+// activationDiagnostics returns warnings if waiting for an activation was interrupted by the operation timeout or a
+// cancellation, as the activation continues on the server side
+func activationDiagnostics(err error) diag.Diagnostics {
+	if errors.Is(err, activation.ErrContextTerminated) {
+		if errors.Is(err, context.DeadlineExceeded) {
+			return diag.Diagnostics{DiagWarnActivationTimeout}
+		}
+		if errors.Is(err, context.Canceled) {
+			return diag.Diagnostics{DiagWarnActivationCanceled}
+		}
 	}
-	return true
+	return diag.FromErr(err)
 }
 
 type expectedActivation struct {
@@ -13,6 +13,7 @@ import (
 
 	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v9/pkg/papi"
 	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v9/pkg/session"
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/common/activation"
 	"github.com/akamai/terraform-provider-akamai/v6/pkg/common/str"
 	"github.com/akamai/terraform-provider-akamai/v6/pkg/common/tf"
 	"github.com/akamai/terraform-provider-akamai/v6/pkg/common/timeouts"
@@ -497,22 +498,18 @@ func createNewActivation(ctx context.Context, client papi.PAPI, activationResour
 	}
 
 	activateIncludeRequest = papi.ActivateIncludeRequest(addComplianceRecord(activationResourceData.complianceRecord, papi.ActivateOrDeactivateIncludeRequest(activateIncludeRequest)))
-	createActivationRetry := CreateActivationRetry
 
 	var actID string
-	var ok bool
-	for {
-
+	err := activation.Retry(ctx, activation.NewBackoff(CreateActivationRetry, activation.DefaultCreateRetryMaxInterval), isActivationErrorRetryable, func(ctx context.Context) error {
 		logger.Debug("sending include activation request")
 		activationResponse, err := client.ActivateInclude(ctx, activateIncludeRequest)
 		if err == nil {
 			actID = activationResponse.ActivationID
-			break
+			return nil
 		}
-		if !isCreateActivationErrorRetryable(err) {
-			return diag.Errorf("%s: %s", "create activation failed", err)
+		if !isActivationErrorRetryable(err) {
+			return err
 		}
-
 		expected := expectedIncludeActivation{
 			IncludeID:  activationResourceData.includeID,
 			ContractID: activationResourceData.contractID,
@@ -521,23 +518,17 @@ func createNewActivation(ctx context.Context, client papi.PAPI, activationResour
 			Network:    activationResourceData.network,
 			Type:       papi.ActivationTypeActivate,
 		}
-		if actID, ok = isIncludeActivationPendingOrActive(ctx, client, expected); ok {
-			break
-		}
-
-		select {
-		case <-time.After(createActivationRetry):
-			createActivationRetry = capDuration(createActivationRetry*2, 5*time.Minute)
-			continue
-
-		case <-ctx.Done():
-			if errors.Is(ctx.Err(), context.DeadlineExceeded) {
-				return diag.Diagnostics{DiagWarnActivationTimeout}
-			} else if errors.Is(ctx.Err(), context.Canceled) {
-				return diag.Diagnostics{DiagWarnActivationCanceled}
-			}
-			return diag.FromErr(fmt.Errorf("activation context terminated: %w", ctx.Err()))
+		if id, ok := isIncludeActivationPendingOrActive(ctx, client, expected); ok {
+			actID = id
+			return nil
 		}
+		return err
+	})
+	if errors.Is(err, activation.ErrContextTerminated) {
+		return activationDiagnostics(err)
+	}
+	if err != nil {
+		return diag.Errorf("%s: %s", "create activation failed", err)
 	}
 
 	logger.Debug("waiting for activation creation")
@@ -562,19 +553,15 @@ func createNewDeactivation(ctx context.Context, client papi.PAPI, activationReso
 
 	deactivateIncludeRequest = papi.DeactivateIncludeRequest(addComplianceRecord(activationResourceData.complianceRecord, papi.ActivateOrDeactivateIncludeRequest(deactivateIncludeRequest)))
 
-	createActivationRetry := CreateActivationRetry
-
 	var actID string
-	var ok bool
-	for {
-
+	err := activation.Retry(ctx, activation.NewBackoff(CreateActivationRetry, activation.DefaultCreateRetryMaxInterval), isActivationErrorRetryable, func(ctx context.Context) error {
 		deactivation, err := client.DeactivateInclude(ctx, deactivateIncludeRequest)
 		if err == nil {
 			actID = deactivation.ActivationID
-			break
+			return nil
 		}
-		if !isCreateActivationErrorRetryable(err) {
-			return diag.Errorf("%s: %s", "create activation failed", err)
+		if !isActivationErrorRetryable(err) {
+			return err
 		}
 		expected := expectedIncludeActivation{
 			IncludeID:  activationResourceData.includeID,
@@ -584,23 +571,17 @@ func createNewDeactivation(ctx context.Context, client papi.PAPI, activationReso
 			Network:    activationResourceData.network,
 			Type:       papi.ActivationTypeDeactivate,
 		}
-		if actID, ok = isIncludeActivationPendingOrActive(ctx, client, expected); ok {
-			break
-		}
-
-		select {
-		case <-time.After(createActivationRetry):
-			createActivationRetry = capDuration(createActivationRetry*2, 5*time.Minute)
-			continue
-
-		case <-ctx.Done():
-			if errors.Is(ctx.Err(), context.DeadlineExceeded) {
-				return diag.Diagnostics{DiagWarnActivationTimeout}
-			} else if errors.Is(ctx.Err(), context.Canceled) {
-				return diag.Diagnostics{DiagWarnActivationCanceled}
-			}
-			return diag.FromErr(fmt.Errorf("activation context terminated: %w", ctx.Err()))
+		if id, ok := isIncludeActivationPendingOrActive(ctx, client, expected); ok {
+			actID = id
+			return nil
 		}
+		return err
+	})
+	if errors.Is(err, activation.ErrContextTerminated) {
+		return activationDiagnostics(err)
+	}
+	if err != nil {
+		return diag.Errorf("%s: %s", "create activation failed", err)
 	}
 
 	logger.Info("waiting for creation of include deactivation")
@@ -675,29 +656,22 @@ func getLatestActiveIncludeActivationResponseInNetwork(ctx context.Context, clie
 }
 
 func waitForActivationCreation(ctx context.Context, client papi.PAPI, includeID, activationID string) (*papi.GetIncludeActivationResponse, error) {
-	for {
-		activation, err := client.GetIncludeActivation(ctx, papi.GetIncludeActivationRequest{
+	var act *papi.GetIncludeActivationResponse
+	err := activation.Retry(ctx, activation.NewPoller(getActivationInterval), func(err error) bool { return errors.Is(err, papi.ErrNotFound) }, func(ctx context.Context) error {
+		var err error
+		act, err = client.GetIncludeActivation(ctx, papi.GetIncludeActivationRequest{
 			IncludeID:    includeID,
 			ActivationID: activationID,
 		})
-		if err == nil {
-			return activation, nil
-		}
-
-		if errors.Is(err, papi.ErrMissingComplianceRecord) {
-			return nil, fmt.Errorf("for 'PRODUCTION' network, 'compliance_record' must be specified: %s", err)
-		}
-		if !errors.Is(err, papi.ErrNotFound) {
-			// return in case we get unexpected error
-			return nil, err
-		}
-
-		select {
-		case <-time.After(getActivationInterval):
-			// wait some time and check again
-			continue
-		}
+		return err
+	})
+	if errors.Is(err, papi.ErrMissingComplianceRecord) {
+		return nil, fmt.Errorf("for 'PRODUCTION' network, 'compliance_record' must be specified: %s", err)
+	}
+	if err != nil {
+		return nil, err
 	}
+	return act, nil
 }
 
 func waitForActivationCondition(ctx context.Context,
@@ -705,52 +679,38 @@ func waitForActivationCondition(ctx context.Context,
 	includeID, activationID string,
 	cond func(papi.ActivationStatus) bool,
 ) (*papi.GetIncludeActivationResponse, diag.Diagnostics) {
-	retriesMax := 5
-	retries5xx := 0
-	for {
-		activation, err := client.GetIncludeActivation(ctx, papi.GetIncludeActivationRequest{
-			IncludeID:    includeID,
-			ActivationID: activationID,
-		})
-		if err != nil {
-			var target = &papi.Error{}
-			if !errors.As(err, &target) {
-				return nil, diag.Errorf("error has unexpected type: %T", err)
-			}
-			if target.StatusCode >= 500 {
-				retries5xx = retries5xx + 1
-				if retries5xx > retriesMax {
-					return nil, diag.Errorf("reached max number of 5xx retries: %d", retries5xx)
-				}
-				continue
+	var act *papi.GetIncludeActivationResponse
+	var actStatus papi.ActivationStatus
+	err := activation.PollNow(ctx, activation.NewPoller(activationPollInterval), activation.DefaultMaxRetries, isActivationErrorRetryable,
+		func(ctx context.Context) (bool, error) {
+			var err error
+			act, err = client.GetIncludeActivation(ctx, papi.GetIncludeActivationRequest{
+				IncludeID:    includeID,
+				ActivationID: activationID,
+			})
+			if err != nil {
+				return false, err
 			}
-
-			return nil, diag.FromErr(err)
-		}
-		retries5xx = 0
-
-		actStatus := activation.Activation.Status
-		if cond(actStatus) {
-			return activation, nil
-		}
-
-		select {
-		case <-time.After(capDuration(activationPollInterval, ActivationPollMinimum)):
-			continue
-		case <-ctx.Done():
-			return nil, diag.FromErr(terminateProcess(ctx, string(actStatus)))
-		}
+			actStatus = act.Activation.Status
+			return cond(actStatus), nil
+		})
+	if errors.Is(err, activation.ErrContextTerminated) {
+		return nil, diag.FromErr(terminateProcess(err, string(actStatus)))
 	}
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+	return act, nil
 }
 
-func terminateProcess(ctx context.Context, actStatus string) error {
-	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+func terminateProcess(err error, actStatus string) error {
+	if errors.Is(err, context.DeadlineExceeded) {
 		return fmt.Errorf("timeout waiting for activation status: current status: %s", actStatus)
 	}
-	if errors.Is(ctx.Err(), context.Canceled) {
+	if errors.Is(err, context.Canceled) {
 		return fmt.Errorf("operation canceled while waiting for activation status, current status: %s", actStatus)
 	}
-	return fmt.Errorf("activation context terminated: %w", ctx.Err())
+	return err
 }
 
 func addComplianceRecord(complianceRecord []interface{}, activateIncludeRequest papi.ActivateOrDeactivateIncludeRequest) papi.ActivateOrDeactivateIncludeRequest {
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"io"
 	"math/rand"
+	"net/http"
 	"net/url"
 	"regexp"
 	"strconv"
@@ -651,6 +652,58 @@ func TestResourcePropertyIncludeActivation(t *testing.T) {
 
 		client.AssertExpectations(t)
 	})
+	t.Run("include activation lifecycle, every activation/deactivation is retried on too many requests error", func(t *testing.T) {
+		client := new(papi.Mock)
+		state := State{}
+
+		actReq := activateIncludeReq("STAGING", false)
+		// create -> fail
+		state = expectWaitPending(client, state, actReq.Network, 2)
+		expectAssertState(client, state)
+		state = expectWaitPending(client, state, actReq.Network, 0)
+		client.On("ActivateInclude", mock.Anything, actReq).
+			Return(nil, &papi.Error{StatusCode: http.StatusTooManyRequests}).Once()
+		state = expectActivateInclude(client, state, actReq, 2)
+		state = expectWaitPending(client, state, actReq.Network, 2)
+
+		// read
+		expectRead(client, state, papi.ActivationNetworkStaging)
+
+		// read
+		expectRead(client, state, papi.ActivationNetworkStaging)
+
+		// delete
+		deactReq := deactivateIncludeReq("STAGING", false)
+		state = expectWaitPending(client, state, deactReq.Network, 2)
+		expectAssertState(client, state)
+		client.On("DeactivateInclude", mock.Anything, deactReq).
+			Return(nil, &papi.Error{StatusCode: http.StatusTooManyRequests}).Once()
+		expectListIncludeActivations(client, state.activations)
+		state = expectDectivateInclude(client, state, deactReq, 2)
+		state = expectWaitPending(client, state, deactReq.Network, 2)
+
+		useClient(client, nil, func() {
+			resource.UnitTest(t, resource.TestCase{
+				ProtoV6ProviderFactories: testutils.NewProtoV6ProviderFactory(NewSubprovider()),
+				Steps: []resource.TestStep{
+					{
+						Config: testutils.LoadFixtureString(t, fmt.Sprintf("%s/property_include_activation.tf", testDir)),
+						Check: checkAttributes(attrs{
+							includeID:    includeID,
+							contractID:   contractID,
+							groupID:      groupID,
+							version:      version,
+							network:      "STAGING",
+							note:         note,
+							notifyEmails: []string{email},
+						}),
+					},
+				},
+			})
+		})
+
+		client.AssertExpectations(t)
+	})
 	t.Run("include activation lifecycle, every activation/deactivation requires retry due to recoverable EOF error without activation processing in background", func(t *testing.T) {
 		client := new(papi.Mock)
 		state := State{}